@@ -0,0 +1,25 @@
+package moby_buildkit_v1 //nolint:revive
+
+import "github.com/moby/buildkit/util/apicaps"
+
+var Caps apicaps.CapList
+
+// Every backwards or forwards non-compatible change needs to add a new capability row.
+// By default new capabilities should be experimental. After merge a capability is
+// considered immutable. After a capability is marked stable it should not be disabled.
+
+const (
+	// CapMultipleExporters is the capability to run all the exporters of
+	// SolveRequest.Exporters. Daemons without it only run the deprecated
+	// Exporter.
+	CapMultipleExporters apicaps.CapID = "exporter.multiple"
+)
+
+func init() {
+	Caps.Init(apicaps.Cap{
+		ID:      CapMultipleExporters,
+		Name:    "multiple exporters",
+		Enabled: true,
+		Status:  apicaps.CapStatusExperimental,
+	})
+}
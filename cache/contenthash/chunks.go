@@ -0,0 +1,53 @@
+package contenthash
+
+import (
+	"encoding/json"
+
+	"github.com/moby/buildkit/cache"
+	digest "github.com/opencontainers/go-digest"
+	"github.com/pkg/errors"
+)
+
+const keyChunkIndex = "buildkit.contenthash.chunks.v0"
+
+// FileChunks are the content-defined chunks of a file of a ref in order.
+type FileChunks []Chunk
+
+// Chunk is a content-defined chunk of a file.
+type Chunk struct {
+	Digest digest.Digest `json:"digest"`
+	Size   int64         `json:"size"`
+}
+
+// Size returns the size of the file the chunks make up.
+func (fc FileChunks) Size() int64 {
+	var size int64
+	for _, c := range fc {
+		size += c.Size
+	}
+	return size
+}
+
+// GetChunkIndex returns the chunks of the files of a ref by their path, as
+// recorded by the last transfer to the ref.
+func GetChunkIndex(md cache.RefMetadata) (map[string]FileChunks, error) {
+	idx := map[string]FileChunks{}
+	dt, err := md.GetExternal(keyChunkIndex)
+	if err != nil {
+		// no index has been recorded for the ref
+		return idx, nil
+	}
+	if err := json.Unmarshal(dt, &idx); err != nil {
+		return nil, errors.Wrap(err, "failed to parse chunk index")
+	}
+	return idx, nil
+}
+
+// SetChunkIndex records the chunks of the files of a ref by their path.
+func SetChunkIndex(md cache.RefMetadata, idx map[string]FileChunks) error {
+	dt, err := json.Marshal(idx)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	return md.SetExternal(keyChunkIndex, dt)
+}
@@ -22,6 +22,7 @@ import (
 	"github.com/moby/buildkit/snapshot"
 	"github.com/moby/buildkit/util/bklog"
 	"github.com/moby/buildkit/util/flightcontrol"
+	"github.com/moby/buildkit/util/metrics"
 	"github.com/moby/buildkit/util/progress"
 	digest "github.com/opencontainers/go-digest"
 	imagespecidentity "github.com/opencontainers/image-spec/identity"
@@ -95,6 +96,8 @@ type cacheManager struct {
 
 	muPrune sync.Mutex // make sure parallel prune is not allowed so there will not be inconsistent results
 	unlazyG flightcontrol.Group
+
+	unregisterMetrics func()
 }
 
 func NewManager(opt ManagerOpt) (Manager, error) {
@@ -120,6 +123,8 @@ func NewManager(opt ManagerOpt) (Manager, error) {
 	}
 	cm.mountPool = p
 
+	cm.unregisterMetrics = metrics.RegisterSnapshotUsage(cm.snapshotUsage)
+
 	// cm.scheduleGC(5 * time.Minute)
 
 	return cm, nil
@@ -331,9 +336,24 @@ func (cm *cacheManager) IdentityMapping() *idtools.IdentityMapping {
 // method should be called after Close.
 func (cm *cacheManager) Close() error {
 	// TODO: allocate internal context and cancel it here
+	cm.unregisterMetrics()
 	return cm.MetadataStore.Close()
 }
 
+// snapshotUsage returns the number of records and the total size of the
+// records whose size has already been calculated.
+func (cm *cacheManager) snapshotUsage(ctx context.Context) (count int64, size int64) {
+	cm.mu.Lock()
+	defer cm.mu.Unlock()
+	for _, cr := range cm.records {
+		count++
+		if s := cr.getSize(); s != sizeUnknown {
+			size += s
+		}
+	}
+	return count, size
+}
+
 // Get returns an immutable snapshot reference for ID
 func (cm *cacheManager) Get(ctx context.Context, id string, pg progress.Controller, opts ...RefOption) (ImmutableRef, error) {
 	cm.mu.Lock()
@@ -552,6 +572,11 @@ func (cm *cacheManager) New(ctx context.Context, s ImmutableRef, sess session.Gr
 	var parent *immutableRef
 	var parentSnapshotID string
 	if s != nil {
+		if er, ok := s.(ExternalRef); ok {
+			if s, err = er.Materialize(ctx); err != nil {
+				return nil, err
+			}
+		}
 		if _, ok := s.(*immutableRef); ok {
 			parent = s.Clone().(*immutableRef)
 		} else {
@@ -704,6 +729,11 @@ func (cm *cacheManager) Merge(ctx context.Context, inputParents []ImmutableRef,
 		if inputParent == nil {
 			continue
 		}
+		if er, ok := inputParent.(ExternalRef); ok {
+			if inputParent, rerr = er.Materialize(ctx); rerr != nil {
+				return nil, rerr
+			}
+		}
 		var parent *immutableRef
 		if p, ok := inputParent.(*immutableRef); ok {
 			parent = p
@@ -833,6 +863,11 @@ func (cm *cacheManager) Diff(ctx context.Context, lower, upper ImmutableRef, pg
 		if inputParent == nil {
 			continue
 		}
+		if er, ok := inputParent.(ExternalRef); ok {
+			if inputParent, rerr = er.Materialize(ctx); rerr != nil {
+				return nil, rerr
+			}
+		}
 		var parent *immutableRef
 		if p, ok := inputParent.(*immutableRef); ok {
 			parent = p
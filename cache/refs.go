@@ -57,6 +57,16 @@ type ImmutableRef interface {
 	LayerChain() RefList
 }
 
+// ExternalRef is an ImmutableRef whose data isn't stored in a snapshot of the
+// cache manager, like a directory of the host that is bind-mounted. The data
+// is copied to a snapshot when the ref is used as the parent of another ref.
+type ExternalRef interface {
+	ImmutableRef
+	// Materialize returns a ref with a copy of the data. The returned ref is
+	// owned by the ExternalRef and must not be released by the caller.
+	Materialize(ctx context.Context) (ImmutableRef, error)
+}
+
 type MutableRef interface {
 	Ref
 	Commit(context.Context) (ImmutableRef, error)
@@ -0,0 +1,527 @@
+package azblob
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/blob"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/bloberror"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/blockblob"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/container"
+	"github.com/containerd/containerd/content"
+	"github.com/moby/buildkit/cache/remotecache"
+	v1 "github.com/moby/buildkit/cache/remotecache/v1"
+	"github.com/moby/buildkit/session"
+	"github.com/moby/buildkit/solver"
+	"github.com/moby/buildkit/util/compression"
+	"github.com/moby/buildkit/worker"
+	digest "github.com/opencontainers/go-digest"
+	ocispecs "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/pkg/errors"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	attrAccountURL      = "account_url"
+	attrAccountName     = "account_name"
+	attrContainer       = "container"
+	attrPrefix          = "prefix"
+	attrManifestsPrefix = "manifests_prefix"
+	attrBlobsPrefix     = "blobs_prefix"
+	attrName            = "name"
+	attrSecretAccessKey = "secret_access_key"
+	attrSASToken        = "sas_token"
+
+	defaultContainer = "buildkit-cache"
+
+	uploadBlockSize   = 4 * 1024 * 1024
+	uploadConcurrency = 4
+)
+
+type Config struct {
+	AccountURL      string
+	AccountName     string
+	Container       string
+	Prefix          string
+	ManifestsPrefix string
+	BlobsPrefix     string
+	Names           []string
+	SecretAccessKey string
+	SASToken        string
+}
+
+func getConfig(attrs map[string]string) (Config, error) {
+	accountURLString, ok := attrs[attrAccountURL]
+	if !ok {
+		accountURLString, ok = os.LookupEnv("BUILDKIT_AZURE_STORAGE_ACCOUNT_URL")
+		if !ok {
+			return Config{}, errors.New("either ${BUILDKIT_AZURE_STORAGE_ACCOUNT_URL} or account_url attribute is required for azblob cache")
+		}
+	}
+	accountURL, err := url.Parse(accountURLString)
+	if err != nil {
+		return Config{}, errors.Wrapf(err, "invalid account url %s for azblob cache", accountURLString)
+	}
+
+	accountName, ok := attrs[attrAccountName]
+	if !ok {
+		accountName, ok = os.LookupEnv("BUILDKIT_AZURE_STORAGE_ACCOUNT_NAME")
+		if !ok {
+			accountName = accountNameFromURL(accountURL)
+		}
+	}
+	if accountName == "" {
+		return Config{}, errors.Errorf("unable to detect account name from %s for azblob cache", accountURLString)
+	}
+
+	containerName, ok := attrs[attrContainer]
+	if !ok {
+		containerName, ok = os.LookupEnv("BUILDKIT_AZURE_STORAGE_CONTAINER")
+		if !ok {
+			containerName = defaultContainer
+		}
+	}
+
+	manifestsPrefix, ok := attrs[attrManifestsPrefix]
+	if !ok {
+		manifestsPrefix = "manifests/"
+	}
+
+	blobsPrefix, ok := attrs[attrBlobsPrefix]
+	if !ok {
+		blobsPrefix = "blobs/"
+	}
+
+	names := []string{"buildkit"}
+	if name, ok := attrs[attrName]; ok && name != "" {
+		names = strings.Split(name, ";")
+	}
+
+	secretAccessKey, ok := attrs[attrSecretAccessKey]
+	if !ok {
+		secretAccessKey = os.Getenv("BUILDKIT_AZURE_STORAGE_SECRET_ACCESS_KEY")
+	}
+
+	sasToken, ok := attrs[attrSASToken]
+	if !ok {
+		sasToken = os.Getenv("BUILDKIT_AZURE_STORAGE_SAS_TOKEN")
+	}
+
+	return Config{
+		AccountURL:      accountURLString,
+		AccountName:     accountName,
+		Container:       containerName,
+		Prefix:          attrs[attrPrefix],
+		ManifestsPrefix: manifestsPrefix,
+		BlobsPrefix:     blobsPrefix,
+		Names:           names,
+		SecretAccessKey: secretAccessKey,
+		SASToken:        strings.TrimPrefix(sasToken, "?"),
+	}, nil
+}
+
+// accountNameFromURL returns the storage account of an account URL. Emulator
+// URLs such as http://127.0.0.1:10000/devstoreaccount1 carry the account in
+// the path, service URLs in the first label of the host.
+func accountNameFromURL(u *url.URL) string {
+	if p := strings.Trim(u.Path, "/"); p != "" {
+		return strings.SplitN(p, "/", 2)[0]
+	}
+	return strings.SplitN(u.Hostname(), ".", 2)[0]
+}
+
+// ResolveCacheExporterFunc for azblob cache exporter.
+func ResolveCacheExporterFunc() remotecache.ResolveCacheExporterFunc {
+	return func(ctx context.Context, g session.Group, attrs map[string]string) (remotecache.Exporter, error) {
+		config, err := getConfig(attrs)
+		if err != nil {
+			return nil, err
+		}
+		return NewExporter(ctx, config)
+	}
+}
+
+type exporter struct {
+	solver.CacheExporterTarget
+	chains *v1.CacheChains
+	client *containerClient
+	config Config
+}
+
+func NewExporter(ctx context.Context, config Config) (remotecache.Exporter, error) {
+	client, err := newContainerClient(config)
+	if err != nil {
+		return nil, err
+	}
+	if config.SecretAccessKey != "" {
+		if err := client.createIfNotExists(ctx); err != nil {
+			return nil, err
+		}
+	}
+	cc := v1.NewCacheChains()
+	return &exporter{CacheExporterTarget: cc, chains: cc, client: client, config: config}, nil
+}
+
+func (e *exporter) Config() remotecache.Config {
+	return remotecache.Config{
+		Compression: compression.New(compression.Default),
+	}
+}
+
+func (e *exporter) Finalize(ctx context.Context) (map[string]string, error) {
+	cacheConfig, descs, err := e.chains.Marshal(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	eg, groupCtx := errgroup.WithContext(ctx)
+	tasks := make(chan int, 10)
+
+	go func() {
+		for i := range cacheConfig.Layers {
+			tasks <- i
+		}
+		close(tasks)
+	}()
+
+	for k := 0; k < 10; k++ {
+		eg.Go(func() error {
+			for index := range tasks {
+				blob := cacheConfig.Layers[index].Blob
+				dgstPair, ok := descs[blob]
+				if !ok {
+					return errors.Errorf("missing blob %s", blob)
+				}
+				if dgstPair.Descriptor.Annotations == nil {
+					return errors.Errorf("invalid descriptor without annotations")
+				}
+				v, ok := dgstPair.Descriptor.Annotations["containerd.io/uncompressed"]
+				if !ok {
+					return errors.Errorf("invalid descriptor without uncompressed annotation")
+				}
+				diffID, err := digest.Parse(v)
+				if err != nil {
+					return errors.Wrapf(err, "failed to parse uncompressed annotation")
+				}
+
+				key := e.client.blobKey(dgstPair.Descriptor.Digest)
+				exists, err := e.client.exists(groupCtx, key)
+				if err != nil {
+					return errors.Wrapf(err, "failed to check blob %s", key)
+				}
+				if !exists {
+					layerDone := remotecache.OneOffProgress(groupCtx, fmt.Sprintf("writing layer %s", blob))
+					ra, err := dgstPair.Provider.ReaderAt(groupCtx, dgstPair.Descriptor)
+					if err != nil {
+						return layerDone(errors.Wrap(err, "error reading layer blob from provider"))
+					}
+					err = e.client.upload(groupCtx, key, content.NewReader(ra))
+					ra.Close()
+					if err != nil {
+						return layerDone(errors.Wrap(err, "error writing layer blob"))
+					}
+					layerDone(nil)
+				}
+
+				la := &v1.LayerAnnotations{
+					DiffID:    diffID,
+					Size:      dgstPair.Descriptor.Size,
+					MediaType: dgstPair.Descriptor.MediaType,
+				}
+				if v, ok := dgstPair.Descriptor.Annotations["buildkit/createdat"]; ok {
+					var t time.Time
+					if err := (&t).UnmarshalText([]byte(v)); err != nil {
+						return err
+					}
+					la.CreatedAt = t.UTC()
+				}
+				cacheConfig.Layers[index].Annotations = la
+			}
+			return nil
+		})
+	}
+
+	if err := eg.Wait(); err != nil {
+		return nil, err
+	}
+
+	dt, err := json.Marshal(cacheConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, name := range e.config.Names {
+		if err := e.client.upload(ctx, e.client.manifestKey(name), bytes.NewReader(dt)); err != nil {
+			return nil, errors.Wrapf(err, "error writing manifest: %s", name)
+		}
+	}
+	return nil, nil
+}
+
+// ResolveCacheImporterFunc for azblob cache importer.
+func ResolveCacheImporterFunc() remotecache.ResolveCacheImporterFunc {
+	return func(ctx context.Context, _ session.Group, attrs map[string]string) (remotecache.Importer, ocispecs.Descriptor, error) {
+		config, err := getConfig(attrs)
+		if err != nil {
+			return nil, ocispecs.Descriptor{}, err
+		}
+		client, err := newContainerClient(config)
+		if err != nil {
+			return nil, ocispecs.Descriptor{}, err
+		}
+		return &importer{client, config}, ocispecs.Descriptor{}, nil
+	}
+}
+
+type importer struct {
+	client *containerClient
+	config Config
+}
+
+func (i *importer) makeDescriptorProviderPair(l v1.CacheLayer) (*v1.DescriptorProviderPair, error) {
+	if l.Annotations == nil {
+		return nil, errors.Errorf("cache layer with missing annotations")
+	}
+	if l.Annotations.DiffID == "" {
+		return nil, errors.Errorf("cache layer with missing diffid")
+	}
+	annotations := map[string]string{}
+	annotations["containerd.io/uncompressed"] = l.Annotations.DiffID.String()
+	if !l.Annotations.CreatedAt.IsZero() {
+		txt, err := l.Annotations.CreatedAt.MarshalText()
+		if err != nil {
+			return nil, err
+		}
+		annotations["buildkit/createdat"] = string(txt)
+	}
+	desc := ocispecs.Descriptor{
+		MediaType:   l.Annotations.MediaType,
+		Digest:      l.Blob,
+		Size:        l.Annotations.Size,
+		Annotations: annotations,
+	}
+	return &v1.DescriptorProviderPair{
+		Descriptor: desc,
+		Provider: &ciProvider{
+			desc:   desc,
+			client: i.client,
+		},
+	}, nil
+}
+
+// load reads the first of the configured manifests that exists, so that a
+// branch can fall back to the cache of its base, e.g. name=feature;main.
+func (i *importer) load(ctx context.Context) (*v1.CacheChains, error) {
+	var config v1.CacheConfig
+	var found bool
+	for _, name := range i.config.Names {
+		var err error
+		found, err = i.client.getManifest(ctx, i.client.manifestKey(name), &config)
+		if err != nil {
+			return nil, err
+		}
+		if found {
+			break
+		}
+	}
+	if !found {
+		return v1.NewCacheChains(), nil
+	}
+
+	allLayers := v1.DescriptorProvider{}
+
+	for _, l := range config.Layers {
+		dpp, err := i.makeDescriptorProviderPair(l)
+		if err != nil {
+			return nil, err
+		}
+		allLayers[l.Blob] = *dpp
+	}
+
+	cc := v1.NewCacheChains()
+	if err := v1.ParseConfig(config, allLayers, cc); err != nil {
+		return nil, err
+	}
+	return cc, nil
+}
+
+func (i *importer) Resolve(ctx context.Context, _ ocispecs.Descriptor, id string, w worker.Worker) (solver.CacheManager, error) {
+	cc, err := i.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	keysStorage, resultStorage, err := v1.NewCacheKeyStorage(cc, w)
+	if err != nil {
+		return nil, err
+	}
+
+	return solver.NewCacheManager(ctx, id, keysStorage, resultStorage), nil
+}
+
+type ciProvider struct {
+	client *containerClient
+	desc   ocispecs.Descriptor
+
+	mu      sync.Mutex
+	checked bool
+}
+
+func (p *ciProvider) CheckDescriptor(ctx context.Context, desc ocispecs.Descriptor) error {
+	if desc.Digest != p.desc.Digest {
+		return nil
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.checked {
+		return nil
+	}
+
+	exists, err := p.client.exists(ctx, p.client.blobKey(desc.Digest))
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return errors.Errorf("blob %s not found", desc.Digest)
+	}
+	p.checked = true
+	return nil
+}
+
+func (p *ciProvider) ReaderAt(ctx context.Context, desc ocispecs.Descriptor) (content.ReaderAt, error) {
+	key := p.client.blobKey(desc.Digest)
+	return remotecache.NewRangeReaderAt(ctx, desc.Size, func(ctx context.Context, off int64) (io.ReadCloser, error) {
+		return p.client.getReader(ctx, key, off)
+	}), nil
+}
+
+type containerClient struct {
+	*container.Client
+	prefix          string
+	blobsPrefix     string
+	manifestsPrefix string
+}
+
+func newContainerClient(config Config) (*containerClient, error) {
+	u, err := url.Parse(config.AccountURL)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid account url %s", config.AccountURL)
+	}
+	u.Path = path.Join(u.Path, config.Container)
+	if config.SASToken != "" {
+		u.RawQuery = config.SASToken
+	}
+
+	var client *container.Client
+	if config.SecretAccessKey != "" {
+		cred, err := container.NewSharedKeyCredential(config.AccountName, config.SecretAccessKey)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create shared key credential")
+		}
+		client, err = container.NewClientWithSharedKeyCredential(u.String(), cred, nil)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create container client")
+		}
+	} else {
+		// SAS tokens are part of the container URL
+		client, err = container.NewClientWithNoCredential(u.String(), nil)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create container client")
+		}
+	}
+
+	return &containerClient{
+		Client:          client,
+		prefix:          config.Prefix,
+		blobsPrefix:     config.BlobsPrefix,
+		manifestsPrefix: config.ManifestsPrefix,
+	}, nil
+}
+
+func (c *containerClient) createIfNotExists(ctx context.Context) error {
+	_, err := c.Create(ctx, nil)
+	if err != nil {
+		if bloberror.HasCode(err, bloberror.ContainerAlreadyExists) {
+			return nil
+		}
+		return errors.Wrap(err, "failed to create container")
+	}
+	return nil
+}
+
+func (c *containerClient) getManifest(ctx context.Context, key string, config *v1.CacheConfig) (bool, error) {
+	body, err := c.getReader(ctx, key, 0)
+	if err != nil {
+		if isNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	defer body.Close()
+
+	decoder := json.NewDecoder(body)
+	if err := decoder.Decode(config); err != nil {
+		return false, errors.WithStack(err)
+	}
+
+	return true, nil
+}
+
+func (c *containerClient) getReader(ctx context.Context, key string, offset int64) (io.ReadCloser, error) {
+	resp, err := c.NewBlobClient(key).DownloadStream(ctx, &blob.DownloadStreamOptions{
+		Range: blob.HTTPRange{Offset: offset},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.NewRetryReader(ctx, &blob.RetryReaderOptions{MaxRetries: 3}), nil
+}
+
+// upload writes the blob in staged blocks. Existing blobs are replaced.
+func (c *containerClient) upload(ctx context.Context, key string, body io.Reader) error {
+	_, err := c.NewBlockBlobClient(key).UploadStream(ctx, body, &blockblob.UploadStreamOptions{
+		BlockSize:   uploadBlockSize,
+		Concurrency: uploadConcurrency,
+	})
+	return err
+}
+
+func (c *containerClient) exists(ctx context.Context, key string) (bool, error) {
+	_, err := c.NewBlobClient(key).GetProperties(ctx, nil)
+	if err != nil {
+		if isNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+func (c *containerClient) blobKey(dgst digest.Digest) string {
+	return c.prefix + c.blobsPrefix + dgst.String()
+}
+
+func (c *containerClient) manifestKey(name string) string {
+	return c.prefix + c.manifestsPrefix + name
+}
+
+func isNotFound(err error) bool {
+	if bloberror.HasCode(err, bloberror.BlobNotFound) {
+		return true
+	}
+	// HEAD responses carry no error body
+	var rerr *azcore.ResponseError
+	return errors.As(err, &rerr) && rerr.StatusCode == http.StatusNotFound
+}
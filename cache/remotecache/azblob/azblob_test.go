@@ -0,0 +1,263 @@
+package azblob
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/xml"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/containerd/containerd/content"
+	"github.com/moby/buildkit/solver"
+	"github.com/moby/buildkit/util/contentutil"
+	digest "github.com/opencontainers/go-digest"
+	ocispecs "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeBlobService is a minimal stand-in for the Blob service of an emulator
+// account that supports the requests made by the cache backend.
+type fakeBlobService struct {
+	mu         sync.Mutex
+	containers map[string]struct{}
+	blobs      map[string][]byte
+	blocks     map[string][]byte
+}
+
+func (s *fakeBlobService) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// /<account>/<container>[/<blob>]
+	parts := strings.SplitN(strings.TrimPrefix(r.URL.Path, "/"), "/", 3)
+	if len(parts) < 2 {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	q := r.URL.Query()
+	if len(parts) == 2 {
+		if r.Method != http.MethodPut || q.Get("restype") != "container" {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		if _, ok := s.containers[parts[1]]; ok {
+			writeError(w, r, http.StatusConflict, "ContainerAlreadyExists")
+			return
+		}
+		s.containers[parts[1]] = struct{}{}
+		w.WriteHeader(http.StatusCreated)
+		return
+	}
+	if _, ok := s.containers[parts[1]]; !ok {
+		writeError(w, r, http.StatusNotFound, "ContainerNotFound")
+		return
+	}
+
+	key := parts[1] + "/" + parts[2]
+	switch r.Method {
+	case http.MethodPut:
+		dt, err := io.ReadAll(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		switch q.Get("comp") {
+		case "block":
+			s.blocks[key+"/"+q.Get("blockid")] = dt
+		case "blocklist":
+			var list struct {
+				Latest []string `xml:"Latest"`
+			}
+			if err := xml.Unmarshal(dt, &list); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			var blob []byte
+			for _, id := range list.Latest {
+				block, ok := s.blocks[key+"/"+id]
+				if !ok {
+					writeError(w, r, http.StatusBadRequest, "InvalidBlockList")
+					return
+				}
+				blob = append(blob, block...)
+			}
+			s.blobs[key] = blob
+		default:
+			s.blobs[key] = dt
+		}
+		w.WriteHeader(http.StatusCreated)
+	case http.MethodHead, http.MethodGet:
+		dt, ok := s.blobs[key]
+		if !ok {
+			writeError(w, r, http.StatusNotFound, "BlobNotFound")
+			return
+		}
+		status := http.StatusOK
+		if rng := r.Header.Get("x-ms-range"); rng != "" {
+			start, err := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(rng, "bytes="), "-"))
+			if err != nil || start > len(dt) {
+				writeError(w, r, http.StatusRequestedRangeNotSatisfiable, "InvalidRange")
+				return
+			}
+			dt = dt[start:]
+			status = http.StatusPartialContent
+		}
+		w.Header().Set("Content-Length", strconv.Itoa(len(dt)))
+		w.Header().Set("ETag", `"`+digest.FromBytes(s.blobs[key]).Encoded()+`"`)
+		w.WriteHeader(status)
+		if r.Method == http.MethodGet {
+			w.Write(dt)
+		}
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func writeError(w http.ResponseWriter, r *http.Request, status int, code string) {
+	w.Header().Set("x-ms-error-code", code)
+	w.WriteHeader(status)
+	if r.Method != http.MethodHead {
+		io.WriteString(w, `<?xml version="1.0" encoding="utf-8"?><Error><Code>`+code+`</Code><Message>fake</Message></Error>`)
+	}
+}
+
+func newTestConfig(t *testing.T) (Config, *fakeBlobService) {
+	fs := &fakeBlobService{
+		containers: map[string]struct{}{},
+		blobs:      map[string][]byte{},
+		blocks:     map[string][]byte{},
+	}
+	srv := httptest.NewServer(fs)
+	t.Cleanup(srv.Close)
+
+	config, err := getConfig(map[string]string{
+		attrAccountURL:      srv.URL + "/devstoreaccount1",
+		attrContainer:       "cache",
+		attrPrefix:          "project/",
+		attrName:            "feature;main",
+		attrSecretAccessKey: base64.StdEncoding.EncodeToString([]byte("key")),
+	})
+	require.NoError(t, err)
+	return config, fs
+}
+
+func TestGetConfig(t *testing.T) {
+	t.Setenv("BUILDKIT_AZURE_STORAGE_SECRET_ACCESS_KEY", "")
+	t.Setenv("BUILDKIT_AZURE_STORAGE_SAS_TOKEN", "")
+
+	config, err := getConfig(map[string]string{
+		attrAccountURL: "https://myaccount.blob.core.windows.net",
+		attrName:       "feature;main",
+		attrSASToken:   "?sv=2020-08-04&sig=abc",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "myaccount", config.AccountName)
+	require.Equal(t, defaultContainer, config.Container)
+	require.Equal(t, []string{"feature", "main"}, config.Names)
+	require.Equal(t, "manifests/", config.ManifestsPrefix)
+	require.Equal(t, "blobs/", config.BlobsPrefix)
+	require.Equal(t, "sv=2020-08-04&sig=abc", config.SASToken)
+
+	config, err = getConfig(map[string]string{
+		attrAccountURL:      "http://127.0.0.1:10000/devstoreaccount1",
+		attrContainer:       "cache",
+		attrPrefix:          "project/",
+		attrSecretAccessKey: "a2V5",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "devstoreaccount1", config.AccountName)
+	require.Equal(t, "cache", config.Container)
+
+	client, err := newContainerClient(config)
+	require.NoError(t, err)
+	u, err := url.Parse(client.URL())
+	require.NoError(t, err)
+	require.Equal(t, "/devstoreaccount1/cache", u.Path)
+	require.Equal(t, "project/blobs/sha256:abc", client.blobKey("sha256:abc"))
+	require.Equal(t, "project/manifests/buildkit", client.manifestKey("buildkit"))
+}
+
+func TestExportImport(t *testing.T) {
+	ctx := context.TODO()
+	config, fs := newTestConfig(t)
+
+	// the layer is larger than a block, so it is uploaded in staged blocks
+	dt := bytes.Repeat([]byte("buildkit"), uploadBlockSize/8+100)
+	buf := contentutil.NewBuffer()
+	desc := ocispecs.Descriptor{
+		MediaType: ocispecs.MediaTypeImageLayerGzip,
+		Digest:    digest.FromBytes(dt),
+		Size:      int64(len(dt)),
+		Annotations: map[string]string{
+			"containerd.io/uncompressed": digest.FromString("uncompressed").String(),
+		},
+	}
+	require.NoError(t, content.WriteBlob(ctx, buf, "layer", bytes.NewReader(dt), desc))
+
+	// exporting again finds the existing container and blob
+	recordDgst := digest.FromString("record")
+	for j := 0; j < 2; j++ {
+		exp, err := NewExporter(ctx, config)
+		require.NoError(t, err)
+		exp.Add(recordDgst).AddResult(time.Now(), &solver.Remote{
+			Descriptors: []ocispecs.Descriptor{desc},
+			Provider:    buf,
+		})
+		_, err = exp.Finalize(ctx)
+		require.NoError(t, err)
+	}
+
+	require.Equal(t, dt, fs.blobs["cache/project/blobs/"+desc.Digest.String()])
+	require.NotEmpty(t, fs.blocks)
+	require.Contains(t, fs.blobs, "cache/project/manifests/feature")
+	require.Contains(t, fs.blobs, "cache/project/manifests/main")
+
+	// the missing cache of another branch falls back to the cache of main
+	delete(fs.blobs, "cache/project/manifests/feature")
+	config.Names = []string{"other", "main"}
+	client, err := newContainerClient(config)
+	require.NoError(t, err)
+	i := &importer{client: client, config: config}
+	cc, err := i.load(ctx)
+	require.NoError(t, err)
+	cfg, descs, err := cc.Marshal(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(cfg.Records))
+	require.Equal(t, recordDgst, cfg.Records[0].Digest)
+	require.Equal(t, 1, len(cfg.Layers))
+
+	pair, ok := descs[desc.Digest]
+	require.True(t, ok)
+	require.NoError(t, pair.Provider.(*ciProvider).CheckDescriptor(ctx, pair.Descriptor))
+	ra, err := pair.Provider.ReaderAt(ctx, pair.Descriptor)
+	require.NoError(t, err)
+	defer ra.Close()
+	readDt, err := io.ReadAll(content.NewReader(ra))
+	require.NoError(t, err)
+	require.Equal(t, dt, readDt)
+
+	// a read from an offset starts a ranged request
+	b := make([]byte, 10)
+	n, err := ra.ReadAt(b, int64(len(dt)-10))
+	require.Equal(t, io.EOF, err)
+	require.Equal(t, dt[len(dt)-10:], b[:n])
+
+	// without any of the caches the import is empty
+	i.config.Names = []string{"other"}
+	cc, err = i.load(ctx)
+	require.NoError(t, err)
+	cfg, _, err = cc.Marshal(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(cfg.Records))
+
+	delete(fs.blobs, "cache/project/blobs/"+desc.Digest.String())
+	require.Error(t, (&ciProvider{client: client, desc: desc}).CheckDescriptor(ctx, desc))
+}
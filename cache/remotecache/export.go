@@ -24,7 +24,9 @@ import (
 
 type ResolveCacheExporterFunc func(ctx context.Context, g session.Group, attrs map[string]string) (Exporter, error)
 
-func oneOffProgress(ctx context.Context, id string) func(err error) error {
+// OneOffProgress writes the start of the progress item id and returns a func
+// that completes it and passes through the error of the step.
+func OneOffProgress(ctx context.Context, id string) func(err error) error {
 	pw, _, _ := progress.NewFromContext(ctx)
 	now := time.Now()
 	st := progress.Status{
@@ -107,7 +109,7 @@ func (ce *contentCacheExporter) Finalize(ctx context.Context) (map[string]string
 		if !ok {
 			return nil, errors.Errorf("missing blob %s", l.Blob)
 		}
-		layerDone := oneOffProgress(ctx, fmt.Sprintf("writing layer %s", l.Blob))
+		layerDone := OneOffProgress(ctx, fmt.Sprintf("writing layer %s", l.Blob))
 		if err := contentutil.Copy(ctx, ce.ingester, dgstPair.Provider, dgstPair.Descriptor, ce.ref, logs.LoggerFromContext(ctx)); err != nil {
 			return nil, layerDone(errors.Wrap(err, "error writing layer blob"))
 		}
@@ -127,7 +129,7 @@ func (ce *contentCacheExporter) Finalize(ctx context.Context) (map[string]string
 		Size:      int64(len(dt)),
 		MediaType: v1.CacheConfigMediaTypeV0,
 	}
-	configDone := oneOffProgress(ctx, fmt.Sprintf("writing config %s", dgst))
+	configDone := OneOffProgress(ctx, fmt.Sprintf("writing config %s", dgst))
 	if err := content.WriteBlob(ctx, ce.ingester, dgst.String(), bytes.NewReader(dt), desc); err != nil {
 		return nil, configDone(errors.Wrap(err, "error writing config blob"))
 	}
@@ -146,7 +148,7 @@ func (ce *contentCacheExporter) Finalize(ctx context.Context) (map[string]string
 		Size:      int64(len(dt)),
 		MediaType: mfst.MediaType,
 	}
-	mfstDone := oneOffProgress(ctx, fmt.Sprintf("writing manifest %s", dgst))
+	mfstDone := OneOffProgress(ctx, fmt.Sprintf("writing manifest %s", dgst))
 	if err := content.WriteBlob(ctx, ce.ingester, dgst.String(), bytes.NewReader(dt), desc); err != nil {
 		return nil, mfstDone(errors.Wrap(err, "error writing manifest blob"))
 	}
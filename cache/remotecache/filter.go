@@ -0,0 +1,130 @@
+package remotecache
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/moby/buildkit/solver"
+	"github.com/moby/buildkit/solver/pb"
+	"github.com/pkg/errors"
+)
+
+const (
+	attrIncludeOps            = "include-ops"
+	attrExcludeOps            = "exclude-ops"
+	attrIncludeProgressGroups = "include-progress-groups"
+	attrExcludeProgressGroups = "exclude-progress-groups"
+	attrIncludeStages         = "include-stages"
+	attrExcludeStages         = "exclude-stages"
+	attrMaxAge                = "max-age"
+	attrMaxSize               = "max-size"
+)
+
+// ExportFilter selects the results exported by a cache exporter by the type
+// of the LLB operation, the progress group or the Dockerfile stage of their
+// vertexes and by their age and size.
+type ExportFilter struct {
+	IncludeOps            []string
+	ExcludeOps            []string
+	IncludeProgressGroups []string
+	ExcludeProgressGroups []string
+	IncludeStages         []string
+	ExcludeStages         []string
+	// MaxAge excludes results created longer ago than it.
+	MaxAge time.Duration
+	// MaxSize excludes results whose topmost layer is larger than it in bytes.
+	MaxSize int64
+}
+
+var _ solver.CacheExportFilter = &ExportFilter{}
+
+// ParseExportFilter parses the filter attributes of a cache exporter. It
+// returns nil if none of them is set.
+func ParseExportFilter(attrs map[string]string) (*ExportFilter, error) {
+	f := &ExportFilter{
+		IncludeOps:            parseList(attrs[attrIncludeOps]),
+		ExcludeOps:            parseList(attrs[attrExcludeOps]),
+		IncludeProgressGroups: parseList(attrs[attrIncludeProgressGroups]),
+		ExcludeProgressGroups: parseList(attrs[attrExcludeProgressGroups]),
+		IncludeStages:         parseList(attrs[attrIncludeStages]),
+		ExcludeStages:         parseList(attrs[attrExcludeStages]),
+	}
+	if v, ok := attrs[attrMaxAge]; ok {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse %s", attrMaxAge)
+		}
+		f.MaxAge = d
+	}
+	if v, ok := attrs[attrMaxSize]; ok {
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse %s", attrMaxSize)
+		}
+		f.MaxSize = n
+	}
+	if len(f.IncludeOps) == 0 && len(f.ExcludeOps) == 0 &&
+		len(f.IncludeProgressGroups) == 0 && len(f.ExcludeProgressGroups) == 0 &&
+		len(f.IncludeStages) == 0 && len(f.ExcludeStages) == 0 &&
+		f.MaxAge == 0 && f.MaxSize == 0 {
+		return nil, nil
+	}
+	return f, nil
+}
+
+func (f *ExportFilter) IncludeVertex(vtx solver.Vertex, createdAt time.Time) bool {
+	if f.MaxAge != 0 && time.Since(createdAt) > f.MaxAge {
+		return false
+	}
+
+	var opType, stage string
+	var progressGroups []string
+	if vtx != nil {
+		opType = solver.OpType(vtx)
+		opts := vtx.Options()
+		stage = opts.Description[pb.DescriptionDockerfileStage]
+		if pg := opts.ProgressGroup; pg != nil {
+			progressGroups = []string{pg.Id, pg.Name}
+		}
+	}
+	return match(f.IncludeOps, f.ExcludeOps, opType) &&
+		match(f.IncludeProgressGroups, f.ExcludeProgressGroups, progressGroups...) &&
+		match(f.IncludeStages, f.ExcludeStages, stage)
+}
+
+func (f *ExportFilter) IncludeRemote(r *solver.Remote) bool {
+	if f.MaxSize == 0 || len(r.Descriptors) == 0 {
+		return true
+	}
+	return r.Descriptors[len(r.Descriptors)-1].Size <= f.MaxSize
+}
+
+// match reports whether any of values is in include, if include is set, and
+// none of them is in exclude.
+func match(include, exclude []string, values ...string) bool {
+	contains := func(list []string) bool {
+		for _, l := range list {
+			for _, v := range values {
+				if v != "" && l == v {
+					return true
+				}
+			}
+		}
+		return false
+	}
+	if len(include) != 0 && !contains(include) {
+		return false
+	}
+	return !contains(exclude)
+}
+
+func parseList(v string) []string {
+	var out []string
+	for _, s := range strings.Split(v, ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			out = append(out, s)
+		}
+	}
+	return out
+}
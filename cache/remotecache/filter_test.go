@@ -0,0 +1,104 @@
+package remotecache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/moby/buildkit/solver"
+	"github.com/moby/buildkit/solver/pb"
+	digest "github.com/opencontainers/go-digest"
+	ocispecs "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/require"
+)
+
+type testVertex struct {
+	op   *pb.Op
+	opts solver.VertexOptions
+}
+
+func (v *testVertex) Digest() digest.Digest         { return "" }
+func (v *testVertex) Sys() interface{}              { return v.op }
+func (v *testVertex) Options() solver.VertexOptions { return v.opts }
+func (v *testVertex) Inputs() []solver.Edge         { return nil }
+func (v *testVertex) Name() string                  { return "" }
+
+func TestParseExportFilter(t *testing.T) {
+	f, err := ParseExportFilter(map[string]string{"mode": "max"})
+	require.NoError(t, err)
+	require.Nil(t, f)
+
+	f, err = ParseExportFilter(map[string]string{
+		"include-ops":    "exec, file",
+		"exclude-stages": "deps",
+		"max-age":        "24h",
+		"max-size":       "1024",
+	})
+	require.NoError(t, err)
+	require.Equal(t, &ExportFilter{
+		IncludeOps:    []string{"exec", "file"},
+		ExcludeStages: []string{"deps"},
+		MaxAge:        24 * time.Hour,
+		MaxSize:       1024,
+	}, f)
+
+	_, err = ParseExportFilter(map[string]string{"max-age": "1x"})
+	require.Error(t, err)
+
+	_, err = ParseExportFilter(map[string]string{"max-size": "1GB"})
+	require.Error(t, err)
+}
+
+func TestExportFilterIncludeVertex(t *testing.T) {
+	exec := &testVertex{
+		op: &pb.Op{Op: &pb.Op_Exec{Exec: &pb.ExecOp{}}},
+		opts: solver.VertexOptions{
+			Description:   map[string]string{pb.DescriptionDockerfileStage: "build"},
+			ProgressGroup: &pb.ProgressGroup{Id: "pg1", Name: "compile"},
+		},
+	}
+	file := &testVertex{
+		op: &pb.Op{Op: &pb.Op_File{File: &pb.FileOp{}}},
+		opts: solver.VertexOptions{
+			Description: map[string]string{pb.DescriptionDockerfileStage: "deps"},
+		},
+	}
+	now := time.Now()
+
+	f := &ExportFilter{IncludeOps: []string{"exec"}}
+	require.True(t, f.IncludeVertex(exec, now))
+	require.False(t, f.IncludeVertex(file, now))
+	require.False(t, f.IncludeVertex(nil, now))
+
+	f = &ExportFilter{ExcludeOps: []string{"exec"}}
+	require.False(t, f.IncludeVertex(exec, now))
+	require.True(t, f.IncludeVertex(file, now))
+	require.True(t, f.IncludeVertex(nil, now))
+
+	f = &ExportFilter{IncludeStages: []string{"build"}}
+	require.True(t, f.IncludeVertex(exec, now))
+	require.False(t, f.IncludeVertex(file, now))
+
+	f = &ExportFilter{ExcludeStages: []string{"deps"}}
+	require.True(t, f.IncludeVertex(exec, now))
+	require.False(t, f.IncludeVertex(file, now))
+
+	f = &ExportFilter{IncludeProgressGroups: []string{"compile"}}
+	require.True(t, f.IncludeVertex(exec, now))
+	require.False(t, f.IncludeVertex(file, now))
+
+	f = &ExportFilter{ExcludeProgressGroups: []string{"pg1"}}
+	require.False(t, f.IncludeVertex(exec, now))
+	require.True(t, f.IncludeVertex(file, now))
+
+	f = &ExportFilter{MaxAge: time.Hour}
+	require.True(t, f.IncludeVertex(exec, now.Add(-time.Minute)))
+	require.False(t, f.IncludeVertex(exec, now.Add(-2*time.Hour)))
+}
+
+func TestExportFilterIncludeRemote(t *testing.T) {
+	r := &solver.Remote{Descriptors: []ocispecs.Descriptor{{Size: 1 << 30}, {Size: 100}}}
+
+	require.True(t, (&ExportFilter{}).IncludeRemote(r))
+	require.True(t, (&ExportFilter{MaxSize: 100}).IncludeRemote(r))
+	require.False(t, (&ExportFilter{MaxSize: 99}).IncludeRemote(r))
+}
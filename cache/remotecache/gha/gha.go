@@ -6,12 +6,14 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/containerd/containerd/content"
 	"github.com/moby/buildkit/cache/remotecache"
 	v1 "github.com/moby/buildkit/cache/remotecache/v1"
+	"github.com/moby/buildkit/client"
 	"github.com/moby/buildkit/session"
 	"github.com/moby/buildkit/solver"
 	"github.com/moby/buildkit/util/compression"
@@ -192,11 +194,34 @@ func ResolveCacheImporterFunc() remotecache.ResolveCacheImporterFunc {
 		if err != nil {
 			return nil, ocispecs.Descriptor{}, err
 		}
-		i, err := NewImporter(cfg)
-		if err != nil {
-			return nil, ocispecs.Descriptor{}, err
+		scopes := strings.Split(cfg.Scope, client.CacheFallbackSeparator)
+		if len(scopes) == 1 {
+			i, err := NewImporter(cfg)
+			if err != nil {
+				return nil, ocispecs.Descriptor{}, err
+			}
+			return i, ocispecs.Descriptor{}, nil
+		}
+
+		// multiple scopes form a fallback chain, e.g. the cache of a branch
+		// followed by the cache of the main branch
+		sources := make([]remotecache.ImportSource, 0, len(scopes))
+		for _, scope := range scopes {
+			scope = strings.TrimSpace(scope)
+			i, err := NewImporter(&Config{
+				Scope: scope,
+				URL:   cfg.URL,
+				Token: cfg.Token,
+			})
+			if err != nil {
+				return nil, ocispecs.Descriptor{}, err
+			}
+			sources = append(sources, remotecache.ImportSource{
+				Name:     "gha:" + scope,
+				Importer: i,
+			})
 		}
-		return i, ocispecs.Descriptor{}, nil
+		return remotecache.NewFallbackImporter(sources), ocispecs.Descriptor{}, nil
 	}
 }
 
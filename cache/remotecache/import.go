@@ -12,6 +12,7 @@ import (
 	v1 "github.com/moby/buildkit/cache/remotecache/v1"
 	"github.com/moby/buildkit/session"
 	"github.com/moby/buildkit/solver"
+	"github.com/moby/buildkit/util/bklog"
 	"github.com/moby/buildkit/util/imageutil"
 	"github.com/moby/buildkit/worker"
 	digest "github.com/opencontainers/go-digest"
@@ -97,6 +98,64 @@ func (ci *contentCacheImporter) Resolve(ctx context.Context, desc ocispecs.Descr
 	return solver.NewCacheManager(ctx, id, keysStorage, resultStorage), nil
 }
 
+// ImportSource is a cache source of a fallback importer.
+type ImportSource struct {
+	// Name identifies the source in progress, e.g. the image reference.
+	Name       string
+	Importer   Importer
+	Descriptor ocispecs.Descriptor
+}
+
+// NewFallbackImporter returns an importer for an ordered list of cache
+// sources, e.g. the cache of a feature branch followed by the cache of the
+// main branch. A cache key is loaded from the first source that has records
+// for it. Sources that fail to resolve are skipped.
+func NewFallbackImporter(sources []ImportSource) Importer {
+	return &fallbackImporter{sources: sources}
+}
+
+type fallbackImporter struct {
+	sources []ImportSource
+}
+
+func (fi *fallbackImporter) Resolve(ctx context.Context, _ ocispecs.Descriptor, id string, w worker.Worker) (solver.CacheManager, error) {
+	cms := make([]solver.CacheManager, len(fi.sources))
+	errs := make([]error, len(fi.sources))
+
+	var eg errgroup.Group
+	for i, src := range fi.sources {
+		func(i int, src ImportSource) {
+			eg.Go(func() error {
+				cm, err := src.Importer.Resolve(ctx, src.Descriptor, id, w)
+				if err != nil {
+					bklog.G(ctx).Warnf("failed to import cache from %s: %v", src.Name, err)
+					errs[i] = err
+					return nil
+				}
+				cms[i] = cm
+				return nil
+			})
+		}(i, src)
+	}
+	eg.Wait()
+
+	resolved := make([]solver.CacheManager, 0, len(cms))
+	names := make([]string, 0, len(cms))
+	for i, cm := range cms {
+		if cm != nil {
+			resolved = append(resolved, cm)
+			names = append(names, fi.sources[i].Name)
+		}
+	}
+	if len(resolved) == 0 {
+		if len(errs) == 0 {
+			return nil, errors.New("no cache sources to import")
+		}
+		return nil, errs[0]
+	}
+	return solver.NewFallbackCacheManager(resolved, names), nil
+}
+
 func readBlob(ctx context.Context, provider content.Provider, desc ocispecs.Descriptor) ([]byte, error) {
 	maxBlobSize := int64(1 << 20)
 	if desc.Size > maxBlobSize {
@@ -0,0 +1,41 @@
+package remotecache
+
+import (
+	"context"
+	"testing"
+
+	"github.com/moby/buildkit/solver"
+	"github.com/moby/buildkit/worker"
+	ocispecs "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+type testImporter struct {
+	err error
+}
+
+func (ti *testImporter) Resolve(ctx context.Context, desc ocispecs.Descriptor, id string, w worker.Worker) (solver.CacheManager, error) {
+	if ti.err != nil {
+		return nil, ti.err
+	}
+	return solver.NewInMemoryCacheManager(), nil
+}
+
+func TestFallbackImporter(t *testing.T) {
+	ctx := context.TODO()
+	errNotFound := errors.New("not found")
+
+	cm, err := NewFallbackImporter([]ImportSource{
+		{Name: "branch", Importer: &testImporter{err: errNotFound}},
+		{Name: "main", Importer: &testImporter{}},
+	}).Resolve(ctx, ocispecs.Descriptor{}, "id", nil)
+	require.NoError(t, err)
+	require.NotNil(t, cm)
+
+	_, err = NewFallbackImporter([]ImportSource{
+		{Name: "branch", Importer: &testImporter{err: errNotFound}},
+		{Name: "main", Importer: &testImporter{err: errNotFound}},
+	}).Resolve(ctx, ocispecs.Descriptor{}, "id", nil)
+	require.ErrorIs(t, err, errNotFound)
+}
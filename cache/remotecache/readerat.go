@@ -0,0 +1,89 @@
+package remotecache
+
+import (
+	"context"
+	"io"
+	"sync"
+
+	"github.com/containerd/containerd/content"
+	"github.com/pkg/errors"
+)
+
+// OpenRangeFunc opens a reader for the blob from offset off to its end.
+type OpenRangeFunc func(ctx context.Context, off int64) (io.ReadCloser, error)
+
+// NewRangeReaderAt returns a reader for a blob of size in a remote storage
+// that supports ranged requests. Sequential reads reuse the body of the
+// previous request, concurrent reads are serialized.
+func NewRangeReaderAt(ctx context.Context, size int64, open OpenRangeFunc) content.ReaderAt {
+	return &rangeReaderAt{
+		ctx:  ctx,
+		size: size,
+		open: open,
+	}
+}
+
+type rangeReaderAt struct {
+	ctx  context.Context
+	size int64
+	open OpenRangeFunc
+
+	mu     sync.Mutex
+	body   io.ReadCloser
+	offset int64
+}
+
+func (r *rangeReaderAt) ReadAt(p []byte, off int64) (int, error) {
+	if off >= r.size {
+		return 0, io.EOF
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.body == nil || off != r.offset {
+		if r.body != nil {
+			r.body.Close()
+			r.body = nil
+		}
+		body, err := r.open(r.ctx, off)
+		if err != nil {
+			return 0, err
+		}
+		r.body = body
+		r.offset = off
+	}
+
+	if remaining := r.size - off; int64(len(p)) > remaining {
+		p = p[:remaining]
+	}
+	n, err := io.ReadFull(r.body, p)
+	r.offset += int64(n)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		// the next read requests the data again
+		r.body.Close()
+		r.body = nil
+	}
+	if err == nil && off+int64(n) >= r.size {
+		err = io.EOF
+	}
+	if errors.Is(err, io.ErrUnexpectedEOF) {
+		err = io.EOF
+	}
+	return n, err
+}
+
+func (r *rangeReaderAt) Size() int64 {
+	return r.size
+}
+
+func (r *rangeReaderAt) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.body != nil {
+		err := r.body.Close()
+		r.body = nil
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package remotecache
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRangeReaderAt(t *testing.T) {
+	dt := bytes.Repeat([]byte("buildkit"), 1024)
+	var mu sync.Mutex
+	var opened []int64
+	ra := NewRangeReaderAt(context.TODO(), int64(len(dt)), func(ctx context.Context, off int64) (io.ReadCloser, error) {
+		mu.Lock()
+		opened = append(opened, off)
+		mu.Unlock()
+		return io.NopCloser(bytes.NewReader(dt[off:])), nil
+	})
+	defer ra.Close()
+	require.Equal(t, int64(len(dt)), ra.Size())
+
+	buf := make([]byte, 100)
+	n, err := ra.ReadAt(buf, 0)
+	require.NoError(t, err)
+	require.Equal(t, dt[:100], buf[:n])
+
+	// sequential read continues the previous request
+	n, err = ra.ReadAt(buf, 100)
+	require.NoError(t, err)
+	require.Equal(t, dt[100:200], buf[:n])
+	require.Equal(t, []int64{0}, opened)
+
+	// random access starts a new ranged request
+	n, err = ra.ReadAt(buf, int64(len(dt)-10))
+	require.Equal(t, io.EOF, err)
+	require.Equal(t, dt[len(dt)-10:], buf[:n])
+	require.Equal(t, []int64{0, int64(len(dt) - 10)}, opened)
+
+	n, err = ra.ReadAt(buf, int64(len(dt)))
+	require.Equal(t, io.EOF, err)
+	require.Equal(t, 0, n)
+
+	// concurrent reads of different ranges don't interfere
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(off int) {
+			defer wg.Done()
+			buf := make([]byte, 512)
+			n, err := ra.ReadAt(buf, int64(off))
+			require.NoError(t, err)
+			require.Equal(t, dt[off:off+512], buf[:n])
+		}(i * 1000)
+	}
+	wg.Wait()
+}
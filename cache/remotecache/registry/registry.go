@@ -3,13 +3,16 @@ package registry
 import (
 	"context"
 	"strconv"
+	"strings"
 
 	"github.com/containerd/containerd/content"
 	"github.com/containerd/containerd/remotes/docker"
 	"github.com/containerd/containerd/snapshots"
 	"github.com/docker/distribution/reference"
 	"github.com/moby/buildkit/cache/remotecache"
+	"github.com/moby/buildkit/client"
 	"github.com/moby/buildkit/session"
+	"github.com/moby/buildkit/util/bklog"
 	"github.com/moby/buildkit/util/compression"
 	"github.com/moby/buildkit/util/contentutil"
 	"github.com/moby/buildkit/util/estargz"
@@ -69,26 +72,58 @@ func ResolveCacheExporterFunc(sm *session.Manager, hosts docker.RegistryHosts) r
 
 func ResolveCacheImporterFunc(sm *session.Manager, cs content.Store, hosts docker.RegistryHosts) remotecache.ResolveCacheImporterFunc {
 	return func(ctx context.Context, g session.Group, attrs map[string]string) (remotecache.Importer, ocispecs.Descriptor, error) {
-		ref, err := canonicalizeRef(attrs[attrRef])
-		if err != nil {
-			return nil, ocispecs.Descriptor{}, err
+		refs := strings.Split(attrs[attrRef], client.CacheFallbackSeparator)
+		if len(refs) == 1 {
+			return resolveImporter(ctx, g, sm, cs, hosts, refs[0])
 		}
-		remote := resolver.DefaultPool.GetResolver(hosts, ref, "pull", sm, g)
-		xref, desc, err := remote.Resolve(ctx, ref)
-		if err != nil {
-			return nil, ocispecs.Descriptor{}, err
-		}
-		fetcher, err := remote.Fetcher(ctx, xref)
-		if err != nil {
-			return nil, ocispecs.Descriptor{}, err
+
+		// multiple refs form a fallback chain, e.g. the cache of a branch
+		// followed by the cache of the main branch
+		var sources []remotecache.ImportSource
+		var firstErr error
+		for _, rawRef := range refs {
+			rawRef = strings.TrimSpace(rawRef)
+			ci, desc, err := resolveImporter(ctx, g, sm, cs, hosts, rawRef)
+			if err != nil {
+				bklog.G(ctx).Warnf("failed to resolve cache import %s: %v", rawRef, err)
+				if firstErr == nil {
+					firstErr = err
+				}
+				continue
+			}
+			sources = append(sources, remotecache.ImportSource{
+				Name:       rawRef,
+				Importer:   ci,
+				Descriptor: desc,
+			})
 		}
-		src := &withDistributionSourceLabel{
-			Provider: contentutil.FromFetcher(limited.Default.WrapFetcher(fetcher, ref)),
-			ref:      ref,
-			source:   cs,
+		if len(sources) == 0 {
+			return nil, ocispecs.Descriptor{}, firstErr
 		}
-		return remotecache.NewImporter(src), desc, nil
+		return remotecache.NewFallbackImporter(sources), ocispecs.Descriptor{}, nil
+	}
+}
+
+func resolveImporter(ctx context.Context, g session.Group, sm *session.Manager, cs content.Store, hosts docker.RegistryHosts, rawRef string) (remotecache.Importer, ocispecs.Descriptor, error) {
+	ref, err := canonicalizeRef(rawRef)
+	if err != nil {
+		return nil, ocispecs.Descriptor{}, err
+	}
+	remote := resolver.DefaultPool.GetResolver(hosts, ref, "pull", sm, g)
+	xref, desc, err := remote.Resolve(ctx, ref)
+	if err != nil {
+		return nil, ocispecs.Descriptor{}, err
+	}
+	fetcher, err := remote.Fetcher(ctx, xref)
+	if err != nil {
+		return nil, ocispecs.Descriptor{}, err
+	}
+	src := &withDistributionSourceLabel{
+		Provider: contentutil.FromFetcher(limited.Default.WrapFetcher(fetcher, ref)),
+		ref:      ref,
+		source:   cs,
 	}
+	return remotecache.NewImporter(src), desc, nil
 }
 
 type withDistributionSourceLabel struct {
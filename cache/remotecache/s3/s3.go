@@ -0,0 +1,500 @@
+package s3
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsConfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/feature/s3/manager"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/aws/smithy-go"
+	"github.com/containerd/containerd/content"
+	"github.com/moby/buildkit/cache/remotecache"
+	v1 "github.com/moby/buildkit/cache/remotecache/v1"
+	"github.com/moby/buildkit/session"
+	"github.com/moby/buildkit/solver"
+	"github.com/moby/buildkit/util/compression"
+	"github.com/moby/buildkit/worker"
+	digest "github.com/opencontainers/go-digest"
+	ocispecs "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/pkg/errors"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	attrBucket          = "bucket"
+	attrRegion          = "region"
+	attrPrefix          = "prefix"
+	attrManifestsPrefix = "manifests_prefix"
+	attrBlobsPrefix     = "blobs_prefix"
+	attrName            = "name"
+	attrEndpointURL     = "endpoint_url"
+	attrUsePathStyle    = "use_path_style"
+	attrAccessKeyID     = "access_key_id"
+	attrSecretAccessKey = "secret_access_key"
+	attrSessionToken    = "session_token"
+)
+
+type Config struct {
+	Bucket          string
+	Region          string
+	Prefix          string
+	ManifestsPrefix string
+	BlobsPrefix     string
+	Names           []string
+	EndpointURL     string
+	UsePathStyle    bool
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string
+}
+
+func getConfig(attrs map[string]string) (Config, error) {
+	bucket, ok := attrs[attrBucket]
+	if !ok {
+		bucket, ok = os.LookupEnv("AWS_BUCKET")
+		if !ok {
+			return Config{}, errors.Errorf("bucket not set for s3 cache")
+		}
+	}
+
+	region, ok := attrs[attrRegion]
+	if !ok {
+		region, ok = os.LookupEnv("AWS_REGION")
+		if !ok {
+			return Config{}, errors.Errorf("region not set for s3 cache")
+		}
+	}
+
+	manifestsPrefix, ok := attrs[attrManifestsPrefix]
+	if !ok {
+		manifestsPrefix = "manifests/"
+	}
+
+	blobsPrefix, ok := attrs[attrBlobsPrefix]
+	if !ok {
+		blobsPrefix = "blobs/"
+	}
+
+	names := []string{"buildkit"}
+	if name, ok := attrs[attrName]; ok && name != "" {
+		names = strings.Split(name, ";")
+	}
+
+	usePathStyle := false
+	if v, ok := attrs[attrUsePathStyle]; ok {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return Config{}, errors.Wrapf(err, "non-bool value %s specified for %s", v, attrUsePathStyle)
+		}
+		usePathStyle = b
+	}
+
+	return Config{
+		Bucket:          bucket,
+		Region:          region,
+		Prefix:          attrs[attrPrefix],
+		ManifestsPrefix: manifestsPrefix,
+		BlobsPrefix:     blobsPrefix,
+		Names:           names,
+		EndpointURL:     attrs[attrEndpointURL],
+		UsePathStyle:    usePathStyle,
+		AccessKeyID:     attrs[attrAccessKeyID],
+		SecretAccessKey: attrs[attrSecretAccessKey],
+		SessionToken:    attrs[attrSessionToken],
+	}, nil
+}
+
+// ResolveCacheExporterFunc for s3 cache exporter.
+func ResolveCacheExporterFunc() remotecache.ResolveCacheExporterFunc {
+	return func(ctx context.Context, g session.Group, attrs map[string]string) (remotecache.Exporter, error) {
+		config, err := getConfig(attrs)
+		if err != nil {
+			return nil, err
+		}
+		return NewExporter(ctx, config)
+	}
+}
+
+type exporter struct {
+	solver.CacheExporterTarget
+	chains   *v1.CacheChains
+	s3Client *s3Client
+	config   Config
+}
+
+func NewExporter(ctx context.Context, config Config) (remotecache.Exporter, error) {
+	s3Client, err := newS3Client(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+	cc := v1.NewCacheChains()
+	return &exporter{CacheExporterTarget: cc, chains: cc, s3Client: s3Client, config: config}, nil
+}
+
+func (e *exporter) Config() remotecache.Config {
+	return remotecache.Config{
+		Compression: compression.New(compression.Default),
+	}
+}
+
+func (e *exporter) Finalize(ctx context.Context) (map[string]string, error) {
+	cacheConfig, descs, err := e.chains.Marshal(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	eg, groupCtx := errgroup.WithContext(ctx)
+	tasks := make(chan int, 10)
+
+	go func() {
+		for i := range cacheConfig.Layers {
+			tasks <- i
+		}
+		close(tasks)
+	}()
+
+	for k := 0; k < 10; k++ {
+		eg.Go(func() error {
+			for index := range tasks {
+				blob := cacheConfig.Layers[index].Blob
+				dgstPair, ok := descs[blob]
+				if !ok {
+					return errors.Errorf("missing blob %s", blob)
+				}
+				if dgstPair.Descriptor.Annotations == nil {
+					return errors.Errorf("invalid descriptor without annotations")
+				}
+				v, ok := dgstPair.Descriptor.Annotations["containerd.io/uncompressed"]
+				if !ok {
+					return errors.Errorf("invalid descriptor without uncompressed annotation")
+				}
+				diffID, err := digest.Parse(v)
+				if err != nil {
+					return errors.Wrapf(err, "failed to parse uncompressed annotation")
+				}
+
+				key := e.s3Client.blobKey(dgstPair.Descriptor.Digest)
+				exists, err := e.s3Client.exists(groupCtx, key)
+				if err != nil {
+					return errors.Wrapf(err, "failed to check blob %s", key)
+				}
+				if !exists {
+					layerDone := remotecache.OneOffProgress(groupCtx, fmt.Sprintf("writing layer %s", blob))
+					ra, err := dgstPair.Provider.ReaderAt(groupCtx, dgstPair.Descriptor)
+					if err != nil {
+						return layerDone(errors.Wrap(err, "error reading layer blob from provider"))
+					}
+					err = e.s3Client.saveMutable(groupCtx, key, content.NewReader(ra))
+					ra.Close()
+					if err != nil {
+						return layerDone(errors.Wrap(err, "error writing layer blob"))
+					}
+					layerDone(nil)
+				}
+
+				la := &v1.LayerAnnotations{
+					DiffID:    diffID,
+					Size:      dgstPair.Descriptor.Size,
+					MediaType: dgstPair.Descriptor.MediaType,
+				}
+				if v, ok := dgstPair.Descriptor.Annotations["buildkit/createdat"]; ok {
+					var t time.Time
+					if err := (&t).UnmarshalText([]byte(v)); err != nil {
+						return err
+					}
+					la.CreatedAt = t.UTC()
+				}
+				cacheConfig.Layers[index].Annotations = la
+			}
+			return nil
+		})
+	}
+
+	if err := eg.Wait(); err != nil {
+		return nil, err
+	}
+
+	dt, err := json.Marshal(cacheConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, name := range e.config.Names {
+		if err := e.s3Client.saveMutable(ctx, e.s3Client.manifestKey(name), bytes.NewReader(dt)); err != nil {
+			return nil, errors.Wrapf(err, "error writing manifest: %s", name)
+		}
+	}
+	return nil, nil
+}
+
+// ResolveCacheImporterFunc for s3 cache importer.
+func ResolveCacheImporterFunc() remotecache.ResolveCacheImporterFunc {
+	return func(ctx context.Context, _ session.Group, attrs map[string]string) (remotecache.Importer, ocispecs.Descriptor, error) {
+		config, err := getConfig(attrs)
+		if err != nil {
+			return nil, ocispecs.Descriptor{}, err
+		}
+		s3Client, err := newS3Client(ctx, config)
+		if err != nil {
+			return nil, ocispecs.Descriptor{}, err
+		}
+		return &importer{s3Client, config}, ocispecs.Descriptor{}, nil
+	}
+}
+
+type importer struct {
+	s3Client *s3Client
+	config   Config
+}
+
+func (i *importer) makeDescriptorProviderPair(l v1.CacheLayer) (*v1.DescriptorProviderPair, error) {
+	if l.Annotations == nil {
+		return nil, errors.Errorf("cache layer with missing annotations")
+	}
+	if l.Annotations.DiffID == "" {
+		return nil, errors.Errorf("cache layer with missing diffid")
+	}
+	annotations := map[string]string{}
+	annotations["containerd.io/uncompressed"] = l.Annotations.DiffID.String()
+	if !l.Annotations.CreatedAt.IsZero() {
+		txt, err := l.Annotations.CreatedAt.MarshalText()
+		if err != nil {
+			return nil, err
+		}
+		annotations["buildkit/createdat"] = string(txt)
+	}
+	desc := ocispecs.Descriptor{
+		MediaType:   l.Annotations.MediaType,
+		Digest:      l.Blob,
+		Size:        l.Annotations.Size,
+		Annotations: annotations,
+	}
+	return &v1.DescriptorProviderPair{
+		Descriptor: desc,
+		Provider: &ciProvider{
+			desc:     desc,
+			s3Client: i.s3Client,
+		},
+	}, nil
+}
+
+// load reads the first of the configured manifests that exists, so that a
+// branch can fall back to the cache of its base, e.g. name=feature;main.
+func (i *importer) load(ctx context.Context) (*v1.CacheChains, error) {
+	var config v1.CacheConfig
+	var found bool
+	for _, name := range i.config.Names {
+		var err error
+		found, err = i.s3Client.getManifest(ctx, i.s3Client.manifestKey(name), &config)
+		if err != nil {
+			return nil, err
+		}
+		if found {
+			break
+		}
+	}
+	if !found {
+		return v1.NewCacheChains(), nil
+	}
+
+	allLayers := v1.DescriptorProvider{}
+
+	for _, l := range config.Layers {
+		dpp, err := i.makeDescriptorProviderPair(l)
+		if err != nil {
+			return nil, err
+		}
+		allLayers[l.Blob] = *dpp
+	}
+
+	cc := v1.NewCacheChains()
+	if err := v1.ParseConfig(config, allLayers, cc); err != nil {
+		return nil, err
+	}
+	return cc, nil
+}
+
+func (i *importer) Resolve(ctx context.Context, _ ocispecs.Descriptor, id string, w worker.Worker) (solver.CacheManager, error) {
+	cc, err := i.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	keysStorage, resultStorage, err := v1.NewCacheKeyStorage(cc, w)
+	if err != nil {
+		return nil, err
+	}
+
+	return solver.NewCacheManager(ctx, id, keysStorage, resultStorage), nil
+}
+
+type ciProvider struct {
+	s3Client *s3Client
+	desc     ocispecs.Descriptor
+
+	mu      sync.Mutex
+	checked bool
+}
+
+func (p *ciProvider) CheckDescriptor(ctx context.Context, desc ocispecs.Descriptor) error {
+	if desc.Digest != p.desc.Digest {
+		return nil
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.checked {
+		return nil
+	}
+
+	exists, err := p.s3Client.exists(ctx, p.s3Client.blobKey(desc.Digest))
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return errors.Errorf("blob %s not found", desc.Digest)
+	}
+	p.checked = true
+	return nil
+}
+
+func (p *ciProvider) ReaderAt(ctx context.Context, desc ocispecs.Descriptor) (content.ReaderAt, error) {
+	key := p.s3Client.blobKey(desc.Digest)
+	return remotecache.NewRangeReaderAt(ctx, desc.Size, func(ctx context.Context, off int64) (io.ReadCloser, error) {
+		return p.s3Client.getReader(ctx, key, off)
+	}), nil
+}
+
+type s3Client struct {
+	*s3.Client
+	*manager.Uploader
+	bucket          string
+	prefix          string
+	blobsPrefix     string
+	manifestsPrefix string
+}
+
+func newS3Client(ctx context.Context, config Config) (*s3Client, error) {
+	cfg, err := awsConfig.LoadDefaultConfig(ctx, awsConfig.WithRegion(config.Region))
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to load s3 config")
+	}
+	client := s3.NewFromConfig(cfg, func(options *s3.Options) {
+		if config.AccessKeyID != "" && config.SecretAccessKey != "" {
+			options.Credentials = credentials.NewStaticCredentialsProvider(config.AccessKeyID, config.SecretAccessKey, config.SessionToken)
+		}
+		if config.EndpointURL != "" {
+			options.UsePathStyle = config.UsePathStyle
+			options.EndpointResolver = s3.EndpointResolverFromURL(config.EndpointURL)
+		}
+	})
+
+	return &s3Client{
+		Client:          client,
+		Uploader:        manager.NewUploader(client),
+		bucket:          config.Bucket,
+		prefix:          config.Prefix,
+		blobsPrefix:     config.BlobsPrefix,
+		manifestsPrefix: config.ManifestsPrefix,
+	}, nil
+}
+
+func (s3Client *s3Client) getManifest(ctx context.Context, key string, config *v1.CacheConfig) (bool, error) {
+	output, err := s3Client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: &s3Client.bucket,
+		Key:    &key,
+	})
+	if err != nil {
+		if isNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	defer output.Body.Close()
+
+	decoder := json.NewDecoder(output.Body)
+	if err := decoder.Decode(config); err != nil {
+		return false, errors.WithStack(err)
+	}
+
+	return true, nil
+}
+
+func (s3Client *s3Client) getReader(ctx context.Context, key string, offset int64) (io.ReadCloser, error) {
+	input := &s3.GetObjectInput{
+		Bucket: &s3Client.bucket,
+		Key:    &key,
+	}
+	if offset > 0 {
+		input.Range = aws.String(fmt.Sprintf("bytes=%d-", offset))
+	}
+
+	output, err := s3Client.GetObject(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+	return output.Body, nil
+}
+
+func (s3Client *s3Client) saveMutable(ctx context.Context, key string, body io.Reader) error {
+	input := &s3.PutObjectInput{
+		Bucket: &s3Client.bucket,
+		Key:    &key,
+		Body:   body,
+	}
+	_, err := s3Client.Upload(ctx, input)
+	return err
+}
+
+func (s3Client *s3Client) exists(ctx context.Context, key string) (bool, error) {
+	_, err := s3Client.HeadObject(ctx, &s3.HeadObjectInput{
+		Bucket: &s3Client.bucket,
+		Key:    &key,
+	})
+	if err != nil {
+		if isNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+func (s3Client *s3Client) blobKey(dgst digest.Digest) string {
+	return s3Client.prefix + s3Client.blobsPrefix + dgst.String()
+}
+
+func (s3Client *s3Client) manifestKey(name string) string {
+	return s3Client.prefix + s3Client.manifestsPrefix + name
+}
+
+func isNotFound(err error) bool {
+	var nsk *s3types.NoSuchKey
+	if errors.As(err, &nsk) {
+		return true
+	}
+	var nf *s3types.NotFound
+	if errors.As(err, &nf) {
+		return true
+	}
+	var ae smithy.APIError
+	if errors.As(err, &ae) {
+		switch ae.ErrorCode() {
+		case "NoSuchKey", "NotFound":
+			return true
+		}
+	}
+	return false
+}
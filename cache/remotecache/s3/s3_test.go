@@ -0,0 +1,211 @@
+package s3
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	v1 "github.com/moby/buildkit/cache/remotecache/v1"
+	digest "github.com/opencontainers/go-digest"
+	ocispecs "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeS3 is a minimal path-style S3 stand-in that supports the requests
+// made by the cache backend.
+type fakeS3 struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+}
+
+func (s *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := strings.TrimPrefix(r.URL.Path, "/")
+	switch r.Method {
+	case http.MethodPut:
+		dt, err := io.ReadAll(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		s.objects[key] = dt
+		w.Header().Set("ETag", `"`+digest.FromBytes(dt).Encoded()+`"`)
+		w.WriteHeader(http.StatusOK)
+	case http.MethodHead, http.MethodGet:
+		dt, ok := s.objects[key]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			if r.Method == http.MethodGet {
+				io.WriteString(w, `<?xml version="1.0" encoding="UTF-8"?><Error><Code>NoSuchKey</Code><Message>not found</Message></Error>`)
+			}
+			return
+		}
+		status := http.StatusOK
+		if rng := r.Header.Get("Range"); rng != "" {
+			start, err := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(rng, "bytes="), "-"))
+			if err != nil || start > len(dt) {
+				w.WriteHeader(http.StatusRequestedRangeNotSatisfiable)
+				return
+			}
+			dt = dt[start:]
+			status = http.StatusPartialContent
+		}
+		w.Header().Set("Content-Length", strconv.Itoa(len(dt)))
+		w.WriteHeader(status)
+		if r.Method == http.MethodGet {
+			w.Write(dt)
+		}
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func newTestClient(t *testing.T) (*s3Client, *fakeS3) {
+	fs := &fakeS3{objects: map[string][]byte{}}
+	srv := httptest.NewServer(fs)
+	t.Cleanup(srv.Close)
+
+	config, err := getConfig(map[string]string{
+		attrBucket:          "cache",
+		attrRegion:          "us-east-1",
+		attrPrefix:          "project/",
+		attrEndpointURL:     srv.URL,
+		attrUsePathStyle:    "true",
+		attrAccessKeyID:     "minioadmin",
+		attrSecretAccessKey: "minioadmin",
+	})
+	require.NoError(t, err)
+
+	client, err := newS3Client(context.TODO(), config)
+	require.NoError(t, err)
+	return client, fs
+}
+
+func TestGetConfig(t *testing.T) {
+	_, err := getConfig(map[string]string{attrRegion: "us-east-1"})
+	if _, ok := os.LookupEnv("AWS_BUCKET"); !ok {
+		require.Error(t, err)
+	}
+
+	config, err := getConfig(map[string]string{
+		attrBucket: "cache",
+		attrRegion: "eu-west-1",
+		attrName:   "main;pr-1",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "cache", config.Bucket)
+	require.Equal(t, "eu-west-1", config.Region)
+	require.Equal(t, []string{"main", "pr-1"}, config.Names)
+	require.Equal(t, "manifests/", config.ManifestsPrefix)
+	require.Equal(t, "blobs/", config.BlobsPrefix)
+	require.False(t, config.UsePathStyle)
+
+	_, err = getConfig(map[string]string{
+		attrBucket:       "cache",
+		attrRegion:       "eu-west-1",
+		attrUsePathStyle: "maybe",
+	})
+	require.Error(t, err)
+}
+
+func TestBlobRoundTrip(t *testing.T) {
+	ctx := context.TODO()
+	client, fs := newTestClient(t)
+
+	dt := bytes.Repeat([]byte("buildkit"), 1024)
+	dgst := digest.FromBytes(dt)
+	key := client.blobKey(dgst)
+	require.Equal(t, "project/blobs/"+dgst.String(), key)
+
+	exists, err := client.exists(ctx, key)
+	require.NoError(t, err)
+	require.False(t, exists)
+
+	require.NoError(t, client.saveMutable(ctx, key, bytes.NewReader(dt)))
+	require.Equal(t, dt, fs.objects["cache/"+key])
+
+	exists, err = client.exists(ctx, key)
+	require.NoError(t, err)
+	require.True(t, exists)
+
+	ra, err := (&ciProvider{s3Client: client}).ReaderAt(ctx, ocispecs.Descriptor{Digest: dgst, Size: int64(len(dt))})
+	require.NoError(t, err)
+	defer ra.Close()
+
+	buf := make([]byte, 100)
+	n, err := ra.ReadAt(buf, 0)
+	require.NoError(t, err)
+	require.Equal(t, dt[:100], buf[:n])
+
+	// a read from an offset starts a ranged request
+	n, err = ra.ReadAt(buf, int64(len(dt)-10))
+	require.Equal(t, io.EOF, err)
+	require.Equal(t, dt[len(dt)-10:], buf[:n])
+}
+
+func TestManifest(t *testing.T) {
+	ctx := context.TODO()
+	client, _ := newTestClient(t)
+
+	var config v1.CacheConfig
+	found, err := client.getManifest(ctx, client.manifestKey("buildkit"), &config)
+	require.NoError(t, err)
+	require.False(t, found)
+
+	require.NoError(t, client.saveMutable(ctx, client.manifestKey("buildkit"), strings.NewReader(`{"layers":[{"blob":"sha256:abc"}]}`)))
+
+	found, err = client.getManifest(ctx, client.manifestKey("buildkit"), &config)
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, 1, len(config.Layers))
+	require.Equal(t, digest.Digest("sha256:abc"), config.Layers[0].Blob)
+}
+
+func TestImporterFallback(t *testing.T) {
+	ctx := context.TODO()
+	client, _ := newTestClient(t)
+
+	dgst := digest.FromBytes([]byte("main"))
+	recordDgst := digest.FromBytes([]byte("record"))
+	dt, err := json.Marshal(v1.CacheConfig{
+		Layers: []v1.CacheLayer{{
+			Blob:        dgst,
+			ParentIndex: -1,
+			Annotations: &v1.LayerAnnotations{MediaType: ocispecs.MediaTypeImageLayerGzip, DiffID: dgst, Size: 4},
+		}},
+		Records: []v1.CacheRecord{{
+			Digest:  recordDgst,
+			Results: []v1.CacheResult{{LayerIndex: 0, CreatedAt: time.Now()}},
+		}},
+	})
+	require.NoError(t, err)
+	require.NoError(t, client.saveMutable(ctx, client.manifestKey("main"), bytes.NewReader(dt)))
+
+	// the missing cache of the branch falls back to the cache of main
+	i := &importer{s3Client: client, config: Config{Names: []string{"feature", "main"}}}
+	cc, err := i.load(ctx)
+	require.NoError(t, err)
+	cfg, _, err := cc.Marshal(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(cfg.Records))
+	require.Equal(t, recordDgst, cfg.Records[0].Digest)
+
+	// without any of the caches the import is empty
+	i = &importer{s3Client: client, config: Config{Names: []string{"feature", "other"}}}
+	cc, err = i.load(ctx)
+	require.NoError(t, err)
+	cfg, _, err = cc.Marshal(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(cfg.Records))
+}
@@ -173,6 +173,9 @@ func (c *Client) controlClient() controlapi.ControlClient {
 }
 
 func (c *Client) Dialer() session.Dialer {
+	if c.sessionDialer != nil {
+		return c.sessionDialer
+	}
 	return grpchijack.Dialer(c.controlClient())
 }
 
@@ -41,6 +41,7 @@ import (
 	"github.com/moby/buildkit/session/sshforward/sshprovider"
 	"github.com/moby/buildkit/solver/errdefs"
 	"github.com/moby/buildkit/solver/pb"
+	spb "github.com/moby/buildkit/sourcepolicy/pb"
 	binfotypes "github.com/moby/buildkit/util/buildinfo/types"
 	"github.com/moby/buildkit/util/contentutil"
 	"github.com/moby/buildkit/util/entitlements"
@@ -48,6 +49,7 @@ import (
 	"github.com/moby/buildkit/util/testutil/echoserver"
 	"github.com/moby/buildkit/util/testutil/httpserver"
 	"github.com/moby/buildkit/util/testutil/integration"
+	digest "github.com/opencontainers/go-digest"
 	ocispecs "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
@@ -98,8 +100,11 @@ func TestIntegration(t *testing.T) {
 		testMountWithNoSource,
 		testInvalidExporter,
 		testReadonlyRootFS,
+		testSourcePolicy,
 		testBasicRegistryCacheImportExport,
 		testBasicLocalCacheImportExport,
+		testLocalCacheExportPrune,
+		testBasicAzblobCacheImportExport,
 		testCachedMounts,
 		testCopyFromEmptyImage,
 		testProxyEnv,
@@ -117,6 +122,7 @@ func TestIntegration(t *testing.T) {
 		testShmSize,
 		testUlimit,
 		testCgroupParent,
+		testResourceLimits,
 		testNetworkMode,
 		testFrontendMetadataReturn,
 		testFrontendUseSolveResults,
@@ -161,6 +167,10 @@ func TestIntegration(t *testing.T) {
 		testUncompressedRegistryCacheImportExport,
 		testStargzLazyRegistryCacheImportExport,
 		testCallInfo,
+		testLocalBind,
+		testLocalBindSharedSession,
+		testSharedSessionLocalChanges,
+		testMultipleExporters,
 	)
 	tests = append(tests, diffOpTestCases()...)
 	integration.Run(t, tests, mirrors)
@@ -678,6 +688,60 @@ func testCgroupParent(t *testing.T, sb integration.Sandbox) {
 	require.NotContains(t, strings.TrimSpace(string(dt2)), `/foocgroup/buildkit/`)
 }
 
+func testResourceLimits(t *testing.T, sb integration.Sandbox) {
+	if sb.Rootless() {
+		t.SkipNow()
+	}
+
+	c, err := New(sb.Context(), sb.Address())
+	require.NoError(t, err)
+	defer c.Close()
+
+	st := llb.Image("busybox:latest").
+		Run(llb.Shlex(`sh -c "(cat /sys/fs/cgroup/pids.max || cat /sys/fs/cgroup/pids/pids.max) > /wd/pids"`), llb.WithResourceLimits(llb.ResourceLimits{Pids: 100})).
+		AddMount("/wd", llb.Scratch())
+
+	def, err := st.Marshal(sb.Context())
+	require.NoError(t, err)
+
+	destDir, err := os.MkdirTemp("", "buildkit")
+	require.NoError(t, err)
+	defer os.RemoveAll(destDir)
+
+	ch := make(chan *SolveStatus)
+	var usage *ResourceUsage
+	eg, ctx := errgroup.WithContext(sb.Context())
+	eg.Go(func() error {
+		_, err := c.Solve(ctx, def, SolveOpt{
+			Exports: []ExportEntry{
+				{
+					Type:      ExporterLocal,
+					OutputDir: destDir,
+				},
+			},
+		}, ch)
+		return err
+	})
+	eg.Go(func() error {
+		for ss := range ch {
+			for _, s := range ss.Statuses {
+				if s.Resources != nil {
+					usage = s.Resources
+				}
+			}
+		}
+		return nil
+	})
+	require.NoError(t, eg.Wait())
+
+	dt, err := os.ReadFile(filepath.Join(destDir, "pids"))
+	require.NoError(t, err)
+	require.Equal(t, "100", strings.TrimSpace(string(dt)))
+
+	require.NotNil(t, usage)
+	require.Greater(t, usage.MemoryPeak, int64(0))
+}
+
 func testNetworkMode(t *testing.T, sb integration.Sandbox) {
 	c, err := New(sb.Context(), sb.Address())
 	require.NoError(t, err)
@@ -1184,6 +1248,242 @@ func testTmpfsMounts(t *testing.T, sb integration.Sandbox) {
 	require.NoError(t, err)
 }
 
+func testLocalBind(t *testing.T, sb integration.Sandbox) {
+	requiresLinux(t)
+	c, err := New(sb.Context(), sb.Address())
+	require.NoError(t, err)
+	defer c.Close()
+
+	dir, err := tmpdir(
+		fstest.CreateFile("foo", []byte("foo"), 0600),
+	)
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	local := llb.Local("mylocal")
+
+	// the local source is used read-only, as the parent of a writable mount
+	// and as the source of a copy
+	run := llb.Image("busybox:latest").Run(
+		llb.Shlex(`sh -c "cat /ro/foo > /out/ro && echo -n bar >> /rw/foo && cp /rw/foo /out/rw"`),
+		llb.AddMount("/ro", local, llb.Readonly),
+	)
+	run.AddMount("/rw", local)
+	out := run.AddMount("/out", llb.Scratch().File(llb.Copy(local, "foo", "copy")))
+
+	def, err := out.Marshal(sb.Context())
+	require.NoError(t, err)
+
+	destDir, err := os.MkdirTemp("", "buildkit")
+	require.NoError(t, err)
+	defer os.RemoveAll(destDir)
+
+	_, err = c.Solve(sb.Context(), def, SolveOpt{
+		Exports: []ExportEntry{
+			{
+				Type:      ExporterLocal,
+				OutputDir: destDir,
+			},
+		},
+		LocalDirs: map[string]string{
+			"mylocal": dir,
+		},
+		BindLocalDirs: true,
+	}, nil)
+	require.NoError(t, err)
+
+	dt, err := os.ReadFile(filepath.Join(destDir, "ro"))
+	require.NoError(t, err)
+	require.Equal(t, "foo", string(dt))
+
+	dt, err = os.ReadFile(filepath.Join(destDir, "rw"))
+	require.NoError(t, err)
+	require.Equal(t, "foobar", string(dt))
+
+	dt, err = os.ReadFile(filepath.Join(destDir, "copy"))
+	require.NoError(t, err)
+	require.Equal(t, "foo", string(dt))
+
+	// the local directory is never written to
+	dt, err = os.ReadFile(filepath.Join(dir, "foo"))
+	require.NoError(t, err)
+	require.Equal(t, "foo", string(dt))
+}
+
+// testLocalBindSharedSession checks that the result of a bind-mounted local
+// source isn't loaded from the cache by the next solve in the same session.
+func testLocalBindSharedSession(t *testing.T, sb integration.Sandbox) {
+	requiresLinux(t)
+	c, err := New(sb.Context(), sb.Address())
+	require.NoError(t, err)
+	defer c.Close()
+
+	dir, err := tmpdir(
+		fstest.CreateFile("foo", []byte("foo"), 0600),
+	)
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	run := llb.Image("busybox:latest").Run(
+		llb.Shlex(`sh -c "cat /src/foo > /out/foo"`),
+		llb.AddMount("/src", llb.Local("mylocal"), llb.Readonly),
+		llb.IgnoreCache,
+	)
+	st := run.AddMount("/out", llb.Scratch())
+
+	def, err := st.Marshal(sb.Context())
+	require.NoError(t, err)
+
+	destDir := t.TempDir()
+
+	opt := SolveOpt{
+		Exports: []ExportEntry{
+			{
+				Type:      ExporterLocal,
+				OutputDir: destDir,
+			},
+		},
+		LocalDirs: map[string]string{
+			"mylocal": dir,
+		},
+		BindLocalDirs: true,
+	}
+
+	ctx, cancel := context.WithCancel(sb.Context())
+	defer cancel()
+
+	s, err := c.NewSession(ctx, def, opt)
+	require.NoError(t, err)
+	defer s.Close()
+	go s.Run(ctx, c.Dialer())
+
+	opt.SharedSession = s
+	opt.SessionPreInitialized = true
+
+	for _, content := range []string{"foo", "foo2"} {
+		err = os.WriteFile(filepath.Join(dir, "foo"), []byte(content), 0600)
+		require.NoError(t, err)
+
+		_, err = c.Solve(ctx, def, opt, nil)
+		require.NoError(t, err)
+
+		dt, err := os.ReadFile(filepath.Join(destDir, "foo"))
+		require.NoError(t, err)
+		require.Equal(t, content, string(dt))
+	}
+}
+
+func testSharedSessionLocalChanges(t *testing.T, sb integration.Sandbox) {
+	requiresLinux(t)
+	c, err := New(sb.Context(), sb.Address())
+	require.NoError(t, err)
+	defer c.Close()
+
+	dir, err := tmpdir(
+		fstest.CreateFile("foo", []byte("foo"), 0600),
+	)
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	st := llb.Scratch().File(llb.Copy(llb.Local("mylocal"), "foo", "bar"))
+
+	def, err := st.Marshal(sb.Context())
+	require.NoError(t, err)
+
+	destDir, err := os.MkdirTemp("", "buildkit")
+	require.NoError(t, err)
+	defer os.RemoveAll(destDir)
+
+	opt := SolveOpt{
+		Exports: []ExportEntry{
+			{
+				Type:      ExporterLocal,
+				OutputDir: destDir,
+			},
+		},
+		LocalDirs: map[string]string{
+			"mylocal": dir,
+		},
+	}
+
+	ctx, cancel := context.WithCancel(sb.Context())
+	defer cancel()
+
+	s, err := c.NewSession(ctx, def, opt)
+	require.NoError(t, err)
+	defer s.Close()
+	go s.Run(ctx, c.Dialer())
+
+	opt.SharedSession = s
+	opt.SessionPreInitialized = true
+
+	_, err = c.Solve(ctx, def, opt, nil)
+	require.NoError(t, err)
+
+	dt, err := os.ReadFile(filepath.Join(destDir, "bar"))
+	require.NoError(t, err)
+	require.Equal(t, "foo", string(dt))
+
+	// the changes are transferred by the next solve in the same session
+	err = os.WriteFile(filepath.Join(dir, "foo"), []byte("foo2"), 0600)
+	require.NoError(t, err)
+
+	_, err = c.Solve(ctx, def, opt, nil)
+	require.NoError(t, err)
+
+	dt, err = os.ReadFile(filepath.Join(destDir, "bar"))
+	require.NoError(t, err)
+	require.Equal(t, "foo2", string(dt))
+}
+
+func testMultipleExporters(t *testing.T, sb integration.Sandbox) {
+	c, err := New(sb.Context(), sb.Address())
+	require.NoError(t, err)
+	defer c.Close()
+
+	st := llb.Scratch().File(llb.Mkfile("foo", 0600, []byte("data")))
+	def, err := st.Marshal(sb.Context())
+	require.NoError(t, err)
+
+	destDir := t.TempDir()
+
+	var tarBuf, ociBuf bytes.Buffer
+	res, err := c.Solve(sb.Context(), def, SolveOpt{
+		Exports: []ExportEntry{
+			{
+				Type:      ExporterLocal,
+				OutputDir: destDir,
+			},
+			{
+				Type:   ExporterTar,
+				Output: fixedWriteCloser(&nopWriteCloser{&tarBuf}),
+			},
+			{
+				Type:   ExporterOCI,
+				Output: fixedWriteCloser(&nopWriteCloser{&ociBuf}),
+			},
+		},
+	}, nil)
+	require.NoError(t, err)
+
+	dt, err := os.ReadFile(filepath.Join(destDir, "foo"))
+	require.NoError(t, err)
+	require.Equal(t, "data", string(dt))
+
+	m, err := testutil.ReadTarToMap(tarBuf.Bytes(), false)
+	require.NoError(t, err)
+	require.Contains(t, m, "foo")
+	require.Equal(t, []byte("data"), m["foo"].Data)
+
+	m, err = testutil.ReadTarToMap(ociBuf.Bytes(), false)
+	require.NoError(t, err)
+	require.Contains(t, m, "index.json")
+
+	// responses of multiple exporters are namespaced by their index
+	require.NotEmpty(t, res.ExporterResponse[exptypes.ExporterResponseKey(2, exptypes.ExporterImageDigestKey)])
+	require.Empty(t, res.ExporterResponse[exptypes.ExporterImageDigestKey])
+}
+
 func testLocalSymlinkEscape(t *testing.T, sb integration.Sandbox) {
 	requiresLinux(t)
 	c, err := New(sb.Context(), sb.Address())
@@ -3757,6 +4057,85 @@ func testBasicLocalCacheImportExport(t *testing.T, sb integration.Sandbox) {
 	testBasicCacheImportExport(t, sb, []CacheOptionsEntry{im}, []CacheOptionsEntry{ex})
 }
 
+func testLocalCacheExportPrune(t *testing.T, sb integration.Sandbox) {
+	integration.SkipIfDockerd(t, sb, "remote cache export")
+	requiresLinux(t)
+	c, err := New(sb.Context(), sb.Address())
+	require.NoError(t, err)
+	defer c.Close()
+
+	dir, err := os.MkdirTemp("", "buildkit")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	busybox := llb.Image("busybox:latest")
+	st := llb.Scratch()
+	st = busybox.Run(llb.Shlex(`sh -c "cat /dev/urandom | head -c 100 | sha256sum > unique"`), llb.Dir("/wd")).AddMount("/wd", st)
+
+	def, err := st.Marshal(sb.Context())
+	require.NoError(t, err)
+
+	export := func(attrs map[string]string) ocispecs.Descriptor {
+		attrs["dest"] = dir
+		res, err := c.Solve(sb.Context(), def, SolveOpt{
+			CacheExports: []CacheOptionsEntry{
+				{
+					Type:  "local",
+					Attrs: attrs,
+				},
+			},
+		}, nil)
+		require.NoError(t, err)
+
+		var desc ocispecs.Descriptor
+		require.NoError(t, json.Unmarshal([]byte(res.ExporterResponse["cache.manifest"]), &desc))
+		return desc
+	}
+
+	blobExists := func(dgst digest.Digest) bool {
+		_, err := os.Stat(filepath.Join(dir, "blobs", dgst.Algorithm().String(), dgst.Hex()))
+		return err == nil
+	}
+
+	first := export(map[string]string{})
+	require.True(t, blobExists(first.Digest))
+
+	ensurePruneAll(t, c, sb)
+
+	second := export(map[string]string{"prune": "true", "prune-keep-duration": "1h"})
+	require.NotEqual(t, first.Digest, second.Digest)
+	require.True(t, blobExists(first.Digest))
+	require.True(t, blobExists(second.Digest))
+
+	ensurePruneAll(t, c, sb)
+
+	third := export(map[string]string{"prune": "true"})
+	require.False(t, blobExists(first.Digest))
+	require.False(t, blobExists(second.Digest))
+	require.True(t, blobExists(third.Digest))
+}
+
+func testBasicAzblobCacheImportExport(t *testing.T, sb integration.Sandbox) {
+	integration.SkipIfDockerd(t, sb, "remote cache export")
+	accountURL, cl, err := integration.NewAzuriteServer("")
+	if errors.Is(err, integration.ErrRequirements) {
+		t.Skip(err.Error())
+	}
+	require.NoError(t, err)
+	defer cl()
+
+	o := CacheOptionsEntry{
+		Type: "azblob",
+		Attrs: map[string]string{
+			"account_url":       accountURL,
+			"secret_access_key": integration.AzuriteAccountKey,
+			"container":         "cachecontainer",
+			"name":              "feature;main",
+		},
+	}
+	testBasicCacheImportExport(t, sb, []CacheOptionsEntry{o}, []CacheOptionsEntry{o})
+}
+
 func testBasicInlineCacheImportExport(t *testing.T, sb integration.Sandbox) {
 	integration.SkipIfDockerd(t, sb, "direct push")
 	requiresLinux(t)
@@ -4481,6 +4860,43 @@ func testReadonlyRootFS(t *testing.T, sb integration.Sandbox) {
 	checkAllReleasable(t, c, sb, true)
 }
 
+func testSourcePolicy(t *testing.T, sb integration.Sandbox) {
+	c, err := New(sb.Context(), sb.Address())
+	require.NoError(t, err)
+	defer c.Close()
+
+	st := llb.Image("docker.io/library/alpine:latest").Run(
+		llb.Args([]string{"/bin/sh", "-c", "test ! -f /etc/alpine-release"}),
+	).Root()
+
+	def, err := st.Marshal(sb.Context())
+	require.NoError(t, err)
+
+	_, err = c.Solve(sb.Context(), def, SolveOpt{
+		SourcePolicy: &spb.Policy{
+			Rules: []*spb.Rule{{
+				Action:   spb.PolicyAction_DENY,
+				Selector: &spb.Selector{Identifier: "docker-image://docker.io/library/alpine:*"},
+			}},
+		},
+	}, nil)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "source denied by policy")
+
+	_, err = c.Solve(sb.Context(), def, SolveOpt{
+		SourcePolicy: &spb.Policy{
+			Rules: []*spb.Rule{{
+				Action:   spb.PolicyAction_CONVERT,
+				Selector: &spb.Selector{Identifier: "docker-image://docker.io/library/alpine:latest", MatchType: spb.MatchType_EXACT},
+				Updates:  &spb.Update{Identifier: "docker-image://docker.io/library/busybox:latest"},
+			}},
+		},
+	}, nil)
+	require.NoError(t, err)
+
+	checkAllReleasable(t, c, sb, true)
+}
+
 func testSourceMap(t *testing.T, sb integration.Sandbox) {
 	c, err := New(sb.Context(), sb.Address())
 	require.NoError(t, err)
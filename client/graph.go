@@ -3,10 +3,18 @@ package client
 import (
 	"time"
 
+	controlapi "github.com/moby/buildkit/api/services/control"
 	"github.com/moby/buildkit/solver/pb"
 	digest "github.com/opencontainers/go-digest"
 )
 
+var emptyLogVertexSize int
+
+func init() {
+	emptyLogVertex := controlapi.VertexLog{}
+	emptyLogVertexSize = emptyLogVertex.Size()
+}
+
 type Vertex struct {
 	Digest        digest.Digest
 	Inputs        []digest.Digest
@@ -27,6 +35,21 @@ type VertexStatus struct {
 	Timestamp time.Time
 	Started   *time.Time
 	Completed *time.Time
+	Resources *ResourceUsage
+}
+
+// ResourceUsage is the cgroup usage of the process of an exec.
+type ResourceUsage struct {
+	// MemoryPeak is the peak memory usage in bytes.
+	MemoryPeak int64
+	// CPUTime is the CPU time consumed by the processes.
+	CPUTime time.Duration
+	// IOReadBytes and IOWriteBytes are the bytes read from and written to
+	// block devices.
+	IOReadBytes  int64
+	IOWriteBytes int64
+	// Pids is the number of processes.
+	Pids int64
 }
 
 type VertexLog struct {
@@ -53,7 +76,151 @@ type SolveStatus struct {
 	Warnings []*VertexWarning
 }
 
+// NewSolveStatus converts a status message received from the control API.
+func NewSolveStatus(resp *controlapi.StatusResponse) *SolveStatus {
+	s := &SolveStatus{}
+	for _, v := range resp.Vertexes {
+		s.Vertexes = append(s.Vertexes, &Vertex{
+			Digest:        v.Digest,
+			Inputs:        v.Inputs,
+			Name:          v.Name,
+			Started:       v.Started,
+			Completed:     v.Completed,
+			Error:         v.Error,
+			Cached:        v.Cached,
+			ProgressGroup: v.ProgressGroup,
+		})
+	}
+	for _, v := range resp.Statuses {
+		s.Statuses = append(s.Statuses, &VertexStatus{
+			ID:        v.ID,
+			Vertex:    v.Vertex,
+			Name:      v.Name,
+			Total:     v.Total,
+			Current:   v.Current,
+			Timestamp: v.Timestamp,
+			Started:   v.Started,
+			Completed: v.Completed,
+			Resources: newResourceUsage(v.Resources),
+		})
+	}
+	for _, v := range resp.Logs {
+		s.Logs = append(s.Logs, &VertexLog{
+			Vertex:    v.Vertex,
+			Stream:    int(v.Stream),
+			Data:      v.Msg,
+			Timestamp: v.Timestamp,
+		})
+	}
+	for _, v := range resp.Warnings {
+		s.Warnings = append(s.Warnings, &VertexWarning{
+			Vertex:     v.Vertex,
+			Level:      int(v.Level),
+			Short:      v.Short,
+			Detail:     v.Detail,
+			URL:        v.Url,
+			SourceInfo: v.Info,
+			Range:      v.Ranges,
+		})
+	}
+	return s
+}
+
+// Marshal converts the status to control API messages. Logs are split
+// between multiple messages so that a single message does not grow too big.
+func (ss *SolveStatus) Marshal() (out []*controlapi.StatusResponse) {
+	logSize := 0
+	for {
+		retry := false
+		sr := controlapi.StatusResponse{}
+		for _, v := range ss.Vertexes {
+			sr.Vertexes = append(sr.Vertexes, &controlapi.Vertex{
+				Digest:        v.Digest,
+				Inputs:        v.Inputs,
+				Name:          v.Name,
+				Started:       v.Started,
+				Completed:     v.Completed,
+				Error:         v.Error,
+				Cached:        v.Cached,
+				ProgressGroup: v.ProgressGroup,
+			})
+		}
+		for _, v := range ss.Statuses {
+			sr.Statuses = append(sr.Statuses, &controlapi.VertexStatus{
+				ID:        v.ID,
+				Vertex:    v.Vertex,
+				Name:      v.Name,
+				Current:   v.Current,
+				Total:     v.Total,
+				Timestamp: v.Timestamp,
+				Started:   v.Started,
+				Completed: v.Completed,
+				Resources: v.Resources.marshal(),
+			})
+		}
+		for i, v := range ss.Logs {
+			sr.Logs = append(sr.Logs, &controlapi.VertexLog{
+				Vertex:    v.Vertex,
+				Stream:    int64(v.Stream),
+				Msg:       v.Data,
+				Timestamp: v.Timestamp,
+			})
+			logSize += len(v.Data) + emptyLogVertexSize
+			// avoid logs growing big and split apart if they do
+			if logSize > 1024*1024 {
+				ss.Vertexes = nil
+				ss.Statuses = nil
+				ss.Logs = ss.Logs[i+1:]
+				retry = true
+				break
+			}
+		}
+		for _, v := range ss.Warnings {
+			sr.Warnings = append(sr.Warnings, &controlapi.VertexWarning{
+				Vertex: v.Vertex,
+				Level:  int64(v.Level),
+				Short:  v.Short,
+				Detail: v.Detail,
+				Info:   v.SourceInfo,
+				Ranges: v.Range,
+				Url:    v.URL,
+			})
+		}
+		out = append(out, &sr)
+		if !retry {
+			break
+		}
+	}
+	return
+}
+
 type SolveResponse struct {
 	// ExporterResponse is also used for CacheExporter
 	ExporterResponse map[string]string
 }
+
+func newResourceUsage(r *controlapi.ResourceUsage) *ResourceUsage {
+	if r == nil {
+		return nil
+	}
+	return &ResourceUsage{
+		MemoryPeak:   r.MemoryPeak,
+		CPUTime:      time.Duration(r.CpuNanos),
+		IOReadBytes:  r.IoReadBytes,
+		IOWriteBytes: r.IoWriteBytes,
+		Pids:         r.Pids,
+	}
+}
+
+func (r *ResourceUsage) marshal() *controlapi.ResourceUsage {
+	if r == nil {
+		return nil
+	}
+	return &controlapi.ResourceUsage{
+		MemoryPeak:   r.MemoryPeak,
+		CpuNanos:     int64(r.CPUTime),
+		IoReadBytes:  r.IOReadBytes,
+		IoWriteBytes: r.IOWriteBytes,
+		Pids:         r.Pids,
+	}
+}
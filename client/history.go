@@ -0,0 +1,79 @@
+package client
+
+import (
+	"bufio"
+	"encoding/binary"
+	"io"
+
+	contentapi "github.com/containerd/containerd/api/services/content/v1"
+	controlapi "github.com/moby/buildkit/api/services/control"
+	"github.com/pkg/errors"
+)
+
+// StatusStreamMediaType is the media type of the progress stream recorded
+// for a build in the build history. The stream is a sequence of
+// varint length-prefixed StatusResponse messages.
+const StatusStreamMediaType = "application/vnd.buildkit.status.v0"
+
+// maxStatusMessageSize limits a single message in a recorded status stream
+const maxStatusMessageSize = 32 * 1024 * 1024
+
+// ControlClient returns the raw control API client. It can be used for
+// APIs that are not wrapped by Client, e.g. the build history.
+func (c *Client) ControlClient() controlapi.ControlClient {
+	return c.controlClient()
+}
+
+// ContentClient returns a client for the content store of the daemon that
+// holds build history blobs.
+func (c *Client) ContentClient() contentapi.ContentClient {
+	return contentapi.NewContentClient(c.conn)
+}
+
+// WriteStatusStream appends the status to a recorded status stream.
+func WriteStatusStream(w io.Writer, ss *SolveStatus) error {
+	var lenbuf [binary.MaxVarintLen64]byte
+	for _, sr := range ss.Marshal() {
+		dt, err := sr.Marshal()
+		if err != nil {
+			return err
+		}
+		n := binary.PutUvarint(lenbuf[:], uint64(len(dt)))
+		if _, err := w.Write(lenbuf[:n]); err != nil {
+			return err
+		}
+		if _, err := w.Write(dt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ReadStatusStream reads a recorded status stream and calls fn for every
+// status update in it.
+func ReadStatusStream(r io.Reader, fn func(*SolveStatus) error) error {
+	br := bufio.NewReader(r)
+	for {
+		l, err := binary.ReadUvarint(br)
+		if err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return errors.Wrap(err, "failed to read status stream")
+		}
+		if l > maxStatusMessageSize {
+			return errors.Errorf("invalid status message size %d", l)
+		}
+		dt := make([]byte, l)
+		if _, err := io.ReadFull(br, dt); err != nil {
+			return errors.Wrap(err, "failed to read status stream")
+		}
+		var sr controlapi.StatusResponse
+		if err := sr.Unmarshal(dt); err != nil {
+			return errors.Wrap(err, "failed to unmarshal status message")
+		}
+		if err := fn(NewSolveStatus(&sr)); err != nil {
+			return err
+		}
+	}
+}
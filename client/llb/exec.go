@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net"
 	"sort"
+	"time"
 
 	"github.com/moby/buildkit/solver/pb"
 	"github.com/moby/buildkit/util/system"
@@ -50,14 +51,16 @@ type mount struct {
 
 type ExecOp struct {
 	MarshalCache
-	proxyEnv    *ProxyEnv
-	root        Output
-	mounts      []*mount
-	base        State
-	constraints Constraints
-	isValidated bool
-	secrets     []SecretInfo
-	ssh         []SSHInfo
+	proxyEnv       *ProxyEnv
+	root           Output
+	mounts         []*mount
+	base           State
+	constraints    Constraints
+	isValidated    bool
+	secrets        []SecretInfo
+	ssh            []SSHInfo
+	timeout        time.Duration
+	validExitCodes []int
 }
 
 func (e *ExecOp) AddMount(target string, source Output, opt ...MountOption) Output {
@@ -114,6 +117,9 @@ func (e *ExecOp) Validate(ctx context.Context, c *Constraints) error {
 	if cwd == "" {
 		return errors.Errorf("working directory is required")
 	}
+	if e.timeout < 0 {
+		return errors.Errorf("invalid negative timeout %s", e.timeout)
+	}
 	for _, m := range e.mounts {
 		if m.source != nil {
 			if err := m.source.Vertex(ctx, c).Validate(ctx, c); err != nil {
@@ -229,6 +235,34 @@ func (e *ExecOp) Marshal(ctx context.Context, c *Constraints) (digest.Digest, []
 		meta.Ulimit = ul
 	}
 
+	limits, err := getResourceLimits(e.base)(ctx, c)
+	if err != nil {
+		return "", nil, nil, nil, err
+	}
+	if limits != nil {
+		addCap(&e.constraints, pb.CapExecMetaResourceLimits)
+		meta.ResourceLimits = &pb.ResourceLimits{
+			NanoCPUs:   limits.NanoCPUs,
+			Memory:     limits.Memory,
+			MemorySwap: limits.MemorySwap,
+			Pids:       limits.Pids,
+			IoWeight:   limits.IOWeight,
+		}
+	}
+
+	if e.timeout > 0 {
+		addCap(&e.constraints, pb.CapExecMetaTimeout)
+		meta.Timeout = int64(e.timeout)
+	}
+
+	if len(e.validExitCodes) > 0 {
+		addCap(&e.constraints, pb.CapExecMetaValidExitCodes)
+		meta.ValidExitCodes = make([]int32, len(e.validExitCodes))
+		for i, code := range e.validExitCodes {
+			meta.ValidExitCodes[i] = int32(code)
+		}
+	}
+
 	network, err := getNetwork(e.base)(ctx, c)
 	if err != nil {
 		return "", nil, nil, nil, err
@@ -574,6 +608,12 @@ func AddUlimit(name UlimitName, soft int64, hard int64) RunOption {
 	})
 }
 
+func WithResourceLimits(l ResourceLimits) RunOption {
+	return runOptionFunc(func(ei *ExecInfo) {
+		ei.State = ei.State.WithResourceLimits(l)
+	})
+}
+
 func WithCgroupParent(cp string) RunOption {
 	return runOptionFunc(func(ei *ExecInfo) {
 		ei.State = ei.State.WithCgroupParent(cp)
@@ -709,6 +749,21 @@ func ReadonlyRootFS() RunOption {
 	})
 }
 
+// WithTimeout kills the process if it does not complete within d.
+func WithTimeout(d time.Duration) RunOption {
+	return runOptionFunc(func(ei *ExecInfo) {
+		ei.Timeout = d
+	})
+}
+
+// WithValidExitCodes sets the exit codes other than zero that do not fail
+// the exec.
+func WithValidExitCodes(codes ...int) RunOption {
+	return runOptionFunc(func(ei *ExecInfo) {
+		ei.ValidExitCodes = append(ei.ValidExitCodes, codes...)
+	})
+}
+
 func WithProxy(ps ProxyEnv) RunOption {
 	return runOptionFunc(func(ei *ExecInfo) {
 		ei.ProxyEnv = &ps
@@ -723,6 +778,8 @@ type ExecInfo struct {
 	ProxyEnv       *ProxyEnv
 	Secrets        []SecretInfo
 	SSH            []SSHInfo
+	Timeout        time.Duration
+	ValidExitCodes []int
 }
 
 type MountInfo struct {
@@ -758,6 +815,22 @@ const (
 	SecurityModeSandbox  = pb.SecurityMode_SANDBOX
 )
 
+// ResourceLimits constrains the resources available to a process. Zero
+// values are not limited.
+type ResourceLimits struct {
+	// NanoCPUs is the CPU quota in units of 1e-9 CPUs, at least 1e7.
+	NanoCPUs int64
+	// Memory is the memory limit in bytes.
+	Memory int64
+	// MemorySwap is the memory plus swap limit in bytes, -1 for unlimited
+	// swap.
+	MemorySwap int64
+	// Pids is the maximum number of processes.
+	Pids int64
+	// IOWeight is the relative IO weight between 10 and 1000.
+	IOWeight uint32
+}
+
 type UlimitName string
 
 const (
@@ -3,6 +3,7 @@ package llb
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/moby/buildkit/solver/pb"
 	"github.com/stretchr/testify/require"
@@ -50,3 +51,26 @@ func TestValidGetMountIndex(t *testing.T) {
 	require.NoError(t, err, "failed to getIndex")
 	require.Equal(t, pb.OutputIndex(1), mountIndex, "unexpected mount index")
 }
+
+func TestExecTimeout(t *testing.T) {
+	t.Parallel()
+
+	st := Image("foo").Run(Shlex("args"), WithTimeout(time.Minute), WithValidExitCodes(1, 2)).Root()
+	def, err := st.Marshal(context.TODO())
+	require.NoError(t, err)
+
+	m, arr := parseDef(t, def.Def)
+	dgst, idx := last(t, arr)
+	require.Equal(t, 0, idx)
+
+	exec := m[dgst].Op.(*pb.Op_Exec).Exec
+	require.Equal(t, int64(time.Minute), exec.Meta.Timeout)
+	require.Equal(t, []int32{1, 2}, exec.Meta.ValidExitCodes)
+	require.True(t, def.Metadata[dgst].Caps[pb.CapExecMetaTimeout])
+	require.True(t, def.Metadata[dgst].Caps[pb.CapExecMetaValidExitCodes])
+
+	st = Image("foo").Run(Shlex("args"), WithTimeout(-time.Second)).Root()
+	_, err = st.Marshal(context.TODO())
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "invalid negative timeout")
+}
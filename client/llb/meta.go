@@ -22,6 +22,7 @@ var (
 	keyHostname     = contextKeyT("llb.exec.hostname")
 	keyUlimit       = contextKeyT("llb.exec.ulimit")
 	keyCgroupParent = contextKeyT("llb.exec.cgroup.parent")
+	keyResources    = contextKeyT("llb.exec.resources")
 	keyUser         = contextKeyT("llb.exec.user")
 
 	keyPlatform = contextKeyT("llb.platform")
@@ -283,6 +284,26 @@ func getCgroupParent(s State) func(context.Context, *Constraints) (string, error
 	}
 }
 
+func resourceLimits(l ResourceLimits) StateOption {
+	return func(s State) State {
+		return s.WithValue(keyResources, l)
+	}
+}
+
+func getResourceLimits(s State) func(context.Context, *Constraints) (*ResourceLimits, error) {
+	return func(ctx context.Context, c *Constraints) (*ResourceLimits, error) {
+		v, err := s.getValue(keyResources)(ctx, c)
+		if err != nil {
+			return nil, err
+		}
+		if v != nil {
+			l := v.(ResourceLimits)
+			return &l, nil
+		}
+		return nil, nil
+	}
+}
+
 func Network(v pb.NetMode) StateOption {
 	return func(s State) State {
 		return s.WithValue(keyNetwork, v)
@@ -244,6 +244,10 @@ func Git(remote, ref string, opts ...GitOption) State {
 		attrs[pb.AttrKeepGitDir] = "true"
 		addCap(&gi.Constraints, pb.CapSourceGitKeepDir)
 	}
+	if gi.Checksum != "" {
+		attrs[pb.AttrGitChecksum] = gi.Checksum
+		addCap(&gi.Constraints, pb.CapSourceGitChecksum)
+	}
 	if url != "" {
 		attrs[pb.AttrFullRemoteURL] = url
 		addCap(&gi.Constraints, pb.CapSourceGitFullURL)
@@ -303,6 +307,7 @@ type GitInfo struct {
 	addAuthCap       bool
 	KnownSSHHosts    string
 	MountSSHSock     string
+	Checksum         string
 }
 
 func KeepGitDir() GitOption {
@@ -332,6 +337,14 @@ func KnownSSHHosts(key string) GitOption {
 	})
 }
 
+// GitChecksum verifies that the resolved commit of the git source starts with
+// the given commit SHA prefix.
+func GitChecksum(sha string) GitOption {
+	return gitOptionFunc(func(gi *GitInfo) {
+		gi.Checksum = sha
+	})
+}
+
 func MountSSHSock(sshID string) GitOption {
 	return gitOptionFunc(func(gi *GitInfo) {
 		gi.MountSSHSock = sshID
@@ -266,6 +266,8 @@ func (s State) Run(ro ...RunOption) ExecState {
 	}
 	exec.secrets = ei.Secrets
 	exec.ssh = ei.SSH
+	exec.timeout = ei.Timeout
+	exec.validExitCodes = ei.ValidExitCodes
 
 	return ExecState{
 		State: s.WithOutput(exec.Output()),
@@ -412,6 +414,12 @@ func (s State) WithCgroupParent(cp string) State {
 	return cgroupParent(cp)(s)
 }
 
+// WithResourceLimits sets the CPU, memory, pids and IO limits of the
+// processes run from the state.
+func (s State) WithResourceLimits(l ResourceLimits) State {
+	return resourceLimits(l)(s)
+}
+
 func (s State) isFileOpCopyInput() {}
 
 type output struct {
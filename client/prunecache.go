@@ -0,0 +1,170 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/containerd/containerd/content"
+	contentlocal "github.com/containerd/containerd/content/local"
+	"github.com/containerd/containerd/errdefs"
+	"github.com/containerd/containerd/images"
+	"github.com/moby/buildkit/client/ociindex"
+	digest "github.com/opencontainers/go-digest"
+	ocispecs "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/pkg/errors"
+)
+
+// cacheConfigMediaTypeV0 is the media type of the cache config blob referenced
+// by the manifest list of an exported cache.
+// Keep in sync with cache/remotecache/v1.CacheConfigMediaTypeV0.
+const cacheConfigMediaTypeV0 = "application/vnd.buildkit.cacheconfig.v0"
+
+// cacheConfig is the subset of cache/remotecache/v1.CacheConfig needed to
+// find the layer blobs of the cache chains.
+type cacheConfig struct {
+	Layers []struct {
+		Blob digest.Digest `json:"blob,omitempty"`
+	} `json:"layers,omitempty"`
+}
+
+// minLocalCachePruneAge is the age under which unreferenced blobs of a local
+// cache directory are never removed. An export writes its blobs before it
+// adds them to index.json, so newer blobs may belong to an export that is
+// still running, possibly from another host sharing the directory.
+const minLocalCachePruneAge = 24 * time.Hour
+
+// PruneLocalCache removes the blobs of the local cache directory dir, as
+// written by the "local" cache exporter, that are not reachable from any of
+// the cache manifests in its index.json. KeepDuration and KeepBytes of the
+// options limit what is removed the same way as for Prune: unreferenced blobs
+// newer than KeepDuration are kept and the oldest blobs are removed first, only
+// until the directory is below KeepBytes. Blobs newer than 24 hours are always
+// kept. Filters are not supported.
+func PruneLocalCache(ctx context.Context, dir string, opts ...PruneOption) ([]content.Info, error) {
+	info := &PruneInfo{}
+	for _, o := range opts {
+		o.SetPruneOption(info)
+	}
+	if len(info.Filter) != 0 {
+		return nil, errors.New("filters are not supported for local cache prune")
+	}
+
+	idx, err := ociindex.ReadIndexJSONFileLocked(filepath.Join(dir, "index.json"))
+	if err != nil {
+		return nil, err
+	}
+	cs, err := contentlocal.NewStore(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	reachable := map[digest.Digest]struct{}{}
+	for _, desc := range idx.Manifests {
+		if err := walkCacheBlobs(ctx, cs, desc, reachable); err != nil {
+			return nil, errors.Wrapf(err, "failed to walk cache manifest %s", desc.Digest)
+		}
+	}
+
+	var (
+		total      int64
+		candidates []content.Info
+	)
+	keepDuration := info.KeepDuration
+	if keepDuration < minLocalCachePruneAge {
+		keepDuration = minLocalCachePruneAge
+	}
+	cutoff := time.Now().Add(-keepDuration)
+	if err := cs.Walk(ctx, func(i content.Info) error {
+		total += i.Size
+		if _, ok := reachable[i.Digest]; ok {
+			return nil
+		}
+		if i.CreatedAt.After(cutoff) {
+			return nil
+		}
+		candidates = append(candidates, i)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].CreatedAt.Before(candidates[j].CreatedAt)
+	})
+
+	var deleted []content.Info
+	for _, i := range candidates {
+		if info.KeepBytes != 0 && total <= info.KeepBytes {
+			break
+		}
+		if err := cs.Delete(ctx, i.Digest); err != nil && !errdefs.IsNotFound(err) {
+			return deleted, err
+		}
+		total -= i.Size
+		deleted = append(deleted, i)
+	}
+	return deleted, nil
+}
+
+// walkCacheBlobs marks desc and all the blobs it references as reachable. The
+// layers of cache configs are followed in addition to the children of
+// manifests and indexes.
+func walkCacheBlobs(ctx context.Context, cs content.Store, desc ocispecs.Descriptor, reachable map[digest.Digest]struct{}) error {
+	if _, ok := reachable[desc.Digest]; ok {
+		return nil
+	}
+	reachable[desc.Digest] = struct{}{}
+
+	switch desc.MediaType {
+	case images.MediaTypeDockerSchema2ManifestList, ocispecs.MediaTypeImageIndex,
+		images.MediaTypeDockerSchema2Manifest, ocispecs.MediaTypeImageManifest, cacheConfigMediaTypeV0, "":
+	default:
+		return nil
+	}
+
+	dt, err := content.ReadBlob(ctx, cs, desc)
+	if err != nil {
+		if errdefs.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	if desc.MediaType == cacheConfigMediaTypeV0 {
+		var config cacheConfig
+		if err := json.Unmarshal(dt, &config); err != nil {
+			return errors.WithStack(err)
+		}
+		for _, l := range config.Layers {
+			reachable[l.Blob] = struct{}{}
+		}
+		return nil
+	}
+
+	// descriptors without a media type may point to either an index or a
+	// manifest, so the children of both are walked
+	var mfst struct {
+		Config    *ocispecs.Descriptor  `json:"config,omitempty"`
+		Layers    []ocispecs.Descriptor `json:"layers,omitempty"`
+		Manifests []ocispecs.Descriptor `json:"manifests,omitempty"`
+	}
+	if err := json.Unmarshal(dt, &mfst); err != nil {
+		if desc.MediaType == "" {
+			return nil
+		}
+		return errors.WithStack(err)
+	}
+	children := append(mfst.Manifests, mfst.Layers...)
+	if mfst.Config != nil {
+		children = append(children, *mfst.Config)
+	}
+	for _, c := range children {
+		if err := walkCacheBlobs(ctx, cs, c, reachable); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,508 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/containerd/containerd/content"
+	"github.com/containerd/containerd/images"
+	"github.com/containerd/containerd/remotes/docker"
+	"github.com/docker/distribution/reference"
+	digest "github.com/opencontainers/go-digest"
+	ocispecs "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/pkg/errors"
+)
+
+// minRegistryCachePruneAge is the age under which caches of a registry are
+// never removed, for the same reason as minLocalCachePruneAge. As the
+// creation time of a cache is the newest of its layers, its blobs are older
+// than this as well.
+const minRegistryCachePruneAge = minLocalCachePruneAge
+
+// PruneRegistryCache removes the caches, as written by the "registry" cache
+// exporter, that are tagged in the repository repo with a tag matching one of
+// the patterns in tags, together with the blobs that no remaining manifest of
+// the repository references. The patterns use the syntax of path.Match and at
+// least one is required, so that only caches that were selected explicitly
+// are removed. KeepDuration and KeepBytes of the options limit what is
+// removed the same way as for Prune: caches newer than KeepDuration are kept
+// and the oldest caches are removed first, only until the caches of the
+// repository are below KeepBytes. Caches of unknown age and caches newer than
+// 24 hours are always kept. Filters are not supported.
+//
+// The registry must allow deleting manifests and blobs. Blobs of cache
+// manifests that were already untagged by a later export can't be listed
+// through the registry API and are left to the garbage collection of the
+// registry.
+func PruneRegistryCache(ctx context.Context, repo string, tags []string, hosts docker.RegistryHosts, opts ...PruneOption) ([]content.Info, error) {
+	info := &PruneInfo{}
+	for _, o := range opts {
+		o.SetPruneOption(info)
+	}
+	if len(info.Filter) != 0 {
+		return nil, errors.New("filters are not supported for registry cache prune")
+	}
+	if len(tags) == 0 {
+		return nil, errors.New("registry cache prune requires the tags of the caches to prune")
+	}
+	for _, t := range tags {
+		if _, err := path.Match(t, ""); err != nil {
+			return nil, errors.Wrapf(err, "invalid tag pattern %q", t)
+		}
+	}
+
+	named, err := reference.ParseNormalizedNamed(repo)
+	if err != nil {
+		return nil, err
+	}
+	if !reference.IsNameOnly(named) {
+		return nil, errors.Errorf("%s is not a repository, the caches to prune are selected by their tags", repo)
+	}
+	rc, err := newRegistryClient(named, hosts)
+	if err != nil {
+		return nil, err
+	}
+	ctx = docker.WithScope(ctx, "repository:"+rc.name+":pull,delete")
+
+	manifests, order, err := rc.listManifests(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		total      int64
+		counted    = map[digest.Digest]struct{}{}
+		candidates []*registryManifest
+	)
+	keepDuration := info.KeepDuration
+	if keepDuration < minRegistryCachePruneAge {
+		keepDuration = minRegistryCachePruneAge
+	}
+	cutoff := time.Now().Add(-keepDuration)
+	for _, m := range order {
+		m.Labels["tag"] = strings.Join(m.tags, ",")
+		if !m.cache {
+			continue
+		}
+		for dgst, size := range m.blobs {
+			if _, ok := counted[dgst]; !ok {
+				counted[dgst] = struct{}{}
+				total += size
+			}
+		}
+		if !m.matchesAll(tags) || m.CreatedAt.IsZero() || m.CreatedAt.After(cutoff) {
+			continue
+		}
+		candidates = append(candidates, m)
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].CreatedAt.Before(candidates[j].CreatedAt)
+	})
+
+	var deleted []content.Info
+	var pruned []*registryManifest
+	for _, m := range candidates {
+		if info.KeepBytes != 0 && total <= info.KeepBytes {
+			break
+		}
+		if err := rc.delete(ctx, "/manifests/"+m.Digest.String()); err != nil {
+			return deleted, errors.Wrapf(err, "failed to delete manifest %s", m.Digest)
+		}
+		delete(manifests, m.Digest)
+		deleted = append(deleted, m.Info)
+		pruned = append(pruned, m)
+		for dgst, size := range m.blobs {
+			if !referencedBlob(manifests, dgst) {
+				total -= size
+			}
+		}
+	}
+	if len(pruned) == 0 {
+		return deleted, nil
+	}
+
+	// an export that is still running may have found a blob of a pruned cache
+	// present, so the tags are listed again right before the blobs are
+	// deleted to keep the blobs of the manifests pushed in the meantime
+	manifests, _, err = rc.listManifests(ctx, manifests)
+	if err != nil {
+		return deleted, err
+	}
+	seen := map[digest.Digest]struct{}{}
+	for _, m := range pruned {
+		for dgst, size := range m.blobs {
+			if _, ok := seen[dgst]; ok {
+				continue
+			}
+			seen[dgst] = struct{}{}
+			if referencedBlob(manifests, dgst) {
+				continue
+			}
+			if err := rc.delete(ctx, "/blobs/"+dgst.String()); err != nil {
+				return deleted, errors.Wrapf(err, "failed to delete blob %s", dgst)
+			}
+			deleted = append(deleted, content.Info{
+				Digest:    dgst,
+				Size:      size,
+				CreatedAt: m.CreatedAt,
+			})
+		}
+	}
+	return deleted, nil
+}
+
+// listManifests returns the manifests tagged in the repository by digest and
+// in the order of their tags. Manifests are keyed by digest, as deleting a
+// manifest removes all the tags pointing to it. The manifests in known are
+// not fetched again.
+func (c *registryClient) listManifests(ctx context.Context, known map[digest.Digest]*registryManifest) (map[digest.Digest]*registryManifest, []*registryManifest, error) {
+	tags, err := c.listTags(ctx)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to list tags of %s", c.name)
+	}
+	manifests := map[digest.Digest]*registryManifest{}
+	var order []*registryManifest
+	for _, tag := range tags {
+		dgst, dt, err := c.getManifest(ctx, tag)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "failed to get manifest for tag %s", tag)
+		}
+		if m, ok := manifests[dgst]; ok {
+			m.tags = append(m.tags, tag)
+			continue
+		}
+		m, ok := known[dgst]
+		if ok {
+			m.tags = []string{tag}
+		} else {
+			m = &registryManifest{
+				Info: content.Info{
+					Digest: dgst,
+					Size:   int64(len(dt)),
+					Labels: map[string]string{},
+				},
+				tags: []string{tag},
+			}
+			if err := c.walkManifest(ctx, m, dt); err != nil {
+				return nil, nil, errors.Wrapf(err, "failed to walk manifest for tag %s", tag)
+			}
+		}
+		manifests[dgst] = m
+		order = append(order, m)
+	}
+	return manifests, order, nil
+}
+
+// registryManifest is a manifest tagged in the repository being pruned.
+type registryManifest struct {
+	content.Info
+	tags []string
+	// blobs are all the blobs and child manifests referenced by the manifest
+	blobs map[digest.Digest]int64
+	// cache is set for manifests written by the registry cache exporter
+	cache bool
+}
+
+// matchesAll returns true if all the tags of the manifest match one of the
+// patterns, a manifest that is also tagged otherwise is kept.
+func (m *registryManifest) matchesAll(patterns []string) bool {
+	for _, t := range m.tags {
+		var ok bool
+		for _, p := range patterns {
+			if matched, _ := path.Match(p, t); matched {
+				ok = true
+				break
+			}
+		}
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func referencedBlob(manifests map[digest.Digest]*registryManifest, dgst digest.Digest) bool {
+	for _, m := range manifests {
+		if _, ok := m.blobs[dgst]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+type registryClient struct {
+	host docker.RegistryHost
+	name string
+}
+
+func newRegistryClient(named reference.Named, hosts docker.RegistryHosts) (*registryClient, error) {
+	domain := reference.Domain(named)
+	hs, err := hosts(domain)
+	if err != nil {
+		return nil, err
+	}
+	// mirrors can't push, so this selects the registry itself
+	for _, h := range hs {
+		if h.Capabilities.Has(docker.HostCapabilityPush) {
+			return &registryClient{host: h, name: reference.Path(named)}, nil
+		}
+	}
+	return nil, errors.Errorf("no registry host with push capability for %s", domain)
+}
+
+func (c *registryClient) url(p string) string {
+	return c.host.Scheme + "://" + c.host.Host + c.host.Path + "/" + c.name + p
+}
+
+func (c *registryClient) do(ctx context.Context, method, u string, header http.Header) (*http.Response, error) {
+	httpClient := c.host.Client
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	for retried := false; ; retried = true {
+		req, err := http.NewRequestWithContext(ctx, method, u, nil)
+		if err != nil {
+			return nil, err
+		}
+		for k, v := range c.host.Header {
+			req.Header[k] = v
+		}
+		for k, v := range header {
+			req.Header[k] = v
+		}
+		if c.host.Authorizer != nil {
+			if err := c.host.Authorizer.Authorize(ctx, req); err != nil {
+				return nil, err
+			}
+		}
+		resp, err := httpClient.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode != http.StatusUnauthorized || c.host.Authorizer == nil || retried {
+			return resp, nil
+		}
+		err = c.host.Authorizer.AddResponses(ctx, []*http.Response{resp})
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (c *registryClient) get(ctx context.Context, u string, header http.Header) (*http.Response, error) {
+	resp, err := c.do(ctx, http.MethodGet, u, header)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, errors.Errorf("unexpected status %s for GET %s", resp.Status, u)
+	}
+	return resp, nil
+}
+
+func (c *registryClient) delete(ctx context.Context, p string) error {
+	u := c.url(p)
+	resp, err := c.do(ctx, http.MethodDelete, u, nil)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusAccepted, http.StatusNotFound:
+		return nil
+	case http.StatusMethodNotAllowed:
+		return errors.Errorf("registry %s does not allow deletion", c.host.Host)
+	default:
+		return errors.Errorf("unexpected status %s for DELETE %s", resp.Status, u)
+	}
+}
+
+func (c *registryClient) listTags(ctx context.Context) ([]string, error) {
+	var tags []string
+	u := c.url("/tags/list")
+	for u != "" {
+		resp, err := c.get(ctx, u, nil)
+		if err != nil {
+			return nil, err
+		}
+		var list struct {
+			Tags []string `json:"tags"`
+		}
+		err = json.NewDecoder(resp.Body).Decode(&list)
+		resp.Body.Close()
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		tags = append(tags, list.Tags...)
+
+		next, err := nextLink(resp)
+		if err != nil {
+			return nil, err
+		}
+		u = next
+	}
+	return tags, nil
+}
+
+// nextLink returns the URL of the next page of a paginated response, if any.
+func nextLink(resp *http.Response) (string, error) {
+	link := resp.Header.Get("Link")
+	if !strings.Contains(link, `rel="next"`) {
+		return "", nil
+	}
+	start, end := strings.Index(link, "<"), strings.Index(link, ">")
+	if start < 0 || end < start {
+		return "", errors.Errorf("invalid link header %q", link)
+	}
+	next, err := url.Parse(link[start+1 : end])
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+	return resp.Request.URL.ResolveReference(next).String(), nil
+}
+
+var manifestAccept = strings.Join([]string{
+	ocispecs.MediaTypeImageIndex,
+	images.MediaTypeDockerSchema2ManifestList,
+	ocispecs.MediaTypeImageManifest,
+	images.MediaTypeDockerSchema2Manifest,
+}, ", ")
+
+func (c *registryClient) getManifest(ctx context.Context, ref string) (digest.Digest, []byte, error) {
+	resp, err := c.get(ctx, c.url("/manifests/"+ref), http.Header{"Accept": []string{manifestAccept}})
+	if err != nil {
+		return "", nil, err
+	}
+	defer resp.Body.Close()
+	dt, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", nil, errors.WithStack(err)
+	}
+	return digest.FromBytes(dt), dt, nil
+}
+
+func (c *registryClient) getBlob(ctx context.Context, dgst digest.Digest) ([]byte, error) {
+	resp, err := c.get(ctx, c.url("/blobs/"+dgst.String()), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	return ioutil.ReadAll(io.LimitReader(resp.Body, 16<<20))
+}
+
+// walkManifest records the blobs and child manifests referenced by the
+// manifest or index dt. For cache manifests, the layers of the cache config
+// are recorded as well and the newest record of the cache config sets the
+// creation time of the cache.
+func (c *registryClient) walkManifest(ctx context.Context, m *registryManifest, dt []byte) error {
+	if m.blobs == nil {
+		m.blobs = map[digest.Digest]int64{}
+	}
+	var mfst struct {
+		Config    *ocispecs.Descriptor  `json:"config,omitempty"`
+		Layers    []ocispecs.Descriptor `json:"layers,omitempty"`
+		Manifests []ocispecs.Descriptor `json:"manifests,omitempty"`
+	}
+	if err := json.Unmarshal(dt, &mfst); err != nil {
+		return errors.WithStack(err)
+	}
+	descs := append(mfst.Manifests, mfst.Layers...)
+	if mfst.Config != nil {
+		descs = append(descs, *mfst.Config)
+	}
+
+	var cacheConfig *ocispecs.Descriptor
+	for i, desc := range descs {
+		if desc.MediaType == cacheConfigMediaTypeV0 {
+			m.cache = true
+			cacheConfig = &descs[i]
+		}
+	}
+	for _, desc := range descs {
+		if _, ok := m.blobs[desc.Digest]; ok {
+			continue
+		}
+		m.blobs[desc.Digest] = desc.Size
+		if m.cache {
+			// the manifests list of a cache contains layers, not manifests
+			continue
+		}
+		switch desc.MediaType {
+		case images.MediaTypeDockerSchema2ManifestList, ocispecs.MediaTypeImageIndex,
+			images.MediaTypeDockerSchema2Manifest, ocispecs.MediaTypeImageManifest:
+			_, child, err := c.getManifest(ctx, desc.Digest.String())
+			if err != nil {
+				return err
+			}
+			if err := c.walkManifest(ctx, m, child); err != nil {
+				return err
+			}
+		}
+	}
+	if cacheConfig != nil {
+		return c.walkCacheConfig(ctx, m, *cacheConfig)
+	}
+	return nil
+}
+
+func (c *registryClient) walkCacheConfig(ctx context.Context, m *registryManifest, desc ocispecs.Descriptor) error {
+	dt, err := c.getBlob(ctx, desc.Digest)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get cache config %s", desc.Digest)
+	}
+	var config struct {
+		Layers []struct {
+			Blob        digest.Digest `json:"blob,omitempty"`
+			Annotations *struct {
+				Size      int64     `json:"size,omitempty"`
+				CreatedAt time.Time `json:"createdAt,omitempty"`
+			} `json:"annotations,omitempty"`
+		} `json:"layers,omitempty"`
+		Records []struct {
+			Results []struct {
+				CreatedAt time.Time `json:"createdAt,omitempty"`
+			} `json:"layers,omitempty"`
+			ChainedResults []struct {
+				CreatedAt time.Time `json:"createdAt,omitempty"`
+			} `json:"chains,omitempty"`
+		} `json:"records,omitempty"`
+	}
+	if err := json.Unmarshal(dt, &config); err != nil {
+		return errors.WithStack(err)
+	}
+	setCreated := func(t time.Time) {
+		if t.After(m.CreatedAt) {
+			m.CreatedAt = t
+		}
+	}
+	for _, l := range config.Layers {
+		var size int64
+		if l.Annotations != nil {
+			size = l.Annotations.Size
+			setCreated(l.Annotations.CreatedAt)
+		}
+		if _, ok := m.blobs[l.Blob]; !ok {
+			m.blobs[l.Blob] = size
+		}
+	}
+	for _, r := range config.Records {
+		for _, res := range r.Results {
+			setCreated(res.CreatedAt)
+		}
+		for _, res := range r.ChainedResults {
+			setCreated(res.CreatedAt)
+		}
+	}
+	return nil
+}
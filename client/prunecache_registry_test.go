@@ -0,0 +1,215 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/containerd/containerd/content"
+	"github.com/containerd/containerd/images"
+	"github.com/containerd/containerd/remotes/docker"
+	digest "github.com/opencontainers/go-digest"
+	ocispecs "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeRegistry serves the tags, manifests and blobs of a single repository
+// and records deletions.
+type fakeRegistry struct {
+	mu        sync.Mutex
+	name      string
+	tags      map[string]digest.Digest
+	manifests map[digest.Digest][]byte
+	blobs     map[digest.Digest][]byte
+	// onDelete is called after a manifest was deleted
+	onDelete func()
+}
+
+func (r *fakeRegistry) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	p := strings.TrimPrefix(req.URL.Path, "/v2/"+r.name)
+	switch {
+	case p == "/tags/list" && req.Method == http.MethodGet:
+		var tags []string
+		for t := range r.tags {
+			tags = append(tags, t)
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"name": r.name, "tags": tags})
+	case strings.HasPrefix(p, "/manifests/"):
+		ref := strings.TrimPrefix(p, "/manifests/")
+		dgst, ok := r.tags[ref]
+		if !ok {
+			dgst = digest.Digest(ref)
+		}
+		dt, ok := r.manifests[dgst]
+		if !ok {
+			http.NotFound(w, req)
+			return
+		}
+		switch req.Method {
+		case http.MethodGet:
+			w.Write(dt)
+		case http.MethodDelete:
+			delete(r.manifests, dgst)
+			for t, d := range r.tags {
+				if d == dgst {
+					delete(r.tags, t)
+				}
+			}
+			if r.onDelete != nil {
+				r.onDelete()
+			}
+			w.WriteHeader(http.StatusAccepted)
+		}
+	case strings.HasPrefix(p, "/blobs/"):
+		dgst := digest.Digest(strings.TrimPrefix(p, "/blobs/"))
+		dt, ok := r.blobs[dgst]
+		if !ok {
+			http.NotFound(w, req)
+			return
+		}
+		switch req.Method {
+		case http.MethodGet:
+			w.Write(dt)
+		case http.MethodDelete:
+			delete(r.blobs, dgst)
+			w.WriteHeader(http.StatusAccepted)
+		}
+	default:
+		http.NotFound(w, req)
+	}
+}
+
+func (r *fakeRegistry) addBlob(mediaType string, dt []byte) ocispecs.Descriptor {
+	desc := ocispecs.Descriptor{MediaType: mediaType, Digest: digest.FromBytes(dt), Size: int64(len(dt))}
+	r.blobs[desc.Digest] = dt
+	return desc
+}
+
+func (r *fakeRegistry) addManifest(t *testing.T, tag string, v interface{}) digest.Digest {
+	dt, err := json.Marshal(v)
+	require.NoError(t, err)
+	dgst := digest.FromBytes(dt)
+	r.manifests[dgst] = dt
+	r.tags[tag] = dgst
+	return dgst
+}
+
+func (r *fakeRegistry) addCache(t *testing.T, tag string, created time.Time, layers ...ocispecs.Descriptor) (digest.Digest, ocispecs.Descriptor) {
+	var cfgLayers []map[string]interface{}
+	for _, l := range layers {
+		cfgLayers = append(cfgLayers, map[string]interface{}{"blob": l.Digest})
+	}
+	dt, err := json.Marshal(map[string]interface{}{
+		"layers": cfgLayers,
+		"records": []map[string]interface{}{
+			{"layers": []map[string]interface{}{{"layer": 0, "createdAt": created}}},
+		},
+	})
+	require.NoError(t, err)
+	config := r.addBlob(cacheConfigMediaTypeV0, dt)
+	return r.addManifest(t, tag, ocispecs.Index{
+		MediaType: images.MediaTypeDockerSchema2ManifestList,
+		Manifests: append(append([]ocispecs.Descriptor{}, layers...), config),
+	}), config
+}
+
+func TestPruneRegistryCache(t *testing.T) {
+	t.Parallel()
+	ctx := context.TODO()
+
+	reg := &fakeRegistry{
+		name:      "library/cache",
+		tags:      map[string]digest.Digest{},
+		manifests: map[digest.Digest][]byte{},
+		blobs:     map[digest.Digest][]byte{},
+	}
+	srv := httptest.NewServer(reg)
+	defer srv.Close()
+	hosts := func(string) ([]docker.RegistryHost, error) {
+		return []docker.RegistryHost{{
+			Client:       srv.Client(),
+			Host:         strings.TrimPrefix(srv.URL, "http://"),
+			Scheme:       "http",
+			Path:         "/v2",
+			Capabilities: docker.HostCapabilityPull | docker.HostCapabilityResolve | docker.HostCapabilityPush,
+		}}, nil
+	}
+	ref := strings.TrimPrefix(srv.URL, "http://") + "/" + reg.name
+
+	day := 24 * time.Hour
+	shared := reg.addBlob(images.MediaTypeDockerSchema2LayerGzip, []byte("shared"))
+	imageLayer := reg.addBlob(images.MediaTypeDockerSchema2LayerGzip, []byte("image"))
+	oldestLayer := reg.addBlob(images.MediaTypeDockerSchema2LayerGzip, []byte("oldest"))
+	oldLayer := reg.addBlob(images.MediaTypeDockerSchema2LayerGzip, []byte("old"))
+	mainLayer := reg.addBlob(images.MediaTypeDockerSchema2LayerGzip, []byte("main"))
+	newLayer := reg.addBlob(images.MediaTypeDockerSchema2LayerGzip, []byte("new"))
+
+	oldestMfst, oldestConfig := reg.addCache(t, "pr-1", time.Now().Add(-10*day), oldestLayer, shared, imageLayer)
+	oldMfst, oldConfig := reg.addCache(t, "pr-2", time.Now().Add(-5*day), oldLayer, shared)
+	newMfst, _ := reg.addCache(t, "pr-3", time.Now().Add(-time.Hour), newLayer, shared)
+	mainMfst, _ := reg.addCache(t, "main", time.Now().Add(-20*day), mainLayer, shared)
+	imageConfig := reg.addBlob(images.MediaTypeDockerSchema2Config, []byte("{}"))
+	reg.addManifest(t, "image", ocispecs.Manifest{
+		MediaType: images.MediaTypeDockerSchema2Manifest,
+		Config:    imageConfig,
+		Layers:    []ocispecs.Descriptor{imageLayer},
+	})
+
+	digests := func(infos []content.Info) []digest.Digest {
+		var dgsts []digest.Digest
+		for _, i := range infos {
+			dgsts = append(dgsts, i.Digest)
+		}
+		return dgsts
+	}
+
+	// the oldest cache is removed, keeping the blobs used by the other caches
+	// and the image
+	deleted, err := PruneRegistryCache(ctx, ref, []string{"pr-*"}, hosts, WithKeepOpt(7*day, 0))
+	require.NoError(t, err)
+	require.ElementsMatch(t, []digest.Digest{oldestMfst, oldestLayer.Digest, oldestConfig.Digest}, digests(deleted))
+
+	// caches that don't match the tags and caches newer than a day are kept
+	deleted, err = PruneRegistryCache(ctx, ref, []string{"pr-*"}, hosts)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []digest.Digest{oldMfst, oldLayer.Digest, oldConfig.Digest}, digests(deleted))
+
+	require.Equal(t, map[string]digest.Digest{"main": mainMfst, "pr-3": newMfst, "image": reg.tags["image"]}, reg.tags)
+	for _, desc := range []ocispecs.Descriptor{shared, imageLayer, mainLayer, newLayer, imageConfig} {
+		require.Contains(t, reg.blobs, desc.Digest)
+	}
+
+	// a blob of a pruned cache that an export pushing at the same time
+	// references is kept
+	reusedLayer := reg.addBlob(images.MediaTypeDockerSchema2LayerGzip, []byte("reused"))
+	staleMfst, staleConfig := reg.addCache(t, "pr-4", time.Now().Add(-10*day), reusedLayer)
+	reg.onDelete = func() {
+		reg.onDelete = nil
+		dt, err := json.Marshal(ocispecs.Index{
+			MediaType: images.MediaTypeDockerSchema2ManifestList,
+			Manifests: []ocispecs.Descriptor{reusedLayer},
+		})
+		require.NoError(t, err)
+		reg.manifests[digest.FromBytes(dt)] = dt
+		reg.tags["pr-5"] = digest.FromBytes(dt)
+	}
+	deleted, err = PruneRegistryCache(ctx, ref, []string{"pr-4"}, hosts)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []digest.Digest{staleMfst, staleConfig.Digest}, digests(deleted))
+	require.Contains(t, reg.blobs, reusedLayer.Digest)
+
+	_, err = PruneRegistryCache(ctx, ref, nil, hosts)
+	require.Error(t, err)
+	_, err = PruneRegistryCache(ctx, ref+":main", []string{"pr-*"}, hosts)
+	require.Error(t, err)
+	_, err = PruneRegistryCache(ctx, ref, []string{"pr-*"}, hosts, WithFilter([]string{"type==regular"}))
+	require.Error(t, err)
+}
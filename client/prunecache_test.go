@@ -0,0 +1,102 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/containerd/containerd/content"
+	contentlocal "github.com/containerd/containerd/content/local"
+	"github.com/containerd/containerd/images"
+	"github.com/moby/buildkit/client/ociindex"
+	digest "github.com/opencontainers/go-digest"
+	ocispecs "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPruneLocalCache(t *testing.T) {
+	t.Parallel()
+	ctx := context.TODO()
+
+	dir := t.TempDir()
+	cs, err := contentlocal.NewStore(dir)
+	require.NoError(t, err)
+
+	writeBlob := func(mediaType string, dt []byte, age time.Duration) ocispecs.Descriptor {
+		desc := ocispecs.Descriptor{
+			MediaType: mediaType,
+			Digest:    digest.FromBytes(dt),
+			Size:      int64(len(dt)),
+		}
+		require.NoError(t, content.WriteBlob(ctx, cs, desc.Digest.String(), bytes.NewReader(dt), desc))
+		tm := time.Now().Add(-age)
+		require.NoError(t, os.Chtimes(filepath.Join(dir, "blobs", desc.Digest.Algorithm().String(), desc.Digest.Hex()), tm, tm))
+		return desc
+	}
+	writeJSON := func(mediaType string, v interface{}, age time.Duration) ocispecs.Descriptor {
+		dt, err := json.Marshal(v)
+		require.NoError(t, err)
+		return writeBlob(mediaType, dt, age)
+	}
+
+	day := 24 * time.Hour
+	layer := writeBlob(images.MediaTypeDockerSchema2LayerGzip, []byte("layer"), 4*day)
+	// referenced only by the cache config, not by the manifest list
+	configLayer := writeBlob(images.MediaTypeDockerSchema2LayerGzip, []byte("config-layer"), 4*day)
+	config := writeJSON(cacheConfigMediaTypeV0, map[string]interface{}{
+		"layers": []map[string]interface{}{
+			{"blob": layer.Digest},
+			{"blob": configLayer.Digest},
+		},
+	}, 4*day)
+	mfst := writeJSON(images.MediaTypeDockerSchema2ManifestList, ocispecs.Index{
+		Manifests: []ocispecs.Descriptor{layer, config},
+	}, 4*day)
+	require.NoError(t, ociindex.PutDescToIndexJSONFileLocked(filepath.Join(dir, "index.json"), mfst, "latest"))
+
+	oldest := writeBlob(images.MediaTypeDockerSchema2LayerGzip, []byte("oldest-unreferenced"), 3*day)
+	old := writeBlob(images.MediaTypeDockerSchema2LayerGzip, []byte("old-unreferenced"), 2*day)
+	recent := writeBlob(images.MediaTypeDockerSchema2LayerGzip, []byte("recent-unreferenced"), day+time.Hour)
+	// may belong to an export that has not updated index.json yet
+	fresh := writeBlob(images.MediaTypeDockerSchema2LayerGzip, []byte("fresh-unreferenced"), time.Minute)
+
+	exists := func(desc ocispecs.Descriptor) bool {
+		_, err := cs.Info(ctx, desc.Digest)
+		return err == nil
+	}
+
+	// keep all but the oldest unreferenced blob
+	var total int64
+	for _, desc := range []ocispecs.Descriptor{layer, configLayer, config, mfst, old, recent, fresh} {
+		total += desc.Size
+	}
+	deleted, err := PruneLocalCache(ctx, dir, WithKeepOpt(0, total))
+	require.NoError(t, err)
+	require.Equal(t, 1, len(deleted))
+	require.Equal(t, oldest.Digest, deleted[0].Digest)
+
+	deleted, err = PruneLocalCache(ctx, dir, WithKeepOpt(36*time.Hour, 0))
+	require.NoError(t, err)
+	require.Equal(t, 1, len(deleted))
+	require.Equal(t, old.Digest, deleted[0].Digest)
+	require.True(t, exists(recent))
+
+	deleted, err = PruneLocalCache(ctx, dir)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(deleted))
+	require.Equal(t, recent.Digest, deleted[0].Digest)
+
+	for _, desc := range []ocispecs.Descriptor{layer, configLayer, config, mfst, fresh} {
+		require.True(t, exists(desc), desc.Digest)
+	}
+	for _, desc := range []ocispecs.Descriptor{oldest, old, recent} {
+		require.False(t, exists(desc), desc.Digest)
+	}
+
+	_, err = PruneLocalCache(ctx, dir, WithFilter([]string{"type==regular"}))
+	require.Error(t, err)
+}
@@ -6,6 +6,7 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
@@ -18,8 +19,8 @@ import (
 	"github.com/moby/buildkit/session"
 	sessioncontent "github.com/moby/buildkit/session/content"
 	"github.com/moby/buildkit/session/filesync"
-	"github.com/moby/buildkit/session/grpchijack"
 	"github.com/moby/buildkit/solver/pb"
+	spb "github.com/moby/buildkit/sourcepolicy/pb"
 	"github.com/moby/buildkit/util/bklog"
 	"github.com/moby/buildkit/util/entitlements"
 	ocispecs "github.com/opencontainers/image-spec/specs-go/v1"
@@ -32,6 +33,7 @@ import (
 type SolveOpt struct {
 	Exports               []ExportEntry
 	LocalDirs             map[string]string
+	BindLocalDirs         bool // allow a daemon on the same host to bind-mount LocalDirs read-only instead of copying them
 	SharedKey             string
 	Frontend              string
 	FrontendAttrs         map[string]string
@@ -40,6 +42,7 @@ type SolveOpt struct {
 	CacheImports          []CacheOptionsEntry
 	Session               []session.Attachable
 	AllowedEntitlements   []entitlements.Entitlement
+	SourcePolicy          *spb.Policy
 	SharedSession         *session.Session // TODO: refactor to better session syncing
 	SessionPreInitialized bool             // TODO: refactor to better session syncing
 }
@@ -56,6 +59,11 @@ type CacheOptionsEntry struct {
 	Attrs map[string]string
 }
 
+// CacheFallbackSeparator separates the values of a cache import attribute
+// that form a fallback chain, e.g. the refs of a registry cache import or the
+// scopes of a gha cache import.
+const CacheFallbackSeparator = "\n"
+
 // Solve calls Solve on the controller.
 // def must be nil if (and only if) opt.Frontend is set.
 func (c *Client) Solve(ctx context.Context, def *llb.Definition, opt SolveOpt, statusChan chan *SolveStatus) (*SolveResponse, error) {
@@ -82,7 +90,7 @@ func (c *Client) solve(ctx context.Context, def *llb.Definition, runGateway runG
 		return nil, errors.New("invalid with def and cb")
 	}
 
-	syncedDirs, err := prepareSyncedDirs(def, opt.LocalDirs)
+	syncedDirs, err := prepareSyncedDirs(def, opt.LocalDirs, opt.BindLocalDirs)
 	if err != nil {
 		return nil, err
 	}
@@ -114,59 +122,31 @@ func (c *Client) solve(ctx context.Context, def *llb.Definition, runGateway runG
 		return nil, err
 	}
 
-	var ex ExportEntry
 	if len(opt.Exports) > 1 {
-		return nil, errors.New("currently only single Exports can be specified")
-	}
-	if len(opt.Exports) == 1 {
-		ex = opt.Exports[0]
-	}
-
-	if !opt.SessionPreInitialized {
-		if len(syncedDirs) > 0 {
-			s.Allow(filesync.NewFSSyncProvider(syncedDirs))
-		}
-
-		for _, a := range opt.Session {
-			s.Allow(a)
+		if err := c.checkMultipleExporters(ctx); err != nil {
+			return nil, err
 		}
+	}
 
-		switch ex.Type {
-		case ExporterLocal:
-			if ex.Output != nil {
-				return nil, errors.New("output file writer is not supported by local exporter")
-			}
-			if ex.OutputDir == "" {
-				return nil, errors.New("output directory is required for local exporter")
-			}
-			s.Allow(filesync.NewFSSyncTargetDir(ex.OutputDir))
-		case ExporterOCI, ExporterDocker, ExporterTar:
-			if ex.OutputDir != "" {
-				return nil, errors.Errorf("output directory %s is not supported by %s exporter", ex.OutputDir, ex.Type)
-			}
-			if ex.Output == nil {
-				return nil, errors.Errorf("output file writer is required for %s exporter", ex.Type)
-			}
-			s.Allow(filesync.NewFSSyncTarget(ex.Output))
-		default:
-			if ex.Output != nil {
-				return nil, errors.Errorf("output file writer is not supported by %s exporter", ex.Type)
-			}
-			if ex.OutputDir != "" {
-				return nil, errors.Errorf("output directory %s is not supported by %s exporter", ex.OutputDir, ex.Type)
-			}
+	var ex ExportEntry
+	exporters := make([]*controlapi.Exporter, 0, len(opt.Exports))
+	for i, e := range opt.Exports {
+		if i == 0 {
+			ex = e
 		}
+		exporters = append(exporters, &controlapi.Exporter{
+			Type:  e.Type,
+			Attrs: e.Attrs,
+		})
+	}
 
-		if len(cacheOpt.contentStores) > 0 {
-			s.Allow(sessioncontent.NewAttachable(cacheOpt.contentStores))
+	if !opt.SessionPreInitialized {
+		if err := prepareSession(s, syncedDirs, opt.Exports, cacheOpt, opt.Session); err != nil {
+			return nil, err
 		}
 
 		eg.Go(func() error {
-			sd := c.sessionDialer
-			if sd == nil {
-				sd = grpchijack.Dialer(c.controlClient())
-			}
-			return s.Run(statusContext, sd)
+			return s.Run(statusContext, c.Dialer())
 		})
 	}
 
@@ -188,8 +168,11 @@ func (c *Client) solve(ctx context.Context, def *llb.Definition, runGateway runG
 				<-time.After(3 * time.Second)
 				cancelStatus()
 			}()
-			bklog.G(ctx).Debugf("stopping session")
-			s.Close()
+			// a preinitialized session is closed by its owner
+			if !opt.SessionPreInitialized {
+				bklog.G(ctx).Debugf("stopping session")
+				s.Close()
+			}
 		}()
 		var pbd *pb.Definition
 		if def != nil {
@@ -210,12 +193,14 @@ func (c *Client) solve(ctx context.Context, def *llb.Definition, runGateway runG
 			Definition:     pbd,
 			Exporter:       ex.Type,
 			ExporterAttrs:  ex.Attrs,
+			Exporters:      exporters,
 			Session:        s.ID(),
 			Frontend:       opt.Frontend,
 			FrontendAttrs:  opt.FrontendAttrs,
 			FrontendInputs: frontendInputs,
 			Cache:          cacheOpt.options,
 			Entitlements:   opt.AllowedEntitlements,
+			SourcePolicy:   opt.SourcePolicy,
 		})
 		if err != nil {
 			return errors.Wrap(err, "failed to solve")
@@ -263,52 +248,8 @@ func (c *Client) solve(ctx context.Context, def *llb.Definition, runGateway runG
 				}
 				return errors.Wrap(err, "failed to receive status")
 			}
-			s := SolveStatus{}
-			for _, v := range resp.Vertexes {
-				s.Vertexes = append(s.Vertexes, &Vertex{
-					Digest:        v.Digest,
-					Inputs:        v.Inputs,
-					Name:          v.Name,
-					Started:       v.Started,
-					Completed:     v.Completed,
-					Error:         v.Error,
-					Cached:        v.Cached,
-					ProgressGroup: v.ProgressGroup,
-				})
-			}
-			for _, v := range resp.Statuses {
-				s.Statuses = append(s.Statuses, &VertexStatus{
-					ID:        v.ID,
-					Vertex:    v.Vertex,
-					Name:      v.Name,
-					Total:     v.Total,
-					Current:   v.Current,
-					Timestamp: v.Timestamp,
-					Started:   v.Started,
-					Completed: v.Completed,
-				})
-			}
-			for _, v := range resp.Logs {
-				s.Logs = append(s.Logs, &VertexLog{
-					Vertex:    v.Vertex,
-					Stream:    int(v.Stream),
-					Data:      v.Msg,
-					Timestamp: v.Timestamp,
-				})
-			}
-			for _, v := range resp.Warnings {
-				s.Warnings = append(s.Warnings, &VertexWarning{
-					Vertex:     v.Vertex,
-					Level:      int(v.Level),
-					Short:      v.Short,
-					Detail:     v.Detail,
-					URL:        v.Url,
-					SourceInfo: v.Info,
-					Range:      v.Ranges,
-				})
-			}
 			if statusChan != nil {
-				statusChan <- &s
+				statusChan <- NewSolveStatus(resp)
 			}
 		}
 	})
@@ -328,11 +269,100 @@ func (c *Client) solve(ctx context.Context, def *llb.Definition, runGateway runG
 				return nil, err
 			}
 		}
+		for csDir, pruneOpts := range cacheOpt.localPrunes {
+			if _, err := PruneLocalCache(ctx, csDir, pruneOpts...); err != nil {
+				return nil, errors.Wrapf(err, "failed to prune local cache %s", csDir)
+			}
+		}
 	}
 	return res, nil
 }
 
-func prepareSyncedDirs(def *llb.Definition, localDirs map[string]string) ([]filesync.SyncedDir, error) {
+// NewSession creates a session with the local directories, export targets and
+// cache stores of opt attached. The session can be shared by multiple solves
+// with the same options by setting SharedSession and SessionPreInitialized,
+// e.g. to build again when the local directories change. The caller runs the
+// session with Dialer and closes it after the last solve.
+func (c *Client) NewSession(ctx context.Context, def *llb.Definition, opt SolveOpt) (*session.Session, error) {
+	syncedDirs, err := prepareSyncedDirs(def, opt.LocalDirs, opt.BindLocalDirs)
+	if err != nil {
+		return nil, err
+	}
+
+	cacheOpt, err := parseCacheOptions(ctx, false, opt)
+	if err != nil {
+		return nil, err
+	}
+
+	s, err := session.NewSession(ctx, defaultSessionName(), opt.SharedKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create session")
+	}
+	if err := prepareSession(s, syncedDirs, opt.Exports, cacheOpt, opt.Session); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// checkMultipleExporters returns an error if the daemon can't run more than
+// one exporter. Older daemons only run the first one.
+func (c *Client) checkMultipleExporters(ctx context.Context) error {
+	res, err := c.controlClient().Info(ctx, &controlapi.InfoRequest{})
+	if err != nil {
+		return errors.Wrap(err, "failed to check support for multiple exporters")
+	}
+	caps := controlapi.Caps.CapSet(res.ControlAPICaps)
+	return caps.Supports(controlapi.CapMultipleExporters)
+}
+
+func prepareSession(s *session.Session, syncedDirs []filesync.SyncedDir, exports []ExportEntry, cacheOpt *cacheOptions, attachables []session.Attachable) error {
+	if len(syncedDirs) > 0 {
+		s.Allow(filesync.NewFSSyncProvider(syncedDirs))
+	}
+
+	for _, a := range attachables {
+		s.Allow(a)
+	}
+
+	var targets []filesync.FSSyncTarget
+	for i, ex := range exports {
+		switch ex.Type {
+		case ExporterLocal:
+			if ex.Output != nil {
+				return errors.New("output file writer is not supported by local exporter")
+			}
+			if ex.OutputDir == "" {
+				return errors.New("output directory is required for local exporter")
+			}
+			targets = append(targets, filesync.FSSyncTarget{ID: i, OutDir: ex.OutputDir})
+		case ExporterOCI, ExporterDocker, ExporterTar:
+			if ex.OutputDir != "" {
+				return errors.Errorf("output directory %s is not supported by %s exporter", ex.OutputDir, ex.Type)
+			}
+			if ex.Output == nil {
+				return errors.Errorf("output file writer is required for %s exporter", ex.Type)
+			}
+			targets = append(targets, filesync.FSSyncTarget{ID: i, Output: ex.Output})
+		default:
+			if ex.Output != nil {
+				return errors.Errorf("output file writer is not supported by %s exporter", ex.Type)
+			}
+			if ex.OutputDir != "" {
+				return errors.Errorf("output directory %s is not supported by %s exporter", ex.OutputDir, ex.Type)
+			}
+		}
+	}
+	if len(targets) > 0 {
+		s.Allow(filesync.NewFSSyncTargets(targets...))
+	}
+
+	if len(cacheOpt.contentStores) > 0 {
+		s.Allow(sessioncontent.NewAttachable(cacheOpt.contentStores))
+	}
+	return nil
+}
+
+func prepareSyncedDirs(def *llb.Definition, localDirs map[string]string, bind bool) ([]filesync.SyncedDir, error) {
 	for _, d := range localDirs {
 		fi, err := os.Stat(d)
 		if err != nil {
@@ -351,7 +381,7 @@ func prepareSyncedDirs(def *llb.Definition, localDirs map[string]string) ([]file
 	dirs := make([]filesync.SyncedDir, 0, len(localDirs))
 	if def == nil {
 		for name, d := range localDirs {
-			dirs = append(dirs, filesync.SyncedDir{Name: name, Dir: d, Map: resetUIDAndGID})
+			dirs = append(dirs, filesync.SyncedDir{Name: name, Dir: d, Map: resetUIDAndGID, BindThrough: bind})
 		}
 	} else {
 		for _, dt := range def.Def {
@@ -366,7 +396,7 @@ func prepareSyncedDirs(def *llb.Definition, localDirs map[string]string) ([]file
 					if !ok {
 						return nil, errors.Errorf("local directory %s not enabled", name)
 					}
-					dirs = append(dirs, filesync.SyncedDir{Name: name, Dir: d, Map: resetUIDAndGID})
+					dirs = append(dirs, filesync.SyncedDir{Name: name, Dir: d, Map: resetUIDAndGID, BindThrough: bind})
 				}
 			}
 		}
@@ -386,6 +416,7 @@ type cacheOptions struct {
 	options         controlapi.CacheOptions
 	contentStores   map[string]content.Store // key: ID of content store ("local:" + csDir)
 	indicesToUpdate map[string]string        // key: index.JSON file name, value: tag
+	localPrunes     map[string][]PruneOption // key: local cache directory
 	frontendAttrs   map[string]string
 }
 
@@ -399,6 +430,7 @@ func parseCacheOptions(ctx context.Context, isGateway bool, opt SolveOpt) (*cach
 	)
 	contentStores := make(map[string]content.Store)
 	indicesToUpdate := make(map[string]string) // key: index.JSON file name, value: tag
+	localPrunes := make(map[string][]PruneOption)
 	frontendAttrs := make(map[string]string)
 	legacyExportAttrs := make(map[string]string)
 	for _, ex := range opt.CacheExports {
@@ -418,6 +450,11 @@ func parseCacheOptions(ctx context.Context, isGateway bool, opt SolveOpt) (*cach
 			// TODO(AkihiroSuda): support custom index JSON path and tag
 			indexJSONPath := filepath.Join(csDir, "index.json")
 			indicesToUpdate[indexJSONPath] = "latest"
+			if pruneOpts, err := parseLocalCachePrune(ex.Attrs); err != nil {
+				return nil, err
+			} else if pruneOpts != nil {
+				localPrunes[csDir] = pruneOpts
+			}
 		}
 		if ex.Type == "registry" && legacyExportRef == "" {
 			legacyExportRef = ex.Attrs["ref"]
@@ -500,7 +537,41 @@ func parseCacheOptions(ctx context.Context, isGateway bool, opt SolveOpt) (*cach
 		},
 		contentStores:   contentStores,
 		indicesToUpdate: indicesToUpdate,
+		localPrunes:     localPrunes,
 		frontendAttrs:   frontendAttrs,
 	}
 	return &res, nil
 }
+
+// parseLocalCachePrune returns the options for pruning the unreferenced blobs
+// of a local cache directory after the export, or nil if pruning isn't
+// enabled with the "prune" attribute.
+func parseLocalCachePrune(attrs map[string]string) ([]PruneOption, error) {
+	v, ok := attrs["prune"]
+	if !ok {
+		return nil, nil
+	}
+	prune, err := strconv.ParseBool(v)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse prune")
+	}
+	if !prune {
+		return nil, nil
+	}
+
+	var (
+		keepDuration time.Duration
+		keepBytes    int64
+	)
+	if v, ok := attrs["prune-keep-duration"]; ok {
+		if keepDuration, err = time.ParseDuration(v); err != nil {
+			return nil, errors.Wrapf(err, "failed to parse prune-keep-duration")
+		}
+	}
+	if v, ok := attrs["prune-keep-bytes"]; ok {
+		if keepBytes, err = strconv.ParseInt(v, 10, 64); err != nil {
+			return nil, errors.Wrapf(err, "failed to parse prune-keep-bytes")
+		}
+	}
+	return []PruneOption{WithKeepOpt(keepDuration, keepBytes)}, nil
+}
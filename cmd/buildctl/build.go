@@ -6,16 +6,22 @@ import (
 	"encoding/json"
 	"io"
 	"os"
+	"sort"
+	"strings"
 
 	"github.com/containerd/continuity"
+	"github.com/google/shlex"
 	"github.com/moby/buildkit/client"
 	"github.com/moby/buildkit/client/llb"
 	"github.com/moby/buildkit/cmd/buildctl/build"
 	bccommon "github.com/moby/buildkit/cmd/buildctl/common"
+	"github.com/moby/buildkit/exporter/containerimage/exptypes"
+	gateway "github.com/moby/buildkit/frontend/gateway/client"
 	"github.com/moby/buildkit/session"
 	"github.com/moby/buildkit/session/auth/authprovider"
 	"github.com/moby/buildkit/session/sshforward/sshprovider"
 	"github.com/moby/buildkit/solver/pb"
+	"github.com/moby/buildkit/util/progress/progressui"
 	"github.com/moby/buildkit/util/progress/progresswriter"
 	digest "github.com/opencontainers/go-digest"
 	"github.com/pkg/errors"
@@ -40,7 +46,7 @@ var buildCommand = cli.Command{
 		},
 		cli.StringFlag{
 			Name:  "progress",
-			Usage: "Set type of progress (auto, plain, tty). Use plain to show container output",
+			Usage: "Set type of progress (auto, plain, tty, rawjson, event). Use plain to show container output",
 			Value: "auto",
 		},
 		cli.StringFlag{
@@ -51,6 +57,10 @@ var buildCommand = cli.Command{
 			Name:  "local",
 			Usage: "Allow build access to the local directory",
 		},
+		cli.BoolFlag{
+			Name:  "local-bind",
+			Usage: "Allow a daemon on the same host to bind-mount the local directories read-only instead of copying them",
+		},
 		cli.StringFlag{
 			Name:  "frontend",
 			Usage: "Define frontend used for build",
@@ -87,6 +97,30 @@ var buildCommand = cli.Command{
 			Name:  "metadata-file",
 			Usage: "Output build metadata (e.g., image digest) to a file as JSON",
 		},
+		cli.BoolFlag{
+			Name:  "summary",
+			Usage: "Print a summary of the build with the critical path and the slowest steps",
+		},
+		cli.StringFlag{
+			Name:  "summary-file",
+			Usage: "Output the build summary to a file as JSON",
+		},
+		cli.StringFlag{
+			Name:  "source-policy-file",
+			Usage: "Read source policy rules from a JSON file",
+		},
+		cli.StringFlag{
+			Name:  "invoke-on-error",
+			Usage: "Start a container with the filesystem of a failed step and run the given command in it with the terminal attached, e.g. --invoke-on-error /bin/sh",
+		},
+		cli.BoolFlag{
+			Name:  "watch",
+			Usage: "Watch the local directories and build again when they change",
+		},
+		cli.StringFlag{
+			Name:  "print",
+			Usage: "Print the result of a frontend subrequest instead of building, e.g. --print outline, --print targets, --print check",
+		},
 	},
 }
 
@@ -180,8 +214,6 @@ func buildAction(clicontext *cli.Context) error {
 		return err
 	}
 
-	eg, ctx := errgroup.WithContext(bccommon.CommandContext(clicontext))
-
 	solveOpt := client.SolveOpt{
 		Exports: exports,
 		// LocalDirs is set later
@@ -202,6 +234,45 @@ func buildAction(clicontext *cli.Context) error {
 	if err != nil {
 		return errors.Wrap(err, "invalid local")
 	}
+	solveOpt.BindLocalDirs = clicontext.Bool("local-bind")
+
+	solveOpt.SourcePolicy, err = build.ParseSourcePolicy(clicontext.String("source-policy-file"))
+	if err != nil {
+		return err
+	}
+
+	printRequest := clicontext.String("print")
+	if printRequest != "" {
+		if solveOpt.Frontend == "" {
+			return errors.Errorf("--print requires --frontend")
+		}
+		if len(exports) > 0 {
+			return errors.Errorf("--print can't be used with --output")
+		}
+		if !strings.HasPrefix(printRequest, "frontend.") {
+			printRequest = "frontend." + printRequest
+		}
+	}
+
+	var invokeArgs []string
+	if v := clicontext.String("invoke-on-error"); v != "" {
+		if printRequest != "" {
+			return errors.Errorf("--invoke-on-error can't be used with --print")
+		}
+		invokeArgs, err = shlex.Split(v)
+		if err != nil {
+			return errors.Wrap(err, "invalid --invoke-on-error command")
+		}
+	}
+
+	if clicontext.Bool("watch") {
+		if printRequest != "" || invokeArgs != nil {
+			return errors.Errorf("--watch can't be used with --print or --invoke-on-error")
+		}
+		if len(solveOpt.LocalDirs) == 0 {
+			return errors.Errorf("--watch requires --local")
+		}
+	}
 
 	var def *llb.Definition
 	if clicontext.String("frontend") == "" {
@@ -221,28 +292,76 @@ func buildAction(clicontext *cli.Context) error {
 		}
 	}
 
+	ctx := bccommon.CommandContext(clicontext)
+	opt := buildOpt{
+		solveOpt:     solveOpt,
+		def:          def,
+		printRequest: printRequest,
+		invokeArgs:   invokeArgs,
+		attachable:   attachable,
+		traceEnc:     traceEnc,
+	}
+	if !clicontext.Bool("watch") {
+		return runBuild(ctx, c, clicontext, opt)
+	}
+	return watchBuild(ctx, c, clicontext, opt)
+}
+
+type buildOpt struct {
+	solveOpt     client.SolveOpt
+	def          *llb.Definition
+	printRequest string
+	invokeArgs   []string
+	attachable   []session.Attachable
+	traceEnc     *json.Encoder
+}
+
+// runBuild runs a single build and displays its progress.
+func runBuild(ctx context.Context, c *client.Client, clicontext *cli.Context, opt buildOpt) error {
+	solveOpt, def, printRequest, invokeArgs := opt.solveOpt, opt.def, opt.printRequest, opt.invokeArgs
+	eg, ctx := errgroup.WithContext(ctx)
+
+	progressMode := clicontext.String("progress")
+	if invokeArgs != nil && progressMode == "auto" {
+		// the tty display would redraw over the attached terminal
+		progressMode = "plain"
+	}
+
 	// not using shared context to not disrupt display but let is finish reporting errors
-	pw, err := progresswriter.NewPrinter(context.TODO(), os.Stderr, clicontext.String("progress"))
+	pw, err := progresswriter.NewPrinter(context.TODO(), os.Stderr, progressMode)
 	if err != nil {
 		return err
 	}
 
-	if traceEnc != nil {
+	if opt.traceEnc != nil {
 		traceCh := make(chan *client.SolveStatus)
 		pw = progresswriter.Tee(pw, traceCh)
 		eg.Go(func() error {
 			for s := range traceCh {
-				if err := traceEnc.Encode(s); err != nil {
+				if err := opt.traceEnc.Encode(s); err != nil {
 					return err
 				}
 			}
 			return nil
 		})
 	}
+
+	var summary *progressui.SummaryCollector
+	if clicontext.Bool("summary") || clicontext.String("summary-file") != "" {
+		summary = progressui.NewSummaryCollector()
+		summaryCh := make(chan *client.SolveStatus)
+		pw = progresswriter.Tee(pw, summaryCh)
+		eg.Go(func() error {
+			for s := range summaryCh {
+				summary.Update(s)
+			}
+			return nil
+		})
+	}
 	mw := progresswriter.NewMultiWriter(pw)
 
 	var writers []progresswriter.Writer
-	for _, at := range attachable {
+	for _, at := range opt.attachable {
 		if s, ok := at.(interface {
 			SetLogger(progresswriter.Logger)
 		}); ok {
@@ -254,13 +373,26 @@ func buildAction(clicontext *cli.Context) error {
 		}
 	}
 
+	var printed []byte
+	var resp *client.SolveResponse
 	eg.Go(func() error {
 		defer func() {
 			for _, w := range writers {
 				close(w.Status())
 			}
 		}()
-		resp, err := c.Solve(ctx, def, solveOpt, progresswriter.ResetTime(mw.WithPrefix("", false)).Status())
+		if printRequest != "" {
+			var err error
+			printed, err = printSubrequest(ctx, c, solveOpt, printRequest, progresswriter.ResetTime(mw.WithPrefix("", false)).Status())
+			return err
+		}
+		if invokeArgs != nil {
+			buildOpt := solveOpt
+			buildOpt.Frontend = ""
+			resp, err = c.Build(ctx, buildOpt, "buildctl", invokeOnErrorBuildFunc(def, solveOpt.Frontend, solveOpt.FrontendAttrs, invokeArgs), progresswriter.ResetTime(mw.WithPrefix("", false)).Status())
+		} else {
+			resp, err = c.Solve(ctx, def, solveOpt, progresswriter.ResetTime(mw.WithPrefix("", false)).Status())
+		}
 		if err != nil {
 			return err
 		}
@@ -283,7 +415,66 @@ func buildAction(clicontext *cli.Context) error {
 		return pw.Err()
 	})
 
-	return eg.Wait()
+	err = eg.Wait()
+	if summary != nil && printRequest == "" {
+		s := summary.Summary(summarySlowest)
+		if resp != nil {
+			s.ImageDigests = imageDigests(resp.ExporterResponse)
+		}
+		if clicontext.Bool("summary") {
+			progressui.PrintSummary(os.Stderr, s)
+		}
+		if summaryFile := clicontext.String("summary-file"); summaryFile != "" {
+			if err1 := writeSummaryFile(summaryFile, s); err1 != nil && err == nil {
+				err = err1
+			}
+		}
+	}
+	if err != nil {
+		return err
+	}
+	if printed != nil {
+		_, err := os.Stdout.Write(printed)
+		return err
+	}
+	return nil
+}
+
+// printSubrequest calls the frontend with a subrequest and returns its result
+// in the text format, or as JSON if the frontend doesn't provide one.
+func printSubrequest(ctx context.Context, c *client.Client, solveOpt client.SolveOpt, req string, statusChan chan *client.SolveStatus) ([]byte, error) {
+	frontend := solveOpt.Frontend
+	solveOpt.Frontend = ""
+
+	frontendOpt := make(map[string]string, len(solveOpt.FrontendAttrs)+2)
+	for k, v := range solveOpt.FrontendAttrs {
+		frontendOpt[k] = v
+	}
+	frontendOpt["requestid"] = req
+	frontendOpt["frontend.caps"] = "moby.buildkit.frontend.subrequests"
+
+	var out []byte
+	_, err := c.Build(ctx, solveOpt, "buildctl", func(ctx context.Context, c gateway.Client) (*gateway.Result, error) {
+		res, err := c.Solve(ctx, gateway.SolveRequest{
+			Frontend:    frontend,
+			FrontendOpt: frontendOpt,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if dt, ok := res.Metadata["result.txt"]; ok {
+			out = dt
+		} else if dt, ok := res.Metadata["result.json"]; ok {
+			out = append(dt, '\n')
+		} else {
+			return nil, errors.Errorf("frontend returned no result for %s", req)
+		}
+		return nil, nil
+	}, statusChan)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
 func writeMetadataFile(filename string, exporterResponse map[string]string) error {
@@ -308,3 +499,27 @@ func writeMetadataFile(filename string, exporterResponse map[string]string) erro
 	}
 	return continuity.AtomicWriteFile(filename, b, 0666)
 }
+
+// summarySlowest is the number of slowest steps in the build summary.
+const summarySlowest = 5
+
+func writeSummaryFile(filename string, s *progressui.Summary) error {
+	b, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	return continuity.AtomicWriteFile(filename, b, 0666)
+}
+
+// imageDigests returns the digests of the images in the exporter response,
+// including the responses of multiple exporters.
+func imageDigests(exporterResponse map[string]string) []string {
+	var dgsts []string
+	for k, v := range exporterResponse {
+		if k == exptypes.ExporterImageDigestKey || strings.HasSuffix(k, "."+exptypes.ExporterImageDigestKey) {
+			dgsts = append(dgsts, v)
+		}
+	}
+	sort.Strings(dgsts)
+	return dgsts
+}
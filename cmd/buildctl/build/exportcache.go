@@ -29,6 +29,12 @@ func parseExportCacheCSV(s string) (client.CacheOptionsEntry, error) {
 		switch key {
 		case "type":
 			ex.Type = value
+		case "include-ops", "exclude-ops", "include-progress-groups", "exclude-progress-groups", "include-stages", "exclude-stages":
+			// repeated filters add to the comma-separated list of values
+			if prev, ok := ex.Attrs[key]; ok {
+				value = prev + "," + value
+			}
+			ex.Attrs[key] = value
 		default:
 			ex.Attrs[key] = value
 		}
@@ -38,6 +38,24 @@ func TestParseExportCache(t *testing.T) {
 				},
 			},
 		},
+		{
+			exportCaches: []string{`type=local,dest=/tmp/cache,include-ops=exec,include-ops=file,"exclude-stages=test,lint"`},
+			expected: []client.CacheOptionsEntry{
+				{
+					Type: "local",
+					Attrs: map[string]string{
+						"dest":           "/tmp/cache",
+						"mode":           "min",
+						"include-ops":    "exec,file",
+						"exclude-stages": "test,lint",
+					},
+				},
+			},
+		},
+		{
+			exportCaches: []string{"type=local,dest=/tmp/cache,include-ops=exec,file"},
+			expectedErr:  "invalid value file",
+		},
 		// TODO: test multiple exportCaches (valid for CLI but not supported by solver)
 
 	}
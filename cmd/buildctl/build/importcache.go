@@ -29,6 +29,12 @@ func parseImportCacheCSV(s string) (client.CacheOptionsEntry, error) {
 		switch key {
 		case "type":
 			im.Type = value
+		case "ref", "scope":
+			// repeated refs or scopes form a fallback chain
+			if prev, ok := im.Attrs[key]; ok {
+				value = prev + client.CacheFallbackSeparator + value
+			}
+			im.Attrs[key] = value
 		default:
 			im.Attrs[key] = value
 		}
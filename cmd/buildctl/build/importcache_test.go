@@ -48,6 +48,17 @@ func TestParseImportCache(t *testing.T) {
 				},
 			},
 		},
+		{
+			importCaches: []string{"type=registry,ref=example.com/foo/bar:feature,ref=example.com/foo/bar:main"},
+			expected: []client.CacheOptionsEntry{
+				{
+					Type: "registry",
+					Attrs: map[string]string{
+						"ref": "example.com/foo/bar:feature\nexample.com/foo/bar:main",
+					},
+				},
+			},
+		},
 		{
 			importCaches: []string{"type=gha,url=https://foo.bar,token=foo"},
 			expected: []client.CacheOptionsEntry{
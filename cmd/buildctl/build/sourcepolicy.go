@@ -0,0 +1,25 @@
+package build
+
+import (
+	"encoding/json"
+	"os"
+
+	spb "github.com/moby/buildkit/sourcepolicy/pb"
+	"github.com/pkg/errors"
+)
+
+// ParseSourcePolicy parses --source-policy-file
+func ParseSourcePolicy(file string) (*spb.Policy, error) {
+	if file == "" {
+		return nil, nil
+	}
+	dt, err := os.ReadFile(file)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read source policy file")
+	}
+	var pol spb.Policy
+	if err := json.Unmarshal(dt, &pol); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse source policy file %s", file)
+	}
+	return &pol, nil
+}
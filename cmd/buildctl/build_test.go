@@ -17,6 +17,7 @@ import (
 	"github.com/containerd/continuity/fs/fstest"
 	"github.com/moby/buildkit/client/llb"
 	"github.com/moby/buildkit/exporter/containerimage/exptypes"
+	"github.com/moby/buildkit/util/progress/progressui"
 	"github.com/moby/buildkit/util/testutil/integration"
 	ocispecs "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/stretchr/testify/require"
@@ -178,6 +179,71 @@ func testBuildMetadataFile(t *testing.T, sb integration.Sandbox) {
 	}
 }
 
+func testBuildEventProgress(t *testing.T, sb integration.Sandbox) {
+	st := llb.Image("busybox").
+		Run(llb.Shlex("sh -c 'echo hello-events'"), llb.WithCustomName("echo"))
+
+	rdr, err := marshal(sb.Context(), st.Root())
+	require.NoError(t, err)
+
+	stderr := &bytes.Buffer{}
+	cmd := sb.Cmd("build --progress=event")
+	cmd.Stdin = rdr
+	cmd.Stderr = stderr
+	err = cmd.Run()
+	require.NoError(t, err, stderr.String())
+
+	var types []progressui.EventType
+	var logs string
+	dec := json.NewDecoder(stderr)
+	for dec.More() {
+		var e progressui.Event
+		require.NoError(t, dec.Decode(&e))
+		if e.Name != "echo" {
+			continue
+		}
+		types = append(types, e.Type)
+		if e.Type == progressui.EventLog {
+			logs += e.Data
+		}
+	}
+	require.NotEmpty(t, types)
+	require.Equal(t, progressui.EventStarted, types[0])
+	require.Equal(t, progressui.EventCompleted, types[len(types)-1])
+	require.Contains(t, logs, "hello-events")
+}
+
+func testBuildSummaryFile(t *testing.T, sb integration.Sandbox) {
+	st := llb.Image("busybox").
+		Run(llb.Shlex("sh -c 'echo -n bar > /foo'"), llb.WithCustomName("write"))
+
+	rdr, err := marshal(sb.Context(), st.Root())
+	require.NoError(t, err)
+
+	tmpDir := t.TempDir()
+	summaryFile := filepath.Join(tmpDir, "summary.json")
+
+	cmd := sb.Cmd("build --progress=plain --output type=image,name=example.com/moby/summary:test,push=false --summary-file " + summaryFile)
+	cmd.Stdin = rdr
+	err = cmd.Run()
+	require.NoError(t, err)
+
+	dt, err := os.ReadFile(summaryFile)
+	require.NoError(t, err)
+
+	var summary progressui.Summary
+	require.NoError(t, json.Unmarshal(dt, &summary))
+	require.Greater(t, summary.Duration, 0.0)
+	require.Equal(t, 0, summary.Errored)
+
+	var names []string
+	for _, v := range summary.CriticalPath {
+		names = append(names, v.Name)
+	}
+	require.Contains(t, names, "write")
+	require.Len(t, summary.ImageDigests, 1)
+}
+
 func marshal(ctx context.Context, st llb.State) (io.Reader, error) {
 	def, err := st.Marshal(ctx)
 	if err != nil {
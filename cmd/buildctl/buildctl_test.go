@@ -22,6 +22,8 @@ func TestCLIIntegration(t *testing.T) {
 		testBuildLocalExporter,
 		testBuildContainerdExporter,
 		testBuildMetadataFile,
+		testBuildEventProgress,
+		testBuildSummaryFile,
 		testPrune,
 		testUsage,
 	),
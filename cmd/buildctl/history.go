@@ -0,0 +1,19 @@
+package main
+
+import (
+	"github.com/moby/buildkit/cmd/buildctl/history"
+	"github.com/urfave/cli"
+)
+
+var historyCommand = cli.Command{
+	Name:  "history",
+	Usage: "inspect the history of builds",
+	Subcommands: []cli.Command{
+		history.LsCommand,
+		history.InspectCommand,
+		history.LogsCommand,
+		history.RmCommand,
+		history.PinCommand,
+		history.UnpinCommand,
+	},
+}
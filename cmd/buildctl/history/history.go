@@ -0,0 +1,91 @@
+package history
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/any"
+	controlapi "github.com/moby/buildkit/api/services/control"
+	"github.com/moby/buildkit/client"
+	"github.com/moby/buildkit/util/grpcerrors"
+	"github.com/pkg/errors"
+	spb "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc/status"
+)
+
+// listRecords returns the records in the build history. If ref is set only
+// the record for that build is returned.
+func listRecords(ctx context.Context, c *client.Client, ref string, activeOnly bool) ([]*controlapi.BuildHistoryRecord, error) {
+	cl, err := c.ControlClient().ListenBuildHistory(ctx, &controlapi.BuildHistoryRequest{
+		Ref:        ref,
+		ActiveOnly: activeOnly,
+		EarlyExit:  true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	var recs []*controlapi.BuildHistoryRecord
+	for {
+		ev, err := cl.Recv()
+		if err != nil {
+			if err == io.EOF {
+				return recs, nil
+			}
+			return nil, err
+		}
+		if ev.Record != nil {
+			recs = append(recs, ev.Record)
+		}
+	}
+}
+
+func getRecord(ctx context.Context, c *client.Client, ref string) (*controlapi.BuildHistoryRecord, error) {
+	if ref == "" {
+		return nil, errors.New("build ref not specified")
+	}
+	recs, err := listRecords(ctx, c, ref, false)
+	if err != nil {
+		return nil, err
+	}
+	if len(recs) == 0 {
+		return nil, errors.Errorf("build %s not found in history", ref)
+	}
+	return recs[0], nil
+}
+
+func recordStatus(rec *controlapi.BuildHistoryRecord) string {
+	switch {
+	case rec.CompletedAt == nil:
+		return "running"
+	case rec.Error != nil:
+		return "error"
+	default:
+		return "completed"
+	}
+}
+
+func recordDuration(rec *controlapi.BuildHistoryRecord) time.Duration {
+	if rec.CreatedAt == nil {
+		return 0
+	}
+	end := time.Now()
+	if rec.CompletedAt != nil {
+		end = *rec.CompletedAt
+	}
+	return end.Sub(*rec.CreatedAt).Round(100 * time.Millisecond)
+}
+
+func recordError(rec *controlapi.BuildHistoryRecord) error {
+	if rec.Error == nil {
+		return nil
+	}
+	st := &spb.Status{
+		Code:    rec.Error.Code,
+		Message: rec.Error.Message,
+	}
+	for _, d := range rec.Error.Details {
+		st.Details = append(st.Details, &any.Any{TypeUrl: d.TypeUrl, Value: d.Value})
+	}
+	return grpcerrors.FromGRPC(status.ErrorProto(st))
+}
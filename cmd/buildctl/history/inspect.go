@@ -0,0 +1,90 @@
+package history
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"text/tabwriter"
+
+	bccommon "github.com/moby/buildkit/cmd/buildctl/common"
+	"github.com/moby/buildkit/solver/errdefs"
+	"github.com/urfave/cli"
+)
+
+var InspectCommand = cli.Command{
+	Name:      "inspect",
+	Usage:     "display details of a build in the history",
+	ArgsUsage: "REF",
+	Action:    inspect,
+}
+
+func inspect(clicontext *cli.Context) error {
+	c, err := bccommon.ResolveClient(clicontext)
+	if err != nil {
+		return err
+	}
+
+	rec, err := getRecord(bccommon.CommandContext(clicontext), c, clicontext.Args().First())
+	if err != nil {
+		return err
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 1, 8, 1, '\t', 0)
+	printKV(tw, "Ref", rec.Ref)
+	printKV(tw, "Status", recordStatus(rec))
+	printKV(tw, "Pinned", rec.Pinned)
+	printKV(tw, "Created at", rec.CreatedAt.Local())
+	if rec.CompletedAt != nil {
+		printKV(tw, "Completed at", rec.CompletedAt.Local())
+	}
+	printKV(tw, "Duration", recordDuration(rec))
+	printKV(tw, "Steps", fmt.Sprintf("%d/%d (%d cached)", rec.NumCompletedSteps, rec.NumTotalSteps, rec.NumCachedSteps))
+	if rec.Frontend != "" {
+		printKV(tw, "Frontend", rec.Frontend)
+	}
+	printMap(tw, "Frontend attr", rec.FrontendAttrs)
+	if rec.Exporter != "" {
+		printKV(tw, "Exporter", rec.Exporter)
+	}
+	printMap(tw, "Exporter attr", rec.ExporterAttrs)
+	for _, e := range rec.CacheImports {
+		printKV(tw, "Cache import", e.Type)
+		printMap(tw, "  attr", e.Attrs)
+	}
+	for _, e := range rec.CacheExports {
+		printKV(tw, "Cache export", e.Type)
+		printMap(tw, "  attr", e.Attrs)
+	}
+	printMap(tw, "Exporter response", rec.ExporterResponse)
+	if rec.Logs != nil {
+		printKV(tw, "Logs", fmt.Sprintf("%s (%d bytes)", rec.Logs.Digest, rec.Logs.Size_))
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	if err := recordError(rec); err != nil {
+		fmt.Fprintln(os.Stdout)
+		for _, s := range errdefs.Sources(err) {
+			s.Print(os.Stdout)
+		}
+		fmt.Fprintf(os.Stdout, "Error: %v\n", err)
+	}
+	return nil
+}
+
+func printKV(w io.Writer, k string, v interface{}) {
+	fmt.Fprintf(w, "%s:\t%v\n", k, v)
+}
+
+func printMap(w io.Writer, prefix string, m map[string]string) {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		printKV(w, prefix, fmt.Sprintf("%s=%s", k, m[k]))
+	}
+}
@@ -0,0 +1,72 @@
+package history
+
+import (
+	"context"
+	"os"
+
+	"github.com/containerd/containerd/content"
+	"github.com/containerd/containerd/content/proxy"
+	"github.com/moby/buildkit/client"
+	bccommon "github.com/moby/buildkit/cmd/buildctl/common"
+	"github.com/moby/buildkit/util/progress/progresswriter"
+	ocispecs "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/pkg/errors"
+	"github.com/urfave/cli"
+)
+
+var LogsCommand = cli.Command{
+	Name:      "logs",
+	Usage:     "replay the progress of a build in the history",
+	ArgsUsage: "REF",
+	Action:    logs,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "progress",
+			Usage: "Set type of progress (auto, plain, tty, rawjson, event). Use plain to show container output",
+			Value: "plain",
+		},
+	},
+}
+
+func logs(clicontext *cli.Context) error {
+	c, err := bccommon.ResolveClient(clicontext)
+	if err != nil {
+		return err
+	}
+	ctx := bccommon.CommandContext(clicontext)
+
+	rec, err := getRecord(ctx, c, clicontext.Args().First())
+	if err != nil {
+		return err
+	}
+	if rec.Logs == nil {
+		return errors.Errorf("no logs recorded for build %s", rec.Ref)
+	}
+
+	store := proxy.NewContentStore(c.ContentClient())
+	ra, err := store.ReaderAt(ctx, ocispecs.Descriptor{
+		MediaType: rec.Logs.MediaType,
+		Digest:    rec.Logs.Digest,
+		Size:      rec.Logs.Size_,
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to open logs for build %s", rec.Ref)
+	}
+	defer ra.Close()
+
+	// not using shared context to not disrupt display but let is finish reporting errors
+	pw, err := progresswriter.NewPrinter(context.TODO(), os.Stderr, clicontext.String("progress"))
+	if err != nil {
+		return err
+	}
+	err = client.ReadStatusStream(content.NewReader(ra), func(ss *client.SolveStatus) error {
+		pw.Status() <- ss
+		return nil
+	})
+	close(pw.Status())
+	<-pw.Done()
+	if err != nil {
+		return err
+	}
+	return pw.Err()
+}
@@ -0,0 +1,53 @@
+package history
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	bccommon "github.com/moby/buildkit/cmd/buildctl/common"
+	"github.com/urfave/cli"
+)
+
+var LsCommand = cli.Command{
+	Name:   "ls",
+	Usage:  "list builds in the history",
+	Action: ls,
+	Flags: []cli.Flag{
+		cli.BoolFlag{
+			Name:  "active",
+			Usage: "Only show running builds",
+		},
+	},
+}
+
+func ls(clicontext *cli.Context) error {
+	c, err := bccommon.ResolveClient(clicontext)
+	if err != nil {
+		return err
+	}
+
+	recs, err := listRecords(bccommon.CommandContext(clicontext), c, "", clicontext.Bool("active"))
+	if err != nil {
+		return err
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 1, 8, 1, '\t', 0)
+	fmt.Fprintln(tw, "REF\tFRONTEND\tSTATUS\tCREATED AT\tDURATION\tSTEPS")
+	for _, rec := range recs {
+		ref := rec.Ref
+		if rec.Pinned {
+			ref += "*"
+		}
+		frontend := rec.Frontend
+		if frontend == "" {
+			frontend = "-"
+		}
+		steps := "-"
+		if rec.NumTotalSteps > 0 {
+			steps = fmt.Sprintf("%d/%d (%d cached)", rec.NumCompletedSteps, rec.NumTotalSteps, rec.NumCachedSteps)
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%v\t%s\n", ref, frontend, recordStatus(rec), rec.CreatedAt.Local().Format("2006-01-02 15:04:05"), recordDuration(rec), steps)
+	}
+	return tw.Flush()
+}
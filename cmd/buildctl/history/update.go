@@ -0,0 +1,54 @@
+package history
+
+import (
+	controlapi "github.com/moby/buildkit/api/services/control"
+	bccommon "github.com/moby/buildkit/cmd/buildctl/common"
+	"github.com/pkg/errors"
+	"github.com/urfave/cli"
+)
+
+var RmCommand = cli.Command{
+	Name:      "rm",
+	Usage:     "remove builds from the history",
+	ArgsUsage: "REF [REF...]",
+	Action: func(clicontext *cli.Context) error {
+		return update(clicontext, &controlapi.UpdateBuildHistoryRequest{Delete: true})
+	},
+}
+
+var PinCommand = cli.Command{
+	Name:      "pin",
+	Usage:     "keep builds in the history until they are removed manually",
+	ArgsUsage: "REF [REF...]",
+	Action: func(clicontext *cli.Context) error {
+		return update(clicontext, &controlapi.UpdateBuildHistoryRequest{Pinned: true})
+	},
+}
+
+var UnpinCommand = cli.Command{
+	Name:      "unpin",
+	Usage:     "allow builds to be released from the history",
+	ArgsUsage: "REF [REF...]",
+	Action: func(clicontext *cli.Context) error {
+		return update(clicontext, &controlapi.UpdateBuildHistoryRequest{Pinned: false})
+	},
+}
+
+func update(clicontext *cli.Context, tmpl *controlapi.UpdateBuildHistoryRequest) error {
+	if clicontext.NArg() == 0 {
+		return errors.New("build ref not specified")
+	}
+	c, err := bccommon.ResolveClient(clicontext)
+	if err != nil {
+		return err
+	}
+	ctx := bccommon.CommandContext(clicontext)
+	for _, ref := range clicontext.Args() {
+		req := *tmpl
+		req.Ref = ref
+		if _, err := c.ControlClient().UpdateBuildHistory(ctx, &req); err != nil {
+			return errors.Wrapf(err, "failed to update build %s", ref)
+		}
+	}
+	return nil
+}
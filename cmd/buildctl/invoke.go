@@ -0,0 +1,164 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/containerd/console"
+	"github.com/moby/buildkit/client/llb"
+	gateway "github.com/moby/buildkit/frontend/gateway/client"
+	gwpb "github.com/moby/buildkit/frontend/gateway/pb"
+	"github.com/moby/buildkit/solver/errdefs"
+	"github.com/moby/buildkit/solver/pb"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// invokeOnErrorBuildFunc returns a build function that solves the definition
+// or frontend request and, if a step fails, starts a container with the
+// filesystem state of the failed step and attaches the terminal to a process
+// running args in it. The error of the build is returned after the process
+// exits.
+func invokeOnErrorBuildFunc(def *llb.Definition, frontend string, frontendAttrs map[string]string, args []string) gateway.BuildFunc {
+	return func(ctx context.Context, c gateway.Client) (*gateway.Result, error) {
+		res, err := solveAndEvaluate(ctx, c, def, frontend, frontendAttrs)
+		if err == nil {
+			return res, nil
+		}
+
+		var se *errdefs.SolveError
+		if !errors.As(err, &se) {
+			return nil, err
+		}
+		if ierr := invokeFailedStep(ctx, c, se, args); ierr != nil {
+			logrus.Warnf("failed to invoke process for failed step: %v", ierr)
+		}
+		return nil, err
+	}
+}
+
+// solveAndEvaluate solves the build and evaluates all its results so that a
+// failing step is reported before the results are exported.
+func solveAndEvaluate(ctx context.Context, c gateway.Client, def *llb.Definition, frontend string, frontendAttrs map[string]string) (*gateway.Result, error) {
+	if def != nil {
+		return c.Solve(ctx, gateway.SolveRequest{
+			Definition: def.ToPB(),
+			Evaluate:   true,
+		})
+	}
+
+	res, err := c.Solve(ctx, gateway.SolveRequest{
+		Frontend:    frontend,
+		FrontendOpt: frontendAttrs,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	refs := make([]gateway.Reference, 0, len(res.Refs)+1)
+	if res.Ref != nil {
+		refs = append(refs, res.Ref)
+	}
+	for _, r := range res.Refs {
+		if r != nil {
+			refs = append(refs, r)
+		}
+	}
+	for _, r := range refs {
+		st, err := r.ToState()
+		if err != nil {
+			// the frontend doesn't return definitions, failures are
+			// reported on export
+			continue
+		}
+		d, err := st.Marshal(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := c.Solve(ctx, gateway.SolveRequest{
+			Definition: d.ToPB(),
+			Evaluate:   true,
+		}); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+func invokeFailedStep(ctx context.Context, c gateway.Client, se *errdefs.SolveError, args []string) error {
+	op := se.Solve.Op
+	if op == nil {
+		return errors.New("failed step is unknown")
+	}
+	exec, ok := op.Op.(*pb.Op_Exec)
+	if !ok {
+		return errors.Errorf("failed step is not a process execution")
+	}
+	if len(se.Solve.MountIDs) != len(exec.Exec.Mounts) {
+		return errors.Errorf("mounts of failed step are not available")
+	}
+
+	mounts := make([]gateway.Mount, 0, len(exec.Exec.Mounts))
+	for i, m := range exec.Exec.Mounts {
+		mounts = append(mounts, gateway.Mount{
+			Selector:  m.Selector,
+			Dest:      m.Dest,
+			ResultID:  se.Solve.MountIDs[i],
+			Readonly:  m.Readonly,
+			MountType: m.MountType,
+			CacheOpt:  m.CacheOpt,
+			SecretOpt: m.SecretOpt,
+			SSHOpt:    m.SSHOpt,
+		})
+	}
+
+	ctr, err := c.NewContainer(ctx, gateway.NewContainerRequest{
+		Mounts:      mounts,
+		NetMode:     exec.Exec.Network,
+		Platform:    op.Platform,
+		Constraints: op.Constraints,
+	})
+	if err != nil {
+		return err
+	}
+	defer ctr.Release(context.TODO())
+
+	con, err := console.ConsoleFromFile(os.Stdin)
+	if err != nil {
+		return errors.Wrap(err, "stdin is not a terminal")
+	}
+	if err := con.SetRaw(); err != nil {
+		return err
+	}
+	defer con.Reset()
+
+	fmt.Fprintf(os.Stderr, "\r\nLaunching %q in the failed step, exit to continue\r\n", args)
+
+	meta := exec.Exec.Meta
+	proc, err := ctr.Start(ctx, gateway.StartRequest{
+		Args:         args,
+		Env:          meta.Env,
+		User:         meta.User,
+		Cwd:          meta.Cwd,
+		Tty:          true,
+		Stdin:        os.Stdin,
+		Stdout:       os.Stdout,
+		Stderr:       os.Stderr,
+		SecurityMode: exec.Exec.Security,
+	})
+	if err != nil {
+		return err
+	}
+	if size, err := con.Size(); err == nil {
+		proc.Resize(ctx, gateway.WinSize{Rows: uint32(size.Height), Cols: uint32(size.Width)})
+	}
+	if err := proc.Wait(); err != nil {
+		// the exit code of the shell is of no interest
+		var exitErr *gwpb.ExitError
+		if !errors.As(err, &exitErr) {
+			return err
+		}
+	}
+	return nil
+}
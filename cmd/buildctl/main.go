@@ -90,8 +90,10 @@ func main() {
 	app.Commands = []cli.Command{
 		diskUsageCommand,
 		pruneCommand,
+		pruneCacheCommand,
 		buildCommand,
 		debugCommand,
+		historyCommand,
 		dialStdioCommand,
 	}
 
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/containerd/containerd/content"
+	"github.com/containerd/containerd/remotes/docker"
+	"github.com/docker/cli/cli/config"
+	"github.com/moby/buildkit/client"
+	"github.com/moby/buildkit/cmd/buildctl/build"
+	bccommon "github.com/moby/buildkit/cmd/buildctl/common"
+	"github.com/pkg/errors"
+	"github.com/tonistiigi/units"
+	"github.com/urfave/cli"
+)
+
+var pruneCacheCommand = cli.Command{
+	Name:      "prune-cache",
+	Usage:     "clean up unreferenced blobs and stale manifests of exported caches",
+	UsageText: "buildctl prune-cache --ref type=local,src=path/to/dir|type=registry,ref=example.com/foo/bar --tag 'pr-*' [--keep-duration 72h] [--keep-storage 10000]",
+	Action:    pruneCache,
+	Flags: []cli.Flag{
+		cli.StringSliceFlag{
+			Name:  "ref",
+			Usage: "Cache to prune, e.g. type=local,src=path/to/dir or type=registry,ref=example.com/foo/bar",
+		},
+		cli.StringSliceFlag{
+			Name:  "tag",
+			Usage: "Tags of the registry caches to prune, as glob patterns, e.g. 'pr-*'",
+		},
+		cli.DurationFlag{
+			Name:  "keep-duration",
+			Usage: "Keep unreferenced blobs and caches newer than this limit",
+		},
+		cli.Float64Flag{
+			Name:  "keep-storage",
+			Usage: "Keep data below this limit (in MB)",
+		},
+		cli.BoolFlag{
+			Name:  "verbose, v",
+			Usage: "Verbose output",
+		},
+	},
+}
+
+func pruneCache(clicontext *cli.Context) error {
+	refs, err := build.ParseImportCache(clicontext.StringSlice("ref"))
+	if err != nil {
+		return err
+	}
+	if len(refs) == 0 {
+		return errors.New("prune-cache requires --ref")
+	}
+
+	ctx := bccommon.CommandContext(clicontext)
+	opts := []client.PruneOption{
+		client.WithKeepOpt(clicontext.Duration("keep-duration"), int64(clicontext.Float64("keep-storage")*1e6)),
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 1, 8, 1, '\t', 0)
+	total := int64(0)
+	var hosts docker.RegistryHosts
+	for _, ref := range refs {
+		var (
+			name    string
+			deleted []content.Info
+		)
+		switch ref.Type {
+		case "local":
+			name = ref.Attrs["src"]
+			if name == "" {
+				return errors.New("local cache prune requires src")
+			}
+			if len(clicontext.StringSlice("tag")) != 0 {
+				return errors.New("--tag is only supported for registry caches")
+			}
+			deleted, err = client.PruneLocalCache(ctx, name, opts...)
+		case "registry":
+			name = ref.Attrs["ref"]
+			if name == "" {
+				return errors.New("registry cache prune requires ref")
+			}
+			if hosts == nil {
+				hosts = registryHosts()
+			}
+			deleted, err = client.PruneRegistryCache(ctx, name, clicontext.StringSlice("tag"), hosts, opts...)
+		default:
+			return errors.Errorf("pruning %s caches is not supported", ref.Type)
+		}
+		for _, info := range deleted {
+			total += info.Size
+			if clicontext.Bool("verbose") {
+				fmt.Fprintf(tw, "%s\t%.2f\t%s\n", info.Digest, units.Bytes(info.Size), info.CreatedAt.Format("2006-01-02 15:04:05"))
+			}
+		}
+		if err != nil {
+			return errors.Wrapf(err, "failed to prune %s", name)
+		}
+	}
+
+	fmt.Fprintf(tw, "Total:\t%.2f\n", units.Bytes(total))
+	tw.Flush()
+	return nil
+}
+
+// registryHosts returns the registry configuration used for pruning registry
+// caches, authenticating with the credentials of the docker config file.
+func registryHosts() docker.RegistryHosts {
+	cfg := config.LoadDefaultConfigFile(os.Stderr)
+	creds := func(host string) (string, string, error) {
+		if host == "registry-1.docker.io" {
+			host = "https://index.docker.io/v1/"
+		}
+		ac, err := cfg.GetAuthConfig(host)
+		if err != nil {
+			return "", "", err
+		}
+		if ac.IdentityToken != "" {
+			return "", ac.IdentityToken, nil
+		}
+		return ac.Username, ac.Password, nil
+	}
+	return docker.ConfigureDefaultRegistries(
+		docker.WithAuthorizer(docker.NewDockerAuthorizer(docker.WithAuthCreds(creds))),
+		docker.WithPlainHTTP(docker.MatchLocalhost),
+	)
+}
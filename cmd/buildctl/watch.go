@@ -0,0 +1,292 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/pkg/fileutils"
+	"github.com/fsnotify/fsnotify"
+	"github.com/moby/buildkit/client"
+	"github.com/moby/buildkit/frontend/dockerfile/dockerignore"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/urfave/cli"
+)
+
+// watchDebounce is how long the local directories need to stay unchanged
+// before a build is started, so that saving many files starts one build.
+const watchDebounce = 200 * time.Millisecond
+
+// the names of the local directories of the Dockerfile frontend
+const (
+	localNameContext    = "context"
+	localNameDockerfile = "dockerfile"
+)
+
+// watchBuild builds once and then again whenever the local directories
+// change, until the command is interrupted. All builds share a session so
+// that only the changed files are transferred. A failed build is reported
+// and the next change starts a new build.
+func watchBuild(ctx context.Context, c *client.Client, clicontext *cli.Context, opt buildOpt) error {
+	ignore, err := newWatchIgnore(opt.solveOpt)
+	if err != nil {
+		return err
+	}
+	changed, err := watchDirs(ctx, opt.solveOpt.LocalDirs, ignore)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	s, err := c.NewSession(ctx, opt.def, opt.solveOpt)
+	if err != nil {
+		return err
+	}
+	defer s.Close()
+
+	var sessionErr error
+	sessionDone := make(chan struct{})
+	go func() {
+		sessionErr = s.Run(ctx, c.Dialer())
+		close(sessionDone)
+		cancel()
+	}()
+	stopped := func() error {
+		<-sessionDone
+		if sessionErr != nil {
+			return errors.Wrap(sessionErr, "session failed")
+		}
+		return errors.New("session closed")
+	}
+
+	opt.solveOpt.SharedSession = s
+	opt.solveOpt.SessionPreInitialized = true
+
+	for {
+		if err := runBuild(ctx, c, clicontext, opt); err != nil {
+			if ctx.Err() != nil {
+				return stopped()
+			}
+			fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		}
+		logrus.Infof("watching for changes in local directories")
+		if err := waitChanged(ctx, changed); err != nil {
+			return stopped()
+		}
+	}
+}
+
+// waitChanged waits for a change and for the changes to settle.
+func waitChanged(ctx context.Context, changed <-chan struct{}) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-changed:
+	}
+	t := time.NewTimer(watchDebounce)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-changed:
+			if !t.Stop() {
+				<-t.C
+			}
+			t.Reset(watchDebounce)
+		case <-t.C:
+			return nil
+		}
+	}
+}
+
+// watchIgnore decides which changes don't start a build.
+type watchIgnore struct {
+	// outputs are the directories the builds export to. Watching them would
+	// start a build for every export.
+	outputs []string
+	// dir is the build context and pm matches its .dockerignore patterns, as
+	// ignored files aren't sent to the build.
+	dir string
+	pm  *fileutils.PatternMatcher
+}
+
+func newWatchIgnore(opt client.SolveOpt) (*watchIgnore, error) {
+	wi := &watchIgnore{}
+	for _, ex := range opt.Exports {
+		if ex.OutputDir != "" {
+			wi.outputs = append(wi.outputs, ex.OutputDir)
+		}
+	}
+	for _, ex := range opt.CacheExports {
+		if ex.Type == "local" && ex.Attrs["dest"] != "" {
+			wi.outputs = append(wi.outputs, ex.Attrs["dest"])
+		}
+	}
+	for i, p := range wi.outputs {
+		p, err := filepath.Abs(p)
+		if err != nil {
+			return nil, err
+		}
+		wi.outputs[i] = p
+	}
+
+	dir, ok := opt.LocalDirs[localNameContext]
+	if !ok {
+		return wi, nil
+	}
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	wi.dir = dir
+
+	// like the Dockerfile frontend, a .dockerignore next to the Dockerfile
+	// takes precedence over the one of the context
+	var ignoreFiles []string
+	if dfDir, ok := opt.LocalDirs[localNameDockerfile]; ok {
+		filename := opt.FrontendAttrs["filename"]
+		if filename == "" {
+			filename = "Dockerfile"
+		}
+		ignoreFiles = append(ignoreFiles, filepath.Join(dfDir, filename+".dockerignore"))
+	}
+	ignoreFiles = append(ignoreFiles, filepath.Join(dir, ".dockerignore"))
+	for _, fn := range ignoreFiles {
+		f, err := os.Open(fn)
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+			return nil, err
+		}
+		excludes, err := dockerignore.ReadAll(f)
+		f.Close()
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse %s", fn)
+		}
+		if wi.pm, err = fileutils.NewPatternMatcher(excludes); err != nil {
+			return nil, errors.Wrapf(err, "invalid patterns in %s", fn)
+		}
+		break
+	}
+	return wi, nil
+}
+
+// ignored returns true if the changes of the path p don't start a build.
+func (wi *watchIgnore) ignored(p string) bool {
+	return wi.isOutput(p) || wi.matches(p)
+}
+
+// skipDir returns true if no changes in the directory p start a build. A
+// directory matched by the patterns can still contain files that are included
+// again by an exclusion pattern.
+func (wi *watchIgnore) skipDir(p string) bool {
+	return wi.isOutput(p) || (wi.matches(p) && !wi.pm.Exclusions())
+}
+
+func (wi *watchIgnore) isOutput(p string) bool {
+	if wi == nil {
+		return false
+	}
+	for _, o := range wi.outputs {
+		if p == o || strings.HasPrefix(p, o+string(filepath.Separator)) {
+			return true
+		}
+	}
+	return false
+}
+
+func (wi *watchIgnore) matches(p string) bool {
+	if wi == nil || wi.pm == nil {
+		return false
+	}
+	rel, err := filepath.Rel(wi.dir, p)
+	if err != nil || rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return false
+	}
+	ok, err := wi.pm.MatchesOrParentMatches(rel)
+	return err == nil && ok
+}
+
+// watchDirs watches the dirs and their subdirectories for changes until ctx
+// is done. A change is signaled on the returned channel, changes made before
+// the signal is received are coalesced. Changes of the paths that are
+// ignored by ignore are not signaled.
+func watchDirs(ctx context.Context, dirs map[string]string, ignore *watchIgnore) (<-chan struct{}, error) {
+	w, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create file watcher")
+	}
+	for _, d := range dirs {
+		d, err := filepath.Abs(d)
+		if err != nil {
+			w.Close()
+			return nil, err
+		}
+		if err := addWatches(w, d, ignore); err != nil {
+			w.Close()
+			return nil, err
+		}
+	}
+
+	changed := make(chan struct{}, 1)
+	go func() {
+		defer w.Close()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case ev, ok := <-w.Events:
+				if !ok {
+					return
+				}
+				if ignore.ignored(ev.Name) {
+					continue
+				}
+				if ev.Op&fsnotify.Create != 0 {
+					if fi, err := os.Lstat(ev.Name); err == nil && fi.IsDir() {
+						if err := addWatches(w, ev.Name, ignore); err != nil {
+							logrus.Warnf("failed to watch %s: %v", ev.Name, err)
+						}
+					}
+				}
+				select {
+				case changed <- struct{}{}:
+				default:
+				}
+			case err, ok := <-w.Errors:
+				if !ok {
+					return
+				}
+				logrus.Warnf("file watcher error: %v", err)
+			}
+		}
+	}()
+	return changed, nil
+}
+
+// addWatches watches dir and all its subdirectories that are not ignored.
+func addWatches(w *fsnotify.Watcher, dir string, ignore *watchIgnore) error {
+	return filepath.Walk(dir, func(p string, fi os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !fi.IsDir() {
+			return nil
+		}
+		if ignore.skipDir(p) {
+			return filepath.SkipDir
+		}
+		if err := w.Add(p); err != nil {
+			return errors.Wrapf(err, "failed to watch %s", p)
+		}
+		return nil
+	})
+}
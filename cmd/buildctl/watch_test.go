@@ -0,0 +1,85 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/moby/buildkit/client"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWatchDirs(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+
+	dir := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "sub"), 0700))
+
+	changed, err := watchDirs(ctx, map[string]string{"src": dir}, nil)
+	require.NoError(t, err)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "sub", "foo"), []byte("foo"), 0600))
+	requireChanged(t, changed)
+
+	// new directories are watched as well
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "new"), 0700))
+	requireChanged(t, changed)
+	require.NoError(t, waitChanged(ctx, changed))
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "new", "bar"), []byte("bar"), 0600))
+	require.NoError(t, waitChanged(ctx, changed))
+
+	cancel()
+	require.ErrorIs(t, waitChanged(ctx, changed), context.Canceled)
+}
+
+func TestWatchIgnore(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+
+	dir := t.TempDir()
+	for _, d := range []string{"out", "cache", "node_modules", "logs"} {
+		require.NoError(t, os.Mkdir(filepath.Join(dir, d), 0700))
+	}
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".dockerignore"), []byte("node_modules\n*.log\nlogs\n!logs/keep\n"), 0600))
+
+	ignore, err := newWatchIgnore(client.SolveOpt{
+		LocalDirs: map[string]string{"context": dir},
+		Exports:   []client.ExportEntry{{Type: client.ExporterLocal, OutputDir: filepath.Join(dir, "out")}},
+		CacheExports: []client.CacheOptionsEntry{
+			{Type: "local", Attrs: map[string]string{"dest": filepath.Join(dir, "cache")}},
+		},
+	})
+	require.NoError(t, err)
+
+	changed, err := watchDirs(ctx, map[string]string{"context": dir}, ignore)
+	require.NoError(t, err)
+
+	// exports and ignored files don't start a build
+	for _, p := range []string{"out/foo", "cache/index.json", "node_modules/foo", "build.log", "logs/foo"} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, p), []byte(p), 0600))
+	}
+	select {
+	case <-changed:
+		t.Fatal("change of ignored file was signaled")
+	case <-time.After(500 * time.Millisecond):
+	}
+
+	// files included again by an exclusion pattern do
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "logs", "keep"), []byte("keep"), 0600))
+	requireChanged(t, changed)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "foo"), []byte("foo"), 0600))
+	requireChanged(t, changed)
+}
+
+func requireChanged(t *testing.T, changed <-chan struct{}) {
+	select {
+	case <-changed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("no change was signaled")
+	}
+}
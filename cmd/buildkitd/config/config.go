@@ -24,6 +24,10 @@ type Config struct {
 	Registries map[string]resolverconfig.RegistryConfig `toml:"registry"`
 
 	DNS *DNSConfig `toml:"dns"`
+
+	History *HistoryConfig `toml:"history"`
+
+	SourcePolicy *SourcePolicyConfig `toml:"sourcepolicy"`
 }
 
 type GRPCConfig struct {
@@ -53,6 +57,17 @@ type NetworkConfig struct {
 	Mode          string `toml:"networkMode"`
 	CNIConfigPath string `toml:"cniConfigPath"`
 	CNIBinaryPath string `toml:"cniBinaryPath"`
+	// CNIPoolSize is the number of CNI network namespaces kept ready to be
+	// used by build steps, zero disables the pool.
+	CNIPoolSize int `toml:"cniPoolSize"`
+	// CNIPoolMaxAge is the age in seconds after which a pooled CNI network
+	// namespace is no longer reused, zero for no limit.
+	CNIPoolMaxAge int64 `toml:"cniPoolMaxAge"`
+	// BridgeName is the bridge used by the "bridge" network mode.
+	BridgeName string `toml:"bridgeName"`
+	// BridgeSubnet is the IPv4 subnet the "bridge" network mode assigns
+	// addresses from.
+	BridgeSubnet string `toml:"bridgeSubnet"`
 }
 
 type OCIConfig struct {
@@ -116,3 +131,30 @@ type DNSConfig struct {
 	Options       []string `toml:"options"`
 	SearchDomains []string `toml:"searchDomains"`
 }
+
+type HistoryConfig struct {
+	// MaxAge is the maximum age of a build history record in seconds.
+	MaxAge int64 `toml:"maxAge"`
+	// MaxEntries is the maximum number of build history records to keep.
+	MaxEntries int64 `toml:"maxEntries"`
+}
+
+// SourcePolicyConfig is the source policy applied to all builds.
+type SourcePolicyConfig struct {
+	// File is the path to a JSON encoded policy. Its rules are evaluated
+	// after the rules defined in the config.
+	File  string             `toml:"file"`
+	Rules []SourcePolicyRule `toml:"rules"`
+}
+
+type SourcePolicyRule struct {
+	// Action is one of ALLOW, DENY or CONVERT.
+	Action   string `toml:"action"`
+	Selector string `toml:"selector"`
+	// MatchType is one of WILDCARD (default), EXACT or REGEX.
+	MatchType string `toml:"matchType"`
+	// UpdateIdentifier and UpdateAttrs are applied to sources matched by
+	// CONVERT rules.
+	UpdateIdentifier string            `toml:"updateIdentifier"`
+	UpdateAttrs      map[string]string `toml:"updateAttrs"`
+}
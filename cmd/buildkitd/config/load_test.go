@@ -30,6 +30,10 @@ snapshotter="overlay"
 rootless=true
 gc=false
 gckeepstorage=123456789
+cniPoolSize=16
+cniPoolMaxAge=3600
+bridgeName="bk0"
+bridgeSubnet="10.20.0.0/24"
 [worker.oci.labels]
 foo="bar"
 "aa.bb.cc"="baz"
@@ -61,6 +65,22 @@ cert="cert.pem"
 nameservers=["1.1.1.1","8.8.8.8"]
 options=["edns0"]
 searchDomains=["example.com"]
+
+[history]
+maxAge=3600
+maxEntries=20
+
+[sourcepolicy]
+file="/etc/buildkit/policy.json"
+[[sourcepolicy.rules]]
+action="DENY"
+selector="docker-image://untrusted.example.com/*"
+[[sourcepolicy.rules]]
+action="CONVERT"
+selector="docker-image://docker.io/*"
+updateIdentifier="docker-image://mirror.example.com/${1}"
+[sourcepolicy.rules.updateAttrs]
+"image.recordtype"="internal"
 `
 
 	cfg, err := Load(bytes.NewBuffer([]byte(testConfig)))
@@ -79,6 +99,10 @@ searchDomains=["example.com"]
 
 	require.NotNil(t, cfg.Workers.OCI.Enabled)
 	require.Equal(t, int64(123456789), cfg.Workers.OCI.GCKeepStorage)
+	require.Equal(t, 16, cfg.Workers.OCI.CNIPoolSize)
+	require.Equal(t, int64(3600), cfg.Workers.OCI.CNIPoolMaxAge)
+	require.Equal(t, "bk0", cfg.Workers.OCI.BridgeName)
+	require.Equal(t, "10.20.0.0/24", cfg.Workers.OCI.BridgeSubnet)
 	require.Equal(t, true, *cfg.Workers.OCI.Enabled)
 	require.Equal(t, "overlay", cfg.Workers.OCI.Snapshotter)
 	require.Equal(t, true, cfg.Workers.OCI.Rootless)
@@ -117,4 +141,16 @@ searchDomains=["example.com"]
 	require.Equal(t, cfg.DNS.Nameservers, []string{"1.1.1.1", "8.8.8.8"})
 	require.Equal(t, cfg.DNS.SearchDomains, []string{"example.com"})
 	require.Equal(t, cfg.DNS.Options, []string{"edns0"})
+
+	require.NotNil(t, cfg.History)
+	require.Equal(t, int64(3600), cfg.History.MaxAge)
+	require.Equal(t, int64(20), cfg.History.MaxEntries)
+
+	require.NotNil(t, cfg.SourcePolicy)
+	require.Equal(t, "/etc/buildkit/policy.json", cfg.SourcePolicy.File)
+	require.Equal(t, 2, len(cfg.SourcePolicy.Rules))
+	require.Equal(t, "DENY", cfg.SourcePolicy.Rules[0].Action)
+	require.Equal(t, "docker-image://untrusted.example.com/*", cfg.SourcePolicy.Rules[0].Selector)
+	require.Equal(t, "docker-image://mirror.example.com/${1}", cfg.SourcePolicy.Rules[1].UpdateIdentifier)
+	require.Equal(t, "internal", cfg.SourcePolicy.Rules[1].UpdateAttrs["image.recordtype"])
 }
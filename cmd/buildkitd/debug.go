@@ -11,8 +11,9 @@ import (
 	"golang.org/x/net/trace"
 )
 
-func setupDebugHandlers(addr string) error {
+func setupDebugHandlers(addr string, metrics http.Handler) error {
 	m := http.NewServeMux()
+	m.Handle("/metrics", metrics)
 	m.Handle("/debug/vars", expvar.Handler())
 	m.Handle("/debug/pprof/", http.HandlerFunc(pprof.Index))
 	m.Handle("/debug/pprof/cmdline", http.HandlerFunc(pprof.Cmdline))
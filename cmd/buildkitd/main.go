@@ -4,6 +4,7 @@ import (
 	"context"
 	"crypto/tls"
 	"crypto/x509"
+	"encoding/json"
 	"fmt"
 	"net"
 	"os"
@@ -14,6 +15,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/containerd/containerd/content/local"
 	"github.com/containerd/containerd/pkg/seed"
 	"github.com/containerd/containerd/pkg/userns"
 	"github.com/containerd/containerd/platforms"
@@ -24,10 +26,12 @@ import (
 	"github.com/gofrs/flock"
 	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
 	"github.com/moby/buildkit/cache/remotecache"
+	azblob "github.com/moby/buildkit/cache/remotecache/azblob"
 	"github.com/moby/buildkit/cache/remotecache/gha"
 	inlineremotecache "github.com/moby/buildkit/cache/remotecache/inline"
 	localremotecache "github.com/moby/buildkit/cache/remotecache/local"
 	registryremotecache "github.com/moby/buildkit/cache/remotecache/registry"
+	s3remotecache "github.com/moby/buildkit/cache/remotecache/s3"
 	"github.com/moby/buildkit/client"
 	"github.com/moby/buildkit/cmd/buildkitd/config"
 	"github.com/moby/buildkit/control"
@@ -38,12 +42,15 @@ import (
 	"github.com/moby/buildkit/frontend/gateway/forwarder"
 	"github.com/moby/buildkit/session"
 	"github.com/moby/buildkit/solver/bboltcachestorage"
+	spb "github.com/moby/buildkit/sourcepolicy/pb"
 	"github.com/moby/buildkit/util/apicaps"
 	"github.com/moby/buildkit/util/appcontext"
 	"github.com/moby/buildkit/util/appdefaults"
 	"github.com/moby/buildkit/util/archutil"
 	"github.com/moby/buildkit/util/bklog"
 	"github.com/moby/buildkit/util/grpcerrors"
+	"github.com/moby/buildkit/util/metrics"
+	"github.com/moby/buildkit/util/peercred"
 	"github.com/moby/buildkit/util/profiler"
 	"github.com/moby/buildkit/util/resolver"
 	"github.com/moby/buildkit/util/stack"
@@ -57,6 +64,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 	"github.com/urfave/cli"
+	"go.etcd.io/bbolt"
 	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"go.opentelemetry.io/otel/propagation"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
@@ -215,8 +223,18 @@ func main() {
 			logrus.SetLevel(logrus.DebugLevel)
 		}
 
+		res, err := detect.Resource()
+		if err != nil {
+			return err
+		}
+		mp, err := metrics.NewProvider(ctx, res)
+		if err != nil {
+			return err
+		}
+		defer mp.Shutdown(context.TODO())
+
 		if cfg.GRPC.DebugAddress != "" {
-			if err := setupDebugHandlers(cfg.GRPC.DebugAddress); err != nil {
+			if err := setupDebugHandlers(cfg.GRPC.DebugAddress, mp.Handler()); err != nil {
 				return err
 			}
 		}
@@ -263,6 +281,7 @@ func main() {
 		if err != nil {
 			return err
 		}
+		defer controller.Close()
 
 		controller.Register(server)
 
@@ -408,6 +427,13 @@ func setDefaultConfig(cfg *config.Config) {
 		cfg.Workers.Containerd.Platforms = formatPlatforms(archutil.SupportedPlatforms(false))
 	}
 
+	if cfg.History == nil {
+		cfg.History = &config.HistoryConfig{
+			MaxAge:     int64((48 * time.Hour).Seconds()),
+			MaxEntries: 50,
+		}
+	}
+
 	cfg.Workers.OCI.NetworkConfig = setDefaultNetworkConfig(cfg.Workers.OCI.NetworkConfig)
 	cfg.Workers.Containerd.NetworkConfig = setDefaultNetworkConfig(cfg.Workers.Containerd.NetworkConfig)
 
@@ -524,7 +550,12 @@ func getListener(addr string, uid, gid int, tlsConfig *tls.Config) (net.Listener
 		if tlsConfig != nil {
 			logrus.Warnf("TLS is disabled for %s", addr)
 		}
-		return sys.GetLocalListener(listenAddr, uid, gid)
+		l, err := sys.GetLocalListener(listenAddr, uid, gid)
+		if err != nil {
+			return nil, err
+		}
+		// record the credentials of local clients for bind-through local sources
+		return peercred.NewListener(l), nil
 	case "fd":
 		return listenFD(listenAddr, tlsConfig)
 	case "tcp":
@@ -655,11 +686,31 @@ func newController(c *cli.Context, cfg *config.Config) (*control.Controller, err
 		"local":    localremotecache.ResolveCacheExporterFunc(sessionManager),
 		"inline":   inlineremotecache.ResolveCacheExporterFunc(),
 		"gha":      gha.ResolveCacheExporterFunc(),
+		"s3":       s3remotecache.ResolveCacheExporterFunc(),
+		"azblob":   azblob.ResolveCacheExporterFunc(),
 	}
 	remoteCacheImporterFuncs := map[string]remotecache.ResolveCacheImporterFunc{
 		"registry": registryremotecache.ResolveCacheImporterFunc(sessionManager, w.ContentStore(), resolverFn),
 		"local":    localremotecache.ResolveCacheImporterFunc(sessionManager),
 		"gha":      gha.ResolveCacheImporterFunc(),
+		"s3":       s3remotecache.ResolveCacheImporterFunc(),
+		"azblob":   azblob.ResolveCacheImporterFunc(),
+	}
+
+	historyDB, err := bbolt.Open(filepath.Join(cfg.Root, "history.db"), 0600, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open build history database")
+	}
+	historyStore, err := local.NewStore(filepath.Join(cfg.Root, "history"))
+	if err != nil {
+		historyDB.Close()
+		return nil, errors.Wrap(err, "failed to create build history content store")
+	}
+
+	srcPol, err := loadSourcePolicy(cfg.SourcePolicy)
+	if err != nil {
+		historyDB.Close()
+		return nil, err
 	}
 
 	return control.NewController(control.Opt{
@@ -671,9 +722,65 @@ func newController(c *cli.Context, cfg *config.Config) (*control.Controller, err
 		CacheKeyStorage:           cacheStorage,
 		Entitlements:              cfg.Entitlements,
 		TraceCollector:            tc,
+		HistoryDB:                 historyDB,
+		HistoryContentStore:       historyStore,
+		HistoryConfig: control.HistoryConfig{
+			MaxAge:     time.Duration(cfg.History.MaxAge) * time.Second,
+			MaxEntries: cfg.History.MaxEntries,
+		},
+		SourcePolicy: srcPol,
 	})
 }
 
+// loadSourcePolicy converts the source policy of the config. Rules from the
+// policy file are appended to the rules defined in the config.
+func loadSourcePolicy(cfg *config.SourcePolicyConfig) (*spb.Policy, error) {
+	if cfg == nil {
+		return nil, nil
+	}
+	pol := &spb.Policy{Version: 1}
+	for _, r := range cfg.Rules {
+		action, ok := spb.PolicyAction_value[strings.ToUpper(r.Action)]
+		if !ok {
+			return nil, errors.Errorf("invalid source policy action %q", r.Action)
+		}
+		matchType := spb.MatchType_WILDCARD
+		if r.MatchType != "" {
+			v, ok := spb.MatchType_value[strings.ToUpper(r.MatchType)]
+			if !ok {
+				return nil, errors.Errorf("invalid source policy match type %q", r.MatchType)
+			}
+			matchType = spb.MatchType(v)
+		}
+		rule := &spb.Rule{
+			Action: spb.PolicyAction(action),
+			Selector: &spb.Selector{
+				Identifier: r.Selector,
+				MatchType:  matchType,
+			},
+		}
+		if r.UpdateIdentifier != "" || len(r.UpdateAttrs) > 0 {
+			rule.Updates = &spb.Update{
+				Identifier: r.UpdateIdentifier,
+				Attrs:      r.UpdateAttrs,
+			}
+		}
+		pol.Rules = append(pol.Rules, rule)
+	}
+	if cfg.File != "" {
+		dt, err := os.ReadFile(cfg.File)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read source policy file")
+		}
+		var filePol spb.Policy
+		if err := json.Unmarshal(dt, &filePol); err != nil {
+			return nil, errors.Wrapf(err, "failed to parse source policy file %s", cfg.File)
+		}
+		pol.Rules = append(pol.Rules, filePol.Rules...)
+	}
+	return pol, nil
+}
+
 func resolverFunc(cfg *config.Config) docker.RegistryHosts {
 	return resolver.NewRegistryConfig(cfg.Registries)
 }
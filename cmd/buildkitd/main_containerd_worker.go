@@ -13,6 +13,7 @@ import (
 	ctd "github.com/containerd/containerd"
 	"github.com/containerd/containerd/pkg/userns"
 	"github.com/moby/buildkit/cmd/buildkitd/config"
+	"github.com/moby/buildkit/util/network/bridgeprovider"
 	"github.com/moby/buildkit/util/network/cniprovider"
 	"github.com/moby/buildkit/util/network/netproviders"
 	"github.com/moby/buildkit/worker"
@@ -77,7 +78,7 @@ func init() {
 		},
 		cli.StringFlag{
 			Name:  "containerd-worker-net",
-			Usage: "worker network type (auto, cni or host)",
+			Usage: "worker network type (auto, cni, bridge or host)",
 			Value: defaultConf.Workers.Containerd.NetworkConfig.Mode,
 		},
 		cli.StringFlag{
@@ -247,6 +248,13 @@ func containerdWorkerInitializer(c *cli.Context, common workerInitializerOpt) ([
 			Root:       common.config.Root,
 			ConfigPath: common.config.Workers.Containerd.CNIConfigPath,
 			BinaryDir:  common.config.Workers.Containerd.CNIBinaryPath,
+			PoolSize:   common.config.Workers.Containerd.CNIPoolSize,
+			PoolMaxAge: time.Duration(common.config.Workers.Containerd.CNIPoolMaxAge) * time.Second,
+		},
+		Bridge: bridgeprovider.Opt{
+			Root:       common.config.Root,
+			BridgeName: common.config.Workers.Containerd.BridgeName,
+			Subnet:     common.config.Workers.Containerd.BridgeSubnet,
 		},
 	}
 
@@ -33,6 +33,7 @@ import (
 	"github.com/moby/buildkit/cmd/buildkitd/config"
 	"github.com/moby/buildkit/executor/oci"
 	"github.com/moby/buildkit/session"
+	"github.com/moby/buildkit/util/network/bridgeprovider"
 	"github.com/moby/buildkit/util/network/cniprovider"
 	"github.com/moby/buildkit/util/network/netproviders"
 	"github.com/moby/buildkit/util/resolver"
@@ -88,7 +89,7 @@ func init() {
 		},
 		cli.StringFlag{
 			Name:  "oci-worker-net",
-			Usage: "worker network type (auto, cni or host)",
+			Usage: "worker network type (auto, cni, bridge or host)",
 			Value: defaultConf.Workers.OCI.NetworkConfig.Mode,
 		},
 		cli.StringFlag{
@@ -282,6 +283,13 @@ func ociWorkerInitializer(c *cli.Context, common workerInitializerOpt) ([]worker
 			Root:       common.config.Root,
 			ConfigPath: common.config.Workers.OCI.CNIConfigPath,
 			BinaryDir:  common.config.Workers.OCI.CNIBinaryPath,
+			PoolSize:   common.config.Workers.OCI.CNIPoolSize,
+			PoolMaxAge: time.Duration(common.config.Workers.OCI.CNIPoolMaxAge) * time.Second,
+		},
+		Bridge: bridgeprovider.Opt{
+			Root:       common.config.Root,
+			BridgeName: common.config.Workers.OCI.BridgeName,
+			Subnet:     common.config.Workers.OCI.BridgeSubnet,
 		},
 	}
 
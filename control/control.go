@@ -6,6 +6,9 @@ import (
 	"sync/atomic"
 	"time"
 
+	contentapi "github.com/containerd/containerd/api/services/content/v1"
+	"github.com/containerd/containerd/content"
+	"github.com/containerd/containerd/services/content/contentserver"
 	controlapi "github.com/moby/buildkit/api/services/control"
 	apitypes "github.com/moby/buildkit/api/types"
 	"github.com/moby/buildkit/cache/remotecache"
@@ -18,6 +21,7 @@ import (
 	"github.com/moby/buildkit/solver"
 	"github.com/moby/buildkit/solver/llbsolver"
 	"github.com/moby/buildkit/solver/pb"
+	spb "github.com/moby/buildkit/sourcepolicy/pb"
 	"github.com/moby/buildkit/util/bklog"
 	"github.com/moby/buildkit/util/imageutil"
 	"github.com/moby/buildkit/util/throttle"
@@ -25,6 +29,7 @@ import (
 	"github.com/moby/buildkit/version"
 	"github.com/moby/buildkit/worker"
 	"github.com/pkg/errors"
+	bolt "go.etcd.io/bbolt"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	tracev1 "go.opentelemetry.io/proto/otlp/collector/trace/v1"
 	"golang.org/x/sync/errgroup"
@@ -42,6 +47,10 @@ type Opt struct {
 	ResolveCacheImporterFuncs map[string]remotecache.ResolveCacheImporterFunc
 	Entitlements              []string
 	TraceCollector            sdktrace.SpanExporter
+	HistoryDB                 *bolt.DB
+	HistoryContentStore       content.Store
+	HistoryConfig             HistoryConfig
+	SourcePolicy              *spb.Policy
 }
 
 type Controller struct { // TODO: ControlService
@@ -51,6 +60,7 @@ type Controller struct { // TODO: ControlService
 	solver           *llbsolver.Solver
 	cache            solver.CacheManager
 	gatewayForwarder *controlgateway.GatewayForwarder
+	history          *HistoryQueue
 	throttledGC      func()
 	gcmu             sync.Mutex
 	*tracev1.UnimplementedTraceServiceServer
@@ -69,6 +79,7 @@ func NewController(opt Opt) (*Controller, error) {
 		GatewayForwarder: gatewayForwarder,
 		SessionManager:   opt.SessionManager,
 		Entitlements:     opt.Entitlements,
+		SourcePolicy:     opt.SourcePolicy,
 	})
 
 	if err != nil {
@@ -81,6 +92,12 @@ func NewController(opt Opt) (*Controller, error) {
 		cache:            cache,
 		gatewayForwarder: gatewayForwarder,
 	}
+	if opt.HistoryDB != nil && opt.HistoryContentStore != nil {
+		c.history, err = NewHistoryQueue(opt.HistoryDB, opt.HistoryContentStore, opt.HistoryConfig)
+		if err != nil {
+			return nil, err
+		}
+	}
 	c.throttledGC = throttle.After(time.Minute, c.gc)
 
 	defer func() {
@@ -90,10 +107,25 @@ func NewController(opt Opt) (*Controller, error) {
 	return c, nil
 }
 
+// Close releases the resources held by the workers and the build history
+// database. It must be called after the last request has completed.
+func (c *Controller) Close() error {
+	err := c.opt.WorkerController.Close()
+	if c.opt.HistoryDB != nil {
+		if err1 := c.opt.HistoryDB.Close(); err1 != nil && err == nil {
+			err = err1
+		}
+	}
+	return err
+}
+
 func (c *Controller) Register(server *grpc.Server) {
 	controlapi.RegisterControlServer(server, c)
 	c.gatewayForwarder.Register(server)
 	tracev1.RegisterTraceServiceServer(server, c)
+	if c.history != nil {
+		contentapi.RegisterContentServer(server, contentserver.New(&roContentStore{c.history.ContentStore()}))
+	}
 }
 
 func (c *Controller) DiskUsage(ctx context.Context, r *controlapi.DiskUsageRequest) (*controlapi.DiskUsageResponse, error) {
@@ -239,10 +271,17 @@ func translateLegacySolveRequest(req *controlapi.SolveRequest) error {
 		req.Cache.Imports = append(req.Cache.Imports, im)
 	}
 	req.Cache.ImportRefsDeprecated = nil
+	// translates Exporter and ExporterAttrs to new Exporters
+	if len(req.Exporters) == 0 && req.Exporter != "" {
+		req.Exporters = []*controlapi.Exporter{{
+			Type:  req.Exporter,
+			Attrs: req.ExporterAttrs,
+		}}
+	}
 	return nil
 }
 
-func (c *Controller) Solve(ctx context.Context, req *controlapi.SolveRequest) (*controlapi.SolveResponse, error) {
+func (c *Controller) Solve(ctx context.Context, req *controlapi.SolveRequest) (res *controlapi.SolveResponse, retErr error) {
 	atomic.AddInt64(&c.buildCount, 1)
 	defer atomic.AddInt64(&c.buildCount, -1)
 
@@ -256,28 +295,30 @@ func (c *Controller) Solve(ctx context.Context, req *controlapi.SolveRequest) (*
 		time.AfterFunc(time.Second, c.throttledGC)
 	}()
 
-	var expi exporter.ExporterInstance
+	var expis []exporter.ExporterInstance
 	// TODO: multiworker
 	// This is actually tricky, as the exporter should come from the worker that has the returned reference. We may need to delay this so that the solver loads this.
 	w, err := c.opt.WorkerController.GetDefault()
 	if err != nil {
 		return nil, err
 	}
-	if req.Exporter != "" {
-		exp, err := w.Exporter(req.Exporter, c.opt.SessionManager)
+	for _, ex := range req.Exporters {
+		exp, err := w.Exporter(ex.Type, c.opt.SessionManager)
 		if err != nil {
 			return nil, err
 		}
-		expi, err = exp.Resolve(ctx, req.ExporterAttrs)
+		expi, err := exp.Resolve(ctx, ex.Attrs)
 		if err != nil {
 			return nil, err
 		}
+		expis = append(expis, expi)
 	}
 
 	var (
-		cacheExporter   remotecache.Exporter
-		cacheExportMode solver.CacheExportMode
-		cacheImports    []frontend.CacheOptionsEntry
+		cacheExporter     remotecache.Exporter
+		cacheExportMode   solver.CacheExportMode
+		cacheExportFilter solver.CacheExportFilter
+		cacheImports      []frontend.CacheOptionsEntry
 	)
 	if len(req.Cache.Exports) > 1 {
 		// TODO(AkihiroSuda): this should be fairly easy
@@ -299,6 +340,13 @@ func (c *Controller) Solve(ctx context.Context, req *controlapi.SolveRequest) (*
 		} else {
 			cacheExportMode = exportMode
 		}
+		filter, err := remotecache.ParseExportFilter(e.Attrs)
+		if err != nil {
+			return nil, err
+		}
+		if filter != nil {
+			cacheExportFilter = filter
+		}
 	}
 	for _, im := range req.Cache.Imports {
 		cacheImports = append(cacheImports, frontend.CacheOptionsEntry{
@@ -307,6 +355,27 @@ func (c *Controller) Solve(ctx context.Context, req *controlapi.SolveRequest) (*
 		})
 	}
 
+	var statusChan chan *client.SolveStatus
+	if c.history != nil {
+		rec, err := c.history.newRecorder(ctx, &controlapi.BuildHistoryRecord{
+			Ref:           req.Ref,
+			Frontend:      req.Frontend,
+			FrontendAttrs: req.FrontendAttrs,
+			Exporter:      req.Exporter,
+			ExporterAttrs: req.ExporterAttrs,
+			Exporters:     req.Exporters,
+			CacheImports:  req.Cache.Imports,
+			CacheExports:  req.Cache.Exports,
+		})
+		if err != nil {
+			return nil, err
+		}
+		statusChan = rec.ch
+		defer func() {
+			rec.finish(context.TODO(), res, retErr)
+		}()
+	}
+
 	resp, err := c.solver.Solve(ctx, req.Ref, req.Session, frontend.SolveRequest{
 		Frontend:       req.Frontend,
 		Definition:     req.Definition,
@@ -314,10 +383,11 @@ func (c *Controller) Solve(ctx context.Context, req *controlapi.SolveRequest) (*
 		FrontendInputs: req.FrontendInputs,
 		CacheImports:   cacheImports,
 	}, llbsolver.ExporterRequest{
-		Exporter:        expi,
-		CacheExporter:   cacheExporter,
-		CacheExportMode: cacheExportMode,
-	}, req.Entitlements)
+		Exporters:         expis,
+		CacheExporter:     cacheExporter,
+		CacheExportMode:   cacheExportMode,
+		CacheExportFilter: cacheExportFilter,
+	}, req.Entitlements, req.SourcePolicy, statusChan)
 	if err != nil {
 		return nil, err
 	}
@@ -340,68 +410,10 @@ func (c *Controller) Status(req *controlapi.StatusRequest, stream controlapi.Con
 			if !ok {
 				return nil
 			}
-			logSize := 0
-			for {
-				retry := false
-				sr := controlapi.StatusResponse{}
-				for _, v := range ss.Vertexes {
-					sr.Vertexes = append(sr.Vertexes, &controlapi.Vertex{
-						Digest:        v.Digest,
-						Inputs:        v.Inputs,
-						Name:          v.Name,
-						Started:       v.Started,
-						Completed:     v.Completed,
-						Error:         v.Error,
-						Cached:        v.Cached,
-						ProgressGroup: v.ProgressGroup,
-					})
-				}
-				for _, v := range ss.Statuses {
-					sr.Statuses = append(sr.Statuses, &controlapi.VertexStatus{
-						ID:        v.ID,
-						Vertex:    v.Vertex,
-						Name:      v.Name,
-						Current:   v.Current,
-						Total:     v.Total,
-						Timestamp: v.Timestamp,
-						Started:   v.Started,
-						Completed: v.Completed,
-					})
-				}
-				for i, v := range ss.Logs {
-					sr.Logs = append(sr.Logs, &controlapi.VertexLog{
-						Vertex:    v.Vertex,
-						Stream:    int64(v.Stream),
-						Msg:       v.Data,
-						Timestamp: v.Timestamp,
-					})
-					logSize += len(v.Data) + emptyLogVertexSize
-					// avoid logs growing big and split apart if they do
-					if logSize > 1024*1024 {
-						ss.Vertexes = nil
-						ss.Statuses = nil
-						ss.Logs = ss.Logs[i+1:]
-						retry = true
-						break
-					}
-				}
-				for _, v := range ss.Warnings {
-					sr.Warnings = append(sr.Warnings, &controlapi.VertexWarning{
-						Vertex: v.Vertex,
-						Level:  int64(v.Level),
-						Short:  v.Short,
-						Detail: v.Detail,
-						Info:   v.SourceInfo,
-						Ranges: v.Range,
-						Url:    v.URL,
-					})
-				}
-				if err := stream.SendMsg(&sr); err != nil {
+			for _, sr := range ss.Marshal() {
+				if err := stream.SendMsg(sr); err != nil {
 					return err
 				}
-				if !retry {
-					break
-				}
 			}
 		}
 	})
@@ -409,6 +421,34 @@ func (c *Controller) Status(req *controlapi.StatusRequest, stream controlapi.Con
 	return eg.Wait()
 }
 
+func (c *Controller) ListenBuildHistory(req *controlapi.BuildHistoryRequest, srv controlapi.Control_ListenBuildHistoryServer) error {
+	if c.history == nil {
+		return status.Errorf(codes.Unavailable, "build history is not enabled")
+	}
+	return c.history.Listen(srv.Context(), req, func(e *controlapi.BuildHistoryEvent) error {
+		return srv.Send(e)
+	})
+}
+
+func (c *Controller) UpdateBuildHistory(ctx context.Context, req *controlapi.UpdateBuildHistoryRequest) (*controlapi.UpdateBuildHistoryResponse, error) {
+	if c.history == nil {
+		return nil, status.Errorf(codes.Unavailable, "build history is not enabled")
+	}
+	if req.Delete {
+		if err := c.history.Delete(ctx, req.Ref); err != nil {
+			return nil, err
+		}
+		return &controlapi.UpdateBuildHistoryResponse{}, nil
+	}
+	if err := c.history.UpdateRef(ctx, req.Ref, func(rec *controlapi.BuildHistoryRecord) error {
+		rec.Pinned = req.Pinned
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return &controlapi.UpdateBuildHistoryResponse{}, nil
+}
+
 func (c *Controller) Session(stream controlapi.Control_SessionServer) error {
 	bklog.G(stream.Context()).Debugf("session started")
 
@@ -451,6 +491,7 @@ func (c *Controller) Info(ctx context.Context, r *controlapi.InfoRequest) (*cont
 			Version:  version.Version,
 			Revision: version.Revision,
 		},
+		ControlAPICaps: controlapi.Caps.All(),
 	}, nil
 }
 
@@ -0,0 +1,484 @@
+package control
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/containerd/containerd/content"
+	cerrdefs "github.com/containerd/containerd/errdefs"
+	"github.com/gogo/googleapis/google/rpc"
+	gogotypes "github.com/gogo/protobuf/types"
+	"github.com/golang/protobuf/ptypes/any"
+	controlapi "github.com/moby/buildkit/api/services/control"
+	"github.com/moby/buildkit/client"
+	"github.com/moby/buildkit/identity"
+	"github.com/moby/buildkit/util/bklog"
+	"github.com/moby/buildkit/util/grpcerrors"
+	digest "github.com/opencontainers/go-digest"
+	"github.com/pkg/errors"
+	bolt "go.etcd.io/bbolt"
+	"google.golang.org/grpc/status"
+)
+
+const recordsBucket = "_records"
+
+// HistoryConfig defines how many completed builds are kept in the history.
+// Zero values disable the corresponding limit. Pinned records are never
+// removed automatically.
+type HistoryConfig struct {
+	MaxAge     time.Duration
+	MaxEntries int64
+}
+
+// HistoryQueue keeps records of the active and completed builds. Completed
+// records are persisted in a bolt database and the recorded progress of the
+// builds is kept in a content store.
+type HistoryQueue struct {
+	mu          sync.Mutex
+	db          *bolt.DB
+	cs          content.Store
+	cfg         HistoryConfig
+	active      map[string]*controlapi.BuildHistoryRecord
+	subscribers map[*historySubscriber]struct{}
+}
+
+type historySubscriber struct {
+	mu     sync.Mutex
+	queue  []*controlapi.BuildHistoryEvent
+	notify chan struct{}
+}
+
+func NewHistoryQueue(db *bolt.DB, cs content.Store, cfg HistoryConfig) (*HistoryQueue, error) {
+	if err := db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists([]byte(recordsBucket))
+		return err
+	}); err != nil {
+		return nil, errors.Wrap(err, "failed to initialize build history")
+	}
+	h := &HistoryQueue{
+		db:          db,
+		cs:          cs,
+		cfg:         cfg,
+		active:      map[string]*controlapi.BuildHistoryRecord{},
+		subscribers: map[*historySubscriber]struct{}{},
+	}
+	go func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		if err := h.gc(context.TODO()); err != nil {
+			bklog.G(context.TODO()).Errorf("failed to clean build history: %+v", err)
+		}
+	}()
+	return h, nil
+}
+
+// ContentStore returns the store holding the recorded build progress.
+func (h *HistoryQueue) ContentStore() content.Store {
+	return h.cs
+}
+
+// Update adds an event to the history. Completed records are persisted and
+// old records are released based on the history config.
+func (h *HistoryQueue) Update(ctx context.Context, e *controlapi.BuildHistoryEvent) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	rec := e.Record
+	switch e.Type {
+	case controlapi.BuildHistoryEventType_STARTED:
+		h.active[rec.Ref] = rec
+	case controlapi.BuildHistoryEventType_COMPLETE:
+		delete(h.active, rec.Ref)
+		if err := h.save(rec); err != nil {
+			return err
+		}
+	default:
+		return errors.Errorf("invalid build history event type %v", e.Type)
+	}
+	h.publish(e)
+
+	if e.Type == controlapi.BuildHistoryEventType_COMPLETE {
+		return h.gc(ctx)
+	}
+	return nil
+}
+
+// UpdateRef modifies a completed record.
+func (h *HistoryQueue) UpdateRef(ctx context.Context, ref string, fn func(*controlapi.BuildHistoryRecord) error) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	rec, err := h.load(ref)
+	if err != nil {
+		return err
+	}
+	if err := fn(rec); err != nil {
+		return err
+	}
+	if err := h.save(rec); err != nil {
+		return err
+	}
+	h.publish(&controlapi.BuildHistoryEvent{
+		Type:   controlapi.BuildHistoryEventType_COMPLETE,
+		Record: rec,
+	})
+	return h.gc(ctx)
+}
+
+// Delete removes a completed record and its recorded progress.
+func (h *HistoryQueue) Delete(ctx context.Context, ref string) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if _, ok := h.active[ref]; ok {
+		return errors.Errorf("build %s is still running", ref)
+	}
+	rec, err := h.load(ref)
+	if err != nil {
+		return err
+	}
+	return h.delete(ctx, rec)
+}
+
+// Listen calls f for the current state of the history and for every new
+// event until ctx is cancelled. If req.EarlyExit is set, Listen returns
+// after the current state has been sent.
+func (h *HistoryQueue) Listen(ctx context.Context, req *controlapi.BuildHistoryRequest, f func(*controlapi.BuildHistoryEvent) error) error {
+	h.mu.Lock()
+	var events []*controlapi.BuildHistoryEvent
+	for _, rec := range h.active {
+		events = append(events, &controlapi.BuildHistoryEvent{
+			Type:   controlapi.BuildHistoryEventType_STARTED,
+			Record: rec,
+		})
+	}
+	if !req.ActiveOnly {
+		if err := h.walk(func(rec *controlapi.BuildHistoryRecord) error {
+			events = append(events, &controlapi.BuildHistoryEvent{
+				Type:   controlapi.BuildHistoryEventType_COMPLETE,
+				Record: rec,
+			})
+			return nil
+		}); err != nil {
+			h.mu.Unlock()
+			return err
+		}
+	}
+	var sub *historySubscriber
+	if !req.EarlyExit {
+		sub = &historySubscriber{notify: make(chan struct{}, 1)}
+		h.subscribers[sub] = struct{}{}
+		defer func() {
+			h.mu.Lock()
+			delete(h.subscribers, sub)
+			h.mu.Unlock()
+		}()
+	}
+	h.mu.Unlock()
+
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].Record.CreatedAt.Before(*events[j].Record.CreatedAt)
+	})
+
+	send := func(e *controlapi.BuildHistoryEvent) error {
+		if req.Ref != "" && e.Record.Ref != req.Ref {
+			return nil
+		}
+		if req.ActiveOnly && e.Type != controlapi.BuildHistoryEventType_STARTED {
+			return nil
+		}
+		return f(e)
+	}
+
+	for _, e := range events {
+		if err := send(e); err != nil {
+			return err
+		}
+	}
+	if sub == nil {
+		return nil
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-sub.notify:
+		}
+		sub.mu.Lock()
+		events := sub.queue
+		sub.queue = nil
+		sub.mu.Unlock()
+		for _, e := range events {
+			if err := send(e); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// OpenLogWriter returns a writer for recording the progress of a build.
+func (h *HistoryQueue) OpenLogWriter(ctx context.Context, ref string) (content.Writer, error) {
+	return content.OpenWriter(ctx, h.cs, content.WithRef("history-"+ref+"-"+identity.NewID()))
+}
+
+// called with h.mu held
+func (h *HistoryQueue) publish(e *controlapi.BuildHistoryEvent) {
+	for sub := range h.subscribers {
+		sub.mu.Lock()
+		sub.queue = append(sub.queue, e)
+		sub.mu.Unlock()
+		select {
+		case sub.notify <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// called with h.mu held
+func (h *HistoryQueue) gc(ctx context.Context) error {
+	if h.cfg.MaxAge == 0 && h.cfg.MaxEntries == 0 {
+		return nil
+	}
+	var recs []*controlapi.BuildHistoryRecord
+	if err := h.walk(func(rec *controlapi.BuildHistoryRecord) error {
+		if !rec.Pinned {
+			recs = append(recs, rec)
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	// newest records first
+	sort.Slice(recs, func(i, j int) bool {
+		return recs[i].CompletedAt.After(*recs[j].CompletedAt)
+	})
+
+	now := time.Now()
+	for i, rec := range recs {
+		expired := h.cfg.MaxAge > 0 && now.Sub(*rec.CompletedAt) > h.cfg.MaxAge
+		overflow := h.cfg.MaxEntries > 0 && int64(i) >= h.cfg.MaxEntries
+		if !expired && !overflow {
+			continue
+		}
+		if err := h.delete(ctx, rec); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// called with h.mu held
+func (h *HistoryQueue) delete(ctx context.Context, rec *controlapi.BuildHistoryRecord) error {
+	if err := h.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket([]byte(recordsBucket)).Delete([]byte(rec.Ref))
+	}); err != nil {
+		return errors.WithStack(err)
+	}
+	if rec.Logs != nil {
+		if err := h.cs.Delete(ctx, rec.Logs.Digest); err != nil && !cerrdefs.IsNotFound(err) {
+			bklog.G(ctx).Warnf("failed to delete build history logs for %s: %v", rec.Ref, err)
+		}
+	}
+	h.publish(&controlapi.BuildHistoryEvent{
+		Type:   controlapi.BuildHistoryEventType_DELETED,
+		Record: rec,
+	})
+	return nil
+}
+
+func (h *HistoryQueue) save(rec *controlapi.BuildHistoryRecord) error {
+	dt, err := rec.Marshal()
+	if err != nil {
+		return err
+	}
+	return errors.WithStack(h.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket([]byte(recordsBucket)).Put([]byte(rec.Ref), dt)
+	}))
+}
+
+func (h *HistoryQueue) load(ref string) (*controlapi.BuildHistoryRecord, error) {
+	var rec controlapi.BuildHistoryRecord
+	if err := h.db.View(func(tx *bolt.Tx) error {
+		dt := tx.Bucket([]byte(recordsBucket)).Get([]byte(ref))
+		if dt == nil {
+			return errors.Wrapf(cerrdefs.ErrNotFound, "build %s not found in history", ref)
+		}
+		return rec.Unmarshal(dt)
+	}); err != nil {
+		return nil, err
+	}
+	return &rec, nil
+}
+
+func (h *HistoryQueue) walk(fn func(*controlapi.BuildHistoryRecord) error) error {
+	var recs []*controlapi.BuildHistoryRecord
+	if err := h.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket([]byte(recordsBucket)).ForEach(func(k, dt []byte) error {
+			var rec controlapi.BuildHistoryRecord
+			if err := rec.Unmarshal(dt); err != nil {
+				return err
+			}
+			recs = append(recs, &rec)
+			return nil
+		})
+	}); err != nil {
+		return errors.WithStack(err)
+	}
+	for _, rec := range recs {
+		if err := fn(rec); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// historyRecorder captures the progress of a single build for the history.
+type historyRecorder struct {
+	h    *HistoryQueue
+	rec  *controlapi.BuildHistoryRecord
+	ch   chan *client.SolveStatus
+	done chan struct{}
+	logs *controlapi.Descriptor
+
+	numTotal, numCached, numCompleted int32
+}
+
+func (h *HistoryQueue) newRecorder(ctx context.Context, rec *controlapi.BuildHistoryRecord) (*historyRecorder, error) {
+	now := time.Now()
+	rec.CreatedAt = &now
+	if err := h.Update(ctx, &controlapi.BuildHistoryEvent{
+		Type:   controlapi.BuildHistoryEventType_STARTED,
+		Record: rec,
+	}); err != nil {
+		return nil, err
+	}
+	r := &historyRecorder{
+		h:    h,
+		rec:  rec,
+		ch:   make(chan *client.SolveStatus, 8),
+		done: make(chan struct{}),
+	}
+	// the logs are recorded even if the client goes away, so the recorder
+	// doesn't use the request context
+	go r.run(bklog.WithLogger(context.TODO(), bklog.G(ctx)))
+	return r, nil
+}
+
+func (r *historyRecorder) run(ctx context.Context) {
+	defer close(r.done)
+
+	w, err := r.h.OpenLogWriter(ctx, r.rec.Ref)
+	if err != nil {
+		bklog.G(ctx).Errorf("failed to open build history log writer: %+v", err)
+	}
+	completed := map[digest.Digest]struct{}{}
+	seen := map[digest.Digest]struct{}{}
+	for ss := range r.ch {
+		for _, v := range ss.Vertexes {
+			if _, ok := seen[v.Digest]; !ok {
+				seen[v.Digest] = struct{}{}
+				r.numTotal++
+			}
+			if v.Completed != nil {
+				if _, ok := completed[v.Digest]; !ok {
+					completed[v.Digest] = struct{}{}
+					r.numCompleted++
+					if v.Cached {
+						r.numCached++
+					}
+				}
+			}
+		}
+		if w != nil {
+			if err := client.WriteStatusStream(w, ss); err != nil {
+				bklog.G(ctx).Errorf("failed to write build history logs: %+v", err)
+				w.Close()
+				w = nil
+			}
+		}
+	}
+	if w == nil {
+		return
+	}
+	defer w.Close()
+	dgst := w.Digest()
+	st, err := w.Status()
+	if err != nil {
+		bklog.G(ctx).Errorf("failed to get build history log status: %+v", err)
+		return
+	}
+	if err := w.Commit(ctx, st.Offset, dgst); err != nil && !cerrdefs.IsAlreadyExists(err) {
+		bklog.G(ctx).Errorf("failed to commit build history logs: %+v", err)
+		return
+	}
+	r.logs = &controlapi.Descriptor{
+		MediaType: client.StatusStreamMediaType,
+		Digest:    dgst,
+		Size_:     st.Offset,
+	}
+}
+
+// finish waits for the status stream to close and records the completed
+// build in the history.
+func (r *historyRecorder) finish(ctx context.Context, resp *controlapi.SolveResponse, err error) {
+	<-r.done
+	now := time.Now()
+	rec := *r.rec
+	rec.CompletedAt = &now
+	rec.Logs = r.logs
+	rec.NumTotalSteps = r.numTotal
+	rec.NumCachedSteps = r.numCached
+	rec.NumCompletedSteps = r.numCompleted
+	if resp != nil {
+		rec.ExporterResponse = resp.ExporterResponse
+	}
+	if err != nil {
+		st, _ := status.FromError(grpcerrors.ToGRPC(err))
+		stp := st.Proto()
+		rec.Error = &rpc.Status{
+			Code:    stp.Code,
+			Message: stp.Message,
+			Details: convertToGogoAny(stp.Details),
+		}
+	}
+	if err := r.h.Update(ctx, &controlapi.BuildHistoryEvent{
+		Type:   controlapi.BuildHistoryEventType_COMPLETE,
+		Record: &rec,
+	}); err != nil {
+		bklog.G(ctx).Errorf("failed to save build history record: %+v", err)
+	}
+}
+
+// roContentStore prevents the clients of the history content store from
+// modifying the recorded data.
+type roContentStore struct {
+	content.Store
+}
+
+func (cs *roContentStore) Writer(ctx context.Context, opts ...content.WriterOpt) (content.Writer, error) {
+	return nil, errors.Errorf("read-only content store")
+}
+
+func (cs *roContentStore) Delete(ctx context.Context, dgst digest.Digest) error {
+	return errors.Errorf("read-only content store")
+}
+
+func (cs *roContentStore) Update(ctx context.Context, info content.Info, fieldpaths ...string) (content.Info, error) {
+	return content.Info{}, errors.Errorf("read-only content store")
+}
+
+func (cs *roContentStore) Abort(ctx context.Context, ref string) error {
+	return errors.Errorf("read-only content store")
+}
+
+func convertToGogoAny(in []*any.Any) []*gogotypes.Any {
+	out := make([]*gogotypes.Any, len(in))
+	for i := range in {
+		out[i] = &gogotypes.Any{TypeUrl: in[i].TypeUrl, Value: in[i].Value}
+	}
+	return out
+}
@@ -0,0 +1,218 @@
+package control
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/containerd/containerd/content"
+	"github.com/containerd/containerd/content/local"
+	controlapi "github.com/moby/buildkit/api/services/control"
+	"github.com/moby/buildkit/client"
+	ocispecs "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/require"
+	bolt "go.etcd.io/bbolt"
+)
+
+func newTestHistoryQueue(t *testing.T, cfg HistoryConfig) *HistoryQueue {
+	tmpdir := t.TempDir()
+	db, err := bolt.Open(filepath.Join(tmpdir, "history.db"), 0600, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	cs, err := local.NewStore(filepath.Join(tmpdir, "content"))
+	require.NoError(t, err)
+	h, err := NewHistoryQueue(db, cs, cfg)
+	require.NoError(t, err)
+	return h
+}
+
+func listHistory(t *testing.T, h *HistoryQueue, req *controlapi.BuildHistoryRequest) []*controlapi.BuildHistoryEvent {
+	req.EarlyExit = true
+	var events []*controlapi.BuildHistoryEvent
+	err := h.Listen(context.TODO(), req, func(e *controlapi.BuildHistoryEvent) error {
+		events = append(events, e)
+		return nil
+	})
+	require.NoError(t, err)
+	return events
+}
+
+func TestHistoryRecorder(t *testing.T) {
+	t.Parallel()
+	ctx := context.TODO()
+	h := newTestHistoryQueue(t, HistoryConfig{})
+
+	r, err := h.newRecorder(ctx, &controlapi.BuildHistoryRecord{Ref: "ref1", Frontend: "dockerfile.v0"})
+	require.NoError(t, err)
+
+	events := listHistory(t, h, &controlapi.BuildHistoryRequest{ActiveOnly: true})
+	require.Equal(t, 1, len(events))
+	require.Equal(t, controlapi.BuildHistoryEventType_STARTED, events[0].Type)
+	require.Equal(t, "ref1", events[0].Record.Ref)
+
+	now := time.Now()
+	r.ch <- &client.SolveStatus{
+		Vertexes: []*client.Vertex{
+			{Digest: "sha256:aaa", Name: "first", Started: &now, Completed: &now, Cached: true},
+			{Digest: "sha256:bbb", Name: "second", Started: &now},
+		},
+		Logs: []*client.VertexLog{
+			{Vertex: "sha256:bbb", Stream: 1, Data: []byte("hello"), Timestamp: now},
+		},
+	}
+	close(r.ch)
+	r.finish(ctx, &controlapi.SolveResponse{ExporterResponse: map[string]string{"foo": "bar"}}, nil)
+
+	events = listHistory(t, h, &controlapi.BuildHistoryRequest{ActiveOnly: true})
+	require.Equal(t, 0, len(events))
+
+	events = listHistory(t, h, &controlapi.BuildHistoryRequest{Ref: "ref1"})
+	require.Equal(t, 1, len(events))
+	rec := events[0].Record
+	require.Equal(t, controlapi.BuildHistoryEventType_COMPLETE, events[0].Type)
+	require.Equal(t, "dockerfile.v0", rec.Frontend)
+	require.Equal(t, "bar", rec.ExporterResponse["foo"])
+	require.Equal(t, int32(2), rec.NumTotalSteps)
+	require.Equal(t, int32(1), rec.NumCompletedSteps)
+	require.Equal(t, int32(1), rec.NumCachedSteps)
+	require.NotNil(t, rec.CompletedAt)
+	require.Nil(t, rec.Error)
+	require.NotNil(t, rec.Logs)
+
+	ra, err := h.ContentStore().ReaderAt(ctx, ocispecs.Descriptor{
+		MediaType: rec.Logs.MediaType,
+		Digest:    rec.Logs.Digest,
+		Size:      rec.Logs.Size_,
+	})
+	require.NoError(t, err)
+	defer ra.Close()
+	var statuses []*client.SolveStatus
+	err = client.ReadStatusStream(content.NewReader(ra), func(ss *client.SolveStatus) error {
+		statuses = append(statuses, ss)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1, len(statuses))
+	require.Equal(t, 2, len(statuses[0].Vertexes))
+	require.Equal(t, "hello", string(statuses[0].Logs[0].Data))
+
+	require.NoError(t, h.Delete(ctx, "ref1"))
+	require.Equal(t, 0, len(listHistory(t, h, &controlapi.BuildHistoryRequest{})))
+	_, err = h.ContentStore().Info(ctx, rec.Logs.Digest)
+	require.Error(t, err)
+}
+
+// ctxContentStore fails writes with a done context like remote stores do.
+type ctxContentStore struct {
+	content.Store
+}
+
+func (cs *ctxContentStore) Writer(ctx context.Context, opts ...content.WriterOpt) (content.Writer, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return cs.Store.Writer(ctx, opts...)
+}
+
+func TestHistoryRecorderClientGone(t *testing.T) {
+	t.Parallel()
+	h := newTestHistoryQueue(t, HistoryConfig{})
+	h.cs = &ctxContentStore{h.cs}
+
+	ctx, cancel := context.WithCancel(context.TODO())
+	cancel()
+	r, err := h.newRecorder(ctx, &controlapi.BuildHistoryRecord{Ref: "ref1"})
+	require.NoError(t, err)
+
+	now := time.Now()
+	r.ch <- &client.SolveStatus{
+		Vertexes: []*client.Vertex{{Digest: "sha256:aaa", Name: "first", Started: &now, Completed: &now}},
+	}
+	close(r.ch)
+	r.finish(context.TODO(), nil, context.Canceled)
+
+	// the logs are recorded after the request context was canceled
+	events := listHistory(t, h, &controlapi.BuildHistoryRequest{Ref: "ref1"})
+	require.Equal(t, 1, len(events))
+	require.NotNil(t, events[0].Record.Logs)
+}
+
+func TestHistoryMaxEntries(t *testing.T) {
+	t.Parallel()
+	ctx := context.TODO()
+	h := newTestHistoryQueue(t, HistoryConfig{MaxEntries: 2})
+
+	for _, ref := range []string{"ref1", "ref2", "ref3"} {
+		r, err := h.newRecorder(ctx, &controlapi.BuildHistoryRecord{Ref: ref})
+		require.NoError(t, err)
+		close(r.ch)
+		r.finish(ctx, nil, nil)
+		if ref == "ref1" {
+			require.NoError(t, h.UpdateRef(ctx, ref, func(rec *controlapi.BuildHistoryRecord) error {
+				rec.Pinned = true
+				return nil
+			}))
+		}
+	}
+
+	events := listHistory(t, h, &controlapi.BuildHistoryRequest{})
+	var refs []string
+	for _, e := range events {
+		refs = append(refs, e.Record.Ref)
+	}
+	// pinned records do not count towards the limit
+	require.Equal(t, []string{"ref1", "ref2", "ref3"}, refs)
+
+	r, err := h.newRecorder(ctx, &controlapi.BuildHistoryRecord{Ref: "ref4"})
+	require.NoError(t, err)
+	close(r.ch)
+	r.finish(ctx, nil, nil)
+
+	events = listHistory(t, h, &controlapi.BuildHistoryRequest{})
+	refs = nil
+	for _, e := range events {
+		refs = append(refs, e.Record.Ref)
+	}
+	require.Equal(t, []string{"ref1", "ref3", "ref4"}, refs)
+}
+
+func TestHistoryListen(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+	h := newTestHistoryQueue(t, HistoryConfig{})
+
+	ch := make(chan *controlapi.BuildHistoryEvent, 4)
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- h.Listen(ctx, &controlapi.BuildHistoryRequest{Ref: "ref1"}, func(e *controlapi.BuildHistoryEvent) error {
+			ch <- e
+			return nil
+		})
+	}()
+
+	// wait for the listener to be registered
+	require.Eventually(t, func() bool {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		return len(h.subscribers) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	for _, ref := range []string{"ref2", "ref1"} {
+		r, err := h.newRecorder(ctx, &controlapi.BuildHistoryRecord{Ref: ref})
+		require.NoError(t, err)
+		close(r.ch)
+		r.finish(ctx, nil, nil)
+	}
+
+	e := <-ch
+	require.Equal(t, controlapi.BuildHistoryEventType_STARTED, e.Type)
+	require.Equal(t, "ref1", e.Record.Ref)
+	e = <-ch
+	require.Equal(t, controlapi.BuildHistoryEventType_COMPLETE, e.Type)
+	require.Equal(t, "ref1", e.Record.Ref)
+
+	cancel()
+	require.ErrorIs(t, <-errCh, context.Canceled)
+}
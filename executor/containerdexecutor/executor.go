@@ -21,9 +21,11 @@ import (
 	"github.com/docker/docker/pkg/idtools"
 	"github.com/moby/buildkit/executor"
 	"github.com/moby/buildkit/executor/oci"
+	"github.com/moby/buildkit/executor/resources"
 	gatewayapi "github.com/moby/buildkit/frontend/gateway/pb"
 	"github.com/moby/buildkit/identity"
 	"github.com/moby/buildkit/snapshot"
+	"github.com/moby/buildkit/solver/errdefs"
 	"github.com/moby/buildkit/solver/pb"
 	"github.com/moby/buildkit/util/network"
 	rootlessspecconv "github.com/moby/buildkit/util/rootless/specconv"
@@ -169,6 +171,9 @@ func (w *containerdExecutor) Run(ctx context.Context, id string, root executor.M
 	defer cleanup()
 	spec.Process.Terminal = meta.Tty
 	if w.rootless {
+		if meta.ResourceLimits != nil {
+			return errors.New("resource limits are not supported in rootless mode")
+		}
 		if err := rootlessspecconv.ToRootless(spec); err != nil {
 			return err
 		}
@@ -208,8 +213,16 @@ func (w *containerdExecutor) Run(ctx context.Context, id string, root executor.M
 		}
 	}()
 
+	var cgroupsPath string
+	if spec.Linux != nil {
+		cgroupsPath = spec.Linux.CgroupsPath
+	}
+
 	trace.SpanFromContext(ctx).AddEvent("Container created")
-	err = w.runProcess(ctx, task, process.Resize, process.Signal, func() {
+	// the cgroup is kept until the task is deleted, so the monitor is stopped
+	// before that to read the final resource usage
+	stopMonitor := resources.Monitor(ctx, cgroupsPath)
+	err = w.runProcess(ctx, task, process.Resize, process.Signal, meta.Timeout, func() {
 		startedOnce.Do(func() {
 			trace.SpanFromContext(ctx).AddEvent("Container started")
 			if started != nil {
@@ -217,6 +230,7 @@ func (w *containerdExecutor) Run(ctx context.Context, id string, root executor.M
 			}
 		})
 	})
+	stopMonitor()
 	return err
 }
 
@@ -304,7 +318,7 @@ func (w *containerdExecutor) Exec(ctx context.Context, id string, process execut
 		return errors.WithStack(err)
 	}
 
-	err = w.runProcess(ctx, taskProcess, process.Resize, process.Signal, nil)
+	err = w.runProcess(ctx, taskProcess, process.Resize, process.Signal, 0, nil)
 	return err
 }
 
@@ -321,7 +335,9 @@ func fixProcessOutput(process *executor.ProcessInfo) {
 	}
 }
 
-func (w *containerdExecutor) runProcess(ctx context.Context, p containerd.Process, resize <-chan executor.WinSize, signal <-chan syscall.Signal, started func()) error {
+// runProcess runs p until it exits. If timeout is set, all the processes of
+// the container are killed when p does not exit within it.
+func (w *containerdExecutor) runProcess(ctx context.Context, p containerd.Process, resize <-chan executor.WinSize, signal <-chan syscall.Signal, timeout time.Duration, started func()) error {
 	// Not using `ctx` here because the context passed only affects the statusCh which we
 	// don't want cancelled when ctx.Done is sent.  We want to process statusCh on cancel.
 	statusCh, err := p.Wait(context.Background())
@@ -383,18 +399,36 @@ func (w *containerdExecutor) runProcess(ctx context.Context, p containerd.Proces
 		}
 	}()
 
+	var timeoutCh <-chan time.Time
+	if timeout > 0 {
+		t := time.NewTimer(timeout)
+		defer t.Stop()
+		timeoutCh = t.C
+	}
+
 	var cancel func()
 	var killCtxDone <-chan struct{}
+	var timedOut bool
 	ctxDone := ctx.Done()
 	for {
 		select {
 		case <-ctxDone:
 			ctxDone = nil
+			timeoutCh = nil
 			var killCtx context.Context
 			killCtx, cancel = context.WithTimeout(context.Background(), 10*time.Second)
 			killCtxDone = killCtx.Done()
 			p.Kill(killCtx, syscall.SIGKILL)
 			io.Cancel()
+		case <-timeoutCh:
+			ctxDone = nil
+			timeoutCh = nil
+			timedOut = true
+			var killCtx context.Context
+			killCtx, cancel = context.WithTimeout(context.Background(), 10*time.Second)
+			killCtxDone = killCtx.Done()
+			p.Kill(killCtx, syscall.SIGKILL, containerd.WithKillAll)
+			io.Cancel()
 		case status := <-statusCh:
 			if cancel != nil {
 				cancel()
@@ -418,6 +452,9 @@ func (w *containerdExecutor) runProcess(ctx context.Context, p containerd.Proces
 					exitErr.Err = errors.Wrap(ctx.Err(), exitErr.Error())
 				default:
 				}
+				if timedOut {
+					return errdefs.NewExecTimeoutError(timeout, exitErr)
+				}
 				return exitErr
 			}
 			return nil
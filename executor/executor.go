@@ -5,6 +5,7 @@ import (
 	"io"
 	"net"
 	"syscall"
+	"time"
 
 	"github.com/moby/buildkit/snapshot"
 	"github.com/moby/buildkit/solver/pb"
@@ -21,8 +22,12 @@ type Meta struct {
 	ExtraHosts     []HostIP
 	Ulimit         []*pb.Ulimit
 	CgroupParent   string
-	NetMode        pb.NetMode
-	SecurityMode   pb.SecurityMode
+	ResourceLimits *pb.ResourceLimits
+	// Timeout is the duration after which the process started by Run is
+	// killed, zero for no timeout
+	Timeout      time.Duration
+	NetMode      pb.NetMode
+	SecurityMode pb.SecurityMode
 }
 
 type Mountable interface {
@@ -105,6 +105,12 @@ func GenerateSpec(ctx context.Context, meta executor.Meta, mounts []executor.Mou
 		return nil, nil, err
 	}
 
+	if resourcesOpts, err := generateResourceLimitsOpts(meta.ResourceLimits); err == nil {
+		opts = append(opts, resourcesOpts...)
+	} else {
+		return nil, nil, err
+	}
+
 	hostname := defaultHostname
 	if meta.Hostname != "" {
 		hostname = meta.Hostname
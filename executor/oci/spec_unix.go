@@ -17,6 +17,7 @@ import (
 	"github.com/moby/buildkit/util/entitlements/security"
 	specs "github.com/opencontainers/runtime-spec/specs-go"
 	"github.com/opencontainers/selinux/go-selinux/label"
+	"github.com/pkg/errors"
 )
 
 func generateMountOpts(resolvConf, hostsFile string) ([]oci.SpecOpts, error) {
@@ -104,6 +105,69 @@ func generateRlimitOpts(ulimits []*pb.Ulimit) ([]oci.SpecOpts, error) {
 	}, nil
 }
 
+const (
+	// cpuPeriod is the CFS period used for CPU limits, in microseconds
+	cpuPeriod = 100000
+	// minNanoCPUs is the lowest CPU limit, as the kernel rejects CFS quotas
+	// below 1ms
+	minNanoCPUs = 1000 * 1e9 / cpuPeriod
+)
+
+func generateResourceLimitsOpts(limits *pb.ResourceLimits) ([]oci.SpecOpts, error) {
+	if limits == nil {
+		return nil, nil
+	}
+	if limits.NanoCPUs < 0 || limits.Memory < 0 || limits.Pids < 0 || limits.MemorySwap < -1 {
+		return nil, errors.New("invalid negative resource limit")
+	}
+	if limits.NanoCPUs != 0 && limits.NanoCPUs < minNanoCPUs {
+		return nil, errors.Errorf("invalid CPU limit %d, must be at least %d nanocpus", limits.NanoCPUs, int64(minNanoCPUs))
+	}
+	if limits.IoWeight != 0 && (limits.IoWeight < 10 || limits.IoWeight > 1000) {
+		return nil, errors.Errorf("invalid IO weight %d, must be between 10 and 1000", limits.IoWeight)
+	}
+	if limits.MemorySwap > 0 && limits.MemorySwap < limits.Memory {
+		return nil, errors.Errorf("memory plus swap limit %d must not be lower than memory limit %d", limits.MemorySwap, limits.Memory)
+	}
+	return []oci.SpecOpts{
+		func(_ context.Context, _ oci.Client, _ *containers.Container, s *specs.Spec) error {
+			if s.Linux == nil {
+				s.Linux = &specs.Linux{}
+			}
+			if s.Linux.Resources == nil {
+				s.Linux.Resources = &specs.LinuxResources{}
+			}
+			r := s.Linux.Resources
+			if limits.NanoCPUs > 0 {
+				period := uint64(cpuPeriod)
+				quota := limits.NanoCPUs * cpuPeriod / 1e9
+				r.CPU = &specs.LinuxCPU{Period: &period, Quota: &quota}
+			}
+			if limits.Memory > 0 || limits.MemorySwap != 0 {
+				if r.Memory == nil {
+					r.Memory = &specs.LinuxMemory{}
+				}
+				if limits.Memory > 0 {
+					mem := limits.Memory
+					r.Memory.Limit = &mem
+				}
+				if limits.MemorySwap != 0 {
+					swap := limits.MemorySwap
+					r.Memory.Swap = &swap
+				}
+			}
+			if limits.Pids > 0 {
+				r.Pids = &specs.LinuxPids{Limit: limits.Pids}
+			}
+			if limits.IoWeight > 0 {
+				w := uint16(limits.IoWeight)
+				r.BlockIO = &specs.LinuxBlockIO{Weight: &w}
+			}
+			return nil
+		},
+	}, nil
+}
+
 // withDefaultProfile sets the default seccomp profile to the spec.
 // Note: must follow the setting of process capabilities
 func withDefaultProfile() oci.SpecOpts {
@@ -0,0 +1,60 @@
+//go:build !windows
+// +build !windows
+
+package oci
+
+import (
+	"context"
+	"testing"
+
+	"github.com/moby/buildkit/solver/pb"
+	specs "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateResourceLimitsOpts(t *testing.T) {
+	t.Parallel()
+
+	opts, err := generateResourceLimitsOpts(nil)
+	require.NoError(t, err)
+	require.Len(t, opts, 0)
+
+	opts, err = generateResourceLimitsOpts(&pb.ResourceLimits{
+		NanoCPUs:   1500000000,
+		Memory:     512 << 20,
+		MemorySwap: -1,
+		Pids:       100,
+		IoWeight:   200,
+	})
+	require.NoError(t, err)
+	require.Len(t, opts, 1)
+
+	s := &specs.Spec{}
+	require.NoError(t, opts[0](context.TODO(), nil, nil, s))
+	r := s.Linux.Resources
+	require.Equal(t, uint64(100000), *r.CPU.Period)
+	require.Equal(t, int64(150000), *r.CPU.Quota)
+	require.Equal(t, int64(512<<20), *r.Memory.Limit)
+	require.Equal(t, int64(-1), *r.Memory.Swap)
+	require.Equal(t, int64(100), r.Pids.Limit)
+	require.Equal(t, uint16(200), *r.BlockIO.Weight)
+
+	opts, err = generateResourceLimitsOpts(&pb.ResourceLimits{NanoCPUs: 10000000})
+	require.NoError(t, err)
+	s = &specs.Spec{}
+	require.NoError(t, opts[0](context.TODO(), nil, nil, s))
+	require.Equal(t, int64(1000), *s.Linux.Resources.CPU.Quota)
+
+	_, err = generateResourceLimitsOpts(&pb.ResourceLimits{NanoCPUs: 9999999})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "invalid CPU limit")
+
+	_, err = generateResourceLimitsOpts(&pb.ResourceLimits{IoWeight: 5})
+	require.Error(t, err)
+
+	_, err = generateResourceLimitsOpts(&pb.ResourceLimits{Memory: 1024, MemorySwap: 512})
+	require.Error(t, err)
+
+	_, err = generateResourceLimitsOpts(&pb.ResourceLimits{Pids: -1})
+	require.Error(t, err)
+}
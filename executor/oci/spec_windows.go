@@ -43,3 +43,10 @@ func generateRlimitOpts(ulimits []*pb.Ulimit) ([]oci.SpecOpts, error) {
 	}
 	return nil, errors.New("no support for POSIXRlimit on Windows")
 }
+
+func generateResourceLimitsOpts(limits *pb.ResourceLimits) ([]oci.SpecOpts, error) {
+	if limits == nil {
+		return nil, nil
+	}
+	return nil, errors.New("no support for resource limits on Windows")
+}
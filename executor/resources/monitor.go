@@ -0,0 +1,104 @@
+// Package resources reports the cgroup resource usage of the containers run
+// by the executors.
+package resources
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/moby/buildkit/identity"
+	"github.com/moby/buildkit/util/progress"
+)
+
+const (
+	sampleInterval = time.Second
+	statusAction   = "resource usage"
+)
+
+// Supported reports whether the usage of the cgroup at cgroupsPath, as set in
+// the OCI spec of a container, can be monitored.
+func Supported(cgroupsPath string) bool {
+	return cgroupsPath != "" && supported(cgroupsPath)
+}
+
+// Monitor samples the usage of the cgroup at cgroupsPath and writes it as a
+// status to the progress of ctx until the returned function is called. The
+// returned function reads the final usage, so it must be called after the
+// process has exited but before the cgroup is removed. The usage of cgroups
+// that can't be read, e.g. in rootless mode, is not reported.
+func Monitor(ctx context.Context, cgroupsPath string) func() {
+	if !Supported(cgroupsPath) {
+		return func() {}
+	}
+
+	pw, _, _ := progress.NewFromContext(ctx)
+	id := statusAction + " " + identity.NewID()
+	started := time.Now()
+
+	var (
+		mu    sync.Mutex
+		usage *progress.ResourceUsage
+	)
+	sample := func() {
+		u, err := readUsage(cgroupsPath)
+		if err != nil {
+			return
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		usage = merge(usage, u)
+		pw.Write(id, progress.Status{
+			Action:    statusAction,
+			Started:   &started,
+			Resources: usage,
+		})
+	}
+
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		t := time.NewTicker(sampleInterval)
+		defer t.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-t.C:
+				sample()
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			close(done)
+			<-stopped
+			sample()
+			mu.Lock()
+			if usage != nil {
+				completed := time.Now()
+				pw.Write(id, progress.Status{
+					Action:    statusAction,
+					Started:   &started,
+					Completed: &completed,
+					Resources: usage,
+				})
+			}
+			mu.Unlock()
+			pw.Close()
+		})
+	}
+}
+
+// merge combines a new sample with the previous ones. Counters are taken from
+// the new sample while the memory peak is the highest seen so far, as not all
+// kernels track it.
+func merge(prev, u *progress.ResourceUsage) *progress.ResourceUsage {
+	if prev != nil && prev.MemoryPeak > u.MemoryPeak {
+		u.MemoryPeak = prev.MemoryPeak
+	}
+	return u
+}
@@ -0,0 +1,155 @@
+package resources
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/moby/buildkit/util/progress"
+	"github.com/pkg/errors"
+)
+
+const cgroupRoot = "/sys/fs/cgroup"
+
+var (
+	unifiedOnce sync.Once
+	unified     bool
+)
+
+func supported(cgroupsPath string) bool {
+	// systemd cgroup paths in the slice:prefix:name format are not resolved
+	return !strings.Contains(cgroupsPath, ":")
+}
+
+func isUnified() bool {
+	unifiedOnce.Do(func() {
+		_, err := os.Stat(filepath.Join(cgroupRoot, "cgroup.controllers"))
+		unified = err == nil
+	})
+	return unified
+}
+
+func readUsage(cgroupsPath string) (*progress.ResourceUsage, error) {
+	if isUnified() {
+		return readUsageV2(filepath.Join(cgroupRoot, cgroupsPath))
+	}
+	return readUsageV1(cgroupRoot, cgroupsPath)
+}
+
+func readUsageV2(dir string) (*progress.ResourceUsage, error) {
+	// the cgroup must exist, the individual files depend on the enabled
+	// controllers and kernel version
+	if _, err := os.Stat(dir); err != nil {
+		return nil, err
+	}
+	u := &progress.ResourceUsage{}
+	if v, err := readInt(filepath.Join(dir, "memory.peak")); err == nil {
+		u.MemoryPeak = v
+	} else if v, err := readInt(filepath.Join(dir, "memory.current")); err == nil {
+		u.MemoryPeak = v
+	}
+	if stat, err := readKeyValues(filepath.Join(dir, "cpu.stat")); err == nil {
+		u.CPUNanos = stat["usage_usec"] * 1000
+	}
+	if err := readLines(filepath.Join(dir, "io.stat"), func(fields []string) {
+		// 8:0 rbytes=1024 wbytes=2048 rios=1 wios=2 dbytes=0 dios=0
+		for _, f := range fields[1:] {
+			k, v, ok := cut(f, "=")
+			if !ok {
+				continue
+			}
+			n, _ := strconv.ParseInt(v, 10, 64)
+			switch k {
+			case "rbytes":
+				u.IOReadBytes += n
+			case "wbytes":
+				u.IOWriteBytes += n
+			}
+		}
+	}); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	if v, err := readInt(filepath.Join(dir, "pids.current")); err == nil {
+		u.Pids = v
+	}
+	return u, nil
+}
+
+func readUsageV1(root, cgroupsPath string) (*progress.ResourceUsage, error) {
+	memDir := filepath.Join(root, "memory", cgroupsPath)
+	if _, err := os.Stat(memDir); err != nil {
+		return nil, err
+	}
+	u := &progress.ResourceUsage{}
+	if v, err := readInt(filepath.Join(memDir, "memory.max_usage_in_bytes")); err == nil {
+		u.MemoryPeak = v
+	}
+	if v, err := readInt(filepath.Join(root, "cpuacct", cgroupsPath, "cpuacct.usage")); err == nil {
+		u.CPUNanos = v
+	}
+	if err := readLines(filepath.Join(root, "blkio", cgroupsPath, "blkio.throttle.io_service_bytes"), func(fields []string) {
+		// 8:0 Read 1024
+		if len(fields) != 3 {
+			return
+		}
+		n, _ := strconv.ParseInt(fields[2], 10, 64)
+		switch fields[1] {
+		case "Read":
+			u.IOReadBytes += n
+		case "Write":
+			u.IOWriteBytes += n
+		}
+	}); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	if v, err := readInt(filepath.Join(root, "pids", cgroupsPath, "pids.current")); err == nil {
+		u.Pids = v
+	}
+	return u, nil
+}
+
+func readInt(p string) (int64, error) {
+	dt, err := os.ReadFile(p)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseInt(strings.TrimSpace(string(dt)), 10, 64)
+}
+
+func readKeyValues(p string) (map[string]int64, error) {
+	m := map[string]int64{}
+	err := readLines(p, func(fields []string) {
+		if len(fields) != 2 {
+			return
+		}
+		if n, err := strconv.ParseInt(fields[1], 10, 64); err == nil {
+			m[fields[0]] = n
+		}
+	})
+	return m, err
+}
+
+func readLines(p string, f func(fields []string)) error {
+	file, err := os.Open(p)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	s := bufio.NewScanner(file)
+	for s.Scan() {
+		if fields := strings.Fields(s.Text()); len(fields) > 0 {
+			f(fields)
+		}
+	}
+	return s.Err()
+}
+
+func cut(s, sep string) (string, string, bool) {
+	if i := strings.Index(s, sep); i >= 0 {
+		return s[:i], s[i+len(sep):], true
+	}
+	return s, "", false
+}
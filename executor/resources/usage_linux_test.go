@@ -0,0 +1,71 @@
+package resources
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/moby/buildkit/util/progress"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	for name, content := range files {
+		p := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(p), 0755))
+		require.NoError(t, os.WriteFile(p, []byte(content), 0644))
+	}
+}
+
+func TestReadUsageV2(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"memory.peak":  "4096\n",
+		"cpu.stat":     "usage_usec 1500\nuser_usec 1000\nsystem_usec 500\n",
+		"io.stat":      "8:0 rbytes=100 wbytes=200 rios=1 wios=2 dbytes=0 dios=0\n8:16 rbytes=1 wbytes=2 rios=1 wios=1 dbytes=0 dios=0\n",
+		"pids.current": "3\n",
+	})
+
+	u, err := readUsageV2(dir)
+	require.NoError(t, err)
+	require.Equal(t, &progress.ResourceUsage{
+		MemoryPeak:   4096,
+		CPUNanos:     1500000,
+		IOReadBytes:  101,
+		IOWriteBytes: 202,
+		Pids:         3,
+	}, u)
+
+	_, err = readUsageV2(filepath.Join(dir, "missing"))
+	require.Error(t, err)
+}
+
+func TestReadUsageV1(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		"memory/buildkit/foo/memory.max_usage_in_bytes":      "8192\n",
+		"cpuacct/buildkit/foo/cpuacct.usage":                 "123456\n",
+		"blkio/buildkit/foo/blkio.throttle.io_service_bytes": "8:0 Read 10\n8:0 Write 20\n8:0 Sync 30\n8:0 Total 30\nTotal 30\n",
+	})
+
+	u, err := readUsageV1(root, "/buildkit/foo")
+	require.NoError(t, err)
+	require.Equal(t, &progress.ResourceUsage{
+		MemoryPeak:   8192,
+		CPUNanos:     123456,
+		IOReadBytes:  10,
+		IOWriteBytes: 20,
+	}, u)
+}
+
+func TestMergeKeepsMemoryPeak(t *testing.T) {
+	t.Parallel()
+
+	u := merge(&progress.ResourceUsage{MemoryPeak: 100, CPUNanos: 1}, &progress.ResourceUsage{MemoryPeak: 50, CPUNanos: 2})
+	require.Equal(t, int64(100), u.MemoryPeak)
+	require.Equal(t, int64(2), u.CPUNanos)
+}
@@ -0,0 +1,17 @@
+//go:build !linux
+// +build !linux
+
+package resources
+
+import (
+	"github.com/moby/buildkit/util/progress"
+	"github.com/pkg/errors"
+)
+
+func supported(cgroupsPath string) bool {
+	return false
+}
+
+func readUsage(cgroupsPath string) (*progress.ResourceUsage, error) {
+	return nil, errors.New("resource usage is only supported on linux")
+}
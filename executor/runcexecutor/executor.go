@@ -22,8 +22,10 @@ import (
 	"github.com/docker/docker/pkg/idtools"
 	"github.com/moby/buildkit/executor"
 	"github.com/moby/buildkit/executor/oci"
+	"github.com/moby/buildkit/executor/resources"
 	gatewayapi "github.com/moby/buildkit/frontend/gateway/pb"
 	"github.com/moby/buildkit/identity"
+	"github.com/moby/buildkit/solver/errdefs"
 	"github.com/moby/buildkit/solver/pb"
 	"github.com/moby/buildkit/util/network"
 	rootlessspecconv "github.com/moby/buildkit/util/rootless/specconv"
@@ -275,6 +277,9 @@ func (w *runcExecutor) Run(ctx context.Context, id string, root executor.Mount,
 	spec.Process.Terminal = meta.Tty
 	spec.Process.OOMScoreAdj = w.oomScoreAdj
 	if w.rootless {
+		if meta.ResourceLimits != nil {
+			return errors.New("resource limits are not supported in rootless mode")
+		}
 		if err := rootlessspecconv.ToRootless(spec); err != nil {
 			return err
 		}
@@ -288,11 +293,20 @@ func (w *runcExecutor) Run(ctx context.Context, id string, root executor.Mount,
 	runCtx, cancelRun := context.WithCancel(context.Background())
 	defer cancelRun()
 
+	// procCtx is done when the process needs to be killed, either because ctx
+	// is canceled or because the process did not complete within its timeout
+	procCtx := ctx
+	if meta.Timeout > 0 {
+		var cancelTimeout context.CancelFunc
+		procCtx, cancelTimeout = context.WithTimeout(ctx, meta.Timeout)
+		defer cancelTimeout()
+	}
+
 	ended := make(chan struct{})
 	go func() {
 		for {
 			select {
-			case <-ctx.Done():
+			case <-procCtx.Done():
 				killCtx, timeout := context.WithTimeout(context.Background(), 7*time.Second)
 				if err := w.runc.Kill(killCtx, id, int(syscall.SIGKILL), nil); err != nil {
 					bklog.G(ctx).Errorf("failed to kill runc %s: %+v", id, err)
@@ -319,6 +333,10 @@ func (w *runcExecutor) Run(ctx context.Context, id string, root executor.Mount,
 	bklog.G(ctx).Debugf("> creating %s %v", id, meta.Args)
 
 	trace.SpanFromContext(ctx).AddEvent("Container created")
+	// the container is kept after it exits so that its final resource usage
+	// can be read before runc removes the cgroup
+	keep := resources.Supported(spec.Linux.CgroupsPath)
+	stopMonitor := resources.Monitor(ctx, spec.Linux.CgroupsPath)
 	err = w.run(runCtx, id, bundle, process, func() {
 		startedOnce.Do(func() {
 			trace.SpanFromContext(ctx).AddEvent("Container started")
@@ -326,8 +344,17 @@ func (w *runcExecutor) Run(ctx context.Context, id string, root executor.Mount,
 				close(started)
 			}
 		})
-	})
+	}, keep)
+	stopMonitor()
 	close(ended)
+	if keep {
+		if err1 := w.runc.Delete(context.TODO(), id, &runc.DeleteOpts{}); err1 != nil {
+			bklog.G(ctx).Warnf("failed to delete container %s: %+v", id, err1)
+		}
+	}
+	if err != nil && ctx.Err() == nil && errors.Is(procCtx.Err(), context.DeadlineExceeded) {
+		return errdefs.NewExecTimeoutError(meta.Timeout, exitError(ctx, err))
+	}
 	return exitError(ctx, err)
 }
 
@@ -0,0 +1,144 @@
+package attestation
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path"
+	"strings"
+
+	cacheutil "github.com/moby/buildkit/cache/util"
+	"github.com/moby/buildkit/exporter"
+	attestationtypes "github.com/moby/buildkit/exporter/attestation/types"
+	"github.com/moby/buildkit/session"
+	"github.com/pkg/errors"
+)
+
+// Statements reads the content of the attestations and returns them as
+// in-toto statements. Subjects of kind self, and the subjects of statements
+// read from bundles, are set to self.
+func Statements(ctx context.Context, s session.Group, atts []exporter.Attestation, self []attestationtypes.Subject) ([]attestationtypes.Statement, error) {
+	var stmts []attestationtypes.Statement
+	for _, att := range atts {
+		switch att.Kind {
+		case attestationtypes.KindInToto:
+			stmt, err := inTotoStatement(ctx, s, att, self)
+			if err != nil {
+				return nil, err
+			}
+			stmts = append(stmts, *stmt)
+		case attestationtypes.KindBundle:
+			bundle, err := bundleStatements(ctx, s, att, self)
+			if err != nil {
+				return nil, err
+			}
+			stmts = append(stmts, bundle...)
+		default:
+			return nil, errors.Errorf("unknown attestation kind %d", att.Kind)
+		}
+	}
+	return stmts, nil
+}
+
+func inTotoStatement(ctx context.Context, s session.Group, att exporter.Attestation, self []attestationtypes.Subject) (*attestationtypes.Statement, error) {
+	if att.InToto.PredicateType == "" {
+		return nil, errors.New("in-toto attestation is missing a predicate type")
+	}
+
+	var dt []byte
+	if att.ContentFunc != nil {
+		var err error
+		if dt, err = att.ContentFunc(); err != nil {
+			return nil, err
+		}
+	} else {
+		if att.Ref == nil {
+			return nil, errors.Errorf("no content for %s attestation", att.InToto.PredicateType)
+		}
+		mount, err := att.Ref.Mount(ctx, true, s)
+		if err != nil {
+			return nil, err
+		}
+		dt, err = cacheutil.ReadFile(ctx, mount, cacheutil.ReadRequest{
+			Filename: att.Path,
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read %s attestation", att.InToto.PredicateType)
+		}
+	}
+	if !json.Valid(dt) {
+		return nil, errors.Errorf("invalid predicate for %s attestation", att.InToto.PredicateType)
+	}
+
+	var subjects []attestationtypes.Subject
+	for _, subject := range att.InToto.Subjects {
+		switch subject.Kind {
+		case attestationtypes.SubjectKindSelf:
+			subjects = append(subjects, self...)
+		case attestationtypes.SubjectKindRaw:
+			subjects = append(subjects, attestationtypes.Subject{
+				Name:   subject.Name,
+				Digest: attestationtypes.DigestSet(subject.Digest...),
+			})
+		default:
+			return nil, errors.Errorf("unknown in-toto subject kind %d", subject.Kind)
+		}
+	}
+	if len(subjects) == 0 {
+		subjects = self
+	}
+
+	return &attestationtypes.Statement{
+		Type:          attestationtypes.StatementType,
+		PredicateType: att.InToto.PredicateType,
+		Subject:       subjects,
+		Predicate:     dt,
+	}, nil
+}
+
+func bundleStatements(ctx context.Context, s session.Group, att exporter.Attestation, self []attestationtypes.Subject) ([]attestationtypes.Statement, error) {
+	if att.Ref == nil {
+		return nil, errors.New("no content for attestation bundle")
+	}
+	mount, err := att.Ref.Mount(ctx, true, s)
+	if err != nil {
+		return nil, err
+	}
+	dir := att.Path
+	if dir == "" {
+		dir = "/"
+	}
+	entries, err := cacheutil.ReadDir(ctx, mount, cacheutil.ReadDirRequest{
+		Path:           dir,
+		IncludePattern: "*.json",
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read attestation bundle")
+	}
+
+	var stmts []attestationtypes.Statement
+	for _, e := range entries {
+		if os.FileMode(e.Mode).IsDir() || !strings.HasSuffix(e.Path, ".json") {
+			continue
+		}
+		dt, err := cacheutil.ReadFile(ctx, mount, cacheutil.ReadRequest{
+			Filename: path.Join(dir, e.Path),
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read attestation bundle file %s", e.Path)
+		}
+		var stmt attestationtypes.Statement
+		if err := json.Unmarshal(dt, &stmt); err != nil {
+			return nil, errors.Wrapf(err, "failed to parse attestation bundle file %s", e.Path)
+		}
+		if stmt.PredicateType == "" {
+			return nil, errors.Errorf("attestation bundle file %s is missing a predicate type", e.Path)
+		}
+		if stmt.Type == "" {
+			stmt.Type = attestationtypes.StatementType
+		}
+		stmt.Subject = self
+		stmts = append(stmts, stmt)
+	}
+	return stmts, nil
+}
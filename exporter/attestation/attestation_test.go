@@ -0,0 +1,87 @@
+package attestation
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/moby/buildkit/exporter"
+	attestationtypes "github.com/moby/buildkit/exporter/attestation/types"
+	digest "github.com/opencontainers/go-digest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInTotoStatements(t *testing.T) {
+	t.Parallel()
+
+	self := []attestationtypes.Subject{{
+		Name:   "_",
+		Digest: attestationtypes.DigestSet(digest.FromBytes([]byte("manifest"))),
+	}}
+	raw := digest.FromBytes([]byte("raw"))
+
+	stmts, err := Statements(context.TODO(), nil, []exporter.Attestation{
+		{
+			ContentFunc: func() ([]byte, error) {
+				return []byte(`{"foo":"bar"}`), nil
+			},
+			InToto: attestationtypes.InToto{
+				PredicateType: attestationtypes.PredicateSLSAProvenance,
+			},
+		},
+		{
+			ContentFunc: func() ([]byte, error) {
+				return []byte(`{}`), nil
+			},
+			InToto: attestationtypes.InToto{
+				PredicateType: "https://example.com/predicate",
+				Subjects: []attestationtypes.InTotoSubject{
+					{Kind: attestationtypes.SubjectKindSelf},
+					{Kind: attestationtypes.SubjectKindRaw, Name: "raw", Digest: []digest.Digest{raw}},
+				},
+			},
+		},
+	}, self)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(stmts))
+
+	require.Equal(t, attestationtypes.StatementType, stmts[0].Type)
+	require.Equal(t, attestationtypes.PredicateSLSAProvenance, stmts[0].PredicateType)
+	require.Equal(t, self, stmts[0].Subject)
+	require.Equal(t, `{"foo":"bar"}`, string(stmts[0].Predicate))
+
+	require.Equal(t, 2, len(stmts[1].Subject))
+	require.Equal(t, self[0], stmts[1].Subject[0])
+	require.Equal(t, "raw", stmts[1].Subject[1].Name)
+	require.Equal(t, raw.Encoded(), stmts[1].Subject[1].Digest["sha256"])
+
+	dt, err := json.Marshal(stmts[0])
+	require.NoError(t, err)
+	require.Contains(t, string(dt), `"_type":"https://in-toto.io/Statement/v0.1"`)
+}
+
+func TestInTotoStatementsInvalid(t *testing.T) {
+	t.Parallel()
+
+	_, err := Statements(context.TODO(), nil, []exporter.Attestation{{
+		ContentFunc: func() ([]byte, error) {
+			return []byte(`{}`), nil
+		},
+	}}, nil)
+	require.Error(t, err)
+
+	_, err = Statements(context.TODO(), nil, []exporter.Attestation{{
+		ContentFunc: func() ([]byte, error) {
+			return []byte(`not json`), nil
+		},
+		InToto: attestationtypes.InToto{
+			PredicateType: attestationtypes.PredicateSLSAProvenance,
+		},
+	}}, nil)
+	require.Error(t, err)
+
+	_, err = Statements(context.TODO(), nil, []exporter.Attestation{{
+		Kind: attestationtypes.KindBundle,
+	}}, nil)
+	require.Error(t, err)
+}
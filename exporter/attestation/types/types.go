@@ -0,0 +1,86 @@
+package attestationtypes
+
+import (
+	"encoding/json"
+
+	digest "github.com/opencontainers/go-digest"
+)
+
+const (
+	// MediaTypeInToto is the media type of in-toto statements stored as
+	// layers of an attestation manifest.
+	MediaTypeInToto = "application/vnd.in-toto+json"
+
+	// AnnotationPredicateType is set on the in-toto statement layers of an
+	// attestation manifest and contains the predicate type of the statement.
+	AnnotationPredicateType = "in-toto.io/predicate-type"
+
+	// StatementType is the in-toto statement type used for all attestations.
+	StatementType = "https://in-toto.io/Statement/v0.1"
+
+	// PredicateSLSAProvenance is the predicate type of SLSA provenance.
+	PredicateSLSAProvenance = "https://slsa.dev/provenance/v0.2"
+
+	// PredicateSPDX is the predicate type of SPDX SBOM documents.
+	PredicateSPDX = "https://spdx.dev/Document"
+)
+
+// Kind defines how the content of an attestation is stored in the result
+// it refers to.
+type Kind int
+
+const (
+	// KindInToto attestations point to a single file that contains the
+	// predicate of an in-toto statement.
+	KindInToto Kind = iota
+	// KindBundle attestations point to a directory of complete in-toto
+	// statements encoded as JSON files.
+	KindBundle
+)
+
+// SubjectKind defines how the subject of an in-toto statement is resolved.
+type SubjectKind int
+
+const (
+	// SubjectKindSelf refers to the image manifest that the attestation is
+	// attached to.
+	SubjectKindSelf SubjectKind = iota
+	// SubjectKindRaw refers to a subject with an explicit name and digest.
+	SubjectKindRaw
+)
+
+// InToto contains the properties of an in-toto attestation.
+type InToto struct {
+	PredicateType string
+	Subjects      []InTotoSubject
+}
+
+// InTotoSubject defines a subject of an in-toto attestation.
+type InTotoSubject struct {
+	Kind   SubjectKind
+	Name   string
+	Digest []digest.Digest
+}
+
+// Statement is an in-toto statement.
+type Statement struct {
+	Type          string          `json:"_type"`
+	PredicateType string          `json:"predicateType"`
+	Subject       []Subject       `json:"subject"`
+	Predicate     json.RawMessage `json:"predicate"`
+}
+
+// Subject is a subject of an in-toto statement.
+type Subject struct {
+	Name   string            `json:"name"`
+	Digest map[string]string `json:"digest"`
+}
+
+// DigestSet converts digests to the digest set format of in-toto subjects.
+func DigestSet(dgsts ...digest.Digest) map[string]string {
+	m := make(map[string]string, len(dgsts))
+	for _, d := range dgsts {
+		m[d.Algorithm().String()] = d.Encoded()
+	}
+	return m
+}
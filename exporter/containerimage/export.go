@@ -299,8 +299,14 @@ func (e *imageExporterInstance) Export(ctx context.Context, src exporter.Source,
 	}
 	defer done(context.TODO())
 
+	oci := e.ociTypes
+	if len(src.Attestations) > 0 && !oci {
+		logrus.Warn("forcibly turning on oci-mediatype mode for attestations")
+		oci = true
+	}
+
 	refCfg := e.refCfg()
-	desc, err := e.opt.ImageWriter.Commit(ctx, src, e.ociTypes, refCfg, e.buildInfo, e.buildInfoAttrs, sessionID)
+	desc, err := e.opt.ImageWriter.Commit(ctx, src, oci, refCfg, e.buildInfo, e.buildInfoAttrs, sessionID)
 	if err != nil {
 		return nil, err
 	}
@@ -1,6 +1,8 @@
 package exptypes
 
 import (
+	"fmt"
+
 	ocispecs "github.com/opencontainers/image-spec/specs-go/v1"
 )
 
@@ -15,6 +17,34 @@ const (
 	ExporterPlatformsKey         = "refs.platforms"
 )
 
+const (
+	// AnnotationDockerReferenceType is set on the index descriptors of
+	// attestation manifests to AttestationManifestType.
+	AnnotationDockerReferenceType = "vnd.docker.reference.type"
+	// AnnotationDockerReferenceDigest is set on the index descriptors of
+	// attestation manifests to the digest of the image manifest they refer to.
+	AnnotationDockerReferenceDigest = "vnd.docker.reference.digest"
+	// AttestationManifestType is the reference type of attestation manifests.
+	AttestationManifestType = "attestation-manifest"
+)
+
+const (
+	// FrontendAttestPrefix is the prefix of frontend options that request
+	// attestations to be generated for the build result,
+	// e.g. "attest:provenance=mode=max".
+	FrontendAttestPrefix = "attest:"
+	// AttestProvenance requests SLSA provenance for the build result.
+	AttestProvenance = "provenance"
+	// AttestSBOM requests an SBOM for the build result.
+	AttestSBOM = "sbom"
+)
+
+// ExporterResponseKey returns the key of an exporter response value of the
+// exporter with index i in a solve request with multiple exporters.
+func ExporterResponseKey(i int, key string) string {
+	return fmt.Sprintf("exporter.%d.%s", i, key)
+}
+
 type Platforms struct {
 	Platforms []Platform
 }
@@ -15,6 +15,8 @@ import (
 	"github.com/moby/buildkit/cache"
 	cacheconfig "github.com/moby/buildkit/cache/config"
 	"github.com/moby/buildkit/exporter"
+	"github.com/moby/buildkit/exporter/attestation"
+	attestationtypes "github.com/moby/buildkit/exporter/attestation/types"
 	"github.com/moby/buildkit/exporter/containerimage/exptypes"
 	"github.com/moby/buildkit/session"
 	"github.com/moby/buildkit/snapshot"
@@ -57,7 +59,9 @@ func (ic *ImageWriter) Commit(ctx context.Context, inp exporter.Source, oci bool
 		return nil, errors.Errorf("unable to export multiple refs, missing platforms mapping")
 	}
 
-	if len(inp.Refs) == 0 {
+	isMap := len(inp.Refs) > 0
+
+	if !isMap && len(inp.Attestations) == 0 {
 		remotes, err := ic.exportLayers(ctx, refCfg, session.NewGroup(sessionID), inp.Ref)
 		if err != nil {
 			return nil, err
@@ -85,17 +89,29 @@ func (ic *ImageWriter) Commit(ctx context.Context, inp exporter.Source, oci bool
 	}
 
 	var p exptypes.Platforms
-	if err := json.Unmarshal(platformsBytes, &p); err != nil {
-		return nil, errors.Wrapf(err, "failed to parse platforms passed to exporter")
-	}
+	inpRefs := inp.Refs
+	if isMap {
+		if err := json.Unmarshal(platformsBytes, &p); err != nil {
+			return nil, errors.Wrapf(err, "failed to parse platforms passed to exporter")
+		}
 
-	if len(p.Platforms) != len(inp.Refs) {
-		return nil, errors.Errorf("number of platforms does not match references %d %d", len(p.Platforms), len(inp.Refs))
+		if len(p.Platforms) != len(inp.Refs) {
+			return nil, errors.Errorf("number of platforms does not match references %d %d", len(p.Platforms), len(inp.Refs))
+		}
+	} else {
+		// attestations can only be attached to an image index so a single
+		// result is exported as an index with one manifest
+		pl, err := platformFromConfig(inp.Metadata[exptypes.ExporterImageConfigKey])
+		if err != nil {
+			return nil, err
+		}
+		p.Platforms = []exptypes.Platform{{Platform: pl}}
+		inpRefs = map[string]cache.ImmutableRef{"": inp.Ref}
 	}
 
-	refs := make([]cache.ImmutableRef, 0, len(inp.Refs))
-	remotesMap := make(map[string]int, len(inp.Refs))
-	for id, r := range inp.Refs {
+	refs := make([]cache.ImmutableRef, 0, len(inpRefs))
+	remotesMap := make(map[string]int, len(inpRefs))
+	for id, r := range inpRefs {
 		remotesMap[id] = len(refs)
 		refs = append(refs, r)
 	}
@@ -126,17 +142,17 @@ func (ic *ImageWriter) Commit(ctx context.Context, inp exporter.Source, oci bool
 
 	labels := map[string]string{}
 
-	for i, p := range p.Platforms {
-		r, ok := inp.Refs[p.ID]
+	for _, p := range p.Platforms {
+		r, ok := inpRefs[p.ID]
 		if !ok {
 			return nil, errors.Errorf("failed to find ref for ID %s", p.ID)
 		}
-		config := inp.Metadata[fmt.Sprintf("%s/%s", exptypes.ExporterImageConfigKey, p.ID)]
-		inlineCache := inp.Metadata[fmt.Sprintf("%s/%s", exptypes.ExporterInlineCache, p.ID)]
+		config := inp.Metadata[metadataKey(exptypes.ExporterImageConfigKey, p.ID)]
+		inlineCache := inp.Metadata[metadataKey(exptypes.ExporterInlineCache, p.ID)]
 
 		var dtbi []byte
 		if buildInfo {
-			if dtbi, err = buildinfo.Format(inp.Metadata[fmt.Sprintf("%s/%s", exptypes.ExporterBuildInfo, p.ID)], buildinfo.FormatOpts{
+			if dtbi, err = buildinfo.Format(inp.Metadata[metadataKey(exptypes.ExporterBuildInfo, p.ID)], buildinfo.FormatOpts{
 				RemoveAttrs: !buildInfoAttrs,
 			}); err != nil {
 				return nil, err
@@ -149,9 +165,17 @@ func (ic *ImageWriter) Commit(ctx context.Context, inp exporter.Source, oci bool
 		}
 		dp := p.Platform
 		desc.Platform = &dp
+		labels[fmt.Sprintf("containerd.io/gc.ref.content.%d", len(idx.Manifests))] = desc.Digest.String()
 		idx.Manifests = append(idx.Manifests, *desc)
 
-		labels[fmt.Sprintf("containerd.io/gc.ref.content.%d", i)] = desc.Digest.String()
+		if atts := inp.Attestations[p.ID]; len(atts) > 0 {
+			attDesc, err := ic.commitAttestationsManifest(ctx, *desc, atts, session.NewGroup(sessionID))
+			if err != nil {
+				return nil, err
+			}
+			labels[fmt.Sprintf("containerd.io/gc.ref.content.%d", len(idx.Manifests))] = attDesc.Digest.String()
+			idx.Manifests = append(idx.Manifests, *attDesc)
+		}
 	}
 
 	idxBytes, err := json.MarshalIndent(idx, "", "   ")
@@ -329,6 +353,106 @@ func (ic *ImageWriter) commitDistributionManifest(ctx context.Context, ref cache
 	}, &configDesc, nil
 }
 
+// commitAttestationsManifest writes a manifest that contains the in-toto
+// statements of the attestations for the image manifest target as layers.
+func (ic *ImageWriter) commitAttestationsManifest(ctx context.Context, target ocispecs.Descriptor, atts []exporter.Attestation, s session.Group) (*ocispecs.Descriptor, error) {
+	stmts, err := attestation.Statements(ctx, s, atts, []attestationtypes.Subject{{
+		Name:   "_",
+		Digest: attestationtypes.DigestSet(target.Digest),
+	}})
+	if err != nil {
+		return nil, err
+	}
+
+	config, err := json.Marshal(ocispecs.Image{
+		Architecture: "unknown",
+		OS:           "unknown",
+		RootFS: ocispecs.RootFS{
+			Type: "layers",
+		},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal attestation config")
+	}
+	configDesc := ocispecs.Descriptor{
+		Digest:    digest.FromBytes(config),
+		Size:      int64(len(config)),
+		MediaType: ocispecs.MediaTypeImageConfig,
+	}
+
+	mfst := struct {
+		// MediaType is reserved in the OCI spec but
+		// excluded from go types.
+		MediaType string `json:"mediaType,omitempty"`
+
+		ocispecs.Manifest
+	}{
+		MediaType: ocispecs.MediaTypeImageManifest,
+		Manifest: ocispecs.Manifest{
+			Versioned: specs.Versioned{
+				SchemaVersion: 2,
+			},
+			Config: configDesc,
+		},
+	}
+
+	labels := map[string]string{
+		"containerd.io/gc.ref.content.0": configDesc.Digest.String(),
+	}
+
+	for i, stmt := range stmts {
+		dt, err := json.Marshal(stmt)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to marshal attestation")
+		}
+		desc := ocispecs.Descriptor{
+			MediaType: attestationtypes.MediaTypeInToto,
+			Digest:    digest.FromBytes(dt),
+			Size:      int64(len(dt)),
+			Annotations: map[string]string{
+				attestationtypes.AnnotationPredicateType: stmt.PredicateType,
+			},
+		}
+		if err := content.WriteBlob(ctx, ic.opt.ContentStore, desc.Digest.String(), bytes.NewReader(dt), desc); err != nil {
+			return nil, errors.Wrapf(err, "error writing attestation blob %s", desc.Digest)
+		}
+		mfst.Layers = append(mfst.Layers, desc)
+		labels[fmt.Sprintf("containerd.io/gc.ref.content.%d", i+1)] = desc.Digest.String()
+	}
+
+	if err := content.WriteBlob(ctx, ic.opt.ContentStore, configDesc.Digest.String(), bytes.NewReader(config), configDesc); err != nil {
+		return nil, errors.Wrap(err, "error writing attestation config blob")
+	}
+
+	mfstJSON, err := json.MarshalIndent(mfst, "", "   ")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal attestation manifest")
+	}
+
+	mfstDigest := digest.FromBytes(mfstJSON)
+	mfstDesc := ocispecs.Descriptor{
+		Digest:    mfstDigest,
+		Size:      int64(len(mfstJSON)),
+		MediaType: ocispecs.MediaTypeImageManifest,
+	}
+	mfstDone := oneOffProgress(ctx, "exporting attestation manifest "+mfstDigest.String())
+
+	if err := content.WriteBlob(ctx, ic.opt.ContentStore, mfstDigest.String(), bytes.NewReader(mfstJSON), mfstDesc, content.WithLabels(labels)); err != nil {
+		return nil, mfstDone(errors.Wrapf(err, "error writing attestation manifest blob %s", mfstDigest))
+	}
+	mfstDone(nil)
+
+	mfstDesc.Platform = &ocispecs.Platform{
+		Architecture: "unknown",
+		OS:           "unknown",
+	}
+	mfstDesc.Annotations = map[string]string{
+		exptypes.AnnotationDockerReferenceType:   exptypes.AttestationManifestType,
+		exptypes.AnnotationDockerReferenceDigest: target.Digest.String(),
+	}
+	return &mfstDesc, nil
+}
+
 func (ic *ImageWriter) ContentStore() content.Store {
 	return ic.opt.ContentStore
 }
@@ -365,6 +489,37 @@ func emptyImageConfig() ([]byte, error) {
 	return dt, errors.Wrap(err, "failed to create empty image config")
 }
 
+// metadataKey returns the exporter metadata key of k for the result with the
+// platform ID. An empty ID refers to a single result.
+func metadataKey(k, id string) string {
+	if id == "" {
+		return k
+	}
+	return fmt.Sprintf("%s/%s", k, id)
+}
+
+func platformFromConfig(dt []byte) (ocispecs.Platform, error) {
+	if len(dt) == 0 {
+		return platforms.Normalize(platforms.DefaultSpec()), nil
+	}
+	var config struct {
+		Architecture string `json:"architecture,omitempty"`
+		OS           string `json:"os,omitempty"`
+		Variant      string `json:"variant,omitempty"`
+	}
+	if err := json.Unmarshal(dt, &config); err != nil {
+		return ocispecs.Platform{}, errors.Wrap(err, "failed to parse platform from image config")
+	}
+	if config.Architecture == "" || config.OS == "" {
+		return platforms.Normalize(platforms.DefaultSpec()), nil
+	}
+	return platforms.Normalize(ocispecs.Platform{
+		Architecture: config.Architecture,
+		OS:           config.OS,
+		Variant:      config.Variant,
+	}), nil
+}
+
 func parseHistoryFromConfig(dt []byte) ([]ocispecs.History, error) {
 	var config struct {
 		History []ocispecs.History
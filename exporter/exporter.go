@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/moby/buildkit/cache"
+	attestationtypes "github.com/moby/buildkit/exporter/attestation/types"
 	"github.com/moby/buildkit/util/compression"
 )
 
@@ -21,6 +22,20 @@ type Source struct {
 	Ref      cache.ImmutableRef
 	Refs     map[string]cache.ImmutableRef
 	Metadata map[string][]byte
+
+	// Attestations are keyed by the same keys as Refs. Attestations for a
+	// single Ref result use an empty key.
+	Attestations map[string][]Attestation
+}
+
+// Attestation is an attestation attached to the exported image. Its content
+// is either read from Path in Ref or returned by ContentFunc.
+type Attestation struct {
+	Kind        attestationtypes.Kind
+	Ref         cache.ImmutableRef
+	Path        string
+	ContentFunc func() ([]byte, error)
+	InToto      attestationtypes.InToto
 }
 
 type Config struct {
@@ -212,13 +212,26 @@ func (e *imageExporterInstance) Export(ctx context.Context, src exporter.Source,
 		src.Metadata[k] = v
 	}
 
+	oci := e.ociTypes
+	if len(src.Attestations) > 0 {
+		if e.opt.Variant == VariantDocker {
+			// docker archives can't contain the image index that attestations
+			// are attached to
+			logrus.Warn("attestations are not supported by the docker exporter, skipping")
+			src.Attestations = nil
+		} else if !oci {
+			logrus.Warn("forcibly turning on oci-mediatype mode for attestations")
+			oci = true
+		}
+	}
+
 	ctx, done, err := leaseutil.WithLease(ctx, e.opt.LeaseManager, leaseutil.MakeTemporary)
 	if err != nil {
 		return nil, err
 	}
 	defer done(context.TODO())
 
-	desc, err := e.opt.ImageWriter.Commit(ctx, src, e.ociTypes, e.refCfg(), e.buildInfo, e.buildInfoAttrs, sessionID)
+	desc, err := e.opt.ImageWriter.Commit(ctx, src, oci, e.refCfg(), e.buildInfo, e.buildInfoAttrs, sessionID)
 	if err != nil {
 		return nil, err
 	}
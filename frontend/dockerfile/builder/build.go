@@ -386,7 +386,7 @@ func Build(ctx context.Context, c client.Client) (*client.Result, error) {
 		return nil, capsError
 	}
 
-	if res, ok, err := checkSubRequest(ctx, opts); ok {
+	if res, ok, err := checkSubRequest(ctx, opts, dtDockerfile, filename); ok {
 		return res, err
 	}
 
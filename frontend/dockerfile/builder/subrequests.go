@@ -4,12 +4,16 @@ import (
 	"context"
 	"encoding/json"
 
+	"github.com/moby/buildkit/frontend/dockerfile/dockerfile2llb"
 	"github.com/moby/buildkit/frontend/gateway/client"
 	"github.com/moby/buildkit/frontend/subrequests"
+	"github.com/moby/buildkit/frontend/subrequests/check"
+	"github.com/moby/buildkit/frontend/subrequests/outline"
+	"github.com/moby/buildkit/frontend/subrequests/targets"
 	"github.com/moby/buildkit/solver/errdefs"
 )
 
-func checkSubRequest(ctx context.Context, opts map[string]string) (*client.Result, bool, error) {
+func checkSubRequest(ctx context.Context, opts map[string]string, dt []byte, filename string) (*client.Result, bool, error) {
 	req, ok := opts["requestid"]
 	if !ok {
 		return nil, false, nil
@@ -18,6 +22,30 @@ func checkSubRequest(ctx context.Context, opts map[string]string) (*client.Resul
 	case subrequests.RequestSubrequestsDescribe:
 		res, err := describe()
 		return res, true, err
+	case check.RequestCheck:
+		r, err := dockerfile2llb.DockerfileLint(dt)
+		if err != nil {
+			return nil, true, err
+		}
+		r.Filename = filename
+		res, err := r.ToResult()
+		return res, true, err
+	case outline.RequestSubrequestsOutline:
+		o, err := dockerfile2llb.Dockerfile2Outline(dt, dockerfile2llb.ConvertOpt{
+			Target: opts[keyTarget],
+		})
+		if err != nil {
+			return nil, true, err
+		}
+		res, err := o.ToResult()
+		return res, true, err
+	case targets.RequestTargets:
+		l, err := dockerfile2llb.ListTargets(dt)
+		if err != nil {
+			return nil, true, err
+		}
+		res, err := l.ToResult()
+		return res, true, err
 	default:
 		return nil, true, errdefs.NewUnsupportedSubrequestError(req)
 	}
@@ -25,6 +53,9 @@ func checkSubRequest(ctx context.Context, opts map[string]string) (*client.Resul
 
 func describe() (*client.Result, error) {
 	all := []subrequests.Request{
+		check.SubrequestCheckDefinition,
+		outline.SubrequestsOutlineDefinition,
+		targets.SubrequestsTargetsDefinition,
 		subrequests.SubrequestsDescribeDefinition,
 	}
 	dt, err := json.MarshalIndent(all, "  ", "")
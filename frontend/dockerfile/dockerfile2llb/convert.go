@@ -10,6 +10,7 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"regexp"
 	"sort"
 	"strconv"
 	"strings"
@@ -20,15 +21,19 @@ import (
 	"github.com/moby/buildkit/client/llb"
 	"github.com/moby/buildkit/client/llb/imagemetaresolver"
 	"github.com/moby/buildkit/frontend/dockerfile/instructions"
+	"github.com/moby/buildkit/frontend/dockerfile/linter"
 	"github.com/moby/buildkit/frontend/dockerfile/parser"
 	"github.com/moby/buildkit/frontend/dockerfile/shell"
+	"github.com/moby/buildkit/frontend/subrequests/check"
 	"github.com/moby/buildkit/identity"
 	"github.com/moby/buildkit/solver/pb"
 	"github.com/moby/buildkit/util/apicaps"
 	binfotypes "github.com/moby/buildkit/util/buildinfo/types"
+	"github.com/moby/buildkit/util/sshutil"
 	"github.com/moby/buildkit/util/suggest"
 	"github.com/moby/buildkit/util/system"
 	"github.com/moby/sys/signal"
+	digest "github.com/opencontainers/go-digest"
 	ocispecs "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/pkg/errors"
 	"golang.org/x/sync/errgroup"
@@ -98,6 +103,10 @@ func Dockerfile2LLB(ctx context.Context, dt []byte, opt ConvertOpt) (*llb.State,
 		return nil, nil, nil, errors.Errorf("the Dockerfile cannot be empty")
 	}
 
+	if opt.Warn == nil {
+		opt.Warn = func(string, string, [][]byte, *parser.Range) {}
+	}
+
 	if opt.ContextLocalName == "" {
 		opt.ContextLocalName = defaultContextLocalName
 	}
@@ -118,6 +127,14 @@ func Dockerfile2LLB(ctx context.Context, dt []byte, opt ConvertOpt) (*llb.State,
 		opt.Warn(w.Short, w.URL, w.Detail, w.Location)
 	}
 
+	linter.Lint(dockerfile, func(w linter.Warning) {
+		var location *parser.Range
+		if len(w.Location) > 0 {
+			location = &w.Location[0]
+		}
+		opt.Warn(w.RuleName+": "+w.Message, w.URL, [][]byte{[]byte(w.Description)}, location)
+	})
+
 	proxyEnv := proxyEnvFromBuildArgs(opt.BuildArgs)
 
 	stages, metaArgs, err := instructions.Parse(dockerfile.AST)
@@ -386,6 +403,7 @@ func Dockerfile2LLB(ctx context.Context, dt []byte, opt ConvertOpt) (*llb.State,
 							llb.Platform(*platform),
 							opt.ImageResolveMode,
 							llb.WithCustomName(prefixCommand(d, "FROM "+d.stage.BaseName, opt.PrefixPlatform, platform, nil)),
+							dfStage(d),
 							location(opt.SourceMap, d.stage.Location),
 						)
 					}
@@ -538,6 +556,47 @@ func Dockerfile2LLB(ctx context.Context, dt []byte, opt ConvertOpt) (*llb.State,
 	return &st, &target.image, buildInfo, nil
 }
 
+// DockerfileLint parses the Dockerfile and returns the warnings of the linter
+// without converting it to LLB.
+func DockerfileLint(dt []byte) (*check.Result, error) {
+	if len(dt) == 0 {
+		return nil, errors.Errorf("the Dockerfile cannot be empty")
+	}
+
+	dockerfile, err := parser.Parse(bytes.NewReader(dt))
+	if err != nil {
+		return nil, err
+	}
+
+	res := &check.Result{
+		Warnings: []check.Warning{},
+	}
+	linter.Lint(dockerfile, func(w linter.Warning) {
+		res.Warnings = append(res.Warnings, check.Warning{
+			RuleName:    w.RuleName,
+			Description: w.Description,
+			URL:         w.URL,
+			Detail:      w.Message,
+			Location:    toPBRanges(w.Location),
+		})
+	})
+	return res, nil
+}
+
+func toPBRanges(location []parser.Range) []*pb.Range {
+	if len(location) == 0 {
+		return nil
+	}
+	out := make([]*pb.Range, 0, len(location))
+	for _, r := range location {
+		out = append(out, &pb.Range{
+			Start: pb.Position{Line: int32(r.Start.Line), Character: int32(r.Start.Character)},
+			End:   pb.Position{Line: int32(r.End.Line), Character: int32(r.End.Character)},
+		})
+	}
+	return out
+}
+
 func metaArgsToMap(metaArgs []instructions.KeyValuePairOptional) map[string]string {
 	m := map[string]string{}
 
@@ -647,12 +706,15 @@ func dispatch(d *dispatchState, cmd command, opt dispatchOpt) error {
 			chown:        c.Chown,
 			chmod:        c.Chmod,
 			link:         c.Link,
+			keepGitDir:   c.KeepGitDir,
+			checksum:     c.Checksum,
+			excludes:     c.Excludes,
 			location:     c.Location(),
 			opt:          opt,
 		})
 		if err == nil {
 			for _, src := range c.SourcePaths {
-				if !strings.HasPrefix(src, "http://") && !strings.HasPrefix(src, "https://") {
+				if !isGitSource(src) && !strings.HasPrefix(src, "http://") && !strings.HasPrefix(src, "https://") {
 					d.ctxPaths[path.Join("/", filepath.ToSlash(src))] = struct{}{}
 				}
 			}
@@ -692,6 +754,8 @@ func dispatch(d *dispatchState, cmd command, opt dispatchOpt) error {
 			chown:        c.Chown,
 			chmod:        c.Chmod,
 			link:         c.Link,
+			excludes:     c.Excludes,
+			parents:      c.Parents,
 			location:     c.Location(),
 			opt:          opt,
 		})
@@ -897,6 +961,8 @@ func dispatchRun(d *dispatchState, c *instructions.RunCommand, proxy *llb.ProxyE
 		opt = append(opt, networkOpt)
 	}
 
+	opt = append(opt, dispatchRunTimeout(c)...)
+
 	if dopt.llbCaps != nil && dopt.llbCaps.Supports(pb.CapExecMetaUlimit) == nil {
 		for _, u := range dopt.ulimit {
 			opt = append(opt, llb.AddUlimit(llb.UlimitName(u.Name), u.Soft, u.Hard))
@@ -911,7 +977,7 @@ func dispatchRun(d *dispatchState, c *instructions.RunCommand, proxy *llb.ProxyE
 	if err != nil {
 		return err
 	}
-	opt = append(opt, llb.WithCustomName(prefixCommand(d, uppercaseCmd(processCmdEnv(&shlex, customname, env)), d.prefixPlatform, pl, env)))
+	opt = append(opt, llb.WithCustomName(prefixCommand(d, uppercaseCmd(processCmdEnv(&shlex, customname, env)), d.prefixPlatform, pl, env)), dfStage(d))
 	for _, h := range dopt.extraHosts {
 		opt = append(opt, llb.AddExtraHost(h.Host, h.IP))
 	}
@@ -956,6 +1022,7 @@ func dispatchWorkdir(d *dispatchState, c *instructions.WorkdirCommand, commit bo
 			}
 			d.state = d.state.File(llb.Mkdir(wd, 0755, mkdirOpt...),
 				llb.WithCustomName(prefixCommand(d, uppercaseCmd(processCmdEnv(opt.shlex, c.String(), env)), d.prefixPlatform, &platform, env)),
+				dfStage(d),
 				location(opt.sourceMap, c.Location()),
 			)
 			withLayer = true
@@ -990,6 +1057,10 @@ func dispatchCopy(d *dispatchState, cfg copyConfig) error {
 		}
 	}
 
+	if cfg.checksum != "" && len(cfg.params.SourcePaths) != 1 {
+		return errors.New("checksum can't be specified for multiple sources")
+	}
+
 	commitMessage := bytes.NewBufferString("")
 	if cfg.isAddCommand {
 		commitMessage.WriteString("ADD")
@@ -1001,7 +1072,36 @@ func dispatchCopy(d *dispatchState, cfg copyConfig) error {
 
 	for _, src := range cfg.params.SourcePaths {
 		commitMessage.WriteString(" " + src)
-		if strings.HasPrefix(src, "http://") || strings.HasPrefix(src, "https://") {
+		if cfg.isAddCommand && isGitSource(src) {
+			remote, ref := src, ""
+			if parts := strings.SplitN(src, "#", 2); len(parts) == 2 {
+				remote, ref = parts[0], parts[1]
+			}
+			gitOpts := []llb.GitOption{dfCmd(cfg.params)}
+			if cfg.keepGitDir {
+				gitOpts = append(gitOpts, llb.KeepGitDir())
+			}
+			if cfg.checksum != "" {
+				if !gitCommitSHAPrefix.MatchString(cfg.checksum) {
+					return errors.Errorf("invalid checksum %q for git source, expected a commit SHA", cfg.checksum)
+				}
+				gitOpts = append(gitOpts, llb.GitChecksum(cfg.checksum))
+			}
+			st := llb.Git(remote, ref, gitOpts...)
+
+			opts := append([]llb.CopyOption{&llb.CopyInfo{
+				Mode:                mode,
+				CopyDirContentsOnly: true,
+				ExcludePatterns:     cfg.excludes,
+				CreateDestPath:      true,
+			}}, copyOpt...)
+
+			if a == nil {
+				a = llb.Copy(st, "/", dest, opts...)
+			} else {
+				a = a.Copy(st, "/", dest, opts...)
+			}
+		} else if strings.HasPrefix(src, "http://") || strings.HasPrefix(src, "https://") {
 			if !cfg.isAddCommand {
 				return errors.New("source can't be a URL for COPY")
 			}
@@ -1019,7 +1119,15 @@ func dispatchCopy(d *dispatchState, cfg copyConfig) error {
 				}
 			}
 
-			st := llb.HTTP(src, llb.Filename(f), dfCmd(cfg.params))
+			httpOpts := []llb.HTTPOption{llb.Filename(f), dfCmd(cfg.params)}
+			if cfg.checksum != "" {
+				dgst, err := digest.Parse(cfg.checksum)
+				if err != nil {
+					return errors.Wrapf(err, "invalid checksum %q", cfg.checksum)
+				}
+				httpOpts = append(httpOpts, llb.Checksum(dgst))
+			}
+			st := llb.HTTP(src, httpOpts...)
 
 			opts := append([]llb.CopyOption{&llb.CopyInfo{
 				Mode:           mode,
@@ -1032,10 +1140,26 @@ func dispatchCopy(d *dispatchState, cfg copyConfig) error {
 				a = a.Copy(st, f, dest, opts...)
 			}
 		} else {
+			if cfg.checksum != "" {
+				return errors.New("checksum can't be specified for local sources")
+			}
+
+			srcPath := filepath.Join("/", src)
+			var includes []string
+			if cfg.parents {
+				var pattern string
+				srcPath, pattern = splitParentsPath(src)
+				if pattern != "" {
+					includes = []string{pattern}
+				}
+			}
+
 			opts := append([]llb.CopyOption{&llb.CopyInfo{
 				Mode:                mode,
 				FollowSymlinks:      true,
 				CopyDirContentsOnly: true,
+				IncludePatterns:     includes,
+				ExcludePatterns:     cfg.excludes,
 				AttemptUnpack:       cfg.isAddCommand,
 				CreateDestPath:      true,
 				AllowWildcard:       true,
@@ -1043,9 +1167,9 @@ func dispatchCopy(d *dispatchState, cfg copyConfig) error {
 			}}, copyOpt...)
 
 			if a == nil {
-				a = llb.Copy(cfg.source, filepath.Join("/", src), dest, opts...)
+				a = llb.Copy(cfg.source, srcPath, dest, opts...)
 			} else {
-				a = a.Copy(cfg.source, filepath.Join("/", src), dest, opts...)
+				a = a.Copy(cfg.source, srcPath, dest, opts...)
 			}
 		}
 	}
@@ -1087,6 +1211,7 @@ func dispatchCopy(d *dispatchState, cfg copyConfig) error {
 	name := uppercaseCmd(processCmdEnv(cfg.opt.shlex, cfg.cmdToPrint.String(), env))
 	fileOpt := []llb.ConstraintsOpt{
 		llb.WithCustomName(prefixCommand(d, name, d.prefixPlatform, &platform, env)),
+		dfStage(d),
 		location(cfg.opt.sourceMap, cfg.location),
 	}
 	if d.ignoreCache {
@@ -1124,10 +1249,45 @@ type copyConfig struct {
 	chown        string
 	chmod        string
 	link         bool
+	keepGitDir   bool
+	checksum     string
+	excludes     []string
+	parents      bool
 	location     []parser.Range
 	opt          dispatchOpt
 }
 
+// gitURLPathWithFragmentSuffix matches http(s) URLs of git repositories with
+// an optional ref and subdirectory fragment.
+var gitURLPathWithFragmentSuffix = regexp.MustCompile(`\.git(?:#.+)?$`)
+
+// splitParentsPath splits a COPY --parents source into the directory that is
+// copied and the include pattern for the source below it, so that the path of
+// the source relative to that directory is kept under the destination. The
+// directory is the part before a "/./" pivot, or the root of the source.
+func splitParentsPath(src string) (string, string) {
+	root := "/"
+	if i := strings.Index(src, "/./"); i >= 0 {
+		root = path.Join("/", src[:i])
+		src = src[i+len("/./"):]
+	}
+	return root, strings.TrimPrefix(path.Join("/", filepath.ToSlash(src)), "/")
+}
+
+// gitCommitSHAPrefix matches an abbreviated or full commit SHA used as the
+// checksum of a git source.
+var gitCommitSHAPrefix = regexp.MustCompile(`^[0-9a-f]{7,64}$`)
+
+// isGitSource reports whether an ADD source refers to a git repository, e.g.
+// https://github.com/moby/buildkit.git#v0.10.1:docs or
+// git@github.com:moby/buildkit.git
+func isGitSource(src string) bool {
+	if strings.HasPrefix(src, "git://") || strings.HasPrefix(src, "ssh://") || sshutil.IsImplicitSSHTransport(src) {
+		return true
+	}
+	return (strings.HasPrefix(src, "http://") || strings.HasPrefix(src, "https://")) && gitURLPathWithFragmentSuffix.MatchString(src)
+}
+
 func dispatchMaintainer(d *dispatchState, c *instructions.MaintainerCommand) error {
 	d.image.Author = c.Maintainer
 	return commitToHistory(&d.image, fmt.Sprintf("MAINTAINER %v", c.Maintainer), false, nil)
@@ -1334,6 +1494,18 @@ func dfCmd(cmd interface{}) llb.ConstraintsOpt {
 	})
 }
 
+// dfStage adds the name of the stage of an op to its description, e.g. for
+// selecting the cache exported for a stage.
+func dfStage(d *dispatchState) llb.ConstraintsOpt {
+	name := d.stage.Name
+	if name == "" {
+		name = d.stageName
+	}
+	return llb.WithDescription(map[string]string{
+		pb.DescriptionDockerfileStage: name,
+	})
+}
+
 func runCommandString(args []string, buildArgs []instructions.KeyValuePairOptional, envMap map[string]string) string {
 	var tmpBuildEnv []string
 	for _, arg := range buildArgs {
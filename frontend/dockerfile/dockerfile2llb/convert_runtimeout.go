@@ -0,0 +1,17 @@
+package dockerfile2llb
+
+import (
+	"github.com/moby/buildkit/client/llb"
+	"github.com/moby/buildkit/frontend/dockerfile/instructions"
+)
+
+func dispatchRunTimeout(c *instructions.RunCommand) []llb.RunOption {
+	var opts []llb.RunOption
+	if timeout := instructions.GetTimeout(c); timeout > 0 {
+		opts = append(opts, llb.WithTimeout(timeout))
+	}
+	if codes := instructions.GetValidExitCodes(c); len(codes) > 0 {
+		opts = append(opts, llb.WithValidExitCodes(codes...))
+	}
+	return opts
+}
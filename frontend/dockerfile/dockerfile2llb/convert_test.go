@@ -1,11 +1,13 @@
 package dockerfile2llb
 
 import (
+	"sort"
 	"strings"
 	"testing"
 
 	"github.com/moby/buildkit/frontend/dockerfile/instructions"
 	"github.com/moby/buildkit/frontend/dockerfile/shell"
+	"github.com/moby/buildkit/solver/pb"
 	"github.com/moby/buildkit/util/appcontext"
 	binfotypes "github.com/moby/buildkit/util/buildinfo/types"
 	ocispecs "github.com/opencontainers/image-spec/specs-go/v1"
@@ -219,3 +221,158 @@ ADD https://raw.githubusercontent.com/moby/buildkit/master/README.md /
 	assert.True(t, strings.HasPrefix(bi.Sources[0].Alias, "docker.io/library/busybox@"))
 	assert.NotEmpty(t, bi.Sources[0].Pin)
 }
+
+func TestIsGitSource(t *testing.T) {
+	for _, src := range []string{
+		"https://github.com/moby/buildkit.git",
+		"https://github.com/moby/buildkit.git#v0.10.1",
+		"https://github.com/moby/buildkit.git#v0.10.1:docs",
+		"git://github.com/moby/buildkit",
+		"ssh://git@github.com/moby/buildkit.git",
+		"git@github.com:moby/buildkit.git",
+	} {
+		assert.True(t, isGitSource(src), src)
+	}
+	for _, src := range []string{
+		"https://example.com/foo.tar.gz",
+		"https://example.com/foo.git/file",
+		"foo.git",
+		"dir/",
+	} {
+		assert.False(t, isGitSource(src), src)
+	}
+}
+
+func TestAddGitSource(t *testing.T) {
+	df := `FROM scratch
+ADD --keep-git-dir https://github.com/moby/buildkit.git#v0.10.1:docs /docs
+`
+	st, _, _, err := Dockerfile2LLB(appcontext.Context(), []byte(df), ConvertOpt{})
+	require.NoError(t, err)
+
+	def, err := st.Marshal(appcontext.Context())
+	require.NoError(t, err)
+
+	var src *pb.SourceOp
+	for _, dt := range def.Def {
+		var op pb.Op
+		require.NoError(t, op.Unmarshal(dt))
+		if s := op.GetSource(); s != nil {
+			src = s
+		}
+	}
+	require.NotNil(t, src)
+	require.Equal(t, "git://github.com/moby/buildkit.git#v0.10.1:docs", src.Identifier)
+	require.Equal(t, "true", src.Attrs[pb.AttrKeepGitDir])
+	require.Equal(t, "https://github.com/moby/buildkit.git", src.Attrs[pb.AttrFullRemoteURL])
+}
+
+func TestAddChecksum(t *testing.T) {
+	sourceOp := func(t *testing.T, df string) *pb.SourceOp {
+		st, _, _, err := Dockerfile2LLB(appcontext.Context(), []byte(df), ConvertOpt{})
+		require.NoError(t, err)
+
+		def, err := st.Marshal(appcontext.Context())
+		require.NoError(t, err)
+
+		for _, dt := range def.Def {
+			var op pb.Op
+			require.NoError(t, op.Unmarshal(dt))
+			if s := op.GetSource(); s != nil {
+				return s
+			}
+		}
+		return nil
+	}
+
+	src := sourceOp(t, `FROM scratch
+ARG SUM=sha256:24454f830cdb571e2c4ad15481119c43b3cafd48dd869a9b2945d1036d1dc68d
+ADD --checksum=${SUM} https://example.com/foo.tar.gz /
+`)
+	require.NotNil(t, src)
+	require.Equal(t, "https://example.com/foo.tar.gz", src.Identifier)
+	require.Equal(t, "sha256:24454f830cdb571e2c4ad15481119c43b3cafd48dd869a9b2945d1036d1dc68d", src.Attrs[pb.AttrHTTPChecksum])
+
+	src = sourceOp(t, `FROM scratch
+ADD --checksum=782acd8e3a32b2d1a2e3b8f1c9e5d3a7b1c2d3e4 https://github.com/moby/buildkit.git#v0.10.1 /src
+`)
+	require.NotNil(t, src)
+	require.Equal(t, "782acd8e3a32b2d1a2e3b8f1c9e5d3a7b1c2d3e4", src.Attrs[pb.AttrGitChecksum])
+
+	for _, df := range []string{
+		"FROM scratch\nADD --checksum=sha256:24454f830cdb571e2c4ad15481119c43b3cafd48dd869a9b2945d1036d1dc68d foo /\n",
+		"FROM scratch\nADD --checksum=md5:abc https://example.com/foo /\n",
+		"FROM scratch\nADD --checksum=sha256:abc https://github.com/moby/buildkit.git /src\n",
+		"FROM scratch\nADD --checksum=sha256:24454f830cdb571e2c4ad15481119c43b3cafd48dd869a9b2945d1036d1dc68d https://example.com/foo https://example.com/bar /\n",
+	} {
+		_, _, _, err := Dockerfile2LLB(appcontext.Context(), []byte(df), ConvertOpt{})
+		require.Error(t, err, df)
+	}
+}
+
+func TestCopyExcludesAndParents(t *testing.T) {
+	df := `FROM scratch
+COPY --exclude=*.md --parents ./a/*.go ./b/./c/d /dest/
+COPY --exclude=vendor . /src/
+`
+	st, _, _, err := Dockerfile2LLB(appcontext.Context(), []byte(df), ConvertOpt{})
+	require.NoError(t, err)
+
+	def, err := st.Marshal(appcontext.Context())
+	require.NoError(t, err)
+
+	var copies []*pb.FileActionCopy
+	for _, dt := range def.Def {
+		var op pb.Op
+		require.NoError(t, op.Unmarshal(dt))
+		if f := op.GetFile(); f != nil {
+			for _, a := range f.Actions {
+				if cp := a.GetCopy(); cp != nil {
+					copies = append(copies, cp)
+				}
+			}
+		}
+	}
+	require.Equal(t, 3, len(copies))
+
+	var parents, excludes []*pb.FileActionCopy
+	for _, cp := range copies {
+		if len(cp.IncludePatterns) > 0 {
+			parents = append(parents, cp)
+		} else {
+			excludes = append(excludes, cp)
+		}
+	}
+	require.Equal(t, 2, len(parents))
+	sort.Slice(parents, func(i, j int) bool { return parents[i].Src < parents[j].Src })
+	require.Equal(t, "/", parents[0].Src)
+	require.Equal(t, []string{"a/*.go"}, parents[0].IncludePatterns)
+	require.Equal(t, "/b", parents[1].Src)
+	require.Equal(t, []string{"c/d"}, parents[1].IncludePatterns)
+	for _, cp := range parents {
+		require.Equal(t, []string{"*.md"}, cp.ExcludePatterns)
+		require.Equal(t, "/dest/", cp.Dest)
+	}
+
+	require.Equal(t, 1, len(excludes))
+	require.Equal(t, "/", excludes[0].Src)
+	require.Equal(t, []string{"vendor"}, excludes[0].ExcludePatterns)
+}
+
+func TestSplitParentsPath(t *testing.T) {
+	for _, tc := range []struct {
+		src     string
+		root    string
+		pattern string
+	}{
+		{src: ".", root: "/", pattern: ""},
+		{src: "./a/b.txt", root: "/", pattern: "a/b.txt"},
+		{src: "/a/*/c", root: "/", pattern: "a/*/c"},
+		{src: "a/./b/c", root: "/a", pattern: "b/c"},
+		{src: "./a/b/./c/../d", root: "/a/b", pattern: "d"},
+	} {
+		root, pattern := splitParentsPath(tc.src)
+		require.Equal(t, tc.root, root, tc.src)
+		require.Equal(t, tc.pattern, pattern, tc.src)
+	}
+}
@@ -0,0 +1,271 @@
+package dockerfile2llb
+
+import (
+	"bytes"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/moby/buildkit/frontend/dockerfile/instructions"
+	"github.com/moby/buildkit/frontend/dockerfile/parser"
+	"github.com/moby/buildkit/frontend/dockerfile/shell"
+	"github.com/moby/buildkit/frontend/subrequests/outline"
+	"github.com/moby/buildkit/frontend/subrequests/targets"
+	"github.com/pkg/errors"
+)
+
+// Dockerfile2Outline returns the build args, secrets and SSH sockets used by
+// the target stage and the stages it depends on, without converting the
+// Dockerfile to LLB.
+func Dockerfile2Outline(dt []byte, opt ConvertOpt) (*outline.Outline, error) {
+	dockerfile, stages, metaArgs, err := parseStages(dt)
+	if err != nil {
+		return nil, err
+	}
+
+	target := len(stages) - 1
+	if opt.Target != "" {
+		target = -1
+		for i, st := range stages {
+			if strings.EqualFold(st.Name, opt.Target) {
+				target = i
+				break
+			}
+		}
+		if target == -1 {
+			return nil, errors.Errorf("target stage %s could not be found", opt.Target)
+		}
+	}
+	reachable := reachableStages(stages, target)
+
+	globals := map[string]string{}
+	globalArgs := map[string]instructions.KeyValuePairOptional{}
+	for _, cmd := range metaArgs {
+		for _, kv := range cmd.Args {
+			globals[kv.Key] = kv.ValueString()
+			globalArgs[kv.Key] = kv
+		}
+	}
+
+	shlex := shell.NewLex(dockerfile.EscapeToken)
+	usedGlobals := map[string]struct{}{}
+	for i, st := range stages {
+		if _, ok := reachable[i]; !ok {
+			continue
+		}
+		for _, v := range []string{st.BaseName, st.Platform} {
+			_, matches, err := shlex.ProcessWordWithMatches(v, globals)
+			if err != nil {
+				continue
+			}
+			for k := range matches {
+				usedGlobals[k] = struct{}{}
+			}
+		}
+	}
+
+	o := &outline.Outline{
+		Name:        stages[target].Name,
+		Description: stages[target].Comment,
+	}
+
+	args := map[string]struct{}{}
+	addArg := func(kv instructions.KeyValuePairOptional, location []parser.Range) {
+		if _, ok := args[kv.Key]; ok {
+			return
+		}
+		args[kv.Key] = struct{}{}
+		o.Args = append(o.Args, outline.Arg{
+			Name:        kv.Key,
+			Description: kv.Comment,
+			Value:       kv.ValueString(),
+			Location:    toPBRanges(location),
+		})
+	}
+	for _, cmd := range metaArgs {
+		for _, kv := range cmd.Args {
+			if _, ok := usedGlobals[kv.Key]; ok {
+				addArg(kv, cmd.Location())
+			}
+		}
+	}
+
+	secrets := map[string]int{}
+	ssh := map[string]int{}
+	for i, st := range stages {
+		if _, ok := reachable[i]; !ok {
+			continue
+		}
+		env := map[string]string{}
+		for _, cmd := range st.Commands {
+			switch c := cmd.(type) {
+			case *instructions.ArgCommand:
+				for _, kv := range c.Args {
+					if g, ok := globalArgs[kv.Key]; ok && kv.Value == nil {
+						kv.Value = g.Value
+						if kv.Comment == "" {
+							kv.Comment = g.Comment
+						}
+					}
+					env[kv.Key] = kv.ValueString()
+					addArg(kv, c.Location())
+				}
+			case *instructions.RunCommand:
+				// mounts are parsed without expansion by runMountPostHook, parse them
+				// again with the build args so that secret and ssh IDs are resolved
+				err := c.Expand(func(word string) (string, error) {
+					return shlex.ProcessWordWithMap(word, env)
+				})
+				if err != nil {
+					continue
+				}
+				for _, m := range instructions.GetMounts(c) {
+					switch m.Type {
+					case instructions.MountTypeSecret:
+						id := m.CacheID
+						if m.Source != "" {
+							id = m.Source
+						}
+						if id == "" {
+							id = path.Base(m.Target)
+						}
+						if j, ok := secrets[id]; ok {
+							o.Secrets[j].Required = o.Secrets[j].Required || m.Required
+							continue
+						}
+						secrets[id] = len(o.Secrets)
+						o.Secrets = append(o.Secrets, outline.Secret{
+							Name:     id,
+							Required: m.Required,
+							Location: toPBRanges(c.Location()),
+						})
+					case instructions.MountTypeSSH:
+						id := m.CacheID
+						if id == "" {
+							id = "default"
+						}
+						if j, ok := ssh[id]; ok {
+							o.SSH[j].Required = o.SSH[j].Required || m.Required
+							continue
+						}
+						ssh[id] = len(o.SSH)
+						o.SSH = append(o.SSH, outline.SSH{
+							Name:     id,
+							Required: m.Required,
+							Location: toPBRanges(c.Location()),
+						})
+					}
+				}
+			}
+		}
+	}
+
+	return o, nil
+}
+
+// ListTargets returns the stages of the Dockerfile that can be built as a
+// target.
+func ListTargets(dt []byte) (*targets.List, error) {
+	dockerfile, stages, metaArgs, err := parseStages(dt)
+	if err != nil {
+		return nil, err
+	}
+
+	globals := map[string]string{}
+	for _, cmd := range metaArgs {
+		for _, kv := range cmd.Args {
+			globals[kv.Key] = kv.ValueString()
+		}
+	}
+	shlex := shell.NewLex(dockerfile.EscapeToken)
+
+	l := &targets.List{
+		Targets: []targets.Target{},
+	}
+	for i, st := range stages {
+		last := i == len(stages)-1
+		if st.Name == "" && !last {
+			continue
+		}
+		base, err := shlex.ProcessWordWithMap(st.BaseName, globals)
+		if err != nil {
+			base = st.BaseName
+		}
+		l.Targets = append(l.Targets, targets.Target{
+			Name:        st.Name,
+			Default:     last,
+			Description: st.Comment,
+			Base:        base,
+			Platform:    st.Platform,
+			Location:    toPBRanges(st.Location),
+		})
+	}
+	return l, nil
+}
+
+func parseStages(dt []byte) (*parser.Result, []instructions.Stage, []instructions.ArgCommand, error) {
+	if len(dt) == 0 {
+		return nil, nil, nil, errors.Errorf("the Dockerfile cannot be empty")
+	}
+	dockerfile, err := parser.Parse(bytes.NewReader(dt))
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	stages, metaArgs, err := instructions.Parse(dockerfile.AST)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if len(stages) == 0 {
+		return nil, nil, nil, errors.Errorf("the Dockerfile has no stages")
+	}
+	return dockerfile, stages, metaArgs, nil
+}
+
+// reachableStages returns the indexes of the target stage and all stages it
+// depends on through FROM, COPY --from and RUN --mount=from.
+func reachableStages(stages []instructions.Stage, target int) map[int]struct{} {
+	byName := map[string]int{}
+	for i, st := range stages {
+		if st.Name != "" {
+			byName[st.Name] = i
+		}
+	}
+	lookup := func(ref string) (int, bool) {
+		if i, ok := byName[strings.ToLower(ref)]; ok {
+			return i, true
+		}
+		if i, err := strconv.Atoi(ref); err == nil && i >= 0 && i < len(stages) {
+			return i, true
+		}
+		return 0, false
+	}
+
+	reachable := map[int]struct{}{}
+	var visit func(i int)
+	visit = func(i int) {
+		if _, ok := reachable[i]; ok {
+			return
+		}
+		reachable[i] = struct{}{}
+		st := stages[i]
+		if j, ok := byName[strings.ToLower(st.BaseName)]; ok {
+			visit(j)
+		}
+		for _, cmd := range st.Commands {
+			switch c := cmd.(type) {
+			case *instructions.CopyCommand:
+				if j, ok := lookup(c.From); ok && c.From != "" {
+					visit(j)
+				}
+			case *instructions.RunCommand:
+				for _, m := range instructions.GetMounts(c) {
+					if j, ok := lookup(m.From); ok && m.From != "" {
+						visit(j)
+					}
+				}
+			}
+		}
+	}
+	visit(target)
+	return reachable
+}
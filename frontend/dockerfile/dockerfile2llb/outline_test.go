@@ -0,0 +1,97 @@
+package dockerfile2llb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const outlineDockerfile = `
+# BASE base image of all stages
+ARG BASE=alpine
+ARG UNUSED=foo
+
+# deps downloads the dependencies
+FROM ${BASE} AS deps
+# GOPROXY proxy used for downloads
+ARG GOPROXY=direct
+RUN --mount=type=secret,id=netrc,required \
+    --mount=type=ssh \
+    true
+
+FROM ${BASE} AS lint
+ARG LINTER
+RUN --mount=type=secret,id=token true
+
+# build compiles the binary
+FROM deps AS build
+ARG BASE
+ARG VERSION
+RUN --mount=type=secret,id=netrc true
+
+FROM scratch
+COPY --from=build /out /
+`
+
+func TestDockerfile2Outline(t *testing.T) {
+	t.Parallel()
+
+	o, err := Dockerfile2Outline([]byte(outlineDockerfile), ConvertOpt{})
+	require.NoError(t, err)
+
+	require.Equal(t, "", o.Name)
+
+	var names []string
+	for _, a := range o.Args {
+		names = append(names, a.Name)
+	}
+	require.Equal(t, []string{"BASE", "GOPROXY", "VERSION"}, names)
+
+	require.Equal(t, "alpine", o.Args[0].Value)
+	require.Equal(t, "base image of all stages", o.Args[0].Description)
+	require.Equal(t, 3, int(o.Args[0].Location[0].Start.Line))
+	require.Equal(t, "direct", o.Args[1].Value)
+	require.Equal(t, "proxy used for downloads", o.Args[1].Description)
+	require.Equal(t, "", o.Args[2].Value)
+
+	require.Equal(t, 1, len(o.Secrets))
+	require.Equal(t, "netrc", o.Secrets[0].Name)
+	require.True(t, o.Secrets[0].Required)
+
+	require.Equal(t, 1, len(o.SSH))
+	require.Equal(t, "default", o.SSH[0].Name)
+	require.False(t, o.SSH[0].Required)
+
+	o, err = Dockerfile2Outline([]byte(outlineDockerfile), ConvertOpt{Target: "lint"})
+	require.NoError(t, err)
+	require.Equal(t, "lint", o.Name)
+	require.Equal(t, 2, len(o.Args))
+	require.Equal(t, "LINTER", o.Args[1].Name)
+	require.Equal(t, 1, len(o.Secrets))
+	require.Equal(t, "token", o.Secrets[0].Name)
+	require.Equal(t, 0, len(o.SSH))
+
+	_, err = Dockerfile2Outline([]byte(outlineDockerfile), ConvertOpt{Target: "notexist"})
+	require.Error(t, err)
+}
+
+func TestListTargets(t *testing.T) {
+	t.Parallel()
+
+	l, err := ListTargets([]byte(outlineDockerfile))
+	require.NoError(t, err)
+	require.Equal(t, 4, len(l.Targets))
+
+	require.Equal(t, "deps", l.Targets[0].Name)
+	require.Equal(t, "downloads the dependencies", l.Targets[0].Description)
+	require.Equal(t, "alpine", l.Targets[0].Base)
+	require.False(t, l.Targets[0].Default)
+
+	require.Equal(t, "build", l.Targets[2].Name)
+	require.Equal(t, "compiles the binary", l.Targets[2].Description)
+	require.Equal(t, "deps", l.Targets[2].Base)
+
+	require.Equal(t, "", l.Targets[3].Name)
+	require.Equal(t, "scratch", l.Targets[3].Base)
+	require.True(t, l.Targets[3].Default)
+}
@@ -30,6 +30,9 @@ import (
 	"github.com/moby/buildkit/frontend/dockerfile/builder"
 	gateway "github.com/moby/buildkit/frontend/gateway/client"
 	"github.com/moby/buildkit/frontend/subrequests"
+	"github.com/moby/buildkit/frontend/subrequests/check"
+	"github.com/moby/buildkit/frontend/subrequests/outline"
+	"github.com/moby/buildkit/frontend/subrequests/targets"
 	"github.com/moby/buildkit/identity"
 	"github.com/moby/buildkit/session"
 	"github.com/moby/buildkit/session/upload/uploadprovider"
@@ -39,6 +42,7 @@ import (
 	"github.com/moby/buildkit/util/testutil"
 	"github.com/moby/buildkit/util/testutil/httpserver"
 	"github.com/moby/buildkit/util/testutil/integration"
+	digest "github.com/opencontainers/go-digest"
 	ocispecs "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
@@ -68,6 +72,8 @@ var allTests = integration.TestFuncs(
 	testDockerignore,
 	testDockerignoreInvalid,
 	testDockerfileFromGit,
+	testAddGit,
+	testAddURLChecksum,
 	testMultiStageImplicitFrom,
 	testMultiStageCaseInsensitive,
 	testLabels,
@@ -103,6 +109,9 @@ var allTests = integration.TestFuncs(
 	testErrorsSourceMap,
 	testMultiArgs,
 	testFrontendSubrequests,
+	testFrontendCheck,
+	testFrontendOutline,
+	testFrontendTargets,
 	testDockefileCheckHostname,
 	testDefaultShellAndPath,
 	testDockerfileLowercase,
@@ -129,6 +138,8 @@ var allTests = integration.TestFuncs(
 	testCopyOverrideFiles,
 	testCopyVarSubstitution,
 	testCopyWildcards,
+	testCopyExcludeParents,
+	testRunTimeout,
 	testCopyRelative,
 	testAddURLChmod,
 	testTarContext,
@@ -3357,6 +3368,114 @@ COPY sub/dir1 subdest6
 	require.Equal(t, "foo-contents", string(dt))
 }
 
+func testRunTimeout(t *testing.T, sb integration.Sandbox) {
+	f := getFrontend(t, sb)
+
+	dockerfile := []byte(`
+FROM busybox
+RUN --valid-exit-codes=3 sh -c 'touch /done; exit 3'
+RUN --timeout=1s sleep 60
+`)
+
+	dir, err := tmpdir(
+		fstest.CreateFile("Dockerfile", dockerfile, 0600),
+	)
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	c, err := client.New(sb.Context(), sb.Address())
+	require.NoError(t, err)
+	defer c.Close()
+
+	start := time.Now()
+	_, err = f.Solve(sb.Context(), c, client.SolveOpt{
+		LocalDirs: map[string]string{
+			builder.DefaultLocalNameDockerfile: dir,
+			builder.DefaultLocalNameContext:    dir,
+		},
+	}, nil)
+	require.Error(t, err)
+	require.Less(t, time.Since(start), 50*time.Second)
+
+	var timeoutErr *errdefs.ExecTimeoutError
+	require.True(t, errors.As(err, &timeoutErr), "%+v", err)
+	require.Equal(t, int64(time.Second), timeoutErr.Timeout)
+	require.Contains(t, err.Error(), "did not complete within 1s")
+}
+
+func testCopyExcludeParents(t *testing.T, sb integration.Sandbox) {
+	f := getFrontend(t, sb)
+
+	dockerfile := []byte(`
+FROM scratch
+COPY --exclude=*.md --exclude=vendor . /all/
+COPY --parents app/*/main.go /parents/
+COPY --parents app/./cmd/foo/main.go /pivot/
+`)
+
+	dir, err := tmpdir(
+		fstest.CreateFile("Dockerfile", dockerfile, 0600),
+		fstest.CreateFile("README.md", []byte(`readme`), 0600),
+		fstest.CreateDir("vendor", 0700),
+		fstest.CreateFile("vendor/dep.go", []byte(`dep`), 0600),
+		fstest.CreateDir("app", 0700),
+		fstest.CreateDir("app/cmd", 0700),
+		fstest.CreateDir("app/cmd/foo", 0700),
+		fstest.CreateFile("app/cmd/foo/main.go", []byte(`foo-main`), 0600),
+		fstest.CreateFile("app/cmd/foo/other.go", []byte(`foo-other`), 0600),
+		fstest.CreateDir("app/lib", 0700),
+		fstest.CreateFile("app/lib/main.go", []byte(`lib-main`), 0600),
+	)
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	c, err := client.New(sb.Context(), sb.Address())
+	require.NoError(t, err)
+	defer c.Close()
+
+	destDir, err := os.MkdirTemp("", "buildkit")
+	require.NoError(t, err)
+	defer os.RemoveAll(destDir)
+
+	_, err = f.Solve(sb.Context(), c, client.SolveOpt{
+		Exports: []client.ExportEntry{
+			{
+				Type:      client.ExporterLocal,
+				OutputDir: destDir,
+			},
+		},
+		LocalDirs: map[string]string{
+			builder.DefaultLocalNameDockerfile: dir,
+			builder.DefaultLocalNameContext:    dir,
+		},
+	}, nil)
+	require.NoError(t, err)
+
+	dt, err := os.ReadFile(filepath.Join(destDir, "all/app/cmd/foo/main.go"))
+	require.NoError(t, err)
+	require.Equal(t, "foo-main", string(dt))
+
+	_, err = os.Stat(filepath.Join(destDir, "all/README.md"))
+	require.True(t, errors.Is(err, os.ErrNotExist))
+
+	_, err = os.Stat(filepath.Join(destDir, "all/vendor"))
+	require.True(t, errors.Is(err, os.ErrNotExist))
+
+	dt, err = os.ReadFile(filepath.Join(destDir, "parents/app/lib/main.go"))
+	require.NoError(t, err)
+	require.Equal(t, "lib-main", string(dt))
+
+	_, err = os.Stat(filepath.Join(destDir, "parents/app/cmd"))
+	require.True(t, errors.Is(err, os.ErrNotExist))
+
+	dt, err = os.ReadFile(filepath.Join(destDir, "pivot/cmd/foo/main.go"))
+	require.NoError(t, err)
+	require.Equal(t, "foo-main", string(dt))
+
+	_, err = os.Stat(filepath.Join(destDir, "pivot/cmd/foo/other.go"))
+	require.True(t, errors.Is(err, os.ErrNotExist))
+}
+
 func testCopyRelative(t *testing.T, sb integration.Sandbox) {
 	f := getFrontend(t, sb)
 
@@ -3463,6 +3582,141 @@ COPY --from=build /dest /dest
 	require.Equal(t, []byte("0644\n0755\n0413\n"), dt)
 }
 
+func testAddGit(t *testing.T, sb integration.Sandbox) {
+	f := getFrontend(t, sb)
+
+	gitDir, err := os.MkdirTemp("", "buildkit")
+	require.NoError(t, err)
+	defer os.RemoveAll(gitDir)
+
+	err = os.MkdirAll(filepath.Join(gitDir, "sub"), 0700)
+	require.NoError(t, err)
+	err = os.WriteFile(filepath.Join(gitDir, "sub", "foo"), []byte("foo-contents"), 0600)
+	require.NoError(t, err)
+
+	err = runShell(gitDir,
+		"git init",
+		"git config --local user.email test",
+		"git config --local user.name test",
+		"git add sub",
+		"git commit -m initial",
+		"git tag v1",
+		"git update-server-info",
+	)
+	require.NoError(t, err)
+
+	server := httptest.NewServer(http.FileServer(http.Dir(filepath.Join(gitDir))))
+	defer server.Close()
+
+	dockerfile := []byte(fmt.Sprintf(`
+FROM scratch
+ADD %[1]s/.git#v1:sub /repo-sub/
+ADD --keep-git-dir %[1]s/.git#v1 /repo/
+`, server.URL))
+
+	dir, err := tmpdir(
+		fstest.CreateFile("Dockerfile", dockerfile, 0600),
+	)
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	destDir, err := os.MkdirTemp("", "buildkit")
+	require.NoError(t, err)
+	defer os.RemoveAll(destDir)
+
+	c, err := client.New(sb.Context(), sb.Address())
+	require.NoError(t, err)
+	defer c.Close()
+
+	_, err = f.Solve(sb.Context(), c, client.SolveOpt{
+		LocalDirs: map[string]string{
+			builder.DefaultLocalNameDockerfile: dir,
+			builder.DefaultLocalNameContext:    dir,
+		},
+		Exports: []client.ExportEntry{
+			{
+				Type:      client.ExporterLocal,
+				OutputDir: destDir,
+			},
+		},
+	}, nil)
+	require.NoError(t, err)
+
+	dt, err := os.ReadFile(filepath.Join(destDir, "repo-sub", "foo"))
+	require.NoError(t, err)
+	require.Equal(t, "foo-contents", string(dt))
+
+	_, err = os.Stat(filepath.Join(destDir, "repo-sub", ".git"))
+	require.True(t, errors.Is(err, os.ErrNotExist))
+
+	dt, err = os.ReadFile(filepath.Join(destDir, "repo", "sub", "foo"))
+	require.NoError(t, err)
+	require.Equal(t, "foo-contents", string(dt))
+
+	_, err = os.Stat(filepath.Join(destDir, "repo", ".git"))
+	require.NoError(t, err)
+}
+
+func testAddURLChecksum(t *testing.T, sb integration.Sandbox) {
+	f := getFrontend(t, sb)
+
+	resp := httpserver.Response{
+		Etag:    identity.NewID(),
+		Content: []byte("content1"),
+	}
+	server := httpserver.NewTestServer(map[string]httpserver.Response{
+		"/foo": resp,
+	})
+	defer server.Close()
+
+	c, err := client.New(sb.Context(), sb.Address())
+	require.NoError(t, err)
+	defer c.Close()
+
+	build := func(checksum digest.Digest) (string, error) {
+		dockerfile := []byte(fmt.Sprintf(`
+FROM scratch
+ADD --checksum=%s %s /dest/
+`, checksum, server.URL+"/foo"))
+
+		dir, err := tmpdir(
+			fstest.CreateFile("Dockerfile", dockerfile, 0600),
+		)
+		require.NoError(t, err)
+		defer os.RemoveAll(dir)
+
+		destDir, err := tmpdir()
+		require.NoError(t, err)
+
+		_, err = f.Solve(sb.Context(), c, client.SolveOpt{
+			LocalDirs: map[string]string{
+				builder.DefaultLocalNameDockerfile: dir,
+				builder.DefaultLocalNameContext:    dir,
+			},
+			Exports: []client.ExportEntry{
+				{
+					Type:      client.ExporterLocal,
+					OutputDir: destDir,
+				},
+			},
+		}, nil)
+		return destDir, err
+	}
+
+	destDir, err := build(digest.FromBytes([]byte("content1")))
+	require.NoError(t, err)
+	defer os.RemoveAll(destDir)
+
+	dt, err := os.ReadFile(filepath.Join(destDir, "dest/foo"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("content1"), dt)
+
+	destDir, err = build(digest.FromBytes([]byte("content2")))
+	defer os.RemoveAll(destDir)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "digest mismatch")
+}
+
 func testDockerfileFromGit(t *testing.T, sb integration.Sandbox) {
 	f := getFrontend(t, sb)
 
@@ -4997,6 +5251,222 @@ COPY Dockerfile Dockerfile
 	require.True(t, called)
 }
 
+func testFrontendCheck(t *testing.T, sb integration.Sandbox) {
+	f := getFrontend(t, sb)
+
+	c, err := client.New(sb.Context(), sb.Address())
+	require.NoError(t, err)
+	defer c.Close()
+
+	dockerfile := []byte(`
+FROM busybox as base
+MAINTAINER me@example.com
+RUN false
+`)
+
+	if gf, ok := f.(*gatewayFrontend); ok {
+		dockerfile = []byte(fmt.Sprintf("#syntax=%s\n\n%s", gf.gw, dockerfile))
+	}
+
+	dir, err := tmpdir(
+		fstest.CreateFile("Dockerfile", dockerfile, 0600),
+	)
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	called := false
+
+	frontend := func(ctx context.Context, c gateway.Client) (*gateway.Result, error) {
+		res, err := c.Solve(ctx, gateway.SolveRequest{
+			FrontendOpt: map[string]string{
+				"requestid":     check.RequestCheck,
+				"frontend.caps": "moby.buildkit.frontend.subrequests",
+			},
+			Frontend: "dockerfile.v0",
+		})
+		require.NoError(t, err)
+
+		dt, ok := res.Metadata["result.json"]
+		require.True(t, ok)
+
+		var result check.Result
+		require.NoError(t, json.Unmarshal(dt, &result))
+		require.Equal(t, "Dockerfile", result.Filename)
+		require.Equal(t, 2, len(result.Warnings))
+
+		rules := []string{result.Warnings[0].RuleName, result.Warnings[1].RuleName}
+		require.Contains(t, rules, "FromAsCasing")
+		require.Contains(t, rules, "MaintainerDeprecated")
+
+		for _, w := range result.Warnings {
+			if w.RuleName == "MaintainerDeprecated" {
+				require.Equal(t, 1, len(w.Location))
+				require.True(t, w.Location[0].Start.Line > 0)
+			}
+		}
+
+		_, ok = res.Metadata["result.txt"]
+		require.True(t, ok)
+
+		called = true
+		return nil, nil
+	}
+
+	_, err = c.Build(sb.Context(), client.SolveOpt{
+		LocalDirs: map[string]string{
+			builder.DefaultLocalNameDockerfile: dir,
+		},
+	}, "", frontend, nil)
+	require.NoError(t, err)
+
+	require.True(t, called)
+}
+
+func testFrontendOutline(t *testing.T, sb integration.Sandbox) {
+	f := getFrontend(t, sb)
+
+	c, err := client.New(sb.Context(), sb.Address())
+	require.NoError(t, err)
+	defer c.Close()
+
+	dockerfile := []byte(`
+ARG IMAGE=busybox
+
+# base is the base stage
+FROM ${IMAGE} AS base
+# HTTP_PORT is the port to listen on
+ARG HTTP_PORT=8080
+RUN --mount=type=secret,id=token,required true
+
+FROM base AS other
+ARG OTHER
+
+FROM base
+RUN --mount=type=ssh true
+`)
+
+	if gf, ok := f.(*gatewayFrontend); ok {
+		dockerfile = []byte(fmt.Sprintf("#syntax=%s\n\n%s", gf.gw, dockerfile))
+	}
+
+	dir, err := tmpdir(
+		fstest.CreateFile("Dockerfile", dockerfile, 0600),
+	)
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	called := false
+
+	frontend := func(ctx context.Context, c gateway.Client) (*gateway.Result, error) {
+		res, err := c.Solve(ctx, gateway.SolveRequest{
+			FrontendOpt: map[string]string{
+				"requestid":     outline.RequestSubrequestsOutline,
+				"frontend.caps": "moby.buildkit.frontend.subrequests",
+			},
+			Frontend: "dockerfile.v0",
+		})
+		require.NoError(t, err)
+
+		dt, ok := res.Metadata["result.json"]
+		require.True(t, ok)
+
+		var o outline.Outline
+		require.NoError(t, json.Unmarshal(dt, &o))
+
+		require.Equal(t, 2, len(o.Args))
+		require.Equal(t, "IMAGE", o.Args[0].Name)
+		require.Equal(t, "busybox", o.Args[0].Value)
+		require.Equal(t, "HTTP_PORT", o.Args[1].Name)
+		require.Equal(t, "8080", o.Args[1].Value)
+		require.Equal(t, "is the port to listen on", o.Args[1].Description)
+
+		require.Equal(t, 1, len(o.Secrets))
+		require.Equal(t, "token", o.Secrets[0].Name)
+		require.True(t, o.Secrets[0].Required)
+
+		require.Equal(t, 1, len(o.SSH))
+		require.Equal(t, "default", o.SSH[0].Name)
+
+		called = true
+		return nil, nil
+	}
+
+	_, err = c.Build(sb.Context(), client.SolveOpt{
+		LocalDirs: map[string]string{
+			builder.DefaultLocalNameDockerfile: dir,
+		},
+	}, "", frontend, nil)
+	require.NoError(t, err)
+
+	require.True(t, called)
+}
+
+func testFrontendTargets(t *testing.T, sb integration.Sandbox) {
+	f := getFrontend(t, sb)
+
+	c, err := client.New(sb.Context(), sb.Address())
+	require.NoError(t, err)
+	defer c.Close()
+
+	dockerfile := []byte(`
+# base is the base stage
+FROM busybox AS base
+
+FROM --platform=$BUILDPLATFORM alpine AS tools
+
+FROM base
+`)
+
+	if gf, ok := f.(*gatewayFrontend); ok {
+		dockerfile = []byte(fmt.Sprintf("#syntax=%s\n\n%s", gf.gw, dockerfile))
+	}
+
+	dir, err := tmpdir(
+		fstest.CreateFile("Dockerfile", dockerfile, 0600),
+	)
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	called := false
+
+	frontend := func(ctx context.Context, c gateway.Client) (*gateway.Result, error) {
+		res, err := c.Solve(ctx, gateway.SolveRequest{
+			FrontendOpt: map[string]string{
+				"requestid":     targets.RequestTargets,
+				"frontend.caps": "moby.buildkit.frontend.subrequests",
+			},
+			Frontend: "dockerfile.v0",
+		})
+		require.NoError(t, err)
+
+		dt, ok := res.Metadata["result.json"]
+		require.True(t, ok)
+
+		var l targets.List
+		require.NoError(t, json.Unmarshal(dt, &l))
+
+		require.Equal(t, 3, len(l.Targets))
+		require.Equal(t, "base", l.Targets[0].Name)
+		require.Equal(t, "is the base stage", l.Targets[0].Description)
+		require.Equal(t, "busybox", l.Targets[0].Base)
+		require.Equal(t, "tools", l.Targets[1].Name)
+		require.Equal(t, "$BUILDPLATFORM", l.Targets[1].Platform)
+		require.True(t, l.Targets[2].Default)
+
+		called = true
+		return nil, nil
+	}
+
+	_, err = c.Build(sb.Context(), client.SolveOpt{
+		LocalDirs: map[string]string{
+			builder.DefaultLocalNameDockerfile: dir,
+		},
+	}, "", frontend, nil)
+	require.NoError(t, err)
+
+	require.True(t, called)
+}
+
 // moby/buildkit#1301
 func testDockefileCheckHostname(t *testing.T, sb integration.Sandbox) {
 	f := getFrontend(t, sb)
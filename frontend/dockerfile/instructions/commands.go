@@ -224,9 +224,12 @@ func (s *SourcesAndDest) ExpandRaw(expander SingleWordExpander) error {
 type AddCommand struct {
 	withNameAndCode
 	SourcesAndDest
-	Chown string
-	Chmod string
-	Link  bool
+	Chown      string
+	Chmod      string
+	Link       bool
+	KeepGitDir bool   // whether to keep .git dir, only meaningful for git sources
+	Checksum   string // expected digest of a remote source or commit SHA of a git source
+	Excludes   []string
 }
 
 // Expand variables
@@ -237,6 +240,16 @@ func (c *AddCommand) Expand(expander SingleWordExpander) error {
 	}
 	c.Chown = expandedChown
 
+	expandedChecksum, err := expander(c.Checksum)
+	if err != nil {
+		return err
+	}
+	c.Checksum = expandedChecksum
+
+	if err := expandSliceInPlace(c.Excludes, expander); err != nil {
+		return err
+	}
+
 	return c.SourcesAndDest.Expand(expander)
 }
 
@@ -247,10 +260,12 @@ func (c *AddCommand) Expand(expander SingleWordExpander) error {
 type CopyCommand struct {
 	withNameAndCode
 	SourcesAndDest
-	From  string
-	Chown string
-	Chmod string
-	Link  bool
+	From     string
+	Chown    string
+	Chmod    string
+	Link     bool
+	Excludes []string
+	Parents  bool // whether to keep the path hierarchy of the sources under the destination
 }
 
 // Expand variables
@@ -261,6 +276,10 @@ func (c *CopyCommand) Expand(expander SingleWordExpander) error {
 	}
 	c.Chown = expandedChown
 
+	if err := expandSliceInPlace(c.Excludes, expander); err != nil {
+		return err
+	}
+
 	return c.SourcesAndDest.Expand(expander)
 }
 
@@ -0,0 +1,73 @@
+package instructions
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+var timeoutKey = "dockerfile/run/timeout"
+
+func init() {
+	parseRunPreHooks = append(parseRunPreHooks, runTimeoutPreHook)
+	parseRunPostHooks = append(parseRunPostHooks, runTimeoutPostHook)
+}
+
+func runTimeoutPreHook(cmd *RunCommand, req parseRequest) error {
+	st := &timeoutState{}
+	st.timeoutFlag = req.flags.AddString("timeout", "")
+	st.exitCodesFlag = req.flags.AddString("valid-exit-codes", "")
+	cmd.setExternalValue(timeoutKey, st)
+	return nil
+}
+
+func runTimeoutPostHook(cmd *RunCommand, req parseRequest) error {
+	st := cmd.getExternalValue(timeoutKey).(*timeoutState)
+	if st == nil {
+		return errors.Errorf("no timeout state")
+	}
+
+	if v := st.timeoutFlag.Value; v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return errors.Wrapf(err, "invalid timeout %q", v)
+		}
+		if d <= 0 {
+			return errors.Errorf("invalid timeout %q, must be positive", v)
+		}
+		st.timeout = d
+	}
+
+	if v := st.exitCodesFlag.Value; v != "" {
+		for _, s := range strings.Split(v, ",") {
+			code, err := strconv.ParseUint(strings.TrimSpace(s), 10, 8)
+			if err != nil {
+				return errors.Errorf("invalid exit code %q", s)
+			}
+			st.validExitCodes = append(st.validExitCodes, int(code))
+		}
+	}
+
+	return nil
+}
+
+// GetTimeout returns the duration after which the process of the RUN
+// command is killed, zero for no timeout.
+func GetTimeout(cmd *RunCommand) time.Duration {
+	return cmd.getExternalValue(timeoutKey).(*timeoutState).timeout
+}
+
+// GetValidExitCodes returns the exit codes other than zero that do not fail
+// the RUN command.
+func GetValidExitCodes(cmd *RunCommand) []int {
+	return cmd.getExternalValue(timeoutKey).(*timeoutState).validExitCodes
+}
+
+type timeoutState struct {
+	timeoutFlag    *Flag
+	exitCodesFlag  *Flag
+	timeout        time.Duration
+	validExitCodes []int
+}
@@ -281,6 +281,9 @@ func parseAdd(req parseRequest) (*AddCommand, error) {
 	flChown := req.flags.AddString("chown", "")
 	flChmod := req.flags.AddString("chmod", "")
 	flLink := req.flags.AddBool("link", false)
+	flKeepGitDir := req.flags.AddBool("keep-git-dir", false)
+	flChecksum := req.flags.AddString("checksum", "")
+	flExcludes := req.flags.AddStrings("exclude")
 	if err := req.flags.Parse(); err != nil {
 		return nil, err
 	}
@@ -296,6 +299,9 @@ func parseAdd(req parseRequest) (*AddCommand, error) {
 		Chown:           flChown.Value,
 		Chmod:           flChmod.Value,
 		Link:            flLink.Value == "true",
+		KeepGitDir:      flKeepGitDir.Value == "true",
+		Checksum:        flChecksum.Value,
+		Excludes:        flExcludes.StringValues,
 	}, nil
 }
 
@@ -307,6 +313,8 @@ func parseCopy(req parseRequest) (*CopyCommand, error) {
 	flFrom := req.flags.AddString("from", "")
 	flChmod := req.flags.AddString("chmod", "")
 	flLink := req.flags.AddBool("link", false)
+	flExcludes := req.flags.AddStrings("exclude")
+	flParents := req.flags.AddBool("parents", false)
 	if err := req.flags.Parse(); err != nil {
 		return nil, err
 	}
@@ -323,6 +331,8 @@ func parseCopy(req parseRequest) (*CopyCommand, error) {
 		Chown:           flChown.Value,
 		Chmod:           flChmod.Value,
 		Link:            flLink.Value == "true",
+		Excludes:        flExcludes.StringValues,
+		Parents:         flParents.Value == "true",
 	}, nil
 }
 
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/moby/buildkit/frontend/dockerfile/command"
 	"github.com/moby/buildkit/frontend/dockerfile/parser"
@@ -239,3 +240,45 @@ func TestRunCmdFlagsUsed(t *testing.T) {
 	require.IsType(t, c, &RunCommand{})
 	require.Equal(t, []string{"mount"}, c.(*RunCommand).FlagsUsed)
 }
+
+func TestCopyExcludesAndParents(t *testing.T) {
+	dockerfile := "COPY --exclude=*.md --exclude=docs --parents ./a/*.go ./b /dest/"
+	r := strings.NewReader(dockerfile)
+	ast, err := parser.Parse(r)
+	require.NoError(t, err)
+
+	n := ast.AST.Children[0]
+	c, err := ParseInstruction(n)
+	require.NoError(t, err)
+	require.IsType(t, c, &CopyCommand{})
+	cmd := c.(*CopyCommand)
+	require.Equal(t, []string{"*.md", "docs"}, cmd.Excludes)
+	require.True(t, cmd.Parents)
+	require.Equal(t, []string{"./a/*.go", "./b"}, cmd.SourcePaths)
+}
+
+func TestRunTimeout(t *testing.T) {
+	dockerfile := "RUN --timeout=10m --valid-exit-codes=1,3 ./test.sh"
+	r := strings.NewReader(dockerfile)
+	ast, err := parser.Parse(r)
+	require.NoError(t, err)
+
+	n := ast.AST.Children[0]
+	c, err := ParseInstruction(n)
+	require.NoError(t, err)
+	require.IsType(t, c, &RunCommand{})
+	cmd := c.(*RunCommand)
+	require.Equal(t, 10*time.Minute, GetTimeout(cmd))
+	require.Equal(t, []int{1, 3}, GetValidExitCodes(cmd))
+
+	for _, dockerfile := range []string{
+		"RUN --timeout=10 ./test.sh",
+		"RUN --timeout=-1s ./test.sh",
+		"RUN --valid-exit-codes=256 ./test.sh",
+	} {
+		ast, err := parser.Parse(strings.NewReader(dockerfile))
+		require.NoError(t, err)
+		_, err = ParseInstruction(ast.AST.Children[0])
+		require.Error(t, err, dockerfile)
+	}
+}
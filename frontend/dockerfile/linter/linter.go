@@ -0,0 +1,185 @@
+package linter
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/moby/buildkit/frontend/dockerfile/instructions"
+	"github.com/moby/buildkit/frontend/dockerfile/parser"
+	"github.com/moby/buildkit/frontend/dockerfile/shell"
+)
+
+// Rule is a check of the linter.
+type Rule struct {
+	Name        string
+	Description string
+	URL         string
+	// Format is the format of the message of a warning, the arguments of
+	// Run are used as its operands.
+	Format string
+}
+
+// Run reports a warning of the rule for the given location.
+func (r Rule) Run(warn WarnFunc, location []parser.Range, args ...interface{}) {
+	warn(Warning{
+		RuleName:    r.Name,
+		Description: r.Description,
+		URL:         r.URL,
+		Message:     fmt.Sprintf(r.Format, args...),
+		Location:    location,
+	})
+}
+
+// Warning is a finding of a rule.
+type Warning struct {
+	RuleName    string
+	Description string
+	URL         string
+	Message     string
+	Location    []parser.Range
+}
+
+// WarnFunc is called for every warning found by the linter.
+type WarnFunc func(Warning)
+
+// builtinArgs are the args that can be used in FROM without declaring them.
+var builtinArgs = []string{
+	"BUILDPLATFORM", "BUILDOS", "BUILDARCH", "BUILDVARIANT",
+	"TARGETPLATFORM", "TARGETOS", "TARGETARCH", "TARGETVARIANT",
+}
+
+type stage struct {
+	name     string
+	location []parser.Range
+	used     bool
+}
+
+// Lint checks a parsed Dockerfile against all rules. Instructions that fail to
+// parse are skipped, reporting them is left to the conversion of the
+// Dockerfile.
+func Lint(res *parser.Result, warn WarnFunc) {
+	shlex := shell.NewLex(res.EscapeToken)
+
+	globalArgs := map[string]string{}
+	for _, k := range builtinArgs {
+		globalArgs[k] = ""
+	}
+
+	var stages []*stage
+	var refs []string
+	for _, n := range res.AST.Children {
+		cmd, err := instructions.ParseInstruction(n)
+		if err != nil {
+			continue
+		}
+		switch c := cmd.(type) {
+		case *instructions.Stage:
+			checkFromCasing(n, c, warn)
+			for _, v := range []string{c.BaseName, c.Platform} {
+				_, unmatched, err := shlex.ProcessWordWithUnmatched(v, globalArgs)
+				if err != nil {
+					continue
+				}
+				for _, k := range sortedKeys(unmatched) {
+					RuleUndefinedArgInFrom.Run(warn, c.Location, k)
+				}
+			}
+			stages = append(stages, &stage{name: c.Name, location: c.Location})
+			refs = append(refs, c.BaseName)
+		case *instructions.ArgCommand:
+			if len(stages) == 0 {
+				for _, arg := range c.Args {
+					globalArgs[arg.Key] = ""
+				}
+			}
+		case *instructions.MaintainerCommand:
+			RuleMaintainerDeprecated.Run(warn, c.Location())
+		case *instructions.CopyCommand:
+			if c.From != "" {
+				refs = append(refs, c.From)
+			}
+		case *instructions.RunCommand:
+			for _, m := range instructions.GetMounts(c) {
+				if m.From != "" {
+					refs = append(refs, m.From)
+				}
+			}
+		case *instructions.CmdCommand, *instructions.EntrypointCommand:
+			checkJSONArgs(n, warn)
+		}
+	}
+
+	for _, ref := range refs {
+		ref = strings.ToLower(ref)
+		for _, s := range stages {
+			if s.name != "" && s.name == ref {
+				s.used = true
+			}
+		}
+		if i, err := strconv.Atoi(ref); err == nil && i >= 0 && i < len(stages) {
+			stages[i].used = true
+		}
+	}
+	// Named stages and the last stage can be built as targets, other
+	// stages are only built when another stage depends on them.
+	for i, s := range stages {
+		if s.name == "" && !s.used && i != len(stages)-1 {
+			RuleUnusedStage.Run(warn, s.location, i)
+		}
+	}
+}
+
+// checkFromCasing reports FROM instructions where the casing of the FROM and
+// AS keywords differs.
+func checkFromCasing(n *parser.Node, st *instructions.Stage, warn WarnFunc) {
+	if st.Name == "" {
+		return
+	}
+	fields := strings.Fields(n.Original)
+	if len(fields) == 0 {
+		return
+	}
+	from := fields[0]
+	var args []string
+	for next := n.Next; next != nil; next = next.Next {
+		args = append(args, next.Value)
+	}
+	if len(args) != 3 {
+		return
+	}
+	as := args[1]
+	if (isUpper(from) && isLower(as)) || (isLower(from) && isUpper(as)) {
+		RuleFromAsCasing.Run(warn, st.Location, as, from)
+	}
+}
+
+// checkJSONArgs reports instructions whose arguments look like a JSON array
+// but failed to parse as one and are therefore run as a shell command.
+func checkJSONArgs(n *parser.Node, warn WarnFunc) {
+	if n.Attributes["json"] || n.Next == nil {
+		return
+	}
+	args := strings.TrimSpace(n.Next.Value)
+	if strings.HasPrefix(args, "[") && strings.HasSuffix(args, "]") {
+		RuleInvalidJSONArgs.Run(warn, n.Location(), strings.ToUpper(n.Value))
+	}
+}
+
+func isUpper(s string) bool {
+	return s == strings.ToUpper(s)
+}
+
+func isLower(s string) bool {
+	return s == strings.ToLower(s)
+}
+
+func sortedKeys(m map[string]struct{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
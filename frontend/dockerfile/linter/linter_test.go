@@ -0,0 +1,85 @@
+package linter
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/moby/buildkit/frontend/dockerfile/parser"
+	"github.com/stretchr/testify/require"
+)
+
+func lint(t *testing.T, dockerfile string) []Warning {
+	res, err := parser.Parse(bytes.NewBufferString(dockerfile))
+	require.NoError(t, err)
+
+	var warnings []Warning
+	Lint(res, func(w Warning) {
+		warnings = append(warnings, w)
+	})
+	return warnings
+}
+
+func TestLintClean(t *testing.T) {
+	t.Parallel()
+
+	warnings := lint(t, `
+ARG BASE=alpine
+FROM ${BASE} AS build
+RUN --mount=from=deps,target=/deps true
+
+FROM --platform=$BUILDPLATFORM busybox AS deps
+
+FROM scratch
+COPY --from=build / /
+CMD ["/bin/sh", "-c", "true"]
+ENTRYPOINT /bin/sh
+`)
+	require.Equal(t, 0, len(warnings))
+}
+
+func TestLintRules(t *testing.T) {
+	t.Parallel()
+
+	warnings := lint(t, `
+FROM ${NOTDECLARED} as build
+MAINTAINER me@example.com
+
+from alpine AS other
+
+FROM scratch
+CMD [/bin/sh, -c, true]
+`)
+	require.Equal(t, 5, len(warnings))
+
+	require.Equal(t, RuleFromAsCasing.Name, warnings[0].RuleName)
+	require.Equal(t, "'as' and 'FROM' keywords' casing do not match", warnings[0].Message)
+	require.Equal(t, 2, warnings[0].Location[0].Start.Line)
+
+	require.Equal(t, RuleUndefinedArgInFrom.Name, warnings[1].RuleName)
+	require.Equal(t, "FROM argument 'NOTDECLARED' is not declared", warnings[1].Message)
+
+	require.Equal(t, RuleMaintainerDeprecated.Name, warnings[2].RuleName)
+	require.Equal(t, 3, warnings[2].Location[0].Start.Line)
+
+	require.Equal(t, RuleFromAsCasing.Name, warnings[3].RuleName)
+	require.Equal(t, 5, warnings[3].Location[0].Start.Line)
+
+	require.Equal(t, RuleInvalidJSONArgs.Name, warnings[4].RuleName)
+	require.Equal(t, 8, warnings[4].Location[0].Start.Line)
+
+	warnings = lint(t, `
+FROM busybox
+RUN true
+
+FROM alpine AS release
+COPY --from=0 / /
+
+FROM busybox
+
+FROM scratch
+`)
+	require.Equal(t, 1, len(warnings))
+	require.Equal(t, RuleUnusedStage.Name, warnings[0].RuleName)
+	require.Equal(t, "Stage 2 is unnamed and not used by any other stage", warnings[0].Message)
+	require.Equal(t, 8, warnings[0].Location[0].Start.Line)
+}
@@ -0,0 +1,34 @@
+package linter
+
+var (
+	RuleMaintainerDeprecated = Rule{
+		Name:        "MaintainerDeprecated",
+		Description: "The MAINTAINER instruction is deprecated, use a label instead to define an image author",
+		URL:         "https://docs.docker.com/engine/reference/builder/#maintainer-deprecated",
+		Format:      "Maintainer instruction is deprecated in favor of using label",
+	}
+	RuleFromAsCasing = Rule{
+		Name:        "FromAsCasing",
+		Description: "The 'as' keyword should match the case of the 'from' keyword",
+		URL:         "https://docs.docker.com/engine/reference/builder/#from",
+		Format:      "'%s' and '%s' keywords' casing do not match",
+	}
+	RuleUndefinedArgInFrom = Rule{
+		Name:        "UndefinedArgInFrom",
+		Description: "FROM command must use declared ARGs",
+		URL:         "https://docs.docker.com/engine/reference/builder/#understand-how-arg-and-from-interact",
+		Format:      "FROM argument '%s' is not declared",
+	}
+	RuleInvalidJSONArgs = Rule{
+		Name:        "InvalidJSONArgs",
+		Description: "Arguments in JSON form must be a valid JSON array of double-quoted strings, otherwise they are run as a shell command",
+		URL:         "https://docs.docker.com/engine/reference/builder/#cmd",
+		Format:      "%s arguments look like a JSON array but are not valid JSON and are run as a shell command",
+	}
+	RuleUnusedStage = Rule{
+		Name:        "UnusedStage",
+		Description: "Unnamed stages that are not the last stage are only built when another stage depends on them",
+		URL:         "https://docs.docker.com/develop/develop-images/multistage-build/",
+		Format:      "Stage %d is unnamed and not used by any other stage",
+	}
+)
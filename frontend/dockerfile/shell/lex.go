@@ -64,6 +64,16 @@ func (s *Lex) ProcessWordWithMatches(word string, env map[string]string) (string
 	return word, sw.matches, err
 }
 
+// ProcessWordWithUnmatched will use the 'env' list of environment variables,
+// replace any env var references in 'word' and return the env references
+// that were not set. References with a default or alternative value, like
+// ${xx:-default}, are not returned.
+func (s *Lex) ProcessWordWithUnmatched(word string, env map[string]string) (string, map[string]struct{}, error) {
+	sw := s.init(word, env)
+	word, _, err := sw.process(word)
+	return word, sw.unmatched, err
+}
+
 func (s *Lex) ProcessWordsWithMap(word string, env map[string]string) ([]string, error) {
 	_, words, err := s.process(word, env)
 	return words, err
@@ -78,6 +88,7 @@ func (s *Lex) init(word string, env map[string]string) *shellWord {
 		rawQuotes:         s.RawQuotes,
 		rawEscapes:        s.RawEscapes,
 		matches:           make(map[string]struct{}),
+		unmatched:         make(map[string]struct{}),
 	}
 	sw.scanner.Init(strings.NewReader(word))
 	return sw
@@ -97,6 +108,7 @@ type shellWord struct {
 	skipUnsetEnv      bool
 	skipProcessQuotes bool
 	matches           map[string]struct{}
+	unmatched         map[string]struct{}
 }
 
 func (sw *shellWord) process(source string) (string, []string, error) {
@@ -319,6 +331,9 @@ func (sw *shellWord) processDollar() (string, error) {
 			return "$", nil
 		}
 		value, found := sw.getEnv(name)
+		if !found {
+			sw.unmatched[name] = struct{}{}
+		}
 		if !found && sw.skipUnsetEnv {
 			return "$" + name, nil
 		}
@@ -339,6 +354,9 @@ func (sw *shellWord) processDollar() (string, error) {
 	case '}':
 		// Normal ${xx} case
 		value, found := sw.getEnv(name)
+		if !found {
+			sw.unmatched[name] = struct{}{}
+		}
 		if !found && sw.skipUnsetEnv {
 			return fmt.Sprintf("${%s}", name), nil
 		}
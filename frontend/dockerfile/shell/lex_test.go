@@ -248,3 +248,17 @@ func TestProcessWithMatches(t *testing.T) {
 
 	require.Equal(t, 0, len(matches))
 }
+
+func TestProcessWithUnmatched(t *testing.T) {
+	shlex := NewLex('\\')
+
+	w, unmatched, err := shlex.ProcessWordWithUnmatched("foo ${BAR} $UNUSED ${OTHER:-abc}", map[string]string{
+		"BAR": "baz",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "foo baz  abc", w)
+
+	require.Equal(t, 1, len(unmatched))
+	_, ok := unmatched["UNUSED"]
+	require.True(t, ok)
+}
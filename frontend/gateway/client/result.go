@@ -4,6 +4,7 @@ import (
 	"context"
 	"sync"
 
+	attestationtypes "github.com/moby/buildkit/exporter/attestation/types"
 	"github.com/pkg/errors"
 )
 
@@ -14,6 +15,21 @@ type Result struct {
 	Ref      Reference
 	Refs     map[string]Reference
 	Metadata map[string][]byte
+
+	// Attestations are keyed by the same keys as Refs. Attestations for a
+	// single Ref result use an empty key.
+	Attestations map[string][]Attestation
+}
+
+// Attestation is an attestation that is attached to the exported image of a
+// result.
+type Attestation struct {
+	Kind attestationtypes.Kind
+	// Ref contains the content of the attestation at Path.
+	Ref  Reference
+	Path string
+
+	InToto attestationtypes.InToto
 }
 
 func NewResult() *Result {
@@ -38,6 +54,15 @@ func (r *Result) AddRef(k string, ref Reference) {
 	r.mu.Unlock()
 }
 
+func (r *Result) AddAttestation(k string, v Attestation) {
+	r.mu.Lock()
+	if r.Attestations == nil {
+		r.Attestations = map[string][]Attestation{}
+	}
+	r.Attestations[k] = append(r.Attestations[k], v)
+	r.mu.Unlock()
+}
+
 func (r *Result) SetRef(ref Reference) {
 	r.Ref = ref
 }
@@ -82,6 +82,24 @@ func (c *bridgeClient) Solve(ctx context.Context, req client.SolveRequest) (*cli
 		c.refs = append(c.refs, rr)
 		cRes.SetRef(rr)
 	}
+	for k, as := range res.Attestations {
+		for _, a := range as {
+			att := client.Attestation{
+				Kind:   a.Kind,
+				Path:   a.Path,
+				InToto: a.InToto,
+			}
+			if a.Ref != nil {
+				rr, err := c.newRef(a.Ref, session.NewGroup(c.sid))
+				if err != nil {
+					return nil, err
+				}
+				c.refs = append(c.refs, rr)
+				att.Ref = rr
+			}
+			cRes.AddAttestation(k, att)
+		}
+	}
 	c.mu.Unlock()
 	cRes.Metadata = res.Metadata
 
@@ -198,6 +216,24 @@ func (c *bridgeClient) toFrontendResult(r *client.Result) (*frontend.Result, err
 		c.final[rr] = struct{}{}
 		res.Ref = rr.ResultProxy
 	}
+	for k, as := range r.Attestations {
+		for _, a := range as {
+			att := frontend.Attestation{
+				Kind:   a.Kind,
+				Path:   a.Path,
+				InToto: a.InToto,
+			}
+			if a.Ref != nil {
+				rr, ok := a.Ref.(*ref)
+				if !ok {
+					return nil, errors.Errorf("invalid reference type for forward %T", a.Ref)
+				}
+				c.final[rr] = struct{}{}
+				att.Ref = rr.ResultProxy
+			}
+			res.AddAttestation(k, att)
+		}
+	}
 	res.Metadata = r.Metadata
 
 	return res, nil
@@ -26,6 +26,7 @@ import (
 	"github.com/moby/buildkit/client"
 	"github.com/moby/buildkit/client/llb"
 	"github.com/moby/buildkit/executor"
+	attestationtypes "github.com/moby/buildkit/exporter/attestation/types"
 	"github.com/moby/buildkit/exporter/containerimage/exptypes"
 	"github.com/moby/buildkit/frontend"
 	gwclient "github.com/moby/buildkit/frontend/gateway/client"
@@ -701,6 +702,24 @@ func (lbf *llbBridgeForwarder) Solve(ctx context.Context, req *pb.SolveRequest)
 			pbRes.Result = &pb.Result_RefDeprecated{RefDeprecated: id}
 		}
 	}
+	if req.AllowResultArrayRef {
+		for k, as := range res.Attestations {
+			pbAtts := &pb.Attestations{}
+			for _, a := range as {
+				pbRef := &pb.Ref{}
+				if a.Ref != nil {
+					pbRef.Id = identity.NewID()
+					pbRef.Def = a.Ref.Definition()
+					lbf.refs[pbRef.Id] = a.Ref
+				}
+				pbAtts.Attestation = append(pbAtts.Attestation, pb.NewAttestation(a.Kind, pbRef, a.Path, a.InToto))
+			}
+			if pbRes.Attestations == nil {
+				pbRes.Attestations = map[string]*pb.Attestations{}
+			}
+			pbRes.Attestations[k] = pbAtts
+		}
+	}
 	lbf.mu.Unlock()
 
 	// compatibility mode for older clients
@@ -895,6 +914,24 @@ func (lbf *llbBridgeForwarder) Return(ctx context.Context, in *pb.ReturnRequest)
 		}
 		r.Refs = m
 	}
+	for k, as := range in.Result.Attestations {
+		for _, a := range as.Attestation {
+			var id string
+			if a.Ref != nil {
+				id = a.Ref.Id
+			}
+			ref, err := lbf.convertRef(id)
+			if err != nil {
+				return nil, err
+			}
+			r.AddAttestation(k, frontend.Attestation{
+				Kind:   attestationtypes.Kind(a.Kind),
+				Ref:    ref,
+				Path:   a.Path,
+				InToto: a.InToto(),
+			})
+		}
+	}
 	return lbf.setResult(r, nil)
 }
 
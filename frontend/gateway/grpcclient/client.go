@@ -16,6 +16,7 @@ import (
 	gogotypes "github.com/gogo/protobuf/types"
 	"github.com/golang/protobuf/ptypes/any"
 	"github.com/moby/buildkit/client/llb"
+	attestationtypes "github.com/moby/buildkit/exporter/attestation/types"
 	"github.com/moby/buildkit/frontend/gateway/client"
 	pb "github.com/moby/buildkit/frontend/gateway/pb"
 	"github.com/moby/buildkit/identity"
@@ -160,6 +161,25 @@ func (c *grpcClient) Run(ctx context.Context, f client.BuildFunc) (retError erro
 						}
 					}
 				}
+				if len(res.Attestations) > 0 && retError == nil {
+					if err := c.caps.Supports(pb.CapAttestations); err != nil {
+						retError = err
+					} else {
+						pbRes.Attestations = map[string]*pb.Attestations{}
+						for k, as := range res.Attestations {
+							pbAtts := &pb.Attestations{}
+							for _, a := range as {
+								pbRef, err := convertRef(a.Ref)
+								if err != nil {
+									retError = err
+									continue
+								}
+								pbAtts.Attestation = append(pbAtts.Attestation, pb.NewAttestation(a.Kind, pbRef, a.Path, a.InToto))
+							}
+							pbRes.Attestations[k] = pbAtts
+						}
+					}
+				}
 				if retError == nil {
 					req.Result = pbRes
 				}
@@ -443,6 +463,23 @@ func (c *grpcClient) Solve(ctx context.Context, creq client.SolveRequest) (res *
 				res.AddRef(k, ref)
 			}
 		}
+		for k, as := range resp.Result.Attestations {
+			for _, a := range as.Attestation {
+				att := client.Attestation{
+					Kind:   attestationtypes.Kind(a.Kind),
+					Path:   a.Path,
+					InToto: a.InToto(),
+				}
+				if a.Ref != nil && a.Ref.Id != "" {
+					ref, err := newReference(c, a.Ref)
+					if err != nil {
+						return nil, err
+					}
+					att.Ref = ref
+				}
+				res.AddAttestation(k, att)
+			}
+		}
 	}
 
 	return res, nil
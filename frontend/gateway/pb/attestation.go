@@ -0,0 +1,39 @@
+package moby_buildkit_v1_frontend //nolint:revive
+
+import (
+	attestationtypes "github.com/moby/buildkit/exporter/attestation/types"
+)
+
+// NewAttestation returns the wire representation of an attestation whose
+// content is stored in ref.
+func NewAttestation(kind attestationtypes.Kind, ref *Ref, path string, intoto attestationtypes.InToto) *Attestation {
+	a := &Attestation{
+		Kind:                AttestationKind(kind),
+		Ref:                 ref,
+		Path:                path,
+		InTotoPredicateType: intoto.PredicateType,
+	}
+	for _, s := range intoto.Subjects {
+		a.InTotoSubjects = append(a.InTotoSubjects, &InTotoSubject{
+			Kind:   InTotoSubjectKind(s.Kind),
+			Name:   s.Name,
+			Digest: s.Digest,
+		})
+	}
+	return a
+}
+
+// InToto returns the in-toto properties of the attestation.
+func (a *Attestation) InToto() attestationtypes.InToto {
+	intoto := attestationtypes.InToto{
+		PredicateType: a.InTotoPredicateType,
+	}
+	for _, s := range a.InTotoSubjects {
+		intoto.Subjects = append(intoto.Subjects, attestationtypes.InTotoSubject{
+			Kind:   attestationtypes.SubjectKind(s.Kind),
+			Name:   s.Name,
+			Digest: s.Digest,
+		})
+	}
+	return intoto
+}
@@ -58,6 +58,10 @@ const (
 
 	// CapGatewayWarnings is the capability to log warnings from frontend
 	CapGatewayWarnings apicaps.CapID = "gateway.warnings"
+
+	// CapAttestations is the capability to return attestations with the
+	// result
+	CapAttestations apicaps.CapID = "attestations"
 )
 
 func init() {
@@ -200,4 +204,11 @@ func init() {
 		Enabled: true,
 		Status:  apicaps.CapStatusExperimental,
 	})
+
+	Caps.Init(apicaps.Cap{
+		ID:      CapAttestations,
+		Name:    "attestations",
+		Enabled: true,
+		Status:  apicaps.CapStatusExperimental,
+	})
 }
@@ -33,17 +33,70 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
 
+type AttestationKind int32
+
+const (
+	AttestationKind_InToto AttestationKind = 0
+	AttestationKind_Bundle AttestationKind = 1
+)
+
+var AttestationKind_name = map[int32]string{
+	0: "InToto",
+	1: "Bundle",
+}
+
+var AttestationKind_value = map[string]int32{
+	"InToto": 0,
+	"Bundle": 1,
+}
+
+func (x AttestationKind) String() string {
+	return proto.EnumName(AttestationKind_name, int32(x))
+}
+
+func (AttestationKind) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_f1a937782ebbded5, []int{0}
+}
+
+type InTotoSubjectKind int32
+
+const (
+	InTotoSubjectKind_Self InTotoSubjectKind = 0
+	InTotoSubjectKind_Raw  InTotoSubjectKind = 1
+)
+
+var InTotoSubjectKind_name = map[int32]string{
+	0: "Self",
+	1: "Raw",
+}
+
+var InTotoSubjectKind_value = map[string]int32{
+	"Self": 0,
+	"Raw":  1,
+}
+
+func (x InTotoSubjectKind) String() string {
+	return proto.EnumName(InTotoSubjectKind_name, int32(x))
+}
+
+func (InTotoSubjectKind) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_f1a937782ebbded5, []int{1}
+}
+
 type Result struct {
 	// Types that are valid to be assigned to Result:
 	//	*Result_RefDeprecated
 	//	*Result_RefsDeprecated
 	//	*Result_Ref
 	//	*Result_Refs
-	Result               isResult_Result   `protobuf_oneof:"result"`
-	Metadata             map[string][]byte `protobuf:"bytes,10,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
-	XXX_unrecognized     []byte            `json:"-"`
-	XXX_sizecache        int32             `json:"-"`
+	Result   isResult_Result   `protobuf_oneof:"result"`
+	Metadata map[string][]byte `protobuf:"bytes,10,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// attestations are keyed by the same keys as refs. Attestations for a
+	// single ref result use an empty key.
+	Attestations         map[string]*Attestations `protobuf:"bytes,11,rep,name=attestations,proto3" json:"attestations,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}                 `json:"-"`
+	XXX_unrecognized     []byte                   `json:"-"`
+	XXX_sizecache        int32                    `json:"-"`
 }
 
 func (m *Result) Reset()         { *m = Result{} }
@@ -145,6 +198,13 @@ func (m *Result) GetMetadata() map[string][]byte {
 	return nil
 }
 
+func (m *Result) GetAttestations() map[string]*Attestations {
+	if m != nil {
+		return m.Attestations
+	}
+	return nil
+}
+
 // XXX_OneofWrappers is for the internal use of the proto package.
 func (*Result) XXX_OneofWrappers() []interface{} {
 	return []interface{}{
@@ -304,6 +364,188 @@ func (m *RefMap) GetRefs() map[string]*Ref {
 	return nil
 }
 
+type Attestations struct {
+	Attestation          []*Attestation `protobuf:"bytes,1,rep,name=attestation,proto3" json:"attestation,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *Attestations) Reset()         { *m = Attestations{} }
+func (m *Attestations) String() string { return proto.CompactTextString(m) }
+func (*Attestations) ProtoMessage()    {}
+func (*Attestations) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f1a937782ebbded5, []int{4}
+}
+func (m *Attestations) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Attestations) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Attestations.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Attestations) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Attestations.Merge(m, src)
+}
+func (m *Attestations) XXX_Size() int {
+	return m.Size()
+}
+func (m *Attestations) XXX_DiscardUnknown() {
+	xxx_messageInfo_Attestations.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Attestations proto.InternalMessageInfo
+
+func (m *Attestations) GetAttestation() []*Attestation {
+	if m != nil {
+		return m.Attestation
+	}
+	return nil
+}
+
+type Attestation struct {
+	Kind                 AttestationKind  `protobuf:"varint,1,opt,name=kind,proto3,enum=moby.buildkit.v1.frontend.AttestationKind" json:"kind,omitempty"`
+	Ref                  *Ref             `protobuf:"bytes,2,opt,name=ref,proto3" json:"ref,omitempty"`
+	Path                 string           `protobuf:"bytes,3,opt,name=path,proto3" json:"path,omitempty"`
+	InTotoPredicateType  string           `protobuf:"bytes,4,opt,name=inTotoPredicateType,proto3" json:"inTotoPredicateType,omitempty"`
+	InTotoSubjects       []*InTotoSubject `protobuf:"bytes,5,rep,name=inTotoSubjects,proto3" json:"inTotoSubjects,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *Attestation) Reset()         { *m = Attestation{} }
+func (m *Attestation) String() string { return proto.CompactTextString(m) }
+func (*Attestation) ProtoMessage()    {}
+func (*Attestation) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f1a937782ebbded5, []int{5}
+}
+func (m *Attestation) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Attestation) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Attestation.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Attestation) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Attestation.Merge(m, src)
+}
+func (m *Attestation) XXX_Size() int {
+	return m.Size()
+}
+func (m *Attestation) XXX_DiscardUnknown() {
+	xxx_messageInfo_Attestation.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Attestation proto.InternalMessageInfo
+
+func (m *Attestation) GetKind() AttestationKind {
+	if m != nil {
+		return m.Kind
+	}
+	return AttestationKind_InToto
+}
+
+func (m *Attestation) GetRef() *Ref {
+	if m != nil {
+		return m.Ref
+	}
+	return nil
+}
+
+func (m *Attestation) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *Attestation) GetInTotoPredicateType() string {
+	if m != nil {
+		return m.InTotoPredicateType
+	}
+	return ""
+}
+
+func (m *Attestation) GetInTotoSubjects() []*InTotoSubject {
+	if m != nil {
+		return m.InTotoSubjects
+	}
+	return nil
+}
+
+type InTotoSubject struct {
+	Kind                 InTotoSubjectKind                            `protobuf:"varint,1,opt,name=kind,proto3,enum=moby.buildkit.v1.frontend.InTotoSubjectKind" json:"kind,omitempty"`
+	Name                 string                                       `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Digest               []github_com_opencontainers_go_digest.Digest `protobuf:"bytes,3,rep,name=digest,proto3,customtype=github.com/opencontainers/go-digest.Digest" json:"digest"`
+	XXX_NoUnkeyedLiteral struct{}                                     `json:"-"`
+	XXX_unrecognized     []byte                                       `json:"-"`
+	XXX_sizecache        int32                                        `json:"-"`
+}
+
+func (m *InTotoSubject) Reset()         { *m = InTotoSubject{} }
+func (m *InTotoSubject) String() string { return proto.CompactTextString(m) }
+func (*InTotoSubject) ProtoMessage()    {}
+func (*InTotoSubject) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f1a937782ebbded5, []int{6}
+}
+func (m *InTotoSubject) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *InTotoSubject) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_InTotoSubject.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *InTotoSubject) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_InTotoSubject.Merge(m, src)
+}
+func (m *InTotoSubject) XXX_Size() int {
+	return m.Size()
+}
+func (m *InTotoSubject) XXX_DiscardUnknown() {
+	xxx_messageInfo_InTotoSubject.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_InTotoSubject proto.InternalMessageInfo
+
+func (m *InTotoSubject) GetKind() InTotoSubjectKind {
+	if m != nil {
+		return m.Kind
+	}
+	return InTotoSubjectKind_Self
+}
+
+func (m *InTotoSubject) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
 type ReturnRequest struct {
 	Result               *Result     `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
 	Error                *rpc.Status `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
@@ -316,7 +558,7 @@ func (m *ReturnRequest) Reset()         { *m = ReturnRequest{} }
 func (m *ReturnRequest) String() string { return proto.CompactTextString(m) }
 func (*ReturnRequest) ProtoMessage()    {}
 func (*ReturnRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f1a937782ebbded5, []int{4}
+	return fileDescriptor_f1a937782ebbded5, []int{7}
 }
 func (m *ReturnRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -369,7 +611,7 @@ func (m *ReturnResponse) Reset()         { *m = ReturnResponse{} }
 func (m *ReturnResponse) String() string { return proto.CompactTextString(m) }
 func (*ReturnResponse) ProtoMessage()    {}
 func (*ReturnResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f1a937782ebbded5, []int{5}
+	return fileDescriptor_f1a937782ebbded5, []int{8}
 }
 func (m *ReturnResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -408,7 +650,7 @@ func (m *InputsRequest) Reset()         { *m = InputsRequest{} }
 func (m *InputsRequest) String() string { return proto.CompactTextString(m) }
 func (*InputsRequest) ProtoMessage()    {}
 func (*InputsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f1a937782ebbded5, []int{6}
+	return fileDescriptor_f1a937782ebbded5, []int{9}
 }
 func (m *InputsRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -448,7 +690,7 @@ func (m *InputsResponse) Reset()         { *m = InputsResponse{} }
 func (m *InputsResponse) String() string { return proto.CompactTextString(m) }
 func (*InputsResponse) ProtoMessage()    {}
 func (*InputsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f1a937782ebbded5, []int{7}
+	return fileDescriptor_f1a937782ebbded5, []int{10}
 }
 func (m *InputsResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -498,7 +740,7 @@ func (m *ResolveImageConfigRequest) Reset()         { *m = ResolveImageConfigReq
 func (m *ResolveImageConfigRequest) String() string { return proto.CompactTextString(m) }
 func (*ResolveImageConfigRequest) ProtoMessage()    {}
 func (*ResolveImageConfigRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f1a937782ebbded5, []int{8}
+	return fileDescriptor_f1a937782ebbded5, []int{11}
 }
 func (m *ResolveImageConfigRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -567,7 +809,7 @@ func (m *ResolveImageConfigResponse) Reset()         { *m = ResolveImageConfigRe
 func (m *ResolveImageConfigResponse) String() string { return proto.CompactTextString(m) }
 func (*ResolveImageConfigResponse) ProtoMessage()    {}
 func (*ResolveImageConfigResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f1a937782ebbded5, []int{9}
+	return fileDescriptor_f1a937782ebbded5, []int{12}
 }
 func (m *ResolveImageConfigResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -628,7 +870,7 @@ func (m *SolveRequest) Reset()         { *m = SolveRequest{} }
 func (m *SolveRequest) String() string { return proto.CompactTextString(m) }
 func (*SolveRequest) ProtoMessage()    {}
 func (*SolveRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f1a937782ebbded5, []int{10}
+	return fileDescriptor_f1a937782ebbded5, []int{13}
 }
 func (m *SolveRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -740,7 +982,7 @@ func (m *CacheOptionsEntry) Reset()         { *m = CacheOptionsEntry{} }
 func (m *CacheOptionsEntry) String() string { return proto.CompactTextString(m) }
 func (*CacheOptionsEntry) ProtoMessage()    {}
 func (*CacheOptionsEntry) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f1a937782ebbded5, []int{11}
+	return fileDescriptor_f1a937782ebbded5, []int{14}
 }
 func (m *CacheOptionsEntry) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -797,7 +1039,7 @@ func (m *SolveResponse) Reset()         { *m = SolveResponse{} }
 func (m *SolveResponse) String() string { return proto.CompactTextString(m) }
 func (*SolveResponse) ProtoMessage()    {}
 func (*SolveResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f1a937782ebbded5, []int{12}
+	return fileDescriptor_f1a937782ebbded5, []int{15}
 }
 func (m *SolveResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -853,7 +1095,7 @@ func (m *ReadFileRequest) Reset()         { *m = ReadFileRequest{} }
 func (m *ReadFileRequest) String() string { return proto.CompactTextString(m) }
 func (*ReadFileRequest) ProtoMessage()    {}
 func (*ReadFileRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f1a937782ebbded5, []int{13}
+	return fileDescriptor_f1a937782ebbded5, []int{16}
 }
 func (m *ReadFileRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -915,7 +1157,7 @@ func (m *FileRange) Reset()         { *m = FileRange{} }
 func (m *FileRange) String() string { return proto.CompactTextString(m) }
 func (*FileRange) ProtoMessage()    {}
 func (*FileRange) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f1a937782ebbded5, []int{14}
+	return fileDescriptor_f1a937782ebbded5, []int{17}
 }
 func (m *FileRange) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -969,7 +1211,7 @@ func (m *ReadFileResponse) Reset()         { *m = ReadFileResponse{} }
 func (m *ReadFileResponse) String() string { return proto.CompactTextString(m) }
 func (*ReadFileResponse) ProtoMessage()    {}
 func (*ReadFileResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f1a937782ebbded5, []int{15}
+	return fileDescriptor_f1a937782ebbded5, []int{18}
 }
 func (m *ReadFileResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1018,7 +1260,7 @@ func (m *ReadDirRequest) Reset()         { *m = ReadDirRequest{} }
 func (m *ReadDirRequest) String() string { return proto.CompactTextString(m) }
 func (*ReadDirRequest) ProtoMessage()    {}
 func (*ReadDirRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f1a937782ebbded5, []int{16}
+	return fileDescriptor_f1a937782ebbded5, []int{19}
 }
 func (m *ReadDirRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1079,7 +1321,7 @@ func (m *ReadDirResponse) Reset()         { *m = ReadDirResponse{} }
 func (m *ReadDirResponse) String() string { return proto.CompactTextString(m) }
 func (*ReadDirResponse) ProtoMessage()    {}
 func (*ReadDirResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f1a937782ebbded5, []int{17}
+	return fileDescriptor_f1a937782ebbded5, []int{20}
 }
 func (m *ReadDirResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1127,7 +1369,7 @@ func (m *StatFileRequest) Reset()         { *m = StatFileRequest{} }
 func (m *StatFileRequest) String() string { return proto.CompactTextString(m) }
 func (*StatFileRequest) ProtoMessage()    {}
 func (*StatFileRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f1a937782ebbded5, []int{18}
+	return fileDescriptor_f1a937782ebbded5, []int{21}
 }
 func (m *StatFileRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1181,7 +1423,7 @@ func (m *StatFileResponse) Reset()         { *m = StatFileResponse{} }
 func (m *StatFileResponse) String() string { return proto.CompactTextString(m) }
 func (*StatFileResponse) ProtoMessage()    {}
 func (*StatFileResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f1a937782ebbded5, []int{19}
+	return fileDescriptor_f1a937782ebbded5, []int{22}
 }
 func (m *StatFileResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1227,7 +1469,7 @@ func (m *PingRequest) Reset()         { *m = PingRequest{} }
 func (m *PingRequest) String() string { return proto.CompactTextString(m) }
 func (*PingRequest) ProtoMessage()    {}
 func (*PingRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f1a937782ebbded5, []int{20}
+	return fileDescriptor_f1a937782ebbded5, []int{23}
 }
 func (m *PingRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1269,7 +1511,7 @@ func (m *PongResponse) Reset()         { *m = PongResponse{} }
 func (m *PongResponse) String() string { return proto.CompactTextString(m) }
 func (*PongResponse) ProtoMessage()    {}
 func (*PongResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f1a937782ebbded5, []int{21}
+	return fileDescriptor_f1a937782ebbded5, []int{24}
 }
 func (m *PongResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1336,7 +1578,7 @@ func (m *WarnRequest) Reset()         { *m = WarnRequest{} }
 func (m *WarnRequest) String() string { return proto.CompactTextString(m) }
 func (*WarnRequest) ProtoMessage()    {}
 func (*WarnRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f1a937782ebbded5, []int{22}
+	return fileDescriptor_f1a937782ebbded5, []int{25}
 }
 func (m *WarnRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1417,7 +1659,7 @@ func (m *WarnResponse) Reset()         { *m = WarnResponse{} }
 func (m *WarnResponse) String() string { return proto.CompactTextString(m) }
 func (*WarnResponse) ProtoMessage()    {}
 func (*WarnResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f1a937782ebbded5, []int{23}
+	return fileDescriptor_f1a937782ebbded5, []int{26}
 }
 func (m *WarnResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1463,7 +1705,7 @@ func (m *NewContainerRequest) Reset()         { *m = NewContainerRequest{} }
 func (m *NewContainerRequest) String() string { return proto.CompactTextString(m) }
 func (*NewContainerRequest) ProtoMessage()    {}
 func (*NewContainerRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f1a937782ebbded5, []int{24}
+	return fileDescriptor_f1a937782ebbded5, []int{27}
 }
 func (m *NewContainerRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1544,7 +1786,7 @@ func (m *NewContainerResponse) Reset()         { *m = NewContainerResponse{} }
 func (m *NewContainerResponse) String() string { return proto.CompactTextString(m) }
 func (*NewContainerResponse) ProtoMessage()    {}
 func (*NewContainerResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f1a937782ebbded5, []int{25}
+	return fileDescriptor_f1a937782ebbded5, []int{28}
 }
 func (m *NewContainerResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1584,7 +1826,7 @@ func (m *ReleaseContainerRequest) Reset()         { *m = ReleaseContainerRequest
 func (m *ReleaseContainerRequest) String() string { return proto.CompactTextString(m) }
 func (*ReleaseContainerRequest) ProtoMessage()    {}
 func (*ReleaseContainerRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f1a937782ebbded5, []int{26}
+	return fileDescriptor_f1a937782ebbded5, []int{29}
 }
 func (m *ReleaseContainerRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1630,7 +1872,7 @@ func (m *ReleaseContainerResponse) Reset()         { *m = ReleaseContainerRespon
 func (m *ReleaseContainerResponse) String() string { return proto.CompactTextString(m) }
 func (*ReleaseContainerResponse) ProtoMessage()    {}
 func (*ReleaseContainerResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f1a937782ebbded5, []int{27}
+	return fileDescriptor_f1a937782ebbded5, []int{30}
 }
 func (m *ReleaseContainerResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1679,7 +1921,7 @@ func (m *ExecMessage) Reset()         { *m = ExecMessage{} }
 func (m *ExecMessage) String() string { return proto.CompactTextString(m) }
 func (*ExecMessage) ProtoMessage()    {}
 func (*ExecMessage) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f1a937782ebbded5, []int{28}
+	return fileDescriptor_f1a937782ebbded5, []int{31}
 }
 func (m *ExecMessage) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1835,7 +2077,7 @@ func (m *InitMessage) Reset()         { *m = InitMessage{} }
 func (m *InitMessage) String() string { return proto.CompactTextString(m) }
 func (*InitMessage) ProtoMessage()    {}
 func (*InitMessage) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f1a937782ebbded5, []int{29}
+	return fileDescriptor_f1a937782ebbded5, []int{32}
 }
 func (m *InitMessage) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1911,7 +2153,7 @@ func (m *ExitMessage) Reset()         { *m = ExitMessage{} }
 func (m *ExitMessage) String() string { return proto.CompactTextString(m) }
 func (*ExitMessage) ProtoMessage()    {}
 func (*ExitMessage) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f1a937782ebbded5, []int{30}
+	return fileDescriptor_f1a937782ebbded5, []int{33}
 }
 func (m *ExitMessage) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1964,7 +2206,7 @@ func (m *StartedMessage) Reset()         { *m = StartedMessage{} }
 func (m *StartedMessage) String() string { return proto.CompactTextString(m) }
 func (*StartedMessage) ProtoMessage()    {}
 func (*StartedMessage) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f1a937782ebbded5, []int{31}
+	return fileDescriptor_f1a937782ebbded5, []int{34}
 }
 func (m *StartedMessage) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2003,7 +2245,7 @@ func (m *DoneMessage) Reset()         { *m = DoneMessage{} }
 func (m *DoneMessage) String() string { return proto.CompactTextString(m) }
 func (*DoneMessage) ProtoMessage()    {}
 func (*DoneMessage) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f1a937782ebbded5, []int{32}
+	return fileDescriptor_f1a937782ebbded5, []int{35}
 }
 func (m *DoneMessage) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2045,7 +2287,7 @@ func (m *FdMessage) Reset()         { *m = FdMessage{} }
 func (m *FdMessage) String() string { return proto.CompactTextString(m) }
 func (*FdMessage) ProtoMessage()    {}
 func (*FdMessage) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f1a937782ebbded5, []int{33}
+	return fileDescriptor_f1a937782ebbded5, []int{36}
 }
 func (m *FdMessage) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2107,7 +2349,7 @@ func (m *ResizeMessage) Reset()         { *m = ResizeMessage{} }
 func (m *ResizeMessage) String() string { return proto.CompactTextString(m) }
 func (*ResizeMessage) ProtoMessage()    {}
 func (*ResizeMessage) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f1a937782ebbded5, []int{34}
+	return fileDescriptor_f1a937782ebbded5, []int{37}
 }
 func (m *ResizeMessage) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2163,7 +2405,7 @@ func (m *SignalMessage) Reset()         { *m = SignalMessage{} }
 func (m *SignalMessage) String() string { return proto.CompactTextString(m) }
 func (*SignalMessage) ProtoMessage()    {}
 func (*SignalMessage) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f1a937782ebbded5, []int{35}
+	return fileDescriptor_f1a937782ebbded5, []int{38}
 }
 func (m *SignalMessage) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2200,13 +2442,19 @@ func (m *SignalMessage) GetName() string {
 }
 
 func init() {
+	proto.RegisterEnum("moby.buildkit.v1.frontend.AttestationKind", AttestationKind_name, AttestationKind_value)
+	proto.RegisterEnum("moby.buildkit.v1.frontend.InTotoSubjectKind", InTotoSubjectKind_name, InTotoSubjectKind_value)
 	proto.RegisterType((*Result)(nil), "moby.buildkit.v1.frontend.Result")
+	proto.RegisterMapType((map[string]*Attestations)(nil), "moby.buildkit.v1.frontend.Result.AttestationsEntry")
 	proto.RegisterMapType((map[string][]byte)(nil), "moby.buildkit.v1.frontend.Result.MetadataEntry")
 	proto.RegisterType((*RefMapDeprecated)(nil), "moby.buildkit.v1.frontend.RefMapDeprecated")
 	proto.RegisterMapType((map[string]string)(nil), "moby.buildkit.v1.frontend.RefMapDeprecated.RefsEntry")
 	proto.RegisterType((*Ref)(nil), "moby.buildkit.v1.frontend.Ref")
 	proto.RegisterType((*RefMap)(nil), "moby.buildkit.v1.frontend.RefMap")
 	proto.RegisterMapType((map[string]*Ref)(nil), "moby.buildkit.v1.frontend.RefMap.RefsEntry")
+	proto.RegisterType((*Attestations)(nil), "moby.buildkit.v1.frontend.Attestations")
+	proto.RegisterType((*Attestation)(nil), "moby.buildkit.v1.frontend.Attestation")
+	proto.RegisterType((*InTotoSubject)(nil), "moby.buildkit.v1.frontend.InTotoSubject")
 	proto.RegisterType((*ReturnRequest)(nil), "moby.buildkit.v1.frontend.ReturnRequest")
 	proto.RegisterType((*ReturnResponse)(nil), "moby.buildkit.v1.frontend.ReturnResponse")
 	proto.RegisterType((*InputsRequest)(nil), "moby.buildkit.v1.frontend.InputsRequest")
@@ -2248,136 +2496,150 @@ func init() {
 func init() { proto.RegisterFile("gateway.proto", fileDescriptor_f1a937782ebbded5) }
 
 var fileDescriptor_f1a937782ebbded5 = []byte{
-	// 2060 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x58, 0xcd, 0x6f, 0xe3, 0xc6,
-	0x15, 0x37, 0x2d, 0xd9, 0x92, 0x9e, 0x3e, 0xd6, 0x99, 0xa4, 0x29, 0x43, 0x04, 0x1b, 0x87, 0x4d,
-	0x5d, 0xed, 0xc6, 0xa1, 0x52, 0x6f, 0x02, 0x6f, 0xbd, 0x45, 0xd2, 0xf5, 0x17, 0xec, 0xc4, 0xf6,
-	0xba, 0xe3, 0x14, 0x0b, 0x04, 0x29, 0x50, 0x5a, 0x1c, 0x69, 0x89, 0xa5, 0x39, 0xec, 0x70, 0xb4,
-	0x5e, 0x27, 0x97, 0xf6, 0xd6, 0x63, 0x81, 0x02, 0xbd, 0x16, 0xe8, 0x5f, 0xd0, 0x53, 0x8f, 0x3d,
-	0xe7, 0xd8, 0x63, 0xd1, 0x43, 0x50, 0xec, 0x1f, 0x51, 0xa0, 0xb7, 0xe2, 0xcd, 0x0c, 0x25, 0x4a,
-	0x96, 0x29, 0x0b, 0x39, 0x69, 0xe6, 0xf1, 0xfd, 0xde, 0xbc, 0xaf, 0x79, 0xef, 0x8d, 0xa0, 0xd9,
-	0xf7, 0x25, 0xbb, 0xf4, 0xaf, 0xbc, 0x44, 0x70, 0xc9, 0xc9, 0x5b, 0x17, 0xfc, 0xfc, 0xca, 0x3b,
-	0x1f, 0x84, 0x51, 0xf0, 0x3c, 0x94, 0xde, 0x8b, 0x9f, 0x7a, 0x3d, 0xc1, 0x63, 0xc9, 0xe2, 0xc0,
-	0xf9, 0xa0, 0x1f, 0xca, 0x67, 0x83, 0x73, 0xaf, 0xcb, 0x2f, 0x3a, 0x7d, 0xde, 0xe7, 0x1d, 0x85,
-	0x38, 0x1f, 0xf4, 0xd4, 0x4e, 0x6d, 0xd4, 0x4a, 0x4b, 0x72, 0x36, 0x26, 0xd9, 0xfb, 0x9c, 0xf7,
-	0x23, 0xe6, 0x27, 0x61, 0x6a, 0x96, 0x1d, 0x91, 0x74, 0x3b, 0xa9, 0xf4, 0xe5, 0x20, 0x35, 0x98,
-	0xf5, 0x1c, 0x06, 0x15, 0xe9, 0x64, 0x8a, 0x74, 0x52, 0x1e, 0xbd, 0x60, 0xa2, 0x93, 0x9c, 0x77,
-	0x78, 0x92, 0x71, 0x77, 0x6e, 0xe4, 0xf6, 0x93, 0xb0, 0x23, 0xaf, 0x12, 0x96, 0x76, 0x2e, 0xb9,
-	0x78, 0xce, 0x84, 0x01, 0x3c, 0xb8, 0x11, 0x30, 0x90, 0x61, 0x84, 0xa8, 0xae, 0x9f, 0xa4, 0x78,
-	0x08, 0xfe, 0x1a, 0x50, 0xde, 0x6c, 0xc9, 0xe3, 0x30, 0x95, 0x61, 0xd8, 0x0f, 0x3b, 0xbd, 0x54,
-	0x61, 0xf4, 0x29, 0x68, 0x84, 0x66, 0x77, 0xff, 0x50, 0x82, 0x65, 0xca, 0xd2, 0x41, 0x24, 0xc9,
-	0x1a, 0x34, 0x05, 0xeb, 0xed, 0xb2, 0x44, 0xb0, 0xae, 0x2f, 0x59, 0x60, 0x5b, 0xab, 0x56, 0xbb,
-	0x76, 0xb0, 0x40, 0xc7, 0xc9, 0xe4, 0x57, 0xd0, 0x12, 0xac, 0x97, 0xe6, 0x18, 0x17, 0x57, 0xad,
-	0x76, 0x7d, 0xe3, 0x7d, 0xef, 0xc6, 0x60, 0x78, 0x94, 0xf5, 0x8e, 0xfd, 0x64, 0x04, 0x39, 0x58,
-	0xa0, 0x13, 0x42, 0xc8, 0x06, 0x94, 0x04, 0xeb, 0xd9, 0x25, 0x25, 0xeb, 0x6e, 0xb1, 0xac, 0x83,
-	0x05, 0x8a, 0xcc, 0x64, 0x13, 0xca, 0x28, 0xc5, 0x2e, 0x2b, 0xd0, 0xbb, 0x33, 0x15, 0x38, 0x58,
-	0xa0, 0x0a, 0x40, 0x3e, 0x87, 0xea, 0x05, 0x93, 0x7e, 0xe0, 0x4b, 0xdf, 0x86, 0xd5, 0x52, 0xbb,
-	0xbe, 0xd1, 0x29, 0x04, 0xa3, 0x83, 0xbc, 0x63, 0x83, 0xd8, 0x8b, 0xa5, 0xb8, 0xa2, 0x43, 0x01,
-	0xce, 0x23, 0x68, 0x8e, 0x7d, 0x22, 0x2b, 0x50, 0x7a, 0xce, 0xae, 0xb4, 0xff, 0x28, 0x2e, 0xc9,
-	0x1b, 0xb0, 0xf4, 0xc2, 0x8f, 0x06, 0x4c, 0xb9, 0xaa, 0x41, 0xf5, 0x66, 0x6b, 0xf1, 0xa1, 0xb5,
-	0x5d, 0x85, 0x65, 0xa1, 0xc4, 0xbb, 0x7f, 0xb6, 0x60, 0x65, 0xd2, 0x4f, 0xe4, 0xd0, 0x58, 0x68,
-	0x29, 0x25, 0x3f, 0x9e, 0xc3, 0xc5, 0x48, 0x48, 0xb5, 0xaa, 0x4a, 0x84, 0xb3, 0x09, 0xb5, 0x21,
-	0x69, 0x96, 0x8a, 0xb5, 0x9c, 0x8a, 0xee, 0x26, 0x94, 0x28, 0xeb, 0x91, 0x16, 0x2c, 0x86, 0x26,
-	0x29, 0xe8, 0x62, 0x18, 0x90, 0x55, 0x28, 0x05, 0xac, 0x67, 0x82, 0xdf, 0xf2, 0x92, 0x73, 0x6f,
-	0x97, 0xf5, 0xc2, 0x38, 0x94, 0x21, 0x8f, 0x29, 0x7e, 0x72, 0xff, 0x6a, 0x61, 0x72, 0xa1, 0x5a,
-	0xe4, 0xd3, 0x31, 0x3b, 0x66, 0xa7, 0xca, 0x35, 0xed, 0x9f, 0x16, 0x6b, 0xff, 0x51, 0x5e, 0xfb,
-	0x99, 0xf9, 0x93, 0xb7, 0x4e, 0x42, 0x93, 0x32, 0x39, 0x10, 0x31, 0x65, 0xbf, 0x1d, 0xb0, 0x54,
-	0x92, 0x9f, 0x65, 0x11, 0x51, 0xf2, 0x67, 0xa5, 0x15, 0x32, 0x52, 0x03, 0x20, 0x6d, 0x58, 0x62,
-	0x42, 0x70, 0x61, 0xb4, 0x20, 0x9e, 0xae, 0x1c, 0x9e, 0x48, 0xba, 0xde, 0x99, 0xaa, 0x1c, 0x54,
-	0x33, 0xb8, 0x2b, 0xd0, 0xca, 0x4e, 0x4d, 0x13, 0x1e, 0xa7, 0xcc, 0xbd, 0x03, 0xcd, 0xc3, 0x38,
-	0x19, 0xc8, 0xd4, 0xe8, 0xe1, 0xfe, 0xc3, 0x82, 0x56, 0x46, 0xd1, 0x3c, 0xe4, 0x2b, 0xa8, 0x8f,
-	0x7c, 0x9c, 0x39, 0x73, 0xab, 0x40, 0xbf, 0x71, 0x7c, 0x2e, 0x40, 0xc6, 0xb7, 0x79, 0x71, 0xce,
-	0x09, 0xac, 0x4c, 0x32, 0x4c, 0xf1, 0xf4, 0x7b, 0xe3, 0x9e, 0x9e, 0x0c, 0x7c, 0xce, 0xb3, 0x7f,
-	0xb2, 0xe0, 0x2d, 0xca, 0x54, 0x29, 0x3c, 0xbc, 0xf0, 0xfb, 0x6c, 0x87, 0xc7, 0xbd, 0xb0, 0x9f,
-	0xb9, 0x79, 0x45, 0x65, 0x55, 0x26, 0x19, 0x13, 0xac, 0x0d, 0xd5, 0xd3, 0xc8, 0x97, 0x3d, 0x2e,
-	0x2e, 0x8c, 0xf0, 0x06, 0x0a, 0xcf, 0x68, 0x74, 0xf8, 0x95, 0xac, 0x42, 0xdd, 0x08, 0x3e, 0xe6,
-	0x01, 0x53, 0x35, 0xa3, 0x46, 0xf3, 0x24, 0x62, 0x43, 0xe5, 0x88, 0xf7, 0x4f, 0xfc, 0x0b, 0xa6,
-	0x8a, 0x43, 0x8d, 0x66, 0x5b, 0xf7, 0x77, 0x16, 0x38, 0xd3, 0xb4, 0x32, 0x2e, 0xfe, 0x0c, 0x96,
-	0x77, 0xc3, 0x3e, 0x4b, 0x75, 0xf4, 0x6b, 0xdb, 0x1b, 0xdf, 0x7e, 0xf7, 0xce, 0xc2, 0xbf, 0xbf,
-	0x7b, 0xe7, 0x7e, 0xae, 0xae, 0xf2, 0x84, 0xc5, 0x5d, 0x1e, 0x4b, 0x3f, 0x8c, 0x99, 0xc0, 0xf6,
-	0xf0, 0x41, 0xa0, 0x20, 0x9e, 0x46, 0x52, 0x23, 0x81, 0xbc, 0x09, 0xcb, 0x5a, 0xba, 0xb9, 0xf6,
-	0x66, 0xe7, 0xfe, 0x7d, 0x09, 0x1a, 0x67, 0xa8, 0x40, 0xe6, 0x0b, 0x0f, 0x60, 0xe4, 0x42, 0x93,
-	0x76, 0x93, 0x8e, 0xcd, 0x71, 0x10, 0x07, 0xaa, 0xfb, 0x26, 0xc4, 0xe6, 0xba, 0x0e, 0xf7, 0xe4,
-	0x4b, 0xa8, 0x67, 0xeb, 0x27, 0x89, 0xb4, 0x4b, 0x2a, 0x47, 0x1e, 0x16, 0xe4, 0x48, 0x5e, 0x13,
-	0x2f, 0x07, 0x35, 0x19, 0x92, 0xa3, 0x90, 0x75, 0x78, 0xcd, 0x8f, 0x22, 0x7e, 0x69, 0xd2, 0x5e,
-	0x25, 0xb0, 0xbd, 0xb4, 0x6a, 0xb5, 0xab, 0xf4, 0xfa, 0x07, 0xf2, 0x21, 0xbc, 0x9e, 0x23, 0x3e,
-	0x16, 0xc2, 0xbf, 0xc2, 0x88, 0x2f, 0x2b, 0xfe, 0x69, 0x9f, 0xb0, 0x06, 0xed, 0x87, 0xb1, 0x1f,
-	0xd9, 0xa0, 0x78, 0xf4, 0x86, 0xb8, 0xd0, 0xd8, 0x7b, 0x99, 0x70, 0x21, 0x99, 0x78, 0x2c, 0xa5,
-	0xb0, 0xeb, 0xca, 0x99, 0x63, 0x34, 0x72, 0x0a, 0x8d, 0x1d, 0xbf, 0xfb, 0x8c, 0x1d, 0x5e, 0x20,
-	0x31, 0xb5, 0x1b, 0xca, 0xec, 0xf5, 0x02, 0xb3, 0x15, 0xfb, 0x93, 0x24, 0x77, 0x19, 0xc6, 0x24,
-	0x90, 0x2e, 0xb4, 0x32, 0xd3, 0xf5, 0x2d, 0xb2, 0x9b, 0x4a, 0xe6, 0xa3, 0x79, 0x5d, 0xa9, 0xd1,
-	0xfa, 0x88, 0x09, 0x91, 0x18, 0xc8, 0x3d, 0xbc, 0x30, 0xbe, 0x64, 0x76, 0x4b, 0xd9, 0x3c, 0xdc,
-	0x3b, 0x9f, 0xc0, 0xca, 0x64, 0x34, 0xe6, 0x29, 0xdb, 0xce, 0x2f, 0xe1, 0xf5, 0x29, 0x2a, 0x7c,
-	0xaf, 0x1b, 0xfd, 0x37, 0x0b, 0x5e, 0xbb, 0xe6, 0x37, 0x42, 0xa0, 0xfc, 0xc5, 0x55, 0xc2, 0x8c,
-	0x48, 0xb5, 0x26, 0xc7, 0xb0, 0x84, 0x71, 0x49, 0xed, 0x45, 0xe5, 0xb4, 0xcd, 0x79, 0x02, 0xe1,
-	0x29, 0xa4, 0x76, 0x98, 0x96, 0xe2, 0x3c, 0x04, 0x18, 0x11, 0xe7, 0x6a, 0x5e, 0x5f, 0x41, 0xd3,
-	0x44, 0xc5, 0x5c, 0xf0, 0x15, 0x3d, 0x67, 0x18, 0x30, 0x4e, 0x11, 0xa3, 0x82, 0x5f, 0x9a, 0xb3,
-	0xe0, 0xbb, 0xdf, 0xc0, 0x1d, 0xca, 0xfc, 0x60, 0x3f, 0x8c, 0xd8, 0xcd, 0x75, 0x0d, 0x6f, 0x6b,
-	0x18, 0xb1, 0x53, 0x5f, 0x3e, 0x1b, 0xde, 0x56, 0xb3, 0x27, 0x5b, 0xb0, 0x44, 0xfd, 0xb8, 0xcf,
-	0xcc, 0xd1, 0xef, 0x15, 0x1c, 0xad, 0x0e, 0x41, 0x5e, 0xaa, 0x21, 0xee, 0x23, 0xa8, 0x0d, 0x69,
-	0x58, 0x6b, 0x9e, 0xf4, 0x7a, 0x29, 0xd3, 0x75, 0xab, 0x44, 0xcd, 0x0e, 0xe9, 0x47, 0x2c, 0xee,
-	0x9b, 0xa3, 0x4b, 0xd4, 0xec, 0xdc, 0x35, 0x1c, 0x36, 0x32, 0xcd, 0x8d, 0x6b, 0x08, 0x94, 0x77,
-	0x71, 0x22, 0xb2, 0xd4, 0x05, 0x53, 0x6b, 0x37, 0xc0, 0x46, 0xe5, 0x07, 0xbb, 0xa1, 0xb8, 0xd9,
-	0x40, 0x1b, 0x2a, 0xbb, 0xa1, 0xc8, 0xd9, 0x97, 0x6d, 0xc9, 0x1a, 0xb6, 0xb0, 0x6e, 0x34, 0x08,
-	0xd0, 0x5a, 0xc9, 0x44, 0x6c, 0x6a, 0xf5, 0x04, 0xd5, 0xfd, 0x54, 0xfb, 0x51, 0x9d, 0x62, 0x94,
-	0x59, 0x87, 0x0a, 0x8b, 0xa5, 0x08, 0x59, 0xd6, 0xe7, 0x88, 0xa7, 0x87, 0x58, 0x4f, 0x0d, 0xb1,
-	0xaa, 0x9f, 0xd2, 0x8c, 0xc5, 0xdd, 0x84, 0x3b, 0x48, 0x28, 0x0e, 0x04, 0x81, 0x72, 0x4e, 0x49,
-	0xb5, 0x76, 0xb7, 0x60, 0x65, 0x04, 0x34, 0x47, 0xaf, 0x41, 0x19, 0x47, 0x64, 0x53, 0x88, 0xa7,
-	0x9d, 0xab, 0xbe, 0xbb, 0x4d, 0xa8, 0x9f, 0x86, 0x71, 0xd6, 0xd1, 0xdc, 0x57, 0x16, 0x34, 0x4e,
-	0x79, 0x3c, 0xea, 0x25, 0xa7, 0x70, 0x27, 0xbb, 0x81, 0x8f, 0x4f, 0x0f, 0x77, 0xfc, 0x24, 0x33,
-	0x65, 0xf5, 0x7a, 0x98, 0xcd, 0x34, 0xef, 0x69, 0xc6, 0xed, 0x32, 0xb6, 0x1d, 0x3a, 0x09, 0x27,
-	0xbf, 0x80, 0xca, 0xd1, 0xd1, 0xb6, 0x92, 0xb4, 0x38, 0x97, 0xa4, 0x0c, 0x46, 0x3e, 0x81, 0xca,
-	0x53, 0xf5, 0xc8, 0x48, 0x4d, 0x6b, 0x98, 0x92, 0x72, 0xda, 0x50, 0xcd, 0x46, 0x59, 0x97, 0x8b,
-	0x80, 0x66, 0x20, 0xf7, 0xbf, 0x16, 0xd4, 0x9f, 0xfa, 0xa3, 0x69, 0xe9, 0x33, 0x58, 0x0e, 0xbe,
-	0x77, 0xbf, 0xd4, 0x5b, 0xbc, 0xc5, 0x11, 0x7b, 0xc1, 0x22, 0x93, 0xaa, 0x7a, 0x83, 0xd4, 0xf4,
-	0x19, 0x17, 0xfa, 0x76, 0x36, 0xa8, 0xde, 0x60, 0x5e, 0x07, 0x4c, 0xfa, 0x61, 0x64, 0x97, 0x57,
-	0x4b, 0xd8, 0x5b, 0xf5, 0x0e, 0xa3, 0x3e, 0x10, 0x91, 0x6a, 0x4a, 0x35, 0x8a, 0x4b, 0xe2, 0x42,
-	0x39, 0x8c, 0x7b, 0x5c, 0xf5, 0x1d, 0x53, 0xdd, 0xce, 0xf8, 0x40, 0x74, 0xd9, 0x61, 0xdc, 0xe3,
-	0x54, 0x7d, 0x23, 0xef, 0xc2, 0xb2, 0xc0, 0x6b, 0x94, 0xda, 0x15, 0xe5, 0x94, 0x1a, 0x72, 0xe9,
-	0xcb, 0x66, 0x3e, 0xb8, 0x2d, 0x68, 0x68, 0xbb, 0xcd, 0xbc, 0xf6, 0xc7, 0x45, 0x78, 0xfd, 0x84,
-	0x5d, 0xee, 0x64, 0x76, 0x65, 0x0e, 0x59, 0x85, 0xfa, 0x90, 0x76, 0xb8, 0x6b, 0xd2, 0x2f, 0x4f,
-	0xc2, 0xc3, 0x8e, 0xf9, 0x20, 0x96, 0x59, 0x0c, 0xd5, 0x61, 0x8a, 0x42, 0xcd, 0x07, 0xf2, 0x63,
-	0xa8, 0x9c, 0x30, 0x89, 0xaf, 0x41, 0x65, 0x75, 0x6b, 0xa3, 0x8e, 0x3c, 0x27, 0x4c, 0xe2, 0x70,
-	0x43, 0xb3, 0x6f, 0x38, 0x31, 0x25, 0xd9, 0xc4, 0x54, 0x9e, 0x36, 0x31, 0x65, 0x5f, 0xc9, 0x26,
-	0xd4, 0xbb, 0x3c, 0x4e, 0xa5, 0xf0, 0x43, 0x3c, 0x78, 0x49, 0x31, 0xff, 0x00, 0x99, 0x75, 0x60,
-	0x77, 0x46, 0x1f, 0x69, 0x9e, 0x93, 0xdc, 0x07, 0x60, 0x2f, 0xa5, 0xf0, 0x0f, 0x78, 0x2a, 0x53,
-	0x7b, 0x59, 0x29, 0x0c, 0x88, 0x43, 0xc2, 0xe1, 0x29, 0xcd, 0x7d, 0x75, 0xdf, 0x84, 0x37, 0xc6,
-	0x3d, 0x62, 0x5c, 0xf5, 0x08, 0x7e, 0x48, 0x59, 0xc4, 0xfc, 0x94, 0xcd, 0xef, 0x2d, 0xd7, 0x01,
-	0xfb, 0x3a, 0xd8, 0x08, 0xfe, 0x5f, 0x09, 0xea, 0x7b, 0x2f, 0x59, 0xf7, 0x98, 0xa5, 0xa9, 0xdf,
-	0x67, 0xe4, 0x6d, 0xa8, 0x9d, 0x0a, 0xde, 0x65, 0x69, 0x3a, 0x94, 0x35, 0x22, 0x90, 0x9f, 0x43,
-	0xf9, 0x30, 0x0e, 0xa5, 0x69, 0x73, 0x6b, 0x85, 0x63, 0x73, 0x28, 0x8d, 0x4c, 0x7c, 0x32, 0xe2,
-	0x96, 0x6c, 0x41, 0x19, 0x8b, 0xc4, 0x6d, 0x0a, 0x75, 0x90, 0xc3, 0x22, 0x86, 0x6c, 0xab, 0x47,
-	0x76, 0xf8, 0x35, 0x33, 0x51, 0x6a, 0x17, 0x77, 0x98, 0xf0, 0x6b, 0x36, 0x92, 0x60, 0x90, 0x64,
-	0x0f, 0x2a, 0x67, 0xd2, 0x17, 0xf8, 0xde, 0xd6, 0xd1, 0xbb, 0x57, 0x34, 0x88, 0x68, 0xce, 0x91,
-	0x94, 0x0c, 0x8b, 0x4e, 0xd8, 0x7b, 0x19, 0x4a, 0x73, 0x1b, 0x8a, 0x9c, 0x80, 0x6c, 0x39, 0x43,
-	0x70, 0x8b, 0xe8, 0x5d, 0x1e, 0x33, 0xbb, 0x32, 0x13, 0x8d, 0x6c, 0x39, 0x34, 0x6e, 0xd1, 0x0d,
-	0x67, 0x61, 0x1f, 0xe7, 0xbb, 0xea, 0x4c, 0x37, 0x68, 0xc6, 0x9c, 0x1b, 0x34, 0x61, 0xbb, 0x02,
-	0x4b, 0x6a, 0x9a, 0x71, 0xff, 0x62, 0x41, 0x3d, 0x17, 0xa7, 0x5b, 0xdc, 0xbb, 0xb7, 0xa1, 0x8c,
-	0xef, 0x74, 0x13, 0xff, 0xaa, 0xba, 0x75, 0x4c, 0xfa, 0x54, 0x51, 0xb1, 0x70, 0xec, 0x07, 0xba,
-	0x28, 0x36, 0x29, 0x2e, 0x91, 0xf2, 0x85, 0xbc, 0x52, 0x21, 0xab, 0x52, 0x5c, 0x92, 0x75, 0xa8,
-	0x9e, 0xb1, 0xee, 0x40, 0x84, 0xf2, 0x4a, 0x05, 0xa1, 0xb5, 0xb1, 0xa2, 0xca, 0x89, 0xa1, 0xa9,
-	0xcb, 0x39, 0xe4, 0x70, 0x3f, 0xc7, 0xe4, 0x1c, 0x29, 0x48, 0xa0, 0xbc, 0x83, 0xaf, 0x15, 0xd4,
-	0xac, 0x49, 0xd5, 0x1a, 0x1f, 0x8c, 0x7b, 0xb3, 0x1e, 0x8c, 0x7b, 0xd9, 0x83, 0x71, 0x3c, 0xa8,
-	0xd8, 0x7d, 0x72, 0x4e, 0x76, 0x1f, 0x43, 0x6d, 0x98, 0x78, 0xf8, 0x56, 0xdf, 0x0f, 0xcc, 0x49,
-	0x8b, 0xfb, 0x01, 0x9a, 0xb2, 0xf7, 0x64, 0x5f, 0x9d, 0x52, 0xa5, 0xb8, 0x1c, 0xf6, 0xfa, 0x52,
-	0xae, 0xd7, 0x6f, 0xe2, 0x53, 0x38, 0x97, 0x7d, 0xc8, 0x44, 0xf9, 0x65, 0x9a, 0xa9, 0x8c, 0x6b,
-	0x6d, 0x46, 0x94, 0x2a, 0x59, 0xca, 0x8c, 0x28, 0x75, 0x7f, 0x04, 0xcd, 0xb1, 0x78, 0x21, 0x93,
-	0x7a, 0x7b, 0x99, 0x91, 0x10, 0xd7, 0x1b, 0xff, 0xaa, 0x41, 0xed, 0xe8, 0x68, 0x7b, 0x5b, 0x84,
-	0x41, 0x9f, 0x91, 0xdf, 0x5b, 0x40, 0xae, 0x3f, 0xc3, 0xc8, 0x47, 0xc5, 0x37, 0x63, 0xfa, 0x5b,
-	0xd2, 0xf9, 0x78, 0x4e, 0x94, 0xe9, 0xcf, 0x5f, 0xc2, 0x92, 0x9a, 0x0d, 0xc9, 0x4f, 0x6e, 0x39,
-	0xd3, 0x3b, 0xed, 0xd9, 0x8c, 0x46, 0x76, 0x17, 0xaa, 0xd9, 0x7c, 0x45, 0xee, 0x17, 0xaa, 0x37,
-	0x36, 0x3e, 0x3a, 0xef, 0xdf, 0x8a, 0xd7, 0x1c, 0xf2, 0x1b, 0xa8, 0x98, 0xb1, 0x89, 0xdc, 0x9b,
-	0x81, 0x1b, 0x0d, 0x70, 0xce, 0xfd, 0xdb, 0xb0, 0x8e, 0xcc, 0xc8, 0xc6, 0xa3, 0x42, 0x33, 0x26,
-	0x86, 0xaf, 0x42, 0x33, 0xae, 0xcd, 0x5b, 0x4f, 0xa1, 0x8c, 0x73, 0x14, 0x29, 0xaa, 0x27, 0xb9,
-	0x41, 0xcb, 0x29, 0x0a, 0xd7, 0xd8, 0x00, 0xf6, 0x6b, 0xac, 0xbb, 0xea, 0x2d, 0x5a, 0x5c, 0x71,
-	0x73, 0x7f, 0xff, 0x38, 0xf7, 0x6e, 0xc1, 0x39, 0x12, 0x6f, 0xde, 0x71, 0xed, 0x5b, 0xfc, 0x07,
-	0x33, 0x5b, 0xfc, 0xc4, 0xbf, 0x3d, 0x1c, 0x1a, 0xf9, 0x76, 0x4a, 0xbc, 0x02, 0xe8, 0x94, 0x49,
-	0xc4, 0xe9, 0xdc, 0x9a, 0xdf, 0x1c, 0xf8, 0x0d, 0xbe, 0x09, 0xc6, 0x5b, 0x2d, 0xd9, 0x28, 0x74,
-	0xc7, 0xd4, 0xa6, 0xee, 0x3c, 0x98, 0x0b, 0x63, 0x0e, 0xf7, 0x75, 0x2b, 0x37, 0xed, 0x9a, 0x14,
-	0x77, 0xa6, 0x61, 0xcb, 0x77, 0x6e, 0xc9, 0xd7, 0xb6, 0x3e, 0xb4, 0x30, 0xcf, 0x70, 0x84, 0x2b,
-	0x94, 0x9d, 0x9b, 0x6d, 0x0b, 0xf3, 0x2c, 0x3f, 0x0b, 0x6e, 0x37, 0xbe, 0x7d, 0x75, 0xd7, 0xfa,
-	0xe7, 0xab, 0xbb, 0xd6, 0x7f, 0x5e, 0xdd, 0xb5, 0xce, 0x97, 0xd5, 0x5f, 0xeb, 0x0f, 0xfe, 0x1f,
-	0x00, 0x00, 0xff, 0xff, 0xce, 0x5a, 0x92, 0x41, 0xac, 0x18, 0x00, 0x00,
+	// 2285 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x19, 0x4d, 0x6f, 0x1b, 0xc7,
+	0x55, 0x2b, 0x52, 0xfc, 0x78, 0xfc, 0x10, 0x3d, 0x4e, 0xd3, 0xcd, 0x22, 0x70, 0x94, 0x6d, 0x2a,
+	0xd3, 0xb2, 0x43, 0xba, 0x74, 0x02, 0xb9, 0x76, 0xeb, 0xc4, 0xfa, 0x82, 0x14, 0x4b, 0x36, 0x3b,
+	0x72, 0xe1, 0x22, 0x48, 0x81, 0xae, 0xb8, 0x43, 0x7a, 0xeb, 0xd5, 0xee, 0x76, 0x77, 0x68, 0x59,
+	0xc9, 0xa5, 0xfd, 0x07, 0x05, 0x0a, 0xb4, 0xc7, 0x02, 0xfd, 0x05, 0xed, 0xa5, 0xc7, 0x9e, 0x03,
+	0xf4, 0xd2, 0x63, 0xd1, 0x43, 0x50, 0xf8, 0x47, 0x14, 0xe8, 0xad, 0x78, 0x33, 0xb3, 0xe4, 0x90,
+	0xa2, 0x97, 0x54, 0x73, 0xe2, 0xcc, 0x9b, 0xf7, 0xfd, 0xe6, 0x7d, 0xcc, 0x12, 0x6a, 0x03, 0x87,
+	0xb3, 0x33, 0xe7, 0xbc, 0x15, 0xc5, 0x21, 0x0f, 0xc9, 0x3b, 0xa7, 0xe1, 0xc9, 0x79, 0xeb, 0x64,
+	0xe8, 0xf9, 0xee, 0x0b, 0x8f, 0xb7, 0x5e, 0xfe, 0xa0, 0xd5, 0x8f, 0xc3, 0x80, 0xb3, 0xc0, 0xb5,
+	0x3e, 0x1c, 0x78, 0xfc, 0xf9, 0xf0, 0xa4, 0xd5, 0x0b, 0x4f, 0xdb, 0x83, 0x70, 0x10, 0xb6, 0x05,
+	0xc5, 0xc9, 0xb0, 0x2f, 0x76, 0x62, 0x23, 0x56, 0x92, 0x93, 0xd5, 0x99, 0x46, 0x1f, 0x84, 0xe1,
+	0xc0, 0x67, 0x4e, 0xe4, 0x25, 0x6a, 0xd9, 0x8e, 0xa3, 0x5e, 0x3b, 0xe1, 0x0e, 0x1f, 0x26, 0x8a,
+	0xe6, 0x96, 0x46, 0x83, 0x8a, 0xb4, 0x53, 0x45, 0xda, 0x49, 0xe8, 0xbf, 0x64, 0x71, 0x3b, 0x3a,
+	0x69, 0x87, 0x51, 0x8a, 0xdd, 0x7e, 0x23, 0xb6, 0x13, 0x79, 0x6d, 0x7e, 0x1e, 0xb1, 0xa4, 0x7d,
+	0x16, 0xc6, 0x2f, 0x58, 0xac, 0x08, 0xee, 0xbc, 0x91, 0x60, 0xc8, 0x3d, 0x1f, 0xa9, 0x7a, 0x4e,
+	0x94, 0xa0, 0x10, 0xfc, 0x55, 0x44, 0xba, 0xd9, 0x3c, 0x0c, 0xbc, 0x84, 0x7b, 0xde, 0xc0, 0x6b,
+	0xf7, 0x13, 0x41, 0x23, 0xa5, 0xa0, 0x11, 0x12, 0xdd, 0xfe, 0x7b, 0x1e, 0x0a, 0x94, 0x25, 0x43,
+	0x9f, 0x93, 0x75, 0xa8, 0xc5, 0xac, 0xbf, 0xc3, 0xa2, 0x98, 0xf5, 0x1c, 0xce, 0x5c, 0xd3, 0x58,
+	0x33, 0x9a, 0xe5, 0xfd, 0x25, 0x3a, 0x09, 0x26, 0x3f, 0x85, 0x7a, 0xcc, 0xfa, 0x89, 0x86, 0xb8,
+	0xbc, 0x66, 0x34, 0x2b, 0x9d, 0x9b, 0xad, 0x37, 0x06, 0xa3, 0x45, 0x59, 0xff, 0xc8, 0x89, 0xc6,
+	0x24, 0xfb, 0x4b, 0x74, 0x8a, 0x09, 0xe9, 0x40, 0x2e, 0x66, 0x7d, 0x33, 0x27, 0x78, 0x5d, 0xcb,
+	0xe6, 0xb5, 0xbf, 0x44, 0x11, 0x99, 0x6c, 0x42, 0x1e, 0xb9, 0x98, 0x79, 0x41, 0xf4, 0xfe, 0x5c,
+	0x05, 0xf6, 0x97, 0xa8, 0x20, 0x20, 0x8f, 0xa0, 0x74, 0xca, 0xb8, 0xe3, 0x3a, 0xdc, 0x31, 0x61,
+	0x2d, 0xd7, 0xac, 0x74, 0xda, 0x99, 0xc4, 0xe8, 0xa0, 0xd6, 0x91, 0xa2, 0xd8, 0x0d, 0x78, 0x7c,
+	0x4e, 0x47, 0x0c, 0xc8, 0x33, 0xa8, 0x3a, 0x9c, 0x33, 0xf4, 0xaa, 0x17, 0x06, 0x89, 0x59, 0x11,
+	0x0c, 0xef, 0xcc, 0x67, 0xf8, 0x50, 0xa3, 0x92, 0x4c, 0x27, 0x18, 0x59, 0xf7, 0xa1, 0x36, 0x21,
+	0x93, 0x34, 0x20, 0xf7, 0x82, 0x9d, 0xcb, 0xc0, 0x50, 0x5c, 0x92, 0xb7, 0x60, 0xe5, 0xa5, 0xe3,
+	0x0f, 0x99, 0x88, 0x41, 0x95, 0xca, 0xcd, 0xbd, 0xe5, 0xbb, 0x86, 0xf5, 0x1c, 0xae, 0x5c, 0xe0,
+	0x3f, 0x83, 0xc1, 0x8f, 0x75, 0x06, 0x95, 0xce, 0xf5, 0x0c, 0xad, 0x75, 0x76, 0x9a, 0xa4, 0xad,
+	0x12, 0x14, 0x62, 0x61, 0x90, 0xfd, 0x7b, 0x03, 0x1a, 0xd3, 0xa1, 0x26, 0x07, 0x2a, 0x48, 0x86,
+	0x70, 0xcb, 0xc7, 0x97, 0xb8, 0x25, 0x08, 0x50, 0x8e, 0x11, 0x2c, 0xac, 0x4d, 0x28, 0x8f, 0x40,
+	0xf3, 0x9c, 0x51, 0xd6, 0x54, 0xb4, 0x37, 0x21, 0x47, 0x59, 0x9f, 0xd4, 0x61, 0xd9, 0x53, 0xf7,
+	0x9a, 0x2e, 0x7b, 0x2e, 0x59, 0x83, 0x9c, 0xcb, 0xfa, 0xca, 0xf4, 0x7a, 0x2b, 0x3a, 0x69, 0xed,
+	0xb0, 0xbe, 0x17, 0x78, 0x68, 0x22, 0xc5, 0x23, 0xfb, 0x4f, 0x06, 0x14, 0xa4, 0x5a, 0xe4, 0x93,
+	0x09, 0x3b, 0xe6, 0xdf, 0xf6, 0x0b, 0xda, 0x3f, 0xcb, 0xd6, 0xfe, 0xa3, 0xc9, 0x48, 0xcc, 0x49,
+	0x01, 0xdd, 0xba, 0x9f, 0x41, 0x55, 0x8f, 0x0d, 0xd9, 0x87, 0x8a, 0x76, 0x8f, 0x94, 0xc2, 0xeb,
+	0x8b, 0x45, 0x96, 0xea, 0xa4, 0xf6, 0x1f, 0x96, 0xa1, 0xa2, 0x1d, 0x92, 0x07, 0x90, 0x7f, 0xe1,
+	0x05, 0xd2, 0x85, 0xf5, 0xce, 0xc6, 0x62, 0x2c, 0x1f, 0x79, 0x81, 0x4b, 0x05, 0x1d, 0xb9, 0x2d,
+	0x93, 0x7c, 0x31, 0x0b, 0x45, 0x8a, 0x13, 0xc8, 0x47, 0x0e, 0x7f, 0x2e, 0xea, 0x42, 0x99, 0x8a,
+	0x35, 0xb9, 0x0d, 0x57, 0xbd, 0xe0, 0x69, 0xc8, 0xc3, 0x6e, 0xcc, 0x5c, 0x0f, 0xaf, 0xca, 0xd3,
+	0xf3, 0x88, 0x89, 0x2a, 0x50, 0xa6, 0xb3, 0x8e, 0x48, 0x17, 0xea, 0x12, 0x7c, 0x3c, 0x3c, 0xf9,
+	0x25, 0xeb, 0xf1, 0xc4, 0x5c, 0x11, 0x4e, 0x69, 0x66, 0xa8, 0x70, 0xa0, 0x13, 0xd0, 0x29, 0x7a,
+	0xfb, 0x2f, 0x06, 0xd4, 0x26, 0x30, 0xc8, 0xa7, 0x13, 0xbe, 0xb9, 0xb5, 0x28, 0x67, 0xcd, 0x3b,
+	0x04, 0xf2, 0x81, 0x73, 0x9a, 0x5e, 0x5f, 0xb1, 0x26, 0x9f, 0x41, 0xc1, 0xf5, 0x06, 0x2c, 0xe1,
+	0x66, 0x6e, 0x2d, 0xd7, 0x2c, 0x6f, 0x75, 0xbe, 0xfe, 0xe6, 0xbd, 0xa5, 0x7f, 0x7d, 0xf3, 0xde,
+	0x86, 0x56, 0xe7, 0xc3, 0x88, 0x05, 0xbd, 0x30, 0xe0, 0x8e, 0x17, 0xb0, 0x18, 0xdb, 0xd5, 0x87,
+	0x92, 0xa4, 0xb5, 0x23, 0x7e, 0xa8, 0xe2, 0x60, 0x73, 0xa8, 0x51, 0xc6, 0x87, 0x71, 0x40, 0xd9,
+	0xaf, 0x86, 0x2c, 0xe1, 0xe4, 0x87, 0x69, 0xe6, 0x9a, 0xc6, 0x02, 0x15, 0x14, 0x11, 0xa9, 0x22,
+	0x20, 0x4d, 0x58, 0x61, 0x71, 0x1c, 0xc6, 0x2a, 0x96, 0xa4, 0x25, 0x9b, 0x64, 0x2b, 0x8e, 0x7a,
+	0xad, 0x63, 0xd1, 0x24, 0xa9, 0x44, 0xb0, 0x1b, 0x50, 0x4f, 0xa5, 0x26, 0x51, 0x18, 0x24, 0xcc,
+	0x5e, 0x45, 0xd7, 0x45, 0x43, 0x9e, 0x28, 0x3d, 0xec, 0xbf, 0x19, 0x50, 0x4f, 0x21, 0x12, 0x87,
+	0x7c, 0x01, 0x95, 0x71, 0x2e, 0xa6, 0x49, 0x77, 0x2f, 0xd3, 0xa9, 0x3a, 0xbd, 0x96, 0xc8, 0x2a,
+	0x07, 0x75, 0x76, 0xd6, 0x63, 0x68, 0x4c, 0x23, 0xcc, 0xc8, 0xc8, 0x0f, 0x26, 0x33, 0x72, 0xba,
+	0x40, 0x68, 0x19, 0xf8, 0x3b, 0x03, 0xde, 0xa1, 0x4c, 0x74, 0xfd, 0x83, 0x53, 0x67, 0xc0, 0xb6,
+	0xc3, 0xa0, 0xef, 0x0d, 0x52, 0x37, 0x37, 0x44, 0xf5, 0x49, 0x39, 0x63, 0x21, 0x6a, 0x42, 0xa9,
+	0xeb, 0x3b, 0xbc, 0x1f, 0xc6, 0xa7, 0x8a, 0x79, 0x15, 0x99, 0xa7, 0x30, 0x3a, 0x3a, 0x25, 0x6b,
+	0x50, 0x51, 0x8c, 0x8f, 0x42, 0x97, 0xa9, 0x34, 0xd0, 0x41, 0xc4, 0x84, 0xe2, 0x61, 0x38, 0x78,
+	0xec, 0x9c, 0xa6, 0x19, 0x90, 0x6e, 0xed, 0x5f, 0x1b, 0x60, 0xcd, 0xd2, 0x4a, 0xb9, 0xf8, 0x33,
+	0x28, 0xc8, 0x0b, 0x22, 0x35, 0xfb, 0xff, 0xae, 0x96, 0xfc, 0x25, 0x6f, 0x43, 0x41, 0x72, 0x57,
+	0x8d, 0x48, 0xed, 0xec, 0xbf, 0xae, 0x40, 0xf5, 0x18, 0x15, 0x48, 0x7d, 0xd1, 0x02, 0x18, 0xbb,
+	0xd0, 0x34, 0x66, 0x3a, 0x56, 0xc3, 0x20, 0x16, 0x94, 0xf6, 0x54, 0x88, 0x55, 0x5e, 0x8c, 0xf6,
+	0xe4, 0x73, 0xa8, 0xa4, 0xeb, 0x27, 0x91, 0x4c, 0x90, 0x4a, 0xe7, 0x6e, 0xc6, 0x1d, 0xd1, 0x35,
+	0x69, 0x69, 0xa4, 0xea, 0x86, 0x68, 0x10, 0x72, 0x0b, 0xae, 0x38, 0xbe, 0x1f, 0x9e, 0xa9, 0x6b,
+	0x2f, 0x2e, 0xb0, 0xb9, 0xb2, 0x66, 0x34, 0x4b, 0xf4, 0xe2, 0x01, 0x56, 0x24, 0x0d, 0xf8, 0x30,
+	0x8e, 0x9d, 0x73, 0x8c, 0x78, 0x41, 0xe0, 0xcf, 0x3a, 0xc2, 0x5e, 0xb5, 0xe7, 0x05, 0x8e, 0x6f,
+	0x82, 0xc0, 0x91, 0x1b, 0x62, 0x43, 0x75, 0xf7, 0x55, 0x14, 0xc6, 0x9c, 0xc5, 0x0f, 0x39, 0x8f,
+	0xcd, 0x8a, 0x70, 0xe6, 0x04, 0x8c, 0x74, 0xa1, 0xba, 0xed, 0xf4, 0x9e, 0xb3, 0x83, 0x53, 0x04,
+	0x26, 0x66, 0x55, 0x98, 0x9d, 0x55, 0x6f, 0x04, 0xfa, 0x93, 0x48, 0x9f, 0x33, 0x74, 0x0e, 0xa4,
+	0x07, 0xf5, 0xd4, 0x74, 0x99, 0x45, 0x66, 0x4d, 0xf0, 0xbc, 0x7f, 0x59, 0x57, 0x4a, 0x6a, 0x29,
+	0x62, 0x8a, 0x25, 0x06, 0x72, 0x17, 0x13, 0xc6, 0xe1, 0xcc, 0xac, 0x0b, 0x9b, 0x47, 0x7b, 0xeb,
+	0x01, 0x34, 0xa6, 0xa3, 0x71, 0x99, 0xf6, 0x6e, 0xfd, 0x04, 0xae, 0xce, 0x50, 0xe1, 0x5b, 0x65,
+	0xf4, 0x9f, 0x0d, 0xb8, 0x72, 0xc1, 0x6f, 0x58, 0xa1, 0x45, 0xab, 0x91, 0x2c, 0xc5, 0x9a, 0x1c,
+	0xc1, 0x0a, 0xc6, 0x25, 0x31, 0x97, 0x85, 0xd3, 0x36, 0x2f, 0x13, 0x88, 0x96, 0xa0, 0x14, 0x4b,
+	0x2a, 0xb9, 0x58, 0x77, 0x01, 0xc6, 0xc0, 0x4b, 0x0d, 0x39, 0x5f, 0x40, 0x4d, 0x45, 0x45, 0x25,
+	0x78, 0x43, 0x76, 0x5b, 0x45, 0x8c, 0xdd, 0x74, 0x5c, 0xf0, 0x73, 0x97, 0x2c, 0xf8, 0xf6, 0x57,
+	0xb0, 0x4a, 0x99, 0xe3, 0xee, 0x79, 0x3e, 0x7b, 0x73, 0x5d, 0xc3, 0x6c, 0xf5, 0x7c, 0xd6, 0xc5,
+	0x8e, 0x9d, 0x66, 0xab, 0xda, 0x93, 0x7b, 0xb0, 0x42, 0x9d, 0x60, 0xc0, 0x94, 0xe8, 0x0f, 0x32,
+	0x44, 0x0b, 0x21, 0x88, 0x4b, 0x25, 0x89, 0x7d, 0x1f, 0xca, 0x23, 0x18, 0xd6, 0x9a, 0x27, 0xfd,
+	0x7e, 0xc2, 0x64, 0xdd, 0xca, 0x51, 0xb5, 0x43, 0xf8, 0x21, 0x0b, 0x06, 0x4a, 0x74, 0x8e, 0xaa,
+	0x9d, 0xbd, 0x0e, 0x8d, 0xb1, 0xe6, 0xca, 0x35, 0x04, 0xf2, 0x3b, 0x38, 0xfc, 0x1b, 0x22, 0xc1,
+	0xc4, 0xda, 0x76, 0xb1, 0x51, 0x39, 0xee, 0x8e, 0x17, 0xbf, 0xd9, 0x40, 0x13, 0x8a, 0x3b, 0x5e,
+	0xac, 0xd9, 0x97, 0x6e, 0xc9, 0x3a, 0xb6, 0xb0, 0x9e, 0x3f, 0x74, 0xd1, 0x5a, 0xce, 0xe2, 0x40,
+	0xd5, 0xea, 0x29, 0xa8, 0xfd, 0x09, 0xac, 0x8e, 0xa4, 0x28, 0x65, 0x6e, 0x41, 0x91, 0x05, 0x3c,
+	0xf6, 0x58, 0xda, 0xe7, 0x48, 0x4b, 0xbe, 0xd7, 0x5a, 0xe2, 0xbd, 0x26, 0xfa, 0x29, 0x4d, 0x51,
+	0xec, 0x4d, 0x58, 0x45, 0x40, 0x76, 0x20, 0x08, 0xe4, 0x35, 0x25, 0xc5, 0xda, 0xbe, 0x07, 0x8d,
+	0x31, 0xa1, 0x12, 0xbd, 0x0e, 0x79, 0x9c, 0xd2, 0x54, 0x21, 0x9e, 0x25, 0x57, 0x9c, 0xdb, 0x35,
+	0xa8, 0x74, 0xbd, 0x20, 0xed, 0x68, 0xf6, 0x6b, 0x03, 0xaa, 0xdd, 0x30, 0x18, 0xf7, 0x92, 0x2e,
+	0xac, 0xa6, 0x19, 0xf8, 0xb0, 0x7b, 0xb0, 0xed, 0x44, 0xa9, 0x29, 0x6b, 0x17, 0xc3, 0xac, 0x1e,
+	0xae, 0x2d, 0x89, 0xb8, 0x95, 0xc7, 0xb6, 0x43, 0xa7, 0xc9, 0xc9, 0xa7, 0x50, 0x3c, 0x3c, 0xdc,
+	0x12, 0x9c, 0x96, 0x2f, 0xc5, 0x29, 0x25, 0x23, 0x0f, 0xa0, 0xf8, 0x4c, 0xbc, 0xa7, 0x13, 0xd5,
+	0x1a, 0x66, 0x5c, 0x39, 0x69, 0xa8, 0x44, 0xa3, 0xac, 0x17, 0xc6, 0x2e, 0x4d, 0x89, 0xec, 0xff,
+	0x18, 0x50, 0x79, 0xe6, 0x8c, 0xa7, 0xa5, 0xf1, 0x28, 0xf6, 0x2d, 0xfa, 0xa5, 0xdc, 0x62, 0x16,
+	0xfb, 0xec, 0x25, 0xf3, 0xd5, 0x55, 0x95, 0x1b, 0x84, 0x26, 0xcf, 0xc3, 0x58, 0x66, 0x67, 0x95,
+	0xca, 0x0d, 0xde, 0x6b, 0x97, 0x71, 0xc7, 0xf3, 0xcd, 0xfc, 0x5a, 0x0e, 0x7b, 0xab, 0xdc, 0x61,
+	0xd4, 0x87, 0xb1, 0x2f, 0x9a, 0x52, 0x99, 0xe2, 0x92, 0xd8, 0x90, 0xf7, 0x82, 0x7e, 0x68, 0x16,
+	0xc6, 0xd5, 0xed, 0x38, 0x1c, 0xc6, 0x3d, 0x76, 0x10, 0xf4, 0x43, 0x2a, 0xce, 0xc8, 0xfb, 0x50,
+	0x88, 0x31, 0x8d, 0x12, 0xb3, 0x28, 0x9c, 0x52, 0x46, 0x2c, 0x99, 0x6c, 0xea, 0xc0, 0xae, 0x43,
+	0x55, 0xda, 0xad, 0xe6, 0xb5, 0xdf, 0x2e, 0xc3, 0xd5, 0xc7, 0xec, 0x6c, 0x3b, 0xb5, 0x2b, 0x75,
+	0xc8, 0x1a, 0x54, 0x46, 0xb0, 0x83, 0x1d, 0x75, 0xfd, 0x74, 0x10, 0x0a, 0x3b, 0x0a, 0x87, 0x01,
+	0x4f, 0x63, 0x28, 0x84, 0x09, 0x08, 0x55, 0x07, 0xe4, 0xfb, 0x50, 0x7c, 0xcc, 0x38, 0x7e, 0xf8,
+	0x10, 0x56, 0xd7, 0x3b, 0x15, 0xc4, 0x79, 0xcc, 0x38, 0x0e, 0x37, 0x34, 0x3d, 0xc3, 0x89, 0x29,
+	0x4a, 0x27, 0xa6, 0xfc, 0xac, 0x89, 0x29, 0x3d, 0x25, 0x9b, 0x50, 0xe9, 0x85, 0x41, 0xc2, 0x63,
+	0xc7, 0x0b, 0xc4, 0xa0, 0x8f, 0xc8, 0xdf, 0x41, 0x64, 0x19, 0xd8, 0xed, 0xf1, 0x21, 0xd5, 0x31,
+	0xc9, 0x06, 0x00, 0x7b, 0xc5, 0x63, 0x67, 0x3f, 0x4c, 0x78, 0x62, 0x16, 0x84, 0xc2, 0x80, 0x74,
+	0x08, 0x38, 0xe8, 0x52, 0xed, 0xd4, 0x7e, 0x1b, 0xde, 0x9a, 0xf4, 0x88, 0x72, 0xd5, 0x7d, 0xf8,
+	0x2e, 0x65, 0x3e, 0x73, 0x12, 0x76, 0x79, 0x6f, 0xd9, 0x16, 0x98, 0x17, 0x89, 0x15, 0xe3, 0xff,
+	0xe6, 0xa0, 0xb2, 0xfb, 0x8a, 0xf5, 0x8e, 0x58, 0x92, 0x38, 0x03, 0x46, 0xde, 0x85, 0x72, 0x37,
+	0x0e, 0x7b, 0x2c, 0x49, 0x46, 0xbc, 0xc6, 0x00, 0xf2, 0x23, 0xc8, 0x1f, 0x04, 0x1e, 0x57, 0x6d,
+	0x6e, 0x3d, 0x73, 0x6c, 0xf6, 0xb8, 0xe2, 0x89, 0x5f, 0x47, 0x70, 0x4b, 0xee, 0x41, 0x1e, 0x8b,
+	0xc4, 0x22, 0x85, 0xda, 0xd5, 0x68, 0x91, 0x86, 0x6c, 0x89, 0xef, 0x49, 0xde, 0x97, 0x4c, 0x45,
+	0xa9, 0x99, 0xdd, 0x61, 0xbc, 0x2f, 0xd9, 0x98, 0x83, 0xa2, 0x24, 0xbb, 0x50, 0x3c, 0xe6, 0x4e,
+	0x8c, 0x9f, 0x96, 0x64, 0xf4, 0x6e, 0x64, 0x0d, 0x22, 0x12, 0x73, 0xcc, 0x25, 0xa5, 0x45, 0x27,
+	0xec, 0xbe, 0xf2, 0xb8, 0x59, 0x98, 0xeb, 0x04, 0x44, 0xd3, 0x0c, 0xc1, 0x2d, 0x52, 0xef, 0x84,
+	0x01, 0x33, 0x8b, 0x73, 0xa9, 0x11, 0x4d, 0xa3, 0xc6, 0x2d, 0xba, 0xe1, 0xd8, 0x1b, 0xe0, 0x7c,
+	0x57, 0x9a, 0xeb, 0x06, 0x89, 0xa8, 0xb9, 0x41, 0x02, 0xb6, 0x8a, 0xb0, 0x22, 0xa6, 0x19, 0xfb,
+	0x8f, 0x06, 0x54, 0xb4, 0x38, 0x2d, 0x90, 0x77, 0xef, 0x42, 0x1e, 0xbf, 0x1c, 0xa9, 0xf8, 0x97,
+	0x44, 0xd6, 0x31, 0xee, 0x50, 0x01, 0xc5, 0xc2, 0xb1, 0xe7, 0xca, 0xa2, 0x58, 0xa3, 0xb8, 0x44,
+	0xc8, 0x53, 0x7e, 0x2e, 0x42, 0x56, 0xa2, 0xb8, 0x24, 0xb7, 0xa0, 0x74, 0xcc, 0x7a, 0xc3, 0xd8,
+	0xe3, 0xe7, 0x22, 0x08, 0xf5, 0x4e, 0x43, 0x94, 0x13, 0x05, 0x13, 0xc9, 0x39, 0xc2, 0xb0, 0x1f,
+	0xe1, 0xe5, 0x1c, 0x2b, 0x48, 0x20, 0xbf, 0x8d, 0xaf, 0x15, 0xd4, 0xac, 0x46, 0xc5, 0x1a, 0x1f,
+	0x8c, 0xbb, 0xf3, 0x1e, 0x8c, 0xbb, 0xe9, 0x83, 0x71, 0x32, 0xa8, 0xd8, 0x7d, 0x34, 0x27, 0xdb,
+	0x0f, 0xa1, 0x3c, 0xba, 0x78, 0xf8, 0x4d, 0x67, 0xcf, 0x55, 0x92, 0x96, 0xf7, 0x5c, 0x34, 0x65,
+	0xf7, 0xc9, 0x9e, 0x90, 0x52, 0xa2, 0xb8, 0x1c, 0xf5, 0xfa, 0x9c, 0xd6, 0xeb, 0x37, 0xa1, 0x26,
+	0x2f, 0x9b, 0xa6, 0x32, 0x0d, 0xcf, 0x92, 0x54, 0x65, 0x5c, 0x4b, 0x33, 0xfc, 0xc4, 0x5c, 0x4e,
+	0xcd, 0xf0, 0x13, 0xfb, 0x7b, 0x50, 0x9b, 0x88, 0x17, 0x22, 0x89, 0xb7, 0x97, 0x1a, 0x09, 0x71,
+	0xbd, 0x71, 0x03, 0x56, 0xa7, 0xbe, 0x7f, 0x10, 0x80, 0x82, 0x7c, 0xf6, 0x37, 0x96, 0x70, 0xbd,
+	0x35, 0x0c, 0x5c, 0x9f, 0x35, 0x8c, 0x8d, 0x75, 0xb8, 0x72, 0xe1, 0x73, 0x00, 0x29, 0x41, 0xfe,
+	0x98, 0xf9, 0xfd, 0xc6, 0x12, 0x29, 0x42, 0x8e, 0x3a, 0x67, 0x0d, 0xa3, 0xf3, 0xcf, 0x32, 0x94,
+	0x0f, 0x0f, 0xb7, 0xb6, 0x62, 0xcf, 0x1d, 0x30, 0xf2, 0x1b, 0x03, 0xc8, 0xc5, 0x97, 0x1d, 0xf9,
+	0x28, 0x3b, 0xd9, 0x66, 0x3f, 0x4f, 0xad, 0x8f, 0x2f, 0x49, 0xa5, 0x5a, 0xfe, 0xe7, 0xb0, 0x22,
+	0xc6, 0x4d, 0x72, 0x7d, 0xc1, 0x67, 0x82, 0xd5, 0x9c, 0x8f, 0xa8, 0x78, 0xf7, 0xa0, 0x94, 0x8e,
+	0x6c, 0x64, 0x23, 0x53, 0xbd, 0x89, 0x89, 0xd4, 0xba, 0xb9, 0x10, 0xae, 0x12, 0xf2, 0x0b, 0x28,
+	0xaa, 0x49, 0x8c, 0xdc, 0x98, 0x43, 0x37, 0x9e, 0x09, 0xad, 0x8d, 0x45, 0x50, 0xc7, 0x66, 0xa4,
+	0x13, 0x57, 0xa6, 0x19, 0x53, 0xf3, 0x9c, 0x75, 0x73, 0x21, 0x5c, 0x25, 0xe4, 0x19, 0xe4, 0x71,
+	0x34, 0x23, 0x59, 0x25, 0x4a, 0x9b, 0xdd, 0xac, 0xac, 0x70, 0x4d, 0xcc, 0x74, 0x3f, 0x87, 0x82,
+	0x7a, 0xde, 0x66, 0x17, 0x71, 0xed, 0x8b, 0x92, 0x75, 0x63, 0x01, 0xcc, 0x31, 0x7b, 0xf5, 0x34,
+	0x6c, 0x2e, 0xf0, 0x59, 0x67, 0x3e, 0xfb, 0xa9, 0x0f, 0x48, 0x21, 0x54, 0xf5, 0x0e, 0x4d, 0x5a,
+	0x19, 0xa4, 0x33, 0x86, 0x1b, 0xab, 0xbd, 0x30, 0xbe, 0x12, 0xf8, 0x15, 0x34, 0xa6, 0xbb, 0x37,
+	0xe9, 0x64, 0xba, 0x63, 0xe6, 0x9c, 0x60, 0xdd, 0xb9, 0x14, 0x8d, 0x12, 0xee, 0xc8, 0xe9, 0x40,
+	0x4d, 0x00, 0x24, 0xbb, 0xd9, 0x8d, 0xa6, 0x08, 0x6b, 0x41, 0xbc, 0xa6, 0x71, 0xdb, 0xc0, 0x7b,
+	0x86, 0x53, 0x61, 0x26, 0x6f, 0x6d, 0x5c, 0xb6, 0xae, 0xcf, 0xc5, 0x93, 0xba, 0x6f, 0x55, 0xbf,
+	0x7e, 0x7d, 0xcd, 0xf8, 0xc7, 0xeb, 0x6b, 0xc6, 0xbf, 0x5f, 0x5f, 0x33, 0x4e, 0x0a, 0xe2, 0x8f,
+	0xa9, 0x3b, 0xff, 0x1b, 0x00, 0x91, 0x20, 0x5e, 0x65, 0xea, 0x1b, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -2927,6 +3189,32 @@ func (m *Result) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if len(m.Attestations) > 0 {
+		for k := range m.Attestations {
+			v := m.Attestations[k]
+			baseI := i
+			if v != nil {
+				{
+					size, err := v.MarshalToSizedBuffer(dAtA[:i])
+					if err != nil {
+						return 0, err
+					}
+					i -= size
+					i = encodeVarintGateway(dAtA, i, uint64(size))
+				}
+				i--
+				dAtA[i] = 0x12
+			}
+			i -= len(k)
+			copy(dAtA[i:], k)
+			i = encodeVarintGateway(dAtA, i, uint64(len(k)))
+			i--
+			dAtA[i] = 0xa
+			i = encodeVarintGateway(dAtA, i, uint64(baseI-i))
+			i--
+			dAtA[i] = 0x5a
+		}
+	}
 	if len(m.Metadata) > 0 {
 		for k := range m.Metadata {
 			v := m.Metadata[k]
@@ -3182,7 +3470,7 @@ func (m *RefMap) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
-func (m *ReturnRequest) Marshal() (dAtA []byte, err error) {
+func (m *Attestations) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -3192,12 +3480,12 @@ func (m *ReturnRequest) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *ReturnRequest) MarshalTo(dAtA []byte) (int, error) {
+func (m *Attestations) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *ReturnRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *Attestations) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -3206,29 +3494,190 @@ func (m *ReturnRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if m.Error != nil {
-		{
-			size, err := m.Error.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
+	if len(m.Attestation) > 0 {
+		for iNdEx := len(m.Attestation) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Attestation[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintGateway(dAtA, i, uint64(size))
 			}
-			i -= size
-			i = encodeVarintGateway(dAtA, i, uint64(size))
+			i--
+			dAtA[i] = 0xa
 		}
-		i--
-		dAtA[i] = 0x12
 	}
-	if m.Result != nil {
-		{
-			size, err := m.Result.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintGateway(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0xa
+	return len(dAtA) - i, nil
+}
+
+func (m *Attestation) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Attestation) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Attestation) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.InTotoSubjects) > 0 {
+		for iNdEx := len(m.InTotoSubjects) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.InTotoSubjects[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintGateway(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x2a
+		}
+	}
+	if len(m.InTotoPredicateType) > 0 {
+		i -= len(m.InTotoPredicateType)
+		copy(dAtA[i:], m.InTotoPredicateType)
+		i = encodeVarintGateway(dAtA, i, uint64(len(m.InTotoPredicateType)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.Path) > 0 {
+		i -= len(m.Path)
+		copy(dAtA[i:], m.Path)
+		i = encodeVarintGateway(dAtA, i, uint64(len(m.Path)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Ref != nil {
+		{
+			size, err := m.Ref.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintGateway(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Kind != 0 {
+		i = encodeVarintGateway(dAtA, i, uint64(m.Kind))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *InTotoSubject) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *InTotoSubject) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *InTotoSubject) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Digest) > 0 {
+		for iNdEx := len(m.Digest) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Digest[iNdEx])
+			copy(dAtA[i:], m.Digest[iNdEx])
+			i = encodeVarintGateway(dAtA, i, uint64(len(m.Digest[iNdEx])))
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if len(m.Name) > 0 {
+		i -= len(m.Name)
+		copy(dAtA[i:], m.Name)
+		i = encodeVarintGateway(dAtA, i, uint64(len(m.Name)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Kind != 0 {
+		i = encodeVarintGateway(dAtA, i, uint64(m.Kind))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ReturnRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ReturnRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ReturnRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Error != nil {
+		{
+			size, err := m.Error.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintGateway(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Result != nil {
+		{
+			size, err := m.Result.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintGateway(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
 	}
 	return len(dAtA) - i, nil
 }
@@ -4605,20 +5054,20 @@ func (m *InitMessage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		dAtA[i] = 0x20
 	}
 	if len(m.Fds) > 0 {
-		dAtA26 := make([]byte, len(m.Fds)*10)
-		var j25 int
+		dAtA28 := make([]byte, len(m.Fds)*10)
+		var j27 int
 		for _, num := range m.Fds {
 			for num >= 1<<7 {
-				dAtA26[j25] = uint8(uint64(num)&0x7f | 0x80)
+				dAtA28[j27] = uint8(uint64(num)&0x7f | 0x80)
 				num >>= 7
-				j25++
+				j27++
 			}
-			dAtA26[j25] = uint8(num)
-			j25++
+			dAtA28[j27] = uint8(num)
+			j27++
 		}
-		i -= j25
-		copy(dAtA[i:], dAtA26[:j25])
-		i = encodeVarintGateway(dAtA, i, uint64(j25))
+		i -= j27
+		copy(dAtA[i:], dAtA28[:j27])
+		i = encodeVarintGateway(dAtA, i, uint64(j27))
 		i--
 		dAtA[i] = 0x1a
 	}
@@ -4894,6 +5343,19 @@ func (m *Result) Size() (n int) {
 			n += mapEntrySize + 1 + sovGateway(uint64(mapEntrySize))
 		}
 	}
+	if len(m.Attestations) > 0 {
+		for k, v := range m.Attestations {
+			_ = k
+			_ = v
+			l = 0
+			if v != nil {
+				l = v.Size()
+				l += 1 + sovGateway(uint64(l))
+			}
+			mapEntrySize := 1 + len(k) + sovGateway(uint64(len(k))) + l
+			n += mapEntrySize + 1 + sovGateway(uint64(mapEntrySize))
+		}
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -5011,6 +5473,82 @@ func (m *RefMap) Size() (n int) {
 	return n
 }
 
+func (m *Attestations) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Attestation) > 0 {
+		for _, e := range m.Attestation {
+			l = e.Size()
+			n += 1 + l + sovGateway(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *Attestation) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Kind != 0 {
+		n += 1 + sovGateway(uint64(m.Kind))
+	}
+	if m.Ref != nil {
+		l = m.Ref.Size()
+		n += 1 + l + sovGateway(uint64(l))
+	}
+	l = len(m.Path)
+	if l > 0 {
+		n += 1 + l + sovGateway(uint64(l))
+	}
+	l = len(m.InTotoPredicateType)
+	if l > 0 {
+		n += 1 + l + sovGateway(uint64(l))
+	}
+	if len(m.InTotoSubjects) > 0 {
+		for _, e := range m.InTotoSubjects {
+			l = e.Size()
+			n += 1 + l + sovGateway(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *InTotoSubject) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Kind != 0 {
+		n += 1 + sovGateway(uint64(m.Kind))
+	}
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovGateway(uint64(l))
+	}
+	if len(m.Digest) > 0 {
+		for _, s := range m.Digest {
+			l = len(s)
+			n += 1 + l + sovGateway(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
 func (m *ReturnRequest) Size() (n int) {
 	if m == nil {
 		return 0
@@ -6082,23 +6620,152 @@ func (m *Result) Unmarshal(dAtA []byte) error {
 			}
 			m.Metadata[mapkey] = mapvalue
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipGateway(dAtA[iNdEx:])
-			if err != nil {
-				return err
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Attestations", wireType)
 			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGateway
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
 				return ErrInvalidLengthGateway
 			}
-			if (iNdEx + skippy) > l {
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthGateway
+			}
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
-			iNdEx += skippy
-		}
-	}
-
+			if m.Attestations == nil {
+				m.Attestations = make(map[string]*Attestations)
+			}
+			var mapkey string
+			var mapvalue *Attestations
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowGateway
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowGateway
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthGateway
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey < 0 {
+						return ErrInvalidLengthGateway
+					}
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					var mapmsglen int
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowGateway
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						mapmsglen |= int(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					if mapmsglen < 0 {
+						return ErrInvalidLengthGateway
+					}
+					postmsgIndex := iNdEx + mapmsglen
+					if postmsgIndex < 0 {
+						return ErrInvalidLengthGateway
+					}
+					if postmsgIndex > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvalue = &Attestations{}
+					if err := mapvalue.Unmarshal(dAtA[iNdEx:postmsgIndex]); err != nil {
+						return err
+					}
+					iNdEx = postmsgIndex
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipGateway(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if (skippy < 0) || (iNdEx+skippy) < 0 {
+						return ErrInvalidLengthGateway
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.Attestations[mapkey] = mapvalue
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipGateway(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthGateway
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
 	if iNdEx > l {
 		return io.ErrUnexpectedEOF
 	}
@@ -6581,6 +7248,429 @@ func (m *RefMap) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *Attestations) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowGateway
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Attestations: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Attestations: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Attestation", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGateway
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGateway
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthGateway
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Attestation = append(m.Attestation, &Attestation{})
+			if err := m.Attestation[len(m.Attestation)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipGateway(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthGateway
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Attestation) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowGateway
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Attestation: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Attestation: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Kind", wireType)
+			}
+			m.Kind = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGateway
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Kind |= AttestationKind(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Ref", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGateway
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGateway
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthGateway
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Ref == nil {
+				m.Ref = &Ref{}
+			}
+			if err := m.Ref.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Path", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGateway
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGateway
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthGateway
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Path = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field InTotoPredicateType", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGateway
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGateway
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthGateway
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.InTotoPredicateType = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field InTotoSubjects", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGateway
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGateway
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthGateway
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.InTotoSubjects = append(m.InTotoSubjects, &InTotoSubject{})
+			if err := m.InTotoSubjects[len(m.InTotoSubjects)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipGateway(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthGateway
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *InTotoSubject) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowGateway
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: InTotoSubject: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: InTotoSubject: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Kind", wireType)
+			}
+			m.Kind = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGateway
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Kind |= InTotoSubjectKind(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGateway
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGateway
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthGateway
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Digest", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGateway
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGateway
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthGateway
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Digest = append(m.Digest, github_com_opencontainers_go_digest.Digest(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipGateway(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthGateway
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func (m *ReturnRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
@@ -1,6 +1,7 @@
 package frontend
 
 import (
+	attestationtypes "github.com/moby/buildkit/exporter/attestation/types"
 	"github.com/moby/buildkit/solver"
 )
 
@@ -8,6 +9,26 @@ type Result struct {
 	Ref      solver.ResultProxy
 	Refs     map[string]solver.ResultProxy
 	Metadata map[string][]byte
+
+	// Attestations are keyed by the same keys as Refs. Attestations for a
+	// single Ref result use an empty key.
+	Attestations map[string][]Attestation
+}
+
+// Attestation is an attestation that is attached to the exported image of a
+// result. Its content is read from Path in Ref.
+type Attestation struct {
+	Kind   attestationtypes.Kind
+	Ref    solver.ResultProxy
+	Path   string
+	InToto attestationtypes.InToto
+}
+
+func (r *Result) AddAttestation(k string, v Attestation) {
+	if r.Attestations == nil {
+		r.Attestations = map[string][]Attestation{}
+	}
+	r.Attestations[k] = append(r.Attestations[k], v)
 }
 
 func (r *Result) EachRef(fn func(solver.ResultProxy) error) (err error) {
@@ -21,5 +42,14 @@ func (r *Result) EachRef(fn func(solver.ResultProxy) error) (err error) {
 			}
 		}
 	}
+	for _, as := range r.Attestations {
+		for _, a := range as {
+			if a.Ref != nil {
+				if err1 := fn(a.Ref); err1 != nil && err == nil {
+					err = err1
+				}
+			}
+		}
+	}
 	return err
 }
@@ -0,0 +1,75 @@
+package check
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/moby/buildkit/frontend/gateway/client"
+	"github.com/moby/buildkit/frontend/subrequests"
+	"github.com/moby/buildkit/solver/pb"
+)
+
+const RequestCheck = "frontend.check"
+
+var SubrequestCheckDefinition = subrequests.Request{
+	Name:        RequestCheck,
+	Version:     "1.0.0",
+	Type:        subrequests.TypeRPC,
+	Description: "Check the build definition for problems without building it",
+	Metadata: []subrequests.Named{
+		{Name: "result.json"},
+		{Name: "result.txt"},
+	},
+}
+
+type Result struct {
+	Filename string    `json:"filename,omitempty"`
+	Warnings []Warning `json:"warnings"`
+}
+
+type Warning struct {
+	RuleName    string      `json:"ruleName"`
+	Description string      `json:"description,omitempty"`
+	URL         string      `json:"url,omitempty"`
+	Detail      string      `json:"detail,omitempty"`
+	Location    []*pb.Range `json:"location,omitempty"`
+}
+
+func (r Result) PrintText(w io.Writer) error {
+	if len(r.Warnings) == 0 {
+		_, err := fmt.Fprintln(w, "No warnings found.")
+		return err
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 1, ' ', 0)
+	fmt.Fprintf(tw, "LOCATION\tRULE\tDETAIL\n")
+	for _, warning := range r.Warnings {
+		loc := r.Filename
+		if len(warning.Location) > 0 {
+			loc = fmt.Sprintf("%s:%d", loc, warning.Location[0].Start.Line)
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", loc, warning.RuleName, warning.Detail)
+	}
+	return tw.Flush()
+}
+
+func (r Result) ToResult() (*client.Result, error) {
+	dt, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	b := &bytes.Buffer{}
+	if err := r.PrintText(b); err != nil {
+		return nil, err
+	}
+
+	res := client.NewResult()
+	res.Metadata = map[string][]byte{
+		"result.json": dt,
+		"result.txt":  b.Bytes(),
+	}
+	return res, nil
+}
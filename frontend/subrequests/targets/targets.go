@@ -0,0 +1,72 @@
+package targets
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/moby/buildkit/frontend/gateway/client"
+	"github.com/moby/buildkit/frontend/subrequests"
+	"github.com/moby/buildkit/solver/pb"
+)
+
+const RequestTargets = "frontend.targets"
+
+var SubrequestsTargetsDefinition = subrequests.Request{
+	Name:        RequestTargets,
+	Version:     "1.0.0",
+	Type:        subrequests.TypeRPC,
+	Description: "List all targets current build supports",
+	Metadata: []subrequests.Named{
+		{Name: "result.json"},
+		{Name: "result.txt"},
+	},
+}
+
+type List struct {
+	Targets []Target `json:"targets"`
+}
+
+type Target struct {
+	Name        string      `json:"name,omitempty"`
+	Default     bool        `json:"default,omitempty"`
+	Description string      `json:"description,omitempty"`
+	Base        string      `json:"base,omitempty"`
+	Platform    string      `json:"platform,omitempty"`
+	Location    []*pb.Range `json:"location,omitempty"`
+}
+
+func (l List) ToResult() (*client.Result, error) {
+	dt, err := json.MarshalIndent(l, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	b := &bytes.Buffer{}
+	if err := l.PrintText(b); err != nil {
+		return nil, err
+	}
+
+	res := client.NewResult()
+	res.Metadata = map[string][]byte{
+		"result.json": dt,
+		"result.txt":  b.Bytes(),
+	}
+	return res, nil
+}
+
+func (l List) PrintText(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 3, ' ', 0)
+	fmt.Fprintf(tw, "TARGET\tDESCRIPTION\n")
+	for _, t := range l.Targets {
+		name := t.Name
+		if name == "" && t.Default {
+			name = "(default)"
+		} else if t.Default {
+			name = fmt.Sprintf("%s (default)", name)
+		}
+		fmt.Fprintf(tw, "%s\t%s\n", name, t.Description)
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,68 @@
+package filesync
+
+const (
+	// chunkedFileMinSize is the size from which the data of files is sent in
+	// chunks by the chunked diffcopy protocol
+	chunkedFileMinSize = 1 << 20
+
+	chunkMinSize = 16 << 10
+	chunkMaxSize = 256 << 10
+	// boundaries are at the positions where the top 16 bits of the rolling
+	// hash are zero, giving chunks of 64KiB on average above the minimum size
+	chunkMask = uint64(1<<16-1) << 48
+)
+
+// gearTable holds the random values of the gear rolling hash. It must not
+// change between versions as the chunk boundaries would move and chunks of
+// previous transfers could no longer be reused.
+var gearTable [256]uint64
+
+func init() {
+	// splitmix64
+	seed := uint64(0x6275696c646b6974)
+	for i := range gearTable {
+		seed += 0x9e3779b97f4a7c15
+		z := seed
+		z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+		z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+		gearTable[i] = z ^ (z >> 31)
+	}
+}
+
+// chunker splits the data of a file into content-defined chunks so that an
+// insertion or removal only changes the chunks around it.
+type chunker struct {
+	buf []byte
+}
+
+// write adds dt to the buffered data and returns the chunks that are
+// complete. If final is set, all the buffered data is returned.
+func (c *chunker) write(dt []byte, final bool) [][]byte {
+	c.buf = append(c.buf, dt...)
+	var chunks [][]byte
+	for len(c.buf) >= chunkMaxSize || (final && len(c.buf) > 0) {
+		n := cutChunk(c.buf)
+		chunks = append(chunks, c.buf[:n:n])
+		c.buf = c.buf[n:]
+	}
+	return chunks
+}
+
+// cutChunk returns the length of the first chunk of dt.
+func cutChunk(dt []byte) int {
+	if len(dt) <= chunkMinSize {
+		return len(dt)
+	}
+	end := len(dt)
+	if end > chunkMaxSize {
+		end = chunkMaxSize
+	}
+	var h uint64
+	for i := chunkMinSize; i < end; i++ {
+		h = h<<1 + gearTable[dt[i]]
+		if h&chunkMask == 0 {
+			return i + 1
+		}
+	}
+	return end
+}
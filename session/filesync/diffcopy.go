@@ -5,10 +5,12 @@ import (
 	"context"
 	io "io"
 	"os"
+	"sync"
 	"time"
 
 	"github.com/moby/buildkit/util/bklog"
 
+	digest "github.com/opencontainers/go-digest"
 	"github.com/pkg/errors"
 	"github.com/tonistiigi/fsutil"
 	fstypes "github.com/tonistiigi/fsutil/types"
@@ -98,6 +100,333 @@ func recvDiffCopy(ds grpc.ClientStream, dest string, cu CacheUpdater, progress p
 	}))
 }
 
+// haveBatchSize is the number of chunk digests sent in a packet when the
+// receiver lists the chunks it has
+const haveBatchSize = 4096
+
+func sendDiffCopyChunked(stream Stream, fs fsutil.FS, progress progressCb) error {
+	cs := &chunkedSendStream{
+		Stream:   stream,
+		fs:       fs,
+		have:     map[digest.Digest]struct{}{},
+		paths:    map[uint32]string{},
+		chunkers: map[uint32]*chunker{},
+	}
+	return errors.WithStack(fsutil.Send(stream.Context(), cs, fs, progress))
+}
+
+func recvDiffCopyChunked(ds grpc.ClientStream, dest string, cu CacheUpdater, progress progressCb, differ fsutil.DiffType, filter func(string, *fstypes.Stat) bool, store ChunkStore) error {
+	have := store.Chunks()
+	for len(have) > 0 {
+		n := len(have)
+		if n > haveBatchSize {
+			n = haveBatchSize
+		}
+		cp := &ChunkedPacket{Have: make([]string, n)}
+		for i, dgst := range have[:n] {
+			cp.Have[i] = dgst.String()
+		}
+		if err := ds.SendMsg(cp); err != nil {
+			return errors.WithStack(err)
+		}
+		have = have[n:]
+	}
+	return recvDiffCopy(&chunkedRecvStream{
+		ClientStream: ds,
+		store:        store,
+		files:        map[uint32]string{},
+		chunks:       map[uint32][]Chunk{},
+		resends:      map[uint32]*resend{},
+	}, dest, cu, progress, differ, filter)
+}
+
+// chunkedSendStream converts the packets of fsutil.Send to the chunked
+// diffcopy protocol. The data of large files is sent in content-defined
+// chunks, leaving out the data of the chunks the receiver already has.
+type chunkedSendStream struct {
+	Stream
+	fs fsutil.FS
+
+	// sendMu serializes the packets of fsutil.Send and of resent files
+	sendMu sync.Mutex
+
+	mu    sync.Mutex
+	have  map[digest.Digest]struct{}
+	paths map[uint32]string
+
+	// only accessed by SendMsg that fsutil.Send calls serially
+	chunkers map[uint32]*chunker
+	nextID   uint32
+}
+
+func (cs *chunkedSendStream) RecvMsg(m interface{}) error {
+	for {
+		var cp ChunkedPacket
+		if err := cs.Stream.RecvMsg(&cp); err != nil {
+			return err
+		}
+		if len(cp.Have) > 0 {
+			cs.mu.Lock()
+			for _, dgst := range cp.Have {
+				cs.have[digest.Digest(dgst)] = struct{}{}
+			}
+			cs.mu.Unlock()
+			continue
+		}
+		if cp.Resend != nil {
+			if err := cs.resend(cp.Resend.ID); err != nil {
+				return err
+			}
+			continue
+		}
+		if cp.Packet == nil {
+			return errors.New("invalid chunked packet")
+		}
+		*m.(*fstypes.Packet) = *cp.Packet
+		return nil
+	}
+}
+
+// resend sends the data of a file again, with no chunks left out.
+func (cs *chunkedSendStream) resend(id uint32) error {
+	cs.mu.Lock()
+	p, ok := cs.paths[id]
+	cs.mu.Unlock()
+	if !ok {
+		return errors.Errorf("invalid resend request %d", id)
+	}
+	f, err := cs.fs.Open(p)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer f.Close()
+	buf := make([]byte, 32*1024)
+	for {
+		n, err := f.Read(buf)
+		if n > 0 {
+			if err := cs.send(&ChunkedPacket{Resent: &FileChunk{ID: id, Data: buf[:n]}}); err != nil {
+				return err
+			}
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return errors.WithStack(err)
+		}
+	}
+	return cs.send(&ChunkedPacket{Resent: &FileChunk{ID: id}})
+}
+
+func (cs *chunkedSendStream) send(cp *ChunkedPacket) error {
+	cs.sendMu.Lock()
+	defer cs.sendMu.Unlock()
+	return cs.Stream.SendMsg(cp)
+}
+
+func (cs *chunkedSendStream) SendMsg(m interface{}) error {
+	p := m.(*fstypes.Packet)
+	switch p.Type {
+	case fstypes.PACKET_STAT:
+		if p.Stat != nil {
+			// ids are assigned to the stat packets in order
+			if os.FileMode(p.Stat.Mode)&os.ModeType == 0 && p.Stat.Size_ >= chunkedFileMinSize {
+				cs.chunkers[cs.nextID] = &chunker{}
+				cs.mu.Lock()
+				cs.paths[cs.nextID] = p.Stat.Path
+				cs.mu.Unlock()
+			}
+			cs.nextID++
+		}
+	case fstypes.PACKET_DATA:
+		if c, ok := cs.chunkers[p.ID]; ok {
+			return cs.sendChunks(p.ID, c, p.Data)
+		}
+	}
+	return cs.send(&ChunkedPacket{Packet: p})
+}
+
+func (cs *chunkedSendStream) sendChunks(id uint32, c *chunker, dt []byte) error {
+	// an empty data packet marks the end of the file
+	final := len(dt) == 0
+	for _, chunk := range c.write(dt, final) {
+		dgst := digest.FromBytes(chunk)
+		fc := &FileChunk{ID: id, Digest: dgst.String()}
+		cs.mu.Lock()
+		if _, ok := cs.have[dgst]; !ok {
+			fc.Data = chunk
+		}
+		cs.mu.Unlock()
+		if err := cs.send(&ChunkedPacket{Chunk: fc}); err != nil {
+			return err
+		}
+	}
+	if !final {
+		return nil
+	}
+	delete(cs.chunkers, id)
+	return cs.send(&ChunkedPacket{Packet: &fstypes.Packet{Type: fstypes.PACKET_DATA, ID: id}})
+}
+
+// chunkedRecvStream converts the chunked diffcopy protocol to the packets
+// of fsutil.Receive, reading the chunks that were left out from the chunk
+// store and recording the chunks of the received files. If a chunk can't be
+// read from the store, the data of its file is requested again in full.
+type chunkedRecvStream struct {
+	grpc.ClientStream
+	store ChunkStore
+
+	// sendMu serializes the packets of fsutil.Receive and resend requests
+	sendMu sync.Mutex
+
+	// only accessed by RecvMsg that fsutil.Receive calls serially
+	files   map[uint32]string
+	chunks  map[uint32][]Chunk
+	resends map[uint32]*resend
+	nextID  uint32
+}
+
+// resend tracks a file whose data is sent again. The rest of the first
+// transfer is dropped and the data that was already passed on is skipped in
+// the resent data.
+type resend struct {
+	skip int64
+	// done is set for the first transfer and the resend once they ended
+	done [2]bool
+}
+
+func (cs *chunkedRecvStream) SendMsg(m interface{}) error {
+	cs.sendMu.Lock()
+	defer cs.sendMu.Unlock()
+	return cs.ClientStream.SendMsg(&ChunkedPacket{Packet: m.(*fstypes.Packet)})
+}
+
+func (cs *chunkedRecvStream) RecvMsg(m interface{}) error {
+	for {
+		var cp ChunkedPacket
+		if err := cs.ClientStream.RecvMsg(&cp); err != nil {
+			return err
+		}
+		p := m.(*fstypes.Packet)
+		ok, err := cs.recv(&cp, p)
+		if err != nil {
+			return err
+		}
+		if ok {
+			return nil
+		}
+	}
+}
+
+// recv converts a chunked packet to p. It returns false if there is no
+// packet for fsutil.Receive, e.g. for dropped data of a file being resent.
+func (cs *chunkedRecvStream) recv(cp *ChunkedPacket, p *fstypes.Packet) (bool, error) {
+	if fc := cp.Resent; fc != nil {
+		r, ok := cs.resends[fc.ID]
+		if !ok {
+			return false, errors.Errorf("unexpected resent data for %d", fc.ID)
+		}
+		dt := fc.Data
+		if len(dt) == 0 {
+			r.done[1] = true
+			if err := cs.endResend(fc.ID, r); err != nil {
+				return false, err
+			}
+		} else if r.skip > 0 {
+			n := r.skip
+			if n > int64(len(dt)) {
+				n = int64(len(dt))
+			}
+			r.skip -= n
+			if dt = dt[n:]; len(dt) == 0 {
+				return false, nil
+			}
+		}
+		*p = fstypes.Packet{Type: fstypes.PACKET_DATA, ID: fc.ID, Data: dt}
+		return true, nil
+	}
+
+	if fc := cp.Chunk; fc != nil {
+		if _, ok := cs.resends[fc.ID]; ok {
+			return false, nil
+		}
+		dgst, err := digest.Parse(fc.Digest)
+		if err != nil {
+			return false, errors.Wrap(err, "invalid chunk digest")
+		}
+		dt := fc.Data
+		if len(dt) == 0 {
+			if dt, err = cs.store.ReadChunk(dgst); err != nil {
+				bklog.G(cs.Context()).Debugf("resending %s: failed to read chunk %s: %v", cs.files[fc.ID], dgst, err)
+				return false, cs.requestResend(fc.ID)
+			}
+		} else if dgst.Algorithm().FromBytes(dt) != dgst {
+			return false, errors.Errorf("invalid data for chunk %s", dgst)
+		}
+		cs.chunks[fc.ID] = append(cs.chunks[fc.ID], Chunk{Digest: dgst, Size: int64(len(dt))})
+		*p = fstypes.Packet{Type: fstypes.PACKET_DATA, ID: fc.ID, Data: dt}
+		return true, nil
+	}
+
+	if cp.Packet == nil {
+		return false, errors.New("invalid chunked packet")
+	}
+	switch cp.Packet.Type {
+	case fstypes.PACKET_STAT:
+		if st := cp.Packet.Stat; st != nil {
+			if os.FileMode(st.Mode)&os.ModeType == 0 {
+				cs.files[cs.nextID] = st.Path
+			}
+			cs.nextID++
+		}
+	case fstypes.PACKET_DATA:
+		if len(cp.Packet.Data) == 0 {
+			if r, ok := cs.resends[cp.Packet.ID]; ok {
+				// the end of the first transfer of a resent file
+				r.done[0] = true
+				return false, cs.endResend(cp.Packet.ID, r)
+			}
+			if fp, ok := cs.files[cp.Packet.ID]; ok {
+				if err := cs.store.SetFileChunks(fp, cs.chunks[cp.Packet.ID]); err != nil {
+					return false, err
+				}
+				delete(cs.files, cp.Packet.ID)
+				delete(cs.chunks, cp.Packet.ID)
+			}
+		}
+	}
+	*p = *cp.Packet
+	return true, nil
+}
+
+// requestResend asks the sender to send the data of file id again.
+func (cs *chunkedRecvStream) requestResend(id uint32) error {
+	var skip int64
+	for _, c := range cs.chunks[id] {
+		skip += c.Size
+	}
+	cs.resends[id] = &resend{skip: skip}
+	delete(cs.chunks, id)
+
+	cs.sendMu.Lock()
+	defer cs.sendMu.Unlock()
+	return errors.WithStack(cs.ClientStream.SendMsg(&ChunkedPacket{Resend: &ResendRequest{ID: id}}))
+}
+
+// endResend forgets a resent file once both of its transfers ended. Its
+// chunks are not recorded, so the next transfer sends all of its data.
+func (cs *chunkedRecvStream) endResend(id uint32, r *resend) error {
+	if !r.done[0] || !r.done[1] {
+		return nil
+	}
+	delete(cs.resends, id)
+	if fp, ok := cs.files[id]; ok {
+		delete(cs.files, id)
+		return cs.store.SetFileChunks(fp, nil)
+	}
+	return nil
+}
+
 func syncTargetDiffCopy(ds grpc.ServerStream, dest string) error {
 	if err := os.MkdirAll(dest, 0700); err != nil {
 		return errors.Wrapf(err, "failed to create synctarget dest dir %s", dest)
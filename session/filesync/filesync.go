@@ -5,9 +5,11 @@ import (
 	"fmt"
 	io "io"
 	"os"
+	"strconv"
 	"strings"
 
 	"github.com/moby/buildkit/session"
+	digest "github.com/opencontainers/go-digest"
 	"github.com/pkg/errors"
 	"github.com/tonistiigi/fsutil"
 	fstypes "github.com/tonistiigi/fsutil/types"
@@ -24,6 +26,7 @@ const (
 	keyFollowPaths        = "followpaths"
 	keyDirName            = "dir-name"
 	keyExporterMetaPrefix = "exporter-md-"
+	keyExporterID         = "exporter-id"
 )
 
 type fsSyncProvider struct {
@@ -37,6 +40,10 @@ type SyncedDir struct {
 	Dir      string
 	Excludes []string
 	Map      func(string, *fstypes.Stat) bool
+	// BindThrough allows a daemon on the same host to bind-mount the
+	// directory instead of copying it. The directory is only bind-mounted if
+	// Map leaves all of its files unchanged.
+	BindThrough bool
 }
 
 // NewFSSyncProvider creates a new provider for sending files from client
@@ -60,6 +67,9 @@ func (sp *fsSyncProvider) DiffCopy(stream FileSync_DiffCopyServer) error {
 func (sp *fsSyncProvider) TarStream(stream FileSync_TarStreamServer) error {
 	return sp.handle("tarstream", stream)
 }
+func (sp *fsSyncProvider) DiffCopyChunked(stream FileSync_DiffCopyChunkedServer) error {
+	return sp.handle("diffcopychunked", stream)
+}
 
 func (sp *fsSyncProvider) handle(method string, stream grpc.ServerStream) (retErr error) {
 	var pr *protocol
@@ -131,9 +141,17 @@ type protocol struct {
 	name   string
 	sendFn func(stream Stream, fs fsutil.FS, progress progressCb) error
 	recvFn func(stream grpc.ClientStream, destDir string, cu CacheUpdater, progress progressCb, differ fsutil.DiffType, mapFunc func(string, *fstypes.Stat) bool) error
+	// recvChunkedFn replaces recvFn for chunked protocols, which are only
+	// used if the receiver has a chunk store
+	recvChunkedFn func(stream grpc.ClientStream, destDir string, cu CacheUpdater, progress progressCb, differ fsutil.DiffType, mapFunc func(string, *fstypes.Stat) bool, cs ChunkStore) error
 }
 
 var supportedProtocols = []protocol{
+	{
+		name:          "diffcopychunked",
+		sendFn:        sendDiffCopyChunked,
+		recvChunkedFn: recvDiffCopyChunked,
+	},
 	{
 		name:   "diffcopy",
 		sendFn: sendDiffCopy,
@@ -153,6 +171,9 @@ type FSSendRequestOpt struct {
 	ProgressCb       func(int, bool)
 	Filter           func(string, *fstypes.Stat) bool
 	Differ           fsutil.DiffType
+	// ChunkStore enables the chunked transfer of large files if the client
+	// supports it
+	ChunkStore ChunkStore
 }
 
 // CacheUpdater is an object capable of sending notifications for the cache hash changes
@@ -162,10 +183,33 @@ type CacheUpdater interface {
 	ContentHasher() fsutil.ContentHasher
 }
 
+// ChunkStore holds the content-defined chunks of the files of previous
+// transfers so that only the chunks that changed need to be sent again
+type ChunkStore interface {
+	// Chunks returns the digests of the chunks in the store
+	Chunks() []digest.Digest
+	// ReadChunk returns the data of a chunk in the store. If it fails, e.g.
+	// because the file holding the chunk has changed, the data of the file
+	// being received is sent again in full.
+	ReadChunk(dgst digest.Digest) ([]byte, error)
+	// SetFileChunks records the chunks of a file received in the transfer.
+	// Files whose data was not sent in chunks are recorded with no chunks.
+	SetFileChunks(p string, chunks []Chunk) error
+}
+
+// Chunk is a content-defined chunk of a file
+type Chunk struct {
+	Digest digest.Digest
+	Size   int64
+}
+
 // FSSync initializes a transfer of files
 func FSSync(ctx context.Context, c session.Caller, opt FSSendRequestOpt) error {
 	var pr *protocol
 	for _, p := range supportedProtocols {
+		if p.recvChunkedFn != nil && opt.ChunkStore == nil {
+			continue
+		}
 		if c.Supports(session.MethodURL(_FileSync_serviceDesc.ServiceName, p.name)) {
 			pr = &p
 			break
@@ -216,32 +260,55 @@ func FSSync(ctx context.Context, c session.Caller, opt FSSendRequestOpt) error {
 			return err
 		}
 		stream = cc
+	case "diffcopychunked":
+		cc, err := client.DiffCopyChunked(ctx)
+		if err != nil {
+			return err
+		}
+		stream = cc
 	default:
 		panic(fmt.Sprintf("invalid protocol: %q", pr.name))
 	}
 
+	if pr.recvChunkedFn != nil {
+		return pr.recvChunkedFn(stream, opt.DestDir, opt.CacheUpdater, opt.ProgressCb, opt.Differ, opt.Filter, opt.ChunkStore)
+	}
 	return pr.recvFn(stream, opt.DestDir, opt.CacheUpdater, opt.ProgressCb, opt.Differ, opt.Filter)
 }
 
 // NewFSSyncTargetDir allows writing into a directory
 func NewFSSyncTargetDir(outdir string) session.Attachable {
-	p := &fsSyncTarget{
-		outdir: outdir,
-	}
-	return p
+	return NewFSSyncTargets(FSSyncTarget{OutDir: outdir})
 }
 
 // NewFSSyncTarget allows writing into an io.WriteCloser
 func NewFSSyncTarget(f func(map[string]string) (io.WriteCloser, error)) session.Attachable {
+	return NewFSSyncTargets(FSSyncTarget{Output: f})
+}
+
+// FSSyncTarget is where the files of an exporter are written, either a
+// directory or an io.WriteCloser
+type FSSyncTarget struct {
+	// ID is the index of the exporter in the solve request
+	ID     int
+	OutDir string
+	Output func(map[string]string) (io.WriteCloser, error)
+}
+
+// NewFSSyncTargets allows writing the files of multiple exporters of a solve,
+// each into the target with the ID of the exporter
+func NewFSSyncTargets(targets ...FSSyncTarget) session.Attachable {
 	p := &fsSyncTarget{
-		f: f,
+		targets: map[int]FSSyncTarget{},
+	}
+	for _, t := range targets {
+		p.targets[t.ID] = t
 	}
 	return p
 }
 
 type fsSyncTarget struct {
-	outdir string
-	f      func(map[string]string) (io.WriteCloser, error)
+	targets map[int]FSSyncTarget
 }
 
 func (sp *fsSyncTarget) Register(server *grpc.Server) {
@@ -249,21 +316,33 @@ func (sp *fsSyncTarget) Register(server *grpc.Server) {
 }
 
 func (sp *fsSyncTarget) DiffCopy(stream FileSend_DiffCopyServer) (err error) {
-	if sp.outdir != "" {
-		return syncTargetDiffCopy(stream, sp.outdir)
+	opts, _ := metadata.FromIncomingContext(stream.Context()) // if no metadata continue with empty object
+
+	id := 0
+	if v, ok := opts[keyExporterID]; ok && len(v) > 0 {
+		if id, err = strconv.Atoi(v[0]); err != nil {
+			return status.Errorf(codes.InvalidArgument, "invalid exporter id %q", v[0])
+		}
+	}
+	t, ok := sp.targets[id]
+	if !ok {
+		return status.Errorf(codes.NotFound, "no target for exporter %d", id)
+	}
+
+	if t.OutDir != "" {
+		return syncTargetDiffCopy(stream, t.OutDir)
 	}
 
-	if sp.f == nil {
+	if t.Output == nil {
 		return errors.New("empty outfile and outdir")
 	}
-	opts, _ := metadata.FromIncomingContext(stream.Context()) // if no metadata continue with empty object
 	md := map[string]string{}
 	for k, v := range opts {
 		if strings.HasPrefix(k, keyExporterMetaPrefix) {
 			md[strings.TrimPrefix(k, keyExporterMetaPrefix)] = strings.Join(v, ",")
 		}
 	}
-	wc, err := sp.f(md)
+	wc, err := t.Output(md)
 	if err != nil {
 		return err
 	}
@@ -287,6 +366,10 @@ func CopyToCaller(ctx context.Context, fs fsutil.FS, c session.Caller, progress
 
 	client := NewFileSendClient(c.Conn())
 
+	opts := map[string][]string{}
+	setExporterID(ctx, opts)
+	ctx = metadata.NewOutgoingContext(ctx, opts)
+
 	cc, err := client.DiffCopy(ctx)
 	if err != nil {
 		return errors.WithStack(err)
@@ -307,6 +390,7 @@ func CopyFileWriter(ctx context.Context, md map[string]string, c session.Caller)
 	for k, v := range md {
 		opts[keyExporterMetaPrefix+k] = []string{v}
 	}
+	setExporterID(ctx, opts)
 
 	ctx = metadata.NewOutgoingContext(ctx, opts)
 
@@ -318,6 +402,21 @@ func CopyFileWriter(ctx context.Context, md map[string]string, c session.Caller)
 	return newStreamWriter(cc), nil
 }
 
+type exporterIDKey struct{}
+
+// WithExporterID returns a context for the exporter with the index id in the
+// solve request. The files that the exporter sends to the client are written
+// to the target with the same ID.
+func WithExporterID(ctx context.Context, id int) context.Context {
+	return context.WithValue(ctx, exporterIDKey{}, id)
+}
+
+func setExporterID(ctx context.Context, opts map[string][]string) {
+	if id, ok := ctx.Value(exporterIDKey{}).(int); ok {
+		opts[keyExporterID] = []string{strconv.Itoa(id)}
+	}
+}
+
 type InvalidSessionError struct {
 	err error
 }
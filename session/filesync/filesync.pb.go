@@ -74,308 +74,943 @@ func (m *BytesMessage) GetData() []byte {
 	return nil
 }
 
-func init() {
-	proto.RegisterType((*BytesMessage)(nil), "moby.filesync.v1.BytesMessage")
+// ChunkedPacket is a packet of the chunked diffcopy protocol. The receiver
+// first lists the chunks it already has, the data of large files is then
+// sent in content-defined chunks that only carry data if the receiver
+// doesn't have them.
+type ChunkedPacket struct {
+	Packet *types.Packet `protobuf:"bytes,1,opt,name=packet,proto3" json:"packet,omitempty"`
+	// have lists the digests of the chunks the receiver already has
+	Have  []string   `protobuf:"bytes,2,rep,name=have,proto3" json:"have,omitempty"`
+	Chunk *FileChunk `protobuf:"bytes,3,opt,name=chunk,proto3" json:"chunk,omitempty"`
+	// resend asks the sender to send the data of a file again without
+	// leaving out any chunks, e.g. if a chunk the receiver had has changed
+	Resend *ResendRequest `protobuf:"bytes,4,opt,name=resend,proto3" json:"resend,omitempty"`
+	// resent is a part of the data of a file sent again, an empty part marks
+	// the end of the file
+	Resent *FileChunk `protobuf:"bytes,5,opt,name=resent,proto3" json:"resent,omitempty"`
+}
+
+func (m *ChunkedPacket) Reset()      { *m = ChunkedPacket{} }
+func (*ChunkedPacket) ProtoMessage() {}
+func (*ChunkedPacket) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d1042549f1f24495, []int{1}
+}
+func (m *ChunkedPacket) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ChunkedPacket) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ChunkedPacket.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ChunkedPacket) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChunkedPacket.Merge(m, src)
+}
+func (m *ChunkedPacket) XXX_Size() int {
+	return m.Size()
+}
+func (m *ChunkedPacket) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChunkedPacket.DiscardUnknown(m)
 }
 
-func init() { proto.RegisterFile("filesync.proto", fileDescriptor_d1042549f1f24495) }
+var xxx_messageInfo_ChunkedPacket proto.InternalMessageInfo
 
-var fileDescriptor_d1042549f1f24495 = []byte{
-	// 281 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0xe2, 0x4b, 0xcb, 0xcc, 0x49,
-	0x2d, 0xae, 0xcc, 0x4b, 0xd6, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0x12, 0xc8, 0xcd, 0x4f, 0xaa,
-	0xd4, 0x83, 0x0b, 0x96, 0x19, 0x4a, 0xe9, 0xa6, 0x67, 0x96, 0x64, 0x94, 0x26, 0xe9, 0x25, 0xe7,
-	0xe7, 0xea, 0x97, 0xe4, 0xe7, 0x65, 0x16, 0x97, 0x64, 0x66, 0xa6, 0x67, 0xea, 0xa7, 0x15, 0x97,
-	0x96, 0x64, 0xe6, 0xe8, 0x97, 0x54, 0x16, 0xa4, 0x16, 0xeb, 0x97, 0x67, 0x16, 0xa5, 0x42, 0x0c,
-	0x50, 0x52, 0xe2, 0xe2, 0x71, 0xaa, 0x2c, 0x49, 0x2d, 0xf6, 0x4d, 0x2d, 0x2e, 0x4e, 0x4c, 0x4f,
-	0x15, 0x12, 0xe2, 0x62, 0x49, 0x49, 0x2c, 0x49, 0x94, 0x60, 0x54, 0x60, 0xd4, 0xe0, 0x09, 0x02,
-	0xb3, 0x8d, 0x9a, 0x19, 0xb9, 0x38, 0xdc, 0x32, 0x73, 0x52, 0x83, 0x2b, 0xf3, 0x92, 0x85, 0xac,
-	0xb8, 0x38, 0x5c, 0x32, 0xd3, 0xd2, 0x9c, 0xf3, 0x0b, 0x2a, 0x85, 0x44, 0xf4, 0x20, 0xc6, 0xea,
-	0x81, 0x8d, 0xd5, 0x0b, 0x48, 0x4c, 0xce, 0x4e, 0x2d, 0x91, 0xc2, 0x2a, 0xaa, 0xc1, 0x68, 0xc0,
-	0x28, 0x64, 0xcd, 0xc5, 0x19, 0x92, 0x58, 0x14, 0x5c, 0x52, 0x94, 0x9a, 0x98, 0x4b, 0xaa, 0x66,
-	0xa3, 0x28, 0xa8, 0x23, 0x52, 0xf3, 0x52, 0x84, 0xfc, 0x90, 0x1c, 0x21, 0xa7, 0x87, 0x1e, 0x06,
-	0x7a, 0xc8, 0x3e, 0x92, 0x22, 0x20, 0x0f, 0x32, 0xdb, 0xc9, 0xee, 0xc2, 0x43, 0x39, 0x86, 0x1b,
-	0x0f, 0xe5, 0x18, 0x3e, 0x3c, 0x94, 0x63, 0x6c, 0x78, 0x24, 0xc7, 0xb8, 0xe2, 0x91, 0x1c, 0xe3,
-	0x89, 0x47, 0x72, 0x8c, 0x17, 0x1e, 0xc9, 0x31, 0x3e, 0x78, 0x24, 0xc7, 0xf8, 0xe2, 0x91, 0x1c,
-	0xc3, 0x87, 0x47, 0x72, 0x8c, 0x13, 0x1e, 0xcb, 0x31, 0x5c, 0x78, 0x2c, 0xc7, 0x70, 0xe3, 0xb1,
-	0x1c, 0x43, 0x14, 0x07, 0xcc, 0xcc, 0x24, 0x36, 0x70, 0x60, 0x1a, 0x03, 0x02, 0x00, 0x00, 0xff,
-	0xff, 0xe6, 0x17, 0x63, 0x59, 0x9f, 0x01, 0x00, 0x00,
+func (m *ChunkedPacket) GetPacket() *types.Packet {
+	if m != nil {
+		return m.Packet
+	}
+	return nil
 }
 
-func (this *BytesMessage) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
+func (m *ChunkedPacket) GetHave() []string {
+	if m != nil {
+		return m.Have
 	}
+	return nil
+}
 
-	that1, ok := that.(*BytesMessage)
-	if !ok {
-		that2, ok := that.(BytesMessage)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
-		}
-	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if !bytes.Equal(this.Data, that1.Data) {
-		return false
+func (m *ChunkedPacket) GetChunk() *FileChunk {
+	if m != nil {
+		return m.Chunk
 	}
-	return true
+	return nil
 }
-func (this *BytesMessage) GoString() string {
-	if this == nil {
-		return "nil"
+
+func (m *ChunkedPacket) GetResend() *ResendRequest {
+	if m != nil {
+		return m.Resend
 	}
-	s := make([]string, 0, 5)
-	s = append(s, "&filesync.BytesMessage{")
-	s = append(s, "Data: "+fmt.Sprintf("%#v", this.Data)+",\n")
-	s = append(s, "}")
-	return strings.Join(s, "")
+	return nil
 }
-func valueToGoStringFilesync(v interface{}, typ string) string {
-	rv := reflect.ValueOf(v)
-	if rv.IsNil() {
-		return "nil"
+
+func (m *ChunkedPacket) GetResent() *FileChunk {
+	if m != nil {
+		return m.Resent
 	}
-	pv := reflect.Indirect(rv).Interface()
-	return fmt.Sprintf("func(v %v) *%v { return &v } ( %#v )", typ, typ, pv)
+	return nil
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
-
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
-
-// FileSyncClient is the client API for FileSync service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type FileSyncClient interface {
-	DiffCopy(ctx context.Context, opts ...grpc.CallOption) (FileSync_DiffCopyClient, error)
-	TarStream(ctx context.Context, opts ...grpc.CallOption) (FileSync_TarStreamClient, error)
+// FileChunk is a content-defined chunk of the data of a file
+type FileChunk struct {
+	ID     uint32 `protobuf:"varint,1,opt,name=ID,proto3" json:"ID,omitempty"`
+	Digest string `protobuf:"bytes,2,opt,name=digest,proto3" json:"digest,omitempty"`
+	Data   []byte `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
 }
 
-type fileSyncClient struct {
-	cc *grpc.ClientConn
+func (m *FileChunk) Reset()      { *m = FileChunk{} }
+func (*FileChunk) ProtoMessage() {}
+func (*FileChunk) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d1042549f1f24495, []int{2}
 }
-
-func NewFileSyncClient(cc *grpc.ClientConn) FileSyncClient {
-	return &fileSyncClient{cc}
+func (m *FileChunk) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
 }
-
-func (c *fileSyncClient) DiffCopy(ctx context.Context, opts ...grpc.CallOption) (FileSync_DiffCopyClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_FileSync_serviceDesc.Streams[0], "/moby.filesync.v1.FileSync/DiffCopy", opts...)
-	if err != nil {
-		return nil, err
+func (m *FileChunk) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_FileChunk.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	x := &fileSyncDiffCopyClient{stream}
-	return x, nil
 }
-
-type FileSync_DiffCopyClient interface {
-	Send(*types.Packet) error
-	Recv() (*types.Packet, error)
-	grpc.ClientStream
+func (m *FileChunk) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FileChunk.Merge(m, src)
 }
-
-type fileSyncDiffCopyClient struct {
-	grpc.ClientStream
+func (m *FileChunk) XXX_Size() int {
+	return m.Size()
 }
-
-func (x *fileSyncDiffCopyClient) Send(m *types.Packet) error {
-	return x.ClientStream.SendMsg(m)
+func (m *FileChunk) XXX_DiscardUnknown() {
+	xxx_messageInfo_FileChunk.DiscardUnknown(m)
 }
 
-func (x *fileSyncDiffCopyClient) Recv() (*types.Packet, error) {
-	m := new(types.Packet)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
-		return nil, err
+var xxx_messageInfo_FileChunk proto.InternalMessageInfo
+
+func (m *FileChunk) GetID() uint32 {
+	if m != nil {
+		return m.ID
 	}
-	return m, nil
+	return 0
 }
 
-func (c *fileSyncClient) TarStream(ctx context.Context, opts ...grpc.CallOption) (FileSync_TarStreamClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_FileSync_serviceDesc.Streams[1], "/moby.filesync.v1.FileSync/TarStream", opts...)
-	if err != nil {
-		return nil, err
+func (m *FileChunk) GetDigest() string {
+	if m != nil {
+		return m.Digest
 	}
-	x := &fileSyncTarStreamClient{stream}
-	return x, nil
+	return ""
 }
 
-type FileSync_TarStreamClient interface {
-	Send(*types.Packet) error
-	Recv() (*types.Packet, error)
-	grpc.ClientStream
+func (m *FileChunk) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
 }
 
-type fileSyncTarStreamClient struct {
-	grpc.ClientStream
+// ResendRequest identifies the file whose data is sent again
+type ResendRequest struct {
+	ID uint32 `protobuf:"varint,1,opt,name=ID,proto3" json:"ID,omitempty"`
 }
 
-func (x *fileSyncTarStreamClient) Send(m *types.Packet) error {
-	return x.ClientStream.SendMsg(m)
+func (m *ResendRequest) Reset()      { *m = ResendRequest{} }
+func (*ResendRequest) ProtoMessage() {}
+func (*ResendRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d1042549f1f24495, []int{3}
 }
-
-func (x *fileSyncTarStreamClient) Recv() (*types.Packet, error) {
-	m := new(types.Packet)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
-		return nil, err
+func (m *ResendRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ResendRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ResendRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return m, nil
+}
+func (m *ResendRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResendRequest.Merge(m, src)
+}
+func (m *ResendRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *ResendRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResendRequest.DiscardUnknown(m)
 }
 
-// FileSyncServer is the server API for FileSync service.
-type FileSyncServer interface {
-	DiffCopy(FileSync_DiffCopyServer) error
-	TarStream(FileSync_TarStreamServer) error
+var xxx_messageInfo_ResendRequest proto.InternalMessageInfo
+
+func (m *ResendRequest) GetID() uint32 {
+	if m != nil {
+		return m.ID
+	}
+	return 0
 }
 
-// UnimplementedFileSyncServer can be embedded to have forward compatible implementations.
-type UnimplementedFileSyncServer struct {
+// LocalDirRequest asks for the location of a synced directory on the host of
+// the client
+type LocalDirRequest struct {
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
 }
 
-func (*UnimplementedFileSyncServer) DiffCopy(srv FileSync_DiffCopyServer) error {
-	return status.Errorf(codes.Unimplemented, "method DiffCopy not implemented")
+func (m *LocalDirRequest) Reset()      { *m = LocalDirRequest{} }
+func (*LocalDirRequest) ProtoMessage() {}
+func (*LocalDirRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d1042549f1f24495, []int{4}
 }
-func (*UnimplementedFileSyncServer) TarStream(srv FileSync_TarStreamServer) error {
-	return status.Errorf(codes.Unimplemented, "method TarStream not implemented")
+func (m *LocalDirRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
 }
-
-func RegisterFileSyncServer(s *grpc.Server, srv FileSyncServer) {
-	s.RegisterService(&_FileSync_serviceDesc, srv)
+func (m *LocalDirRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_LocalDirRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
 }
-
-func _FileSync_DiffCopy_Handler(srv interface{}, stream grpc.ServerStream) error {
-	return srv.(FileSyncServer).DiffCopy(&fileSyncDiffCopyServer{stream})
+func (m *LocalDirRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LocalDirRequest.Merge(m, src)
+}
+func (m *LocalDirRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *LocalDirRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_LocalDirRequest.DiscardUnknown(m)
 }
 
-type FileSync_DiffCopyServer interface {
-	Send(*types.Packet) error
-	Recv() (*types.Packet, error)
-	grpc.ServerStream
+var xxx_messageInfo_LocalDirRequest proto.InternalMessageInfo
+
+func (m *LocalDirRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
 }
 
-type fileSyncDiffCopyServer struct {
-	grpc.ServerStream
+// LocalDirResponse is the location of a synced directory on the host of the
+// client. The device and inode numbers let the daemon check that the path
+// refers to the same directory for it.
+type LocalDirResponse struct {
+	Path string `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	Dev  uint64 `protobuf:"varint,2,opt,name=dev,proto3" json:"dev,omitempty"`
+	Ino  uint64 `protobuf:"varint,3,opt,name=ino,proto3" json:"ino,omitempty"`
 }
 
-func (x *fileSyncDiffCopyServer) Send(m *types.Packet) error {
-	return x.ServerStream.SendMsg(m)
+func (m *LocalDirResponse) Reset()      { *m = LocalDirResponse{} }
+func (*LocalDirResponse) ProtoMessage() {}
+func (*LocalDirResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d1042549f1f24495, []int{5}
+}
+func (m *LocalDirResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *LocalDirResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_LocalDirResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *LocalDirResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LocalDirResponse.Merge(m, src)
+}
+func (m *LocalDirResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *LocalDirResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_LocalDirResponse.DiscardUnknown(m)
 }
 
-func (x *fileSyncDiffCopyServer) Recv() (*types.Packet, error) {
-	m := new(types.Packet)
-	if err := x.ServerStream.RecvMsg(m); err != nil {
-		return nil, err
+var xxx_messageInfo_LocalDirResponse proto.InternalMessageInfo
+
+func (m *LocalDirResponse) GetPath() string {
+	if m != nil {
+		return m.Path
 	}
-	return m, nil
+	return ""
 }
 
-func _FileSync_TarStream_Handler(srv interface{}, stream grpc.ServerStream) error {
-	return srv.(FileSyncServer).TarStream(&fileSyncTarStreamServer{stream})
+func (m *LocalDirResponse) GetDev() uint64 {
+	if m != nil {
+		return m.Dev
+	}
+	return 0
 }
 
-type FileSync_TarStreamServer interface {
-	Send(*types.Packet) error
-	Recv() (*types.Packet, error)
-	grpc.ServerStream
+func (m *LocalDirResponse) GetIno() uint64 {
+	if m != nil {
+		return m.Ino
+	}
+	return 0
 }
 
-type fileSyncTarStreamServer struct {
-	grpc.ServerStream
+func init() {
+	proto.RegisterType((*BytesMessage)(nil), "moby.filesync.v1.BytesMessage")
+	proto.RegisterType((*ChunkedPacket)(nil), "moby.filesync.v1.ChunkedPacket")
+	proto.RegisterType((*FileChunk)(nil), "moby.filesync.v1.FileChunk")
+	proto.RegisterType((*ResendRequest)(nil), "moby.filesync.v1.ResendRequest")
+	proto.RegisterType((*LocalDirRequest)(nil), "moby.filesync.v1.LocalDirRequest")
+	proto.RegisterType((*LocalDirResponse)(nil), "moby.filesync.v1.LocalDirResponse")
 }
 
-func (x *fileSyncTarStreamServer) Send(m *types.Packet) error {
-	return x.ServerStream.SendMsg(m)
+func init() { proto.RegisterFile("filesync.proto", fileDescriptor_d1042549f1f24495) }
+
+var fileDescriptor_d1042549f1f24495 = []byte{
+	// 525 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x93, 0x41, 0x8f, 0xd2, 0x4e,
+	0x18, 0xc6, 0x3b, 0xc0, 0x12, 0x78, 0xff, 0xcb, 0x2e, 0x99, 0xfc, 0x63, 0x1a, 0x4c, 0x66, 0xb1,
+	0x89, 0x09, 0x07, 0x2d, 0x2e, 0x7b, 0x30, 0xd1, 0xc4, 0xc3, 0x2e, 0xd1, 0xac, 0x51, 0xa3, 0xb3,
+	0x26, 0x26, 0x7b, 0x1b, 0xda, 0x01, 0x26, 0x0b, 0x6d, 0xed, 0x0c, 0x98, 0xde, 0xfc, 0x08, 0x7e,
+	0x0c, 0x6f, 0x7e, 0x0d, 0x8f, 0x1c, 0xf7, 0x28, 0xe5, 0xe2, 0x71, 0xe3, 0x27, 0x30, 0x9d, 0xb6,
+	0xc8, 0x02, 0x91, 0x78, 0x7b, 0xfa, 0xf6, 0xf7, 0x3e, 0xf3, 0xce, 0x33, 0x33, 0x70, 0xd0, 0x17,
+	0x23, 0x2e, 0x23, 0xcf, 0xb1, 0x83, 0xd0, 0x57, 0x3e, 0xae, 0x8f, 0xfd, 0x5e, 0x64, 0x2f, 0x8b,
+	0xd3, 0xe3, 0xc6, 0xc3, 0x81, 0x50, 0xc3, 0x49, 0xcf, 0x76, 0xfc, 0x71, 0x5b, 0xf9, 0x9e, 0x90,
+	0x4a, 0x88, 0x81, 0x68, 0xf7, 0xe5, 0x44, 0x89, 0x51, 0x5b, 0x45, 0x01, 0x97, 0xed, 0x4f, 0x22,
+	0xe4, 0xa9, 0x81, 0x65, 0xc1, 0xfe, 0x69, 0xa4, 0xb8, 0x7c, 0xcd, 0xa5, 0x64, 0x03, 0x8e, 0x31,
+	0x94, 0x5c, 0xa6, 0x98, 0x89, 0x9a, 0xa8, 0xb5, 0x4f, 0xb5, 0xb6, 0x7e, 0x21, 0xa8, 0x9d, 0x0d,
+	0x27, 0xde, 0x15, 0x77, 0xdf, 0x32, 0xe7, 0x8a, 0x2b, 0xfc, 0x00, 0xca, 0x81, 0x56, 0x9a, 0xfb,
+	0xaf, 0xf3, 0xbf, 0x9d, 0xfa, 0xdb, 0xda, 0xdf, 0x4e, 0x29, 0x9a, 0x31, 0x89, 0xe7, 0x90, 0x4d,
+	0xb9, 0x59, 0x68, 0x16, 0x5b, 0x55, 0xaa, 0x35, 0x3e, 0x86, 0x3d, 0x27, 0xb1, 0x34, 0x8b, 0xda,
+	0xe0, 0xae, 0xbd, 0xbe, 0x11, 0xfb, 0xb9, 0x18, 0x71, 0xbd, 0x2a, 0x4d, 0x49, 0xfc, 0x18, 0xca,
+	0x21, 0x97, 0xdc, 0x73, 0xcd, 0x92, 0xee, 0x39, 0xda, 0xec, 0xa1, 0xfa, 0x3f, 0xe5, 0x1f, 0x27,
+	0x5c, 0x2a, 0x9a, 0xe1, 0xf8, 0x24, 0x6b, 0x54, 0xe6, 0xde, 0xee, 0xc5, 0x32, 0xd4, 0x7a, 0x01,
+	0xd5, 0x65, 0x11, 0x1f, 0x40, 0xe1, 0xbc, 0xab, 0xf7, 0x5a, 0xa3, 0x85, 0xf3, 0x2e, 0xbe, 0x03,
+	0x65, 0x57, 0x0c, 0xb8, 0x54, 0x66, 0xa1, 0x89, 0x5a, 0x55, 0x9a, 0x7d, 0x2d, 0xd3, 0x2b, 0xae,
+	0xa4, 0x77, 0x04, 0xb5, 0x5b, 0x63, 0xad, 0x9b, 0x59, 0xf7, 0xe1, 0xf0, 0x95, 0xef, 0xb0, 0x51,
+	0x57, 0x84, 0x39, 0x82, 0xa1, 0xe4, 0xb1, 0x31, 0xd7, 0x50, 0x95, 0x6a, 0x6d, 0xbd, 0x84, 0xfa,
+	0x1f, 0x4c, 0x06, 0xbe, 0x27, 0xf5, 0x69, 0x05, 0x4c, 0x0d, 0x73, 0x2e, 0xd1, 0xb8, 0x0e, 0x45,
+	0x97, 0x4f, 0xf5, 0x60, 0x25, 0x9a, 0xc8, 0xa4, 0x22, 0x3c, 0x5f, 0x0f, 0x55, 0xa2, 0x89, 0xec,
+	0x7c, 0x2b, 0x40, 0x25, 0xd9, 0xdd, 0x45, 0xe4, 0x39, 0xf8, 0x09, 0x54, 0xba, 0xa2, 0xdf, 0x3f,
+	0xf3, 0x83, 0x08, 0x6f, 0x3d, 0xc8, 0xc6, 0xd6, 0x6a, 0x0b, 0x3d, 0x42, 0xf8, 0x29, 0x54, 0xdf,
+	0xb3, 0xf0, 0x42, 0x85, 0x9c, 0x8d, 0xff, 0xb9, 0xf9, 0x03, 0x1c, 0xe6, 0x0b, 0x67, 0xd7, 0x0b,
+	0x6f, 0x39, 0xd3, 0x5b, 0x37, 0xaf, 0xb1, 0x0b, 0xd0, 0xc6, 0xef, 0xa0, 0x92, 0x47, 0x85, 0xef,
+	0x6d, 0x36, 0xac, 0xa5, 0xdd, 0xb0, 0xfe, 0x86, 0xa4, 0x49, 0x77, 0x2e, 0xb3, 0xc0, 0x92, 0xfb,
+	0xf4, 0x66, 0x25, 0x30, 0xb2, 0xd9, 0xbb, 0xfa, 0x9e, 0x1a, 0x3b, 0xfe, 0x27, 0xe3, 0x9e, 0x3e,
+	0x9b, 0xcd, 0x89, 0x71, 0x3d, 0x27, 0xc6, 0xcd, 0x9c, 0xa0, 0xcf, 0x31, 0x41, 0x5f, 0x63, 0x82,
+	0xbe, 0xc7, 0x04, 0xcd, 0x62, 0x82, 0x7e, 0xc4, 0x04, 0xfd, 0x8c, 0x89, 0x71, 0x13, 0x13, 0xf4,
+	0x65, 0x41, 0x8c, 0xd9, 0x82, 0x18, 0xd7, 0x0b, 0x62, 0x5c, 0x56, 0x72, 0xcf, 0x5e, 0x59, 0x3f,
+	0xe5, 0x93, 0xdf, 0x03, 0x00, 0xc7, 0x00, 0x7b, 0x29, 0x1d, 0x04, 0x00, 0x00,
 }
 
-func (x *fileSyncTarStreamServer) Recv() (*types.Packet, error) {
-	m := new(types.Packet)
-	if err := x.ServerStream.RecvMsg(m); err != nil {
-		return nil, err
+func (this *BytesMessage) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	return m, nil
-}
 
-var _FileSync_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "moby.filesync.v1.FileSync",
-	HandlerType: (*FileSyncServer)(nil),
-	Methods:     []grpc.MethodDesc{},
-	Streams: []grpc.StreamDesc{
-		{
-			StreamName:    "DiffCopy",
-			Handler:       _FileSync_DiffCopy_Handler,
-			ServerStreams: true,
-			ClientStreams: true,
-		},
-		{
-			StreamName:    "TarStream",
-			Handler:       _FileSync_TarStream_Handler,
-			ServerStreams: true,
-			ClientStreams: true,
-		},
-	},
-	Metadata: "filesync.proto",
+	that1, ok := that.(*BytesMessage)
+	if !ok {
+		that2, ok := that.(BytesMessage)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if !bytes.Equal(this.Data, that1.Data) {
+		return false
+	}
+	return true
 }
+func (this *ChunkedPacket) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-// FileSendClient is the client API for FileSend service.
+	that1, ok := that.(*ChunkedPacket)
+	if !ok {
+		that2, ok := that.(ChunkedPacket)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if !this.Packet.Equal(that1.Packet) {
+		return false
+	}
+	if len(this.Have) != len(that1.Have) {
+		return false
+	}
+	for i := range this.Have {
+		if this.Have[i] != that1.Have[i] {
+			return false
+		}
+	}
+	if !this.Chunk.Equal(that1.Chunk) {
+		return false
+	}
+	if !this.Resend.Equal(that1.Resend) {
+		return false
+	}
+	if !this.Resent.Equal(that1.Resent) {
+		return false
+	}
+	return true
+}
+func (this *FileChunk) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*FileChunk)
+	if !ok {
+		that2, ok := that.(FileChunk)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.ID != that1.ID {
+		return false
+	}
+	if this.Digest != that1.Digest {
+		return false
+	}
+	if !bytes.Equal(this.Data, that1.Data) {
+		return false
+	}
+	return true
+}
+func (this *ResendRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*ResendRequest)
+	if !ok {
+		that2, ok := that.(ResendRequest)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.ID != that1.ID {
+		return false
+	}
+	return true
+}
+func (this *LocalDirRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*LocalDirRequest)
+	if !ok {
+		that2, ok := that.(LocalDirRequest)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.Name != that1.Name {
+		return false
+	}
+	return true
+}
+func (this *LocalDirResponse) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*LocalDirResponse)
+	if !ok {
+		that2, ok := that.(LocalDirResponse)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.Path != that1.Path {
+		return false
+	}
+	if this.Dev != that1.Dev {
+		return false
+	}
+	if this.Ino != that1.Ino {
+		return false
+	}
+	return true
+}
+func (this *BytesMessage) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 5)
+	s = append(s, "&filesync.BytesMessage{")
+	s = append(s, "Data: "+fmt.Sprintf("%#v", this.Data)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *ChunkedPacket) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 9)
+	s = append(s, "&filesync.ChunkedPacket{")
+	if this.Packet != nil {
+		s = append(s, "Packet: "+fmt.Sprintf("%#v", this.Packet)+",\n")
+	}
+	s = append(s, "Have: "+fmt.Sprintf("%#v", this.Have)+",\n")
+	if this.Chunk != nil {
+		s = append(s, "Chunk: "+fmt.Sprintf("%#v", this.Chunk)+",\n")
+	}
+	if this.Resend != nil {
+		s = append(s, "Resend: "+fmt.Sprintf("%#v", this.Resend)+",\n")
+	}
+	if this.Resent != nil {
+		s = append(s, "Resent: "+fmt.Sprintf("%#v", this.Resent)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *FileChunk) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 7)
+	s = append(s, "&filesync.FileChunk{")
+	s = append(s, "ID: "+fmt.Sprintf("%#v", this.ID)+",\n")
+	s = append(s, "Digest: "+fmt.Sprintf("%#v", this.Digest)+",\n")
+	s = append(s, "Data: "+fmt.Sprintf("%#v", this.Data)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *ResendRequest) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 5)
+	s = append(s, "&filesync.ResendRequest{")
+	s = append(s, "ID: "+fmt.Sprintf("%#v", this.ID)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *LocalDirRequest) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 5)
+	s = append(s, "&filesync.LocalDirRequest{")
+	s = append(s, "Name: "+fmt.Sprintf("%#v", this.Name)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *LocalDirResponse) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 7)
+	s = append(s, "&filesync.LocalDirResponse{")
+	s = append(s, "Path: "+fmt.Sprintf("%#v", this.Path)+",\n")
+	s = append(s, "Dev: "+fmt.Sprintf("%#v", this.Dev)+",\n")
+	s = append(s, "Ino: "+fmt.Sprintf("%#v", this.Ino)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func valueToGoStringFilesync(v interface{}, typ string) string {
+	rv := reflect.ValueOf(v)
+	if rv.IsNil() {
+		return "nil"
+	}
+	pv := reflect.Indirect(rv).Interface()
+	return fmt.Sprintf("func(v %v) *%v { return &v } ( %#v )", typ, typ, pv)
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// FileSyncClient is the client API for FileSync service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type FileSendClient interface {
-	DiffCopy(ctx context.Context, opts ...grpc.CallOption) (FileSend_DiffCopyClient, error)
+type FileSyncClient interface {
+	DiffCopy(ctx context.Context, opts ...grpc.CallOption) (FileSync_DiffCopyClient, error)
+	TarStream(ctx context.Context, opts ...grpc.CallOption) (FileSync_TarStreamClient, error)
+	DiffCopyChunked(ctx context.Context, opts ...grpc.CallOption) (FileSync_DiffCopyChunkedClient, error)
+	LocalDir(ctx context.Context, in *LocalDirRequest, opts ...grpc.CallOption) (*LocalDirResponse, error)
 }
 
-type fileSendClient struct {
+type fileSyncClient struct {
 	cc *grpc.ClientConn
 }
 
-func NewFileSendClient(cc *grpc.ClientConn) FileSendClient {
-	return &fileSendClient{cc}
+func NewFileSyncClient(cc *grpc.ClientConn) FileSyncClient {
+	return &fileSyncClient{cc}
 }
 
-func (c *fileSendClient) DiffCopy(ctx context.Context, opts ...grpc.CallOption) (FileSend_DiffCopyClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_FileSend_serviceDesc.Streams[0], "/moby.filesync.v1.FileSend/DiffCopy", opts...)
+func (c *fileSyncClient) DiffCopy(ctx context.Context, opts ...grpc.CallOption) (FileSync_DiffCopyClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_FileSync_serviceDesc.Streams[0], "/moby.filesync.v1.FileSync/DiffCopy", opts...)
 	if err != nil {
 		return nil, err
 	}
-	x := &fileSendDiffCopyClient{stream}
+	x := &fileSyncDiffCopyClient{stream}
 	return x, nil
 }
 
-type FileSend_DiffCopyClient interface {
-	Send(*BytesMessage) error
-	Recv() (*BytesMessage, error)
+type FileSync_DiffCopyClient interface {
+	Send(*types.Packet) error
+	Recv() (*types.Packet, error)
 	grpc.ClientStream
 }
 
-type fileSendDiffCopyClient struct {
+type fileSyncDiffCopyClient struct {
 	grpc.ClientStream
 }
 
-func (x *fileSendDiffCopyClient) Send(m *BytesMessage) error {
+func (x *fileSyncDiffCopyClient) Send(m *types.Packet) error {
 	return x.ClientStream.SendMsg(m)
 }
 
-func (x *fileSendDiffCopyClient) Recv() (*BytesMessage, error) {
-	m := new(BytesMessage)
+func (x *fileSyncDiffCopyClient) Recv() (*types.Packet, error) {
+	m := new(types.Packet)
 	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
 	return m, nil
 }
 
-// FileSendServer is the server API for FileSend service.
-type FileSendServer interface {
-	DiffCopy(FileSend_DiffCopyServer) error
-}
-
+func (c *fileSyncClient) TarStream(ctx context.Context, opts ...grpc.CallOption) (FileSync_TarStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_FileSync_serviceDesc.Streams[1], "/moby.filesync.v1.FileSync/TarStream", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &fileSyncTarStreamClient{stream}
+	return x, nil
+}
+
+type FileSync_TarStreamClient interface {
+	Send(*types.Packet) error
+	Recv() (*types.Packet, error)
+	grpc.ClientStream
+}
+
+type fileSyncTarStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *fileSyncTarStreamClient) Send(m *types.Packet) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *fileSyncTarStreamClient) Recv() (*types.Packet, error) {
+	m := new(types.Packet)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *fileSyncClient) DiffCopyChunked(ctx context.Context, opts ...grpc.CallOption) (FileSync_DiffCopyChunkedClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_FileSync_serviceDesc.Streams[2], "/moby.filesync.v1.FileSync/DiffCopyChunked", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &fileSyncDiffCopyChunkedClient{stream}
+	return x, nil
+}
+
+type FileSync_DiffCopyChunkedClient interface {
+	Send(*ChunkedPacket) error
+	Recv() (*ChunkedPacket, error)
+	grpc.ClientStream
+}
+
+type fileSyncDiffCopyChunkedClient struct {
+	grpc.ClientStream
+}
+
+func (x *fileSyncDiffCopyChunkedClient) Send(m *ChunkedPacket) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *fileSyncDiffCopyChunkedClient) Recv() (*ChunkedPacket, error) {
+	m := new(ChunkedPacket)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *fileSyncClient) LocalDir(ctx context.Context, in *LocalDirRequest, opts ...grpc.CallOption) (*LocalDirResponse, error) {
+	out := new(LocalDirResponse)
+	err := c.cc.Invoke(ctx, "/moby.filesync.v1.FileSync/LocalDir", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// FileSyncServer is the server API for FileSync service.
+type FileSyncServer interface {
+	DiffCopy(FileSync_DiffCopyServer) error
+	TarStream(FileSync_TarStreamServer) error
+	DiffCopyChunked(FileSync_DiffCopyChunkedServer) error
+	LocalDir(context.Context, *LocalDirRequest) (*LocalDirResponse, error)
+}
+
+// UnimplementedFileSyncServer can be embedded to have forward compatible implementations.
+type UnimplementedFileSyncServer struct {
+}
+
+func (*UnimplementedFileSyncServer) DiffCopy(srv FileSync_DiffCopyServer) error {
+	return status.Errorf(codes.Unimplemented, "method DiffCopy not implemented")
+}
+func (*UnimplementedFileSyncServer) TarStream(srv FileSync_TarStreamServer) error {
+	return status.Errorf(codes.Unimplemented, "method TarStream not implemented")
+}
+func (*UnimplementedFileSyncServer) DiffCopyChunked(srv FileSync_DiffCopyChunkedServer) error {
+	return status.Errorf(codes.Unimplemented, "method DiffCopyChunked not implemented")
+}
+func (*UnimplementedFileSyncServer) LocalDir(ctx context.Context, req *LocalDirRequest) (*LocalDirResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LocalDir not implemented")
+}
+
+func RegisterFileSyncServer(s *grpc.Server, srv FileSyncServer) {
+	s.RegisterService(&_FileSync_serviceDesc, srv)
+}
+
+func _FileSync_DiffCopy_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(FileSyncServer).DiffCopy(&fileSyncDiffCopyServer{stream})
+}
+
+type FileSync_DiffCopyServer interface {
+	Send(*types.Packet) error
+	Recv() (*types.Packet, error)
+	grpc.ServerStream
+}
+
+type fileSyncDiffCopyServer struct {
+	grpc.ServerStream
+}
+
+func (x *fileSyncDiffCopyServer) Send(m *types.Packet) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *fileSyncDiffCopyServer) Recv() (*types.Packet, error) {
+	m := new(types.Packet)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _FileSync_TarStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(FileSyncServer).TarStream(&fileSyncTarStreamServer{stream})
+}
+
+type FileSync_TarStreamServer interface {
+	Send(*types.Packet) error
+	Recv() (*types.Packet, error)
+	grpc.ServerStream
+}
+
+type fileSyncTarStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *fileSyncTarStreamServer) Send(m *types.Packet) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *fileSyncTarStreamServer) Recv() (*types.Packet, error) {
+	m := new(types.Packet)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _FileSync_DiffCopyChunked_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(FileSyncServer).DiffCopyChunked(&fileSyncDiffCopyChunkedServer{stream})
+}
+
+type FileSync_DiffCopyChunkedServer interface {
+	Send(*ChunkedPacket) error
+	Recv() (*ChunkedPacket, error)
+	grpc.ServerStream
+}
+
+type fileSyncDiffCopyChunkedServer struct {
+	grpc.ServerStream
+}
+
+func (x *fileSyncDiffCopyChunkedServer) Send(m *ChunkedPacket) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *fileSyncDiffCopyChunkedServer) Recv() (*ChunkedPacket, error) {
+	m := new(ChunkedPacket)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _FileSync_LocalDir_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LocalDirRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FileSyncServer).LocalDir(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/moby.filesync.v1.FileSync/LocalDir",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FileSyncServer).LocalDir(ctx, req.(*LocalDirRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _FileSync_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "moby.filesync.v1.FileSync",
+	HandlerType: (*FileSyncServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "LocalDir",
+			Handler:    _FileSync_LocalDir_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "DiffCopy",
+			Handler:       _FileSync_DiffCopy_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "TarStream",
+			Handler:       _FileSync_TarStream_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "DiffCopyChunked",
+			Handler:       _FileSync_DiffCopyChunked_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "filesync.proto",
+}
+
+// FileSendClient is the client API for FileSend service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type FileSendClient interface {
+	DiffCopy(ctx context.Context, opts ...grpc.CallOption) (FileSend_DiffCopyClient, error)
+}
+
+type fileSendClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewFileSendClient(cc *grpc.ClientConn) FileSendClient {
+	return &fileSendClient{cc}
+}
+
+func (c *fileSendClient) DiffCopy(ctx context.Context, opts ...grpc.CallOption) (FileSend_DiffCopyClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_FileSend_serviceDesc.Streams[0], "/moby.filesync.v1.FileSend/DiffCopy", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &fileSendDiffCopyClient{stream}
+	return x, nil
+}
+
+type FileSend_DiffCopyClient interface {
+	Send(*BytesMessage) error
+	Recv() (*BytesMessage, error)
+	grpc.ClientStream
+}
+
+type fileSendDiffCopyClient struct {
+	grpc.ClientStream
+}
+
+func (x *fileSendDiffCopyClient) Send(m *BytesMessage) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *fileSendDiffCopyClient) Recv() (*BytesMessage, error) {
+	m := new(BytesMessage)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// FileSendServer is the server API for FileSend service.
+type FileSendServer interface {
+	DiffCopy(FileSend_DiffCopyServer) error
+}
+
 // UnimplementedFileSendServer can be embedded to have forward compatible implementations.
 type UnimplementedFileSendServer struct {
 }
@@ -411,103 +1046,1072 @@ func (x *fileSendDiffCopyServer) Recv() (*BytesMessage, error) {
 	if err := x.ServerStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
-	return m, nil
-}
-
-var _FileSend_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "moby.filesync.v1.FileSend",
-	HandlerType: (*FileSendServer)(nil),
-	Methods:     []grpc.MethodDesc{},
-	Streams: []grpc.StreamDesc{
-		{
-			StreamName:    "DiffCopy",
-			Handler:       _FileSend_DiffCopy_Handler,
-			ServerStreams: true,
-			ClientStreams: true,
-		},
-	},
-	Metadata: "filesync.proto",
-}
+	return m, nil
+}
+
+var _FileSend_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "moby.filesync.v1.FileSend",
+	HandlerType: (*FileSendServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "DiffCopy",
+			Handler:       _FileSend_DiffCopy_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "filesync.proto",
+}
+
+func (m *BytesMessage) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *BytesMessage) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *BytesMessage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Data) > 0 {
+		i -= len(m.Data)
+		copy(dAtA[i:], m.Data)
+		i = encodeVarintFilesync(dAtA, i, uint64(len(m.Data)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ChunkedPacket) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ChunkedPacket) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ChunkedPacket) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Resent != nil {
+		{
+			size, err := m.Resent.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintFilesync(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x2a
+	}
+	if m.Resend != nil {
+		{
+			size, err := m.Resend.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintFilesync(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.Chunk != nil {
+		{
+			size, err := m.Chunk.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintFilesync(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Have) > 0 {
+		for iNdEx := len(m.Have) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Have[iNdEx])
+			copy(dAtA[i:], m.Have[iNdEx])
+			i = encodeVarintFilesync(dAtA, i, uint64(len(m.Have[iNdEx])))
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if m.Packet != nil {
+		{
+			size, err := m.Packet.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintFilesync(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *FileChunk) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *FileChunk) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *FileChunk) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Data) > 0 {
+		i -= len(m.Data)
+		copy(dAtA[i:], m.Data)
+		i = encodeVarintFilesync(dAtA, i, uint64(len(m.Data)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Digest) > 0 {
+		i -= len(m.Digest)
+		copy(dAtA[i:], m.Digest)
+		i = encodeVarintFilesync(dAtA, i, uint64(len(m.Digest)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.ID != 0 {
+		i = encodeVarintFilesync(dAtA, i, uint64(m.ID))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ResendRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ResendRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ResendRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.ID != 0 {
+		i = encodeVarintFilesync(dAtA, i, uint64(m.ID))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *LocalDirRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *LocalDirRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *LocalDirRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Name) > 0 {
+		i -= len(m.Name)
+		copy(dAtA[i:], m.Name)
+		i = encodeVarintFilesync(dAtA, i, uint64(len(m.Name)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *LocalDirResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *LocalDirResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *LocalDirResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Ino != 0 {
+		i = encodeVarintFilesync(dAtA, i, uint64(m.Ino))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.Dev != 0 {
+		i = encodeVarintFilesync(dAtA, i, uint64(m.Dev))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.Path) > 0 {
+		i -= len(m.Path)
+		copy(dAtA[i:], m.Path)
+		i = encodeVarintFilesync(dAtA, i, uint64(len(m.Path)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintFilesync(dAtA []byte, offset int, v uint64) int {
+	offset -= sovFilesync(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *BytesMessage) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Data)
+	if l > 0 {
+		n += 1 + l + sovFilesync(uint64(l))
+	}
+	return n
+}
+
+func (m *ChunkedPacket) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Packet != nil {
+		l = m.Packet.Size()
+		n += 1 + l + sovFilesync(uint64(l))
+	}
+	if len(m.Have) > 0 {
+		for _, s := range m.Have {
+			l = len(s)
+			n += 1 + l + sovFilesync(uint64(l))
+		}
+	}
+	if m.Chunk != nil {
+		l = m.Chunk.Size()
+		n += 1 + l + sovFilesync(uint64(l))
+	}
+	if m.Resend != nil {
+		l = m.Resend.Size()
+		n += 1 + l + sovFilesync(uint64(l))
+	}
+	if m.Resent != nil {
+		l = m.Resent.Size()
+		n += 1 + l + sovFilesync(uint64(l))
+	}
+	return n
+}
+
+func (m *FileChunk) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ID != 0 {
+		n += 1 + sovFilesync(uint64(m.ID))
+	}
+	l = len(m.Digest)
+	if l > 0 {
+		n += 1 + l + sovFilesync(uint64(l))
+	}
+	l = len(m.Data)
+	if l > 0 {
+		n += 1 + l + sovFilesync(uint64(l))
+	}
+	return n
+}
+
+func (m *ResendRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ID != 0 {
+		n += 1 + sovFilesync(uint64(m.ID))
+	}
+	return n
+}
+
+func (m *LocalDirRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovFilesync(uint64(l))
+	}
+	return n
+}
+
+func (m *LocalDirResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Path)
+	if l > 0 {
+		n += 1 + l + sovFilesync(uint64(l))
+	}
+	if m.Dev != 0 {
+		n += 1 + sovFilesync(uint64(m.Dev))
+	}
+	if m.Ino != 0 {
+		n += 1 + sovFilesync(uint64(m.Ino))
+	}
+	return n
+}
+
+func sovFilesync(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozFilesync(x uint64) (n int) {
+	return sovFilesync(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (this *BytesMessage) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&BytesMessage{`,
+		`Data:` + fmt.Sprintf("%v", this.Data) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ChunkedPacket) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&ChunkedPacket{`,
+		`Packet:` + strings.Replace(fmt.Sprintf("%v", this.Packet), "Packet", "types.Packet", 1) + `,`,
+		`Have:` + fmt.Sprintf("%v", this.Have) + `,`,
+		`Chunk:` + strings.Replace(this.Chunk.String(), "FileChunk", "FileChunk", 1) + `,`,
+		`Resend:` + strings.Replace(this.Resend.String(), "ResendRequest", "ResendRequest", 1) + `,`,
+		`Resent:` + strings.Replace(this.Resent.String(), "FileChunk", "FileChunk", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *FileChunk) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&FileChunk{`,
+		`ID:` + fmt.Sprintf("%v", this.ID) + `,`,
+		`Digest:` + fmt.Sprintf("%v", this.Digest) + `,`,
+		`Data:` + fmt.Sprintf("%v", this.Data) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ResendRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&ResendRequest{`,
+		`ID:` + fmt.Sprintf("%v", this.ID) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *LocalDirRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&LocalDirRequest{`,
+		`Name:` + fmt.Sprintf("%v", this.Name) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *LocalDirResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&LocalDirResponse{`,
+		`Path:` + fmt.Sprintf("%v", this.Path) + `,`,
+		`Dev:` + fmt.Sprintf("%v", this.Dev) + `,`,
+		`Ino:` + fmt.Sprintf("%v", this.Ino) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func valueToStringFilesync(v interface{}) string {
+	rv := reflect.ValueOf(v)
+	if rv.IsNil() {
+		return "nil"
+	}
+	pv := reflect.Indirect(rv).Interface()
+	return fmt.Sprintf("*%v", pv)
+}
+func (m *BytesMessage) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowFilesync
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: BytesMessage: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: BytesMessage: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFilesync
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthFilesync
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthFilesync
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Data = append(m.Data[:0], dAtA[iNdEx:postIndex]...)
+			if m.Data == nil {
+				m.Data = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipFilesync(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthFilesync
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ChunkedPacket) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowFilesync
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ChunkedPacket: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ChunkedPacket: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Packet", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFilesync
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthFilesync
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthFilesync
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Packet == nil {
+				m.Packet = &types.Packet{}
+			}
+			if err := m.Packet.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Have", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFilesync
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFilesync
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthFilesync
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Have = append(m.Have, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Chunk", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFilesync
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthFilesync
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthFilesync
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Chunk == nil {
+				m.Chunk = &FileChunk{}
+			}
+			if err := m.Chunk.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Resend", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFilesync
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthFilesync
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthFilesync
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Resend == nil {
+				m.Resend = &ResendRequest{}
+			}
+			if err := m.Resend.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Resent", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFilesync
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthFilesync
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthFilesync
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Resent == nil {
+				m.Resent = &FileChunk{}
+			}
+			if err := m.Resent.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipFilesync(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthFilesync
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *FileChunk) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowFilesync
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: FileChunk: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: FileChunk: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+			}
+			m.ID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFilesync
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ID |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Digest", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFilesync
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFilesync
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthFilesync
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Digest = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFilesync
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthFilesync
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthFilesync
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Data = append(m.Data[:0], dAtA[iNdEx:postIndex]...)
+			if m.Data == nil {
+				m.Data = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipFilesync(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthFilesync
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
 
-func (m *BytesMessage) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
 	}
-	return dAtA[:n], nil
-}
-
-func (m *BytesMessage) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+	return nil
 }
-
-func (m *BytesMessage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Data) > 0 {
-		i -= len(m.Data)
-		copy(dAtA[i:], m.Data)
-		i = encodeVarintFilesync(dAtA, i, uint64(len(m.Data)))
-		i--
-		dAtA[i] = 0xa
+func (m *ResendRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowFilesync
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ResendRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ResendRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+			}
+			m.ID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFilesync
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ID |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipFilesync(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthFilesync
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
 	}
-	return len(dAtA) - i, nil
-}
 
-func encodeVarintFilesync(dAtA []byte, offset int, v uint64) int {
-	offset -= sovFilesync(v)
-	base := offset
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
 	}
-	dAtA[offset] = uint8(v)
-	return base
+	return nil
 }
-func (m *BytesMessage) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.Data)
-	if l > 0 {
-		n += 1 + l + sovFilesync(uint64(l))
+func (m *LocalDirRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowFilesync
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: LocalDirRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: LocalDirRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFilesync
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFilesync
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthFilesync
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipFilesync(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthFilesync
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
 	}
-	return n
-}
 
-func sovFilesync(x uint64) (n int) {
-	return (math_bits.Len64(x|1) + 6) / 7
-}
-func sozFilesync(x uint64) (n int) {
-	return sovFilesync(uint64((x << 1) ^ uint64((int64(x) >> 63))))
-}
-func (this *BytesMessage) String() string {
-	if this == nil {
-		return "nil"
-	}
-	s := strings.Join([]string{`&BytesMessage{`,
-		`Data:` + fmt.Sprintf("%v", this.Data) + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func valueToStringFilesync(v interface{}) string {
-	rv := reflect.ValueOf(v)
-	if rv.IsNil() {
-		return "nil"
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
 	}
-	pv := reflect.Indirect(rv).Interface()
-	return fmt.Sprintf("*%v", pv)
+	return nil
 }
-func (m *BytesMessage) Unmarshal(dAtA []byte) error {
+func (m *LocalDirResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -530,17 +2134,17 @@ func (m *BytesMessage) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: BytesMessage: wiretype end group for non-group")
+			return fmt.Errorf("proto: LocalDirResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: BytesMessage: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: LocalDirResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Path", wireType)
 			}
-			var byteLen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowFilesync
@@ -550,26 +2154,62 @@ func (m *BytesMessage) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthFilesync
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthFilesync
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Data = append(m.Data[:0], dAtA[iNdEx:postIndex]...)
-			if m.Data == nil {
-				m.Data = []byte{}
-			}
+			m.Path = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Dev", wireType)
+			}
+			m.Dev = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFilesync
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Dev |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Ino", wireType)
+			}
+			m.Ino = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFilesync
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Ino |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipFilesync(dAtA[iNdEx:])
@@ -1,16 +1,26 @@
 package filesync
 
 import (
+	"bytes"
 	"context"
+	"io"
+	"math/rand"
+	"net"
 	"os"
 	"path/filepath"
+	"sync"
 	"testing"
 
 	"github.com/moby/buildkit/session"
 	"github.com/moby/buildkit/session/testutil"
+	"github.com/moby/buildkit/util/peercred"
+	digest "github.com/opencontainers/go-digest"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	fstypes "github.com/tonistiigi/fsutil/types"
 	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc/peer"
 )
 
 func TestFileSyncIncludePatterns(t *testing.T) {
@@ -72,3 +82,320 @@ func TestFileSyncIncludePatterns(t *testing.T) {
 	err = g.Wait()
 	require.NoError(t, err)
 }
+
+type testChunkStore struct {
+	mu     sync.Mutex
+	chunks map[digest.Digest][]byte
+	files  map[string][]Chunk
+	reads  int
+	// changed is a chunk that fails to read as if its file had changed
+	changed digest.Digest
+}
+
+func (s *testChunkStore) Chunks() []digest.Digest {
+	var out []digest.Digest
+	for dgst := range s.chunks {
+		out = append(out, dgst)
+	}
+	return out
+}
+
+func (s *testChunkStore) ReadChunk(dgst digest.Digest) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	dt, ok := s.chunks[dgst]
+	if !ok {
+		return nil, errors.Errorf("chunk %s not found", dgst)
+	}
+	if dgst == s.changed {
+		return nil, errors.Errorf("chunk %s has changed", dgst)
+	}
+	s.reads++
+	return dt, nil
+}
+
+func (s *testChunkStore) SetFileChunks(p string, chunks []Chunk) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.files[p] = chunks
+	return nil
+}
+
+func TestFileSyncChunked(t *testing.T) {
+	ctx := context.TODO()
+	t.Parallel()
+	tmpDir := t.TempDir()
+
+	big := make([]byte, 3<<20)
+	_, err := rand.New(rand.NewSource(1)).Read(big) //nolint:gosec
+	require.NoError(t, err)
+
+	err = os.WriteFile(filepath.Join(tmpDir, "big"), big, 0600)
+	require.NoError(t, err)
+
+	err = os.WriteFile(filepath.Join(tmpDir, "small"), []byte("content"), 0600)
+	require.NoError(t, err)
+
+	s, err := session.NewSession(ctx, "foo", "bar")
+	require.NoError(t, err)
+
+	m, err := session.NewManager()
+	require.NoError(t, err)
+
+	fs := NewFSSyncProvider([]SyncedDir{{Name: "test0", Dir: tmpDir}})
+	s.Allow(fs)
+
+	dialer := session.Dialer(testutil.TestStream(testutil.Handler(m.HandleConn)))
+
+	g, ctx := errgroup.WithContext(context.Background())
+
+	g.Go(func() error {
+		return s.Run(ctx, dialer)
+	})
+
+	g.Go(func() (reterr error) {
+		c, err := m.Get(ctx, s.ID(), false)
+		if err != nil {
+			return err
+		}
+
+		store := &testChunkStore{chunks: map[digest.Digest][]byte{}, files: map[string][]Chunk{}}
+		destDir := t.TempDir()
+		if err := FSSync(ctx, c, FSSendRequestOpt{
+			Name:       "test0",
+			DestDir:    destDir,
+			ChunkStore: store,
+		}); err != nil {
+			return err
+		}
+
+		dt, err := os.ReadFile(filepath.Join(destDir, "big"))
+		if err != nil {
+			return err
+		}
+		assert.Equal(t, big, dt)
+		assert.Equal(t, 0, store.reads)
+		assert.Contains(t, store.files, "small")
+		assert.Equal(t, 0, len(store.files["small"]))
+
+		var size int64
+		for _, c := range store.files["big"] {
+			size += c.Size
+		}
+		assert.Equal(t, int64(len(big)), size)
+		assert.Greater(t, len(store.files["big"]), 1)
+
+		// keep the chunks of the first transfer and change the file slightly
+		var offset int64
+		for _, c := range store.files["big"] {
+			store.chunks[c.Digest] = big[offset : offset+c.Size]
+			offset += c.Size
+		}
+		copy(big[len(big)/2:], "changed")
+		if err := os.WriteFile(filepath.Join(tmpDir, "big"), big, 0600); err != nil {
+			return err
+		}
+
+		destDir = t.TempDir()
+		if err := FSSync(ctx, c, FSSendRequestOpt{
+			Name:       "test0",
+			DestDir:    destDir,
+			ChunkStore: store,
+		}); err != nil {
+			return err
+		}
+
+		dt, err = os.ReadFile(filepath.Join(destDir, "big"))
+		if err != nil {
+			return err
+		}
+		assert.Equal(t, big, dt)
+		// only the changed chunk is sent
+		assert.Equal(t, len(store.files["big"])-1, store.reads)
+
+		// a chunk that can't be read makes the file be sent again in full
+		offset = 0
+		for _, c := range store.files["big"] {
+			store.chunks[c.Digest] = big[offset : offset+c.Size]
+			offset += c.Size
+		}
+		store.changed = store.files["big"][len(store.files["big"])/2].Digest
+
+		destDir = t.TempDir()
+		if err := FSSync(ctx, c, FSSendRequestOpt{
+			Name:       "test0",
+			DestDir:    destDir,
+			ChunkStore: store,
+		}); err != nil {
+			return err
+		}
+
+		dt, err = os.ReadFile(filepath.Join(destDir, "big"))
+		if err != nil {
+			return err
+		}
+		assert.Equal(t, big, dt)
+		// the chunks of a resent file are not recorded
+		assert.Contains(t, store.files, "big")
+		assert.Equal(t, 0, len(store.files["big"]))
+
+		return s.Close()
+	})
+
+	err = g.Wait()
+	require.NoError(t, err)
+}
+
+func TestLocalDir(t *testing.T) {
+	ctx := context.TODO()
+	t.Parallel()
+	tmpDir := t.TempDir()
+
+	err := os.WriteFile(filepath.Join(tmpDir, "foo"), []byte("foo"), 0600)
+	require.NoError(t, err)
+
+	uid := uint32(os.Getuid())
+	keepOwner := func(p string, st *fstypes.Stat) bool {
+		st.Uid = uid
+		return true
+	}
+	changeOwner := func(p string, st *fstypes.Stat) bool {
+		st.Uid = uid + 1
+		return true
+	}
+
+	s, err := session.NewSession(ctx, "foo", "bar")
+	require.NoError(t, err)
+
+	m, err := session.NewManager()
+	require.NoError(t, err)
+
+	fs := NewFSSyncProvider([]SyncedDir{
+		{Name: "bind", Dir: tmpDir, BindThrough: true},
+		{Name: "copy", Dir: tmpDir},
+		{Name: "excludes", Dir: tmpDir, Excludes: []string{"foo"}, BindThrough: true},
+		{Name: "unmapped", Dir: tmpDir, Map: keepOwner, BindThrough: true},
+		{Name: "mapped", Dir: tmpDir, Map: changeOwner, BindThrough: true},
+	})
+	s.Allow(fs)
+
+	// connect like a client on the same host with the user of the daemon
+	local := peer.NewContext(context.TODO(), &peer.Peer{Addr: &peercred.Addr{Creds: peercred.Creds{UID: os.Geteuid()}}})
+	dialer := session.Dialer(testutil.TestStream(func(ctx context.Context, conn net.Conn, meta map[string][]string) error {
+		return m.HandleConn(local, conn, meta)
+	}))
+
+	g, ctx := errgroup.WithContext(context.Background())
+
+	g.Go(func() error {
+		return s.Run(ctx, dialer)
+	})
+
+	g.Go(func() (reterr error) {
+		c, err := m.Get(ctx, s.ID(), false)
+		if err != nil {
+			return err
+		}
+
+		p, err := LocalDir(ctx, c, "bind")
+		if err != nil {
+			return err
+		}
+		assert.Equal(t, tmpDir, p)
+
+		_, err = LocalDir(ctx, c, "copy")
+		assert.Error(t, err)
+
+		_, err = LocalDir(ctx, c, "excludes")
+		assert.Error(t, err)
+
+		p, err = LocalDir(ctx, c, "unmapped")
+		if err != nil {
+			return err
+		}
+		assert.Equal(t, tmpDir, p)
+
+		// the daemon would see a different owner than in a copy
+		_, err = LocalDir(ctx, c, "mapped")
+		assert.Error(t, err)
+
+		_, err = LocalDir(ctx, c, "unknown")
+		assert.Error(t, err)
+
+		return s.Close()
+	})
+
+	err = g.Wait()
+	require.NoError(t, err)
+}
+
+func TestFSSyncTargets(t *testing.T) {
+	ctx := context.TODO()
+	t.Parallel()
+
+	s, err := session.NewSession(ctx, "foo", "bar")
+	require.NoError(t, err)
+
+	m, err := session.NewManager()
+	require.NoError(t, err)
+
+	var outputs [2]bytes.Buffer
+	var mds [2]map[string]string
+	var targets []FSSyncTarget
+	for i := range outputs {
+		i := i
+		targets = append(targets, FSSyncTarget{
+			ID: i,
+			Output: func(md map[string]string) (io.WriteCloser, error) {
+				mds[i] = md
+				return nopWriteCloser{&outputs[i]}, nil
+			},
+		})
+	}
+	s.Allow(NewFSSyncTargets(targets...))
+
+	dialer := session.Dialer(testutil.TestStream(testutil.Handler(m.HandleConn)))
+
+	g, ctx := errgroup.WithContext(context.Background())
+
+	g.Go(func() error {
+		return s.Run(ctx, dialer)
+	})
+
+	g.Go(func() error {
+		c, err := m.Get(ctx, s.ID(), false)
+		if err != nil {
+			return err
+		}
+		wc, err := CopyFileWriter(WithExporterID(ctx, 1), map[string]string{"name": "out"}, c)
+		if err != nil {
+			return err
+		}
+		if _, err := wc.Write([]byte("data")); err != nil {
+			return err
+		}
+		if err := wc.Close(); err != nil {
+			return err
+		}
+
+		wc, err = CopyFileWriter(WithExporterID(ctx, 2), nil, c)
+		if err == nil {
+			err = wc.Close()
+		}
+		assert.Error(t, err)
+		return s.Close()
+	})
+
+	err = g.Wait()
+	require.NoError(t, err)
+
+	require.Equal(t, "", outputs[0].String())
+	require.Equal(t, "data", outputs[1].String())
+	require.Equal(t, map[string]string{"name": "out"}, mds[1])
+}
+
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error { return nil }
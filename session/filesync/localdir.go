@@ -0,0 +1,98 @@
+package filesync
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/moby/buildkit/session"
+	"github.com/moby/buildkit/util/peercred"
+	"github.com/pkg/errors"
+	"github.com/tonistiigi/fsutil"
+	fstypes "github.com/tonistiigi/fsutil/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (sp *fsSyncProvider) LocalDir(ctx context.Context, req *LocalDirRequest) (*LocalDirResponse, error) {
+	dir, ok := sp.dirs[req.Name]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "no access allowed to dir %q", req.Name)
+	}
+	// excludes hide files from the daemon that a bind mount would expose
+	if !dir.BindThrough || len(dir.Excludes) != 0 {
+		return nil, status.Errorf(codes.PermissionDenied, "no direct access allowed to dir %q", req.Name)
+	}
+	p, err := filepath.Abs(dir.Dir)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	fi, err := os.Stat(p)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	dev, ino, ok := devIno(fi)
+	if !ok {
+		return nil, status.Errorf(codes.Unimplemented, "direct access to dir %q is not supported", req.Name)
+	}
+	if dir.Map != nil {
+		if err := checkUnmapped(ctx, p, dir.Map); err != nil {
+			return nil, status.Errorf(codes.FailedPrecondition, "no direct access allowed to dir %q: %v", req.Name, err)
+		}
+	}
+	return &LocalDirResponse{Path: p, Dev: dev, Ino: ino}, nil
+}
+
+// checkUnmapped returns an error if the map function of a synced directory
+// changes or filters any of its files. The daemon sees the files of a bind
+// mount as they are, e.g. with the ownership that a copy would have reset.
+func checkUnmapped(ctx context.Context, p string, m func(string, *fstypes.Stat) bool) error {
+	return fsutil.Walk(ctx, p, nil, func(path string, fi os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		st, ok := fi.Sys().(*fstypes.Stat)
+		if !ok {
+			return errors.Errorf("invalid fileinfo for %s", path)
+		}
+		mapped := proto.Clone(st).(*fstypes.Stat)
+		if !m(mapped.Path, mapped) {
+			return errors.Errorf("%s is filtered", st.Path)
+		}
+		if !mapped.Equal(st) {
+			return errors.Errorf("ownership or mode of %s differs from the copied file", st.Path)
+		}
+		return nil
+	})
+}
+
+// LocalDir returns the path of a synced directory of the client if the daemon
+// can access it directly instead of copying it. The client needs to allow it
+// for the directory and to be connected over a unix socket of the same host.
+// The client also needs to run as root or as the user of the daemon so that
+// it can't read files through the daemon that it couldn't read itself.
+func LocalDir(ctx context.Context, c session.Caller, name string) (string, error) {
+	if !c.Supports(session.MethodURL(_FileSync_serviceDesc.ServiceName, "localdir")) {
+		return "", errors.New("client does not support direct access to local dirs")
+	}
+	creds, ok := peercred.FromContext(c.Context())
+	if !ok {
+		return "", errors.New("client is not connected over a local socket")
+	}
+	if creds.UID != 0 && creds.UID != os.Geteuid() {
+		return "", errors.Errorf("client user %d is not allowed direct access to local dirs", creds.UID)
+	}
+	resp, err := NewFileSyncClient(c.Conn()).LocalDir(ctx, &LocalDirRequest{Name: name})
+	if err != nil {
+		return "", err
+	}
+	fi, err := os.Stat(resp.Path)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to access local dir %s", resp.Path)
+	}
+	if dev, ino, ok := devIno(fi); !ok || dev != resp.Dev || ino != resp.Ino || !fi.IsDir() {
+		return "", errors.Errorf("%s is not the local dir of the client", resp.Path)
+	}
+	return resp.Path, nil
+}
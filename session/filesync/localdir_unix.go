@@ -0,0 +1,17 @@
+//go:build !windows
+// +build !windows
+
+package filesync
+
+import (
+	"os"
+	"syscall"
+)
+
+func devIno(fi os.FileInfo) (uint64, uint64, bool) {
+	st, ok := fi.Sys().(*syscall.Stat_t)
+	if !ok {
+		return 0, 0, false
+	}
+	return uint64(st.Dev), uint64(st.Ino), true //nolint:unconvert // dev is int32 on darwin
+}
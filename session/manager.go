@@ -7,6 +7,7 @@ import (
 	"strings"
 	"sync"
 
+	"github.com/moby/buildkit/util/metrics"
 	"github.com/pkg/errors"
 	"google.golang.org/grpc"
 )
@@ -135,10 +136,12 @@ func (sm *Manager) handleConn(ctx context.Context, conn net.Conn, opts map[strin
 	sm.updateCondition.Broadcast()
 	sm.mu.Unlock()
 
+	metrics.SessionStarted(ctx)
 	defer func() {
 		sm.mu.Lock()
 		delete(sm.sessions, id)
 		sm.mu.Unlock()
+		metrics.SessionEnded(context.TODO())
 	}()
 
 	<-c.ctx.Done()
@@ -38,6 +38,9 @@ type cacheManager struct {
 
 	backend CacheKeyStorage
 	results CacheResultStorage
+
+	// source is set if the cache manager is part of a fallback chain
+	source *cacheSource
 }
 
 func (c *cacheManager) ReleaseUnreferenced() error {
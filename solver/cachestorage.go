@@ -12,6 +12,11 @@ import (
 
 var ErrNotFound = errors.Errorf("not found")
 
+// ErrNotStorable is returned by CacheResultStorage.Save for results that
+// can't be loaded again by their ID, like directories that are bind-mounted
+// from the client. Such results are used without being added to the cache.
+var ErrNotStorable = errors.Errorf("result can not be stored")
+
 // CacheKeyStorage is interface for persisting cache metadata
 type CacheKeyStorage interface {
 	Exists(id string) bool
@@ -6,6 +6,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/moby/buildkit/util/progress"
 	digest "github.com/opencontainers/go-digest"
 	"github.com/pkg/errors"
 	"golang.org/x/sync/errgroup"
@@ -15,6 +16,75 @@ func NewCombinedCacheManager(cms []CacheManager, main CacheManager) CacheManager
 	return &combinedCacheManager{cms: cms, main: main}
 }
 
+// NewFallbackCacheManager combines the cache managers of an ordered list of
+// cache sources. The records of a cache key are only loaded from the first
+// source that has any, identical records are deduplicated and loading a
+// record reports the name of its source in the progress of the vertex.
+func NewFallbackCacheManager(cms []CacheManager, names []string) CacheManager {
+	chain := &cacheChain{names: names}
+	for i, cm := range cms {
+		setCacheSource(cm, &cacheSource{chain: chain, index: i})
+	}
+	return NewCombinedCacheManager(cms, nil)
+}
+
+type cacheChain struct {
+	names []string
+}
+
+// cacheSource is the position of a cache manager in a fallback chain.
+type cacheSource struct {
+	chain *cacheChain
+	index int
+}
+
+func (s *cacheSource) name() string {
+	return s.chain.names[s.index]
+}
+
+// before reports whether s precedes o in the same fallback chain.
+func (s *cacheSource) before(o *cacheSource) bool {
+	return s != nil && o != nil && s.chain == o.chain && s.index < o.index
+}
+
+// preferred reports whether s is chosen over o when both have the same
+// record. Sources of different chains are ordered by their position and name
+// so that the choice doesn't depend on map iteration or query order.
+func (s *cacheSource) preferred(o *cacheSource) bool {
+	if s == nil || o == nil {
+		return false
+	}
+	if s.chain == o.chain || s.index != o.index {
+		return s.index < o.index
+	}
+	return s.name() < o.name()
+}
+
+// keySource returns the fallback chain source of the cache managers that
+// returned the key that comes first in chain order.
+func keySource(k *CacheKey) *cacheSource {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	var src *cacheSource
+	for c := range k.ids {
+		if c.source != nil && (src == nil || c.source.preferred(src)) {
+			src = c.source
+		}
+	}
+	return src
+}
+
+func setCacheSource(cm CacheManager, src *cacheSource) {
+	switch cm := cm.(type) {
+	case *cacheManager:
+		cm.source = src
+	case *combinedCacheManager:
+		for _, c := range cm.cms {
+			setCacheSource(c, src)
+		}
+	}
+}
+
 type combinedCacheManager struct {
 	cms    []CacheManager
 	main   CacheManager
@@ -46,7 +116,7 @@ func (cm *combinedCacheManager) Query(inp []CacheKeyWithSelector, inputIndex Ind
 				}
 				mu.Lock()
 				for _, r := range recs {
-					if _, ok := keys[r.ID]; !ok || c == cm.main {
+					if prev, ok := keys[r.ID]; !ok || c == cm.main || keySource(r).preferred(keySource(prev)) {
 						keys[r.ID] = r
 					}
 				}
@@ -60,6 +130,22 @@ func (cm *combinedCacheManager) Query(inp []CacheKeyWithSelector, inputIndex Ind
 		return nil, err
 	}
 
+	// only keep the keys of the first source of a fallback chain that has
+	// any
+	first := map[*cacheChain]*cacheSource{}
+	for _, k := range keys {
+		if src := keySource(k); src != nil {
+			if f, ok := first[src.chain]; !ok || src.before(f) {
+				first[src.chain] = src
+			}
+		}
+	}
+	for id, k := range keys {
+		if src := keySource(k); src != nil && first[src.chain] != src {
+			delete(keys, id)
+		}
+	}
+
 	out := make([]*CacheKey, 0, len(keys))
 	for _, k := range keys {
 		out = append(out, k)
@@ -68,6 +154,12 @@ func (cm *combinedCacheManager) Query(inp []CacheKeyWithSelector, inputIndex Ind
 }
 
 func (cm *combinedCacheManager) Load(ctx context.Context, rec *CacheRecord) (res Result, err error) {
+	if src := rec.cacheManager.source; src != nil {
+		pw, _, _ := progress.NewFromContext(ctx)
+		now := time.Now()
+		pw.Write("importing cache from "+src.name(), progress.Status{Started: &now, Completed: &now})
+		pw.Close()
+	}
 	results, err := rec.cacheManager.LoadWithParents(ctx, rec)
 	if err != nil {
 		return nil, err
@@ -118,7 +210,7 @@ func (cm *combinedCacheManager) Records(ck *CacheKey) ([]*CacheRecord, error) {
 				}
 				mu.Lock()
 				for _, rec := range recs {
-					if _, ok := records[rec.ID]; !ok || c == cm.main {
+					if prev, ok := records[rec.ID]; !ok || c == cm.main || c.source.preferred(prev.cacheManager.source) {
 						if c == cm.main {
 							rec.Priority = 1
 						}
@@ -135,6 +227,22 @@ func (cm *combinedCacheManager) Records(ck *CacheKey) ([]*CacheRecord, error) {
 		return nil, err
 	}
 
+	// only keep the records of the first source of a fallback chain that
+	// has any
+	first := map[*cacheChain]*cacheSource{}
+	for _, rec := range records {
+		if src := rec.cacheManager.source; src != nil {
+			if f, ok := first[src.chain]; !ok || src.before(f) {
+				first[src.chain] = src
+			}
+		}
+	}
+	for id, rec := range records {
+		if src := rec.cacheManager.source; src != nil && first[src.chain] != src {
+			delete(records, id)
+		}
+	}
+
 	out := make([]*CacheRecord, 0, len(records))
 	for _, rec := range records {
 		out = append(out, rec)
@@ -6,7 +6,9 @@ import (
 	"time"
 
 	"github.com/moby/buildkit/solver/internal/pipe"
+	"github.com/moby/buildkit/solver/pb"
 	"github.com/moby/buildkit/util/bklog"
+	"github.com/moby/buildkit/util/metrics"
 	digest "github.com/opencontainers/go-digest"
 	"github.com/pkg/errors"
 )
@@ -370,7 +372,7 @@ func (e *edge) unpark(incoming []pipe.Sender, updates, allPipes []pipe.Receiver,
 func (e *edge) makeExportable(k *CacheKey, records []*CacheRecord) ExportableCacheKey {
 	return ExportableCacheKey{
 		CacheKey: k,
-		Exporter: &exporter{k: k, records: records, override: e.edge.Vertex.Options().ExportCache},
+		Exporter: &exporter{k: k, records: records, override: e.edge.Vertex.Options().ExportCache, vtx: e.edge.Vertex},
 	}
 }
 
@@ -892,6 +894,7 @@ func (e *edge) loadCache(ctx context.Context) (interface{}, error) {
 		bklog.G(ctx).Debugf("load cache for %s err: %v", e.edge.Vertex.Name(), err)
 		return nil, errors.Wrap(err, "failed to load cache")
 	}
+	metrics.RecordCacheLookup(ctx, OpType(e.edge.Vertex), true)
 
 	return NewCachedResult(res, []ExportableCacheKey{{CacheKey: rec.key, Exporter: &exporter{k: rec.key, record: rec, edge: e}}}), nil
 }
@@ -899,6 +902,7 @@ func (e *edge) loadCache(ctx context.Context) (interface{}, error) {
 // execOp creates a request to execute the vertex operation
 func (e *edge) execOp(ctx context.Context) (interface{}, error) {
 	cacheKeys, inputs := e.commitOptions()
+	metrics.RecordCacheLookup(ctx, OpType(e.edge.Vertex), false)
 	results, subExporters, err := e.op.Exec(ctx, toResultSlice(inputs))
 	if err != nil {
 		return nil, errors.WithStack(err)
@@ -922,6 +926,9 @@ func (e *edge) execOp(ctx context.Context) (interface{}, error) {
 	for _, cacheKey := range cacheKeys {
 		ck, err := e.op.Cache().Save(cacheKey, res, time.Now())
 		if err != nil {
+			if errors.Is(err, ErrNotStorable) {
+				continue
+			}
 			return nil, err
 		}
 
@@ -987,3 +994,28 @@ func withSelector(keys []ExportableCacheKey, selector digest.Digest) []CacheKeyW
 	}
 	return out
 }
+
+// OpType returns the type of the LLB operation of a vertex, e.g. "exec" or
+// "file", as reported in the cache metrics and matched by cache export filters.
+func OpType(v Vertex) string {
+	op, ok := v.Sys().(*pb.Op)
+	if !ok {
+		return "unknown"
+	}
+	switch op.Op.(type) {
+	case *pb.Op_Exec:
+		return "exec"
+	case *pb.Op_Source:
+		return "source"
+	case *pb.Op_File:
+		return "file"
+	case *pb.Op_Build:
+		return "build"
+	case *pb.Op_Merge:
+		return "merge"
+	case *pb.Op_Diff:
+		return "diff"
+	default:
+		return "unknown"
+	}
+}
@@ -181,6 +181,45 @@ func (m *Subrequest) GetName() string {
 	return ""
 }
 
+type ExecTimeout struct {
+	// Timeout of the exec in nanoseconds.
+	Timeout              int64    `protobuf:"varint,1,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ExecTimeout) Reset()         { *m = ExecTimeout{} }
+func (m *ExecTimeout) String() string { return proto.CompactTextString(m) }
+func (*ExecTimeout) ProtoMessage()    {}
+func (*ExecTimeout) Descriptor() ([]byte, []int) {
+	return fileDescriptor_689dc58a5060aff5, []int{4}
+}
+func (m *ExecTimeout) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExecTimeout.Unmarshal(m, b)
+}
+func (m *ExecTimeout) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExecTimeout.Marshal(b, m, deterministic)
+}
+func (m *ExecTimeout) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExecTimeout.Merge(m, src)
+}
+func (m *ExecTimeout) XXX_Size() int {
+	return xxx_messageInfo_ExecTimeout.Size(m)
+}
+func (m *ExecTimeout) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExecTimeout.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExecTimeout proto.InternalMessageInfo
+
+func (m *ExecTimeout) GetTimeout() int64 {
+	if m != nil {
+		return m.Timeout
+	}
+	return 0
+}
+
 type Solve struct {
 	InputIDs []string `protobuf:"bytes,1,rep,name=inputIDs,proto3" json:"inputIDs,omitempty"`
 	MountIDs []string `protobuf:"bytes,2,rep,name=mountIDs,proto3" json:"mountIDs,omitempty"`
@@ -198,7 +237,7 @@ func (m *Solve) Reset()         { *m = Solve{} }
 func (m *Solve) String() string { return proto.CompactTextString(m) }
 func (*Solve) ProtoMessage()    {}
 func (*Solve) Descriptor() ([]byte, []int) {
-	return fileDescriptor_689dc58a5060aff5, []int{4}
+	return fileDescriptor_689dc58a5060aff5, []int{5}
 }
 func (m *Solve) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Solve.Unmarshal(m, b)
@@ -294,7 +333,7 @@ func (m *FileAction) Reset()         { *m = FileAction{} }
 func (m *FileAction) String() string { return proto.CompactTextString(m) }
 func (*FileAction) ProtoMessage()    {}
 func (*FileAction) Descriptor() ([]byte, []int) {
-	return fileDescriptor_689dc58a5060aff5, []int{5}
+	return fileDescriptor_689dc58a5060aff5, []int{6}
 }
 func (m *FileAction) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_FileAction.Unmarshal(m, b)
@@ -333,7 +372,7 @@ func (m *ContentCache) Reset()         { *m = ContentCache{} }
 func (m *ContentCache) String() string { return proto.CompactTextString(m) }
 func (*ContentCache) ProtoMessage()    {}
 func (*ContentCache) Descriptor() ([]byte, []int) {
-	return fileDescriptor_689dc58a5060aff5, []int{6}
+	return fileDescriptor_689dc58a5060aff5, []int{7}
 }
 func (m *ContentCache) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ContentCache.Unmarshal(m, b)
@@ -365,6 +404,7 @@ func init() {
 	proto.RegisterType((*Source)(nil), "errdefs.Source")
 	proto.RegisterType((*FrontendCap)(nil), "errdefs.FrontendCap")
 	proto.RegisterType((*Subrequest)(nil), "errdefs.Subrequest")
+	proto.RegisterType((*ExecTimeout)(nil), "errdefs.ExecTimeout")
 	proto.RegisterType((*Solve)(nil), "errdefs.Solve")
 	proto.RegisterType((*FileAction)(nil), "errdefs.FileAction")
 	proto.RegisterType((*ContentCache)(nil), "errdefs.ContentCache")
@@ -373,27 +413,28 @@ func init() {
 func init() { proto.RegisterFile("errdefs.proto", fileDescriptor_689dc58a5060aff5) }
 
 var fileDescriptor_689dc58a5060aff5 = []byte{
-	// 348 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x91, 0xcd, 0x8e, 0xd3, 0x30,
-	0x14, 0x85, 0x27, 0xbf, 0x43, 0x6e, 0x81, 0x85, 0x81, 0x51, 0x34, 0xab, 0x8c, 0xc5, 0xa2, 0x48,
-	0x90, 0x48, 0xc3, 0x13, 0x40, 0xd1, 0x68, 0x66, 0x55, 0xc9, 0x95, 0xd8, 0xc7, 0xc9, 0x4d, 0x6b,
-	0x48, 0x6c, 0xe3, 0xd8, 0xa8, 0xbc, 0x1b, 0x0f, 0x87, 0xe2, 0xa4, 0x65, 0x16, 0xdd, 0xe5, 0xe4,
-	0xfb, 0x7c, 0xed, 0x63, 0xc3, 0x2b, 0x34, 0xa6, 0xc5, 0x6e, 0x2c, 0xb5, 0x51, 0x56, 0x91, 0xeb,
-	0x25, 0xde, 0x7e, 0xdc, 0x0b, 0x7b, 0x70, 0xbc, 0x6c, 0xd4, 0x50, 0x0d, 0x8a, 0xff, 0xa9, 0xb8,
-	0x13, 0x7d, 0xfb, 0x53, 0xd8, 0x6a, 0x54, 0xfd, 0x6f, 0x34, 0x95, 0xe6, 0x95, 0xd2, 0xcb, 0x32,
-	0x5a, 0x40, 0xfa, 0x1d, 0x8d, 0xc5, 0x23, 0xb9, 0x81, 0xb4, 0x15, 0x7b, 0x1c, 0x6d, 0x1e, 0x14,
-	0xc1, 0x3a, 0x63, 0x4b, 0xa2, 0x5b, 0x48, 0x77, 0xca, 0x99, 0x06, 0x09, 0x85, 0x58, 0xc8, 0x4e,
-	0x79, 0xbe, 0xba, 0x7f, 0x5d, 0x6a, 0x5e, 0xce, 0xe4, 0x49, 0x76, 0x8a, 0x79, 0x46, 0xee, 0x20,
-	0x35, 0xb5, 0xdc, 0xe3, 0x98, 0x87, 0x45, 0xb4, 0x5e, 0xdd, 0x67, 0x93, 0xc5, 0xa6, 0x3f, 0x6c,
-	0x01, 0xf4, 0x0e, 0x56, 0x0f, 0x46, 0x49, 0x8b, 0xb2, 0xdd, 0xd4, 0x9a, 0x10, 0x88, 0x65, 0x3d,
-	0xe0, 0xb2, 0xab, 0xff, 0xa6, 0x05, 0xc0, 0xce, 0x71, 0x83, 0xbf, 0x1c, 0x8e, 0xf6, 0xa2, 0xf1,
-	0x37, 0x80, 0x64, 0x37, 0xf5, 0x21, 0xb7, 0xf0, 0x42, 0x48, 0xed, 0xec, 0xd3, 0xb7, 0x31, 0x0f,
-	0x8a, 0x68, 0x9d, 0xb1, 0x73, 0x9e, 0xd8, 0xa0, 0x9c, 0xf4, 0x2c, 0x9c, 0xd9, 0x29, 0x93, 0x1b,
-	0x08, 0x95, 0xce, 0x23, 0xdf, 0x25, 0x9d, 0x4e, 0xb9, 0xd5, 0x2c, 0x54, 0x9a, 0x7c, 0x80, 0xb8,
-	0x13, 0x3d, 0xe6, 0xb1, 0x27, 0x6f, 0xca, 0xd3, 0x35, 0x3f, 0x88, 0x1e, 0xbf, 0x34, 0x56, 0x28,
-	0xf9, 0x78, 0xc5, 0xbc, 0x42, 0x3e, 0x41, 0xd2, 0xd4, 0xcd, 0x01, 0xf3, 0xc4, 0xbb, 0xef, 0xce,
-	0xee, 0xc6, 0xd7, 0xb3, 0x9b, 0x09, 0x3e, 0x5e, 0xb1, 0xd9, 0xfa, 0x9a, 0xc1, 0xf5, 0xe8, 0xf8,
-	0x0f, 0x6c, 0x2c, 0xa5, 0x00, 0xff, 0xe7, 0x91, 0xb7, 0x90, 0x08, 0xd9, 0xe2, 0xd1, 0x37, 0x8c,
-	0xd8, 0x1c, 0xe8, 0x7b, 0x78, 0xf9, 0x7c, 0xce, 0x65, 0x8b, 0xa7, 0xfe, 0x1d, 0x3f, 0xff, 0x0b,
-	0x00, 0x00, 0xff, 0xff, 0x1e, 0xfa, 0x9c, 0x6f, 0x0f, 0x02, 0x00, 0x00,
+	// 364 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x91, 0xcf, 0x6e, 0xd4, 0x30,
+	0x10, 0xc6, 0x9b, 0xbf, 0x4b, 0x26, 0xc0, 0xc1, 0x40, 0x15, 0xf5, 0x94, 0x5a, 0x48, 0x2c, 0x12,
+	0x24, 0x52, 0x79, 0x02, 0x58, 0xa8, 0xda, 0x53, 0x25, 0x2f, 0xe2, 0x1e, 0x27, 0x93, 0xad, 0x21,
+	0xb1, 0x8d, 0x63, 0xa3, 0xe5, 0xdd, 0x78, 0x38, 0x14, 0x27, 0x5b, 0x38, 0xf4, 0x36, 0x5f, 0x7e,
+	0xbf, 0x4c, 0xf4, 0x65, 0xe0, 0x19, 0x1a, 0xd3, 0x61, 0x3f, 0x55, 0xda, 0x28, 0xab, 0xc8, 0x66,
+	0x8d, 0x17, 0xef, 0x0e, 0xc2, 0xde, 0x3b, 0x5e, 0xb5, 0x6a, 0xac, 0x47, 0xc5, 0x7f, 0xd7, 0xdc,
+	0x89, 0xa1, 0xfb, 0x21, 0x6c, 0x3d, 0xa9, 0xe1, 0x17, 0x9a, 0x5a, 0xf3, 0x5a, 0xe9, 0xf5, 0x35,
+	0x5a, 0x42, 0xfa, 0x0d, 0x8d, 0xc5, 0x23, 0x39, 0x87, 0xb4, 0x13, 0x07, 0x9c, 0x6c, 0x11, 0x94,
+	0xc1, 0x36, 0x63, 0x6b, 0xa2, 0x77, 0x90, 0xee, 0x95, 0x33, 0x2d, 0x12, 0x0a, 0xb1, 0x90, 0xbd,
+	0xf2, 0x3c, 0xbf, 0x7a, 0x5e, 0x69, 0x5e, 0x2d, 0xe4, 0x56, 0xf6, 0x8a, 0x79, 0x46, 0x2e, 0x21,
+	0x35, 0x8d, 0x3c, 0xe0, 0x54, 0x84, 0x65, 0xb4, 0xcd, 0xaf, 0xb2, 0xd9, 0x62, 0xf3, 0x13, 0xb6,
+	0x02, 0x7a, 0x09, 0xf9, 0xb5, 0x51, 0xd2, 0xa2, 0xec, 0x76, 0x8d, 0x26, 0x04, 0x62, 0xd9, 0x8c,
+	0xb8, 0x7e, 0xd5, 0xcf, 0xb4, 0x04, 0xd8, 0x3b, 0x6e, 0xf0, 0xa7, 0xc3, 0xc9, 0x3e, 0x6a, 0xbc,
+	0x81, 0xfc, 0xcb, 0x11, 0xdb, 0xaf, 0x62, 0x44, 0xe5, 0x2c, 0x29, 0x60, 0x63, 0x97, 0xd1, 0x5b,
+	0x11, 0x3b, 0x45, 0xfa, 0x27, 0x80, 0x64, 0x3f, 0x17, 0x27, 0x17, 0xf0, 0x44, 0x48, 0xed, 0xec,
+	0xed, 0xe7, 0xa9, 0x08, 0xca, 0x68, 0x9b, 0xb1, 0x87, 0x3c, 0xb3, 0x51, 0x39, 0xe9, 0x59, 0xb8,
+	0xb0, 0x53, 0x26, 0xe7, 0x10, 0x2a, 0x5d, 0x44, 0xbe, 0x74, 0x3a, 0xd7, 0xb9, 0xd3, 0x2c, 0x54,
+	0x9a, 0xbc, 0x85, 0xb8, 0x17, 0x03, 0x16, 0xb1, 0x27, 0x2f, 0xaa, 0xd3, 0x3d, 0xae, 0xc5, 0x80,
+	0x1f, 0x5b, 0x2b, 0x94, 0xbc, 0x39, 0x63, 0x5e, 0x21, 0xef, 0x21, 0x69, 0x9b, 0xf6, 0x1e, 0x8b,
+	0xc4, 0xbb, 0xaf, 0x1e, 0xdc, 0x9d, 0xff, 0x0f, 0x76, 0x37, 0xc3, 0x9b, 0x33, 0xb6, 0x58, 0x9f,
+	0x32, 0xd8, 0x4c, 0x8e, 0x7f, 0xc7, 0xd6, 0x52, 0x0a, 0xf0, 0x6f, 0x1f, 0x79, 0x09, 0x89, 0x90,
+	0x1d, 0x1e, 0xd7, 0x92, 0x4b, 0xa0, 0xaf, 0xe1, 0xe9, 0xff, 0x7b, 0x1e, 0xb7, 0x78, 0xea, 0x0f,
+	0xfe, 0xe1, 0xef, 0x00, 0x78, 0xef, 0xc6, 0xf4, 0x38, 0x02, 0x00, 0x00,
 }
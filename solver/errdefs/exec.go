@@ -0,0 +1,44 @@
+package errdefs
+
+import (
+	fmt "fmt"
+	"time"
+
+	"github.com/containerd/typeurl"
+	"github.com/moby/buildkit/util/grpcerrors"
+)
+
+func init() {
+	typeurl.Register((*ExecTimeout)(nil), "github.com/moby/buildkit", "errdefs.ExecTimeout+json")
+}
+
+// ExecTimeoutError is returned when the process of an exec was killed
+// because it did not complete within its timeout.
+type ExecTimeoutError struct {
+	ExecTimeout
+	error
+}
+
+func (e *ExecTimeoutError) Error() string {
+	msg := fmt.Sprintf("process did not complete within %s", time.Duration(e.ExecTimeout.Timeout))
+	if e.error != nil {
+		msg += ": " + e.error.Error()
+	}
+	return msg
+}
+
+func (e *ExecTimeoutError) Unwrap() error {
+	return e.error
+}
+
+func (e *ExecTimeoutError) ToProto() grpcerrors.TypedErrorProto {
+	return &e.ExecTimeout
+}
+
+func NewExecTimeoutError(timeout time.Duration, err error) error {
+	return &ExecTimeoutError{ExecTimeout: ExecTimeout{Timeout: int64(timeout)}, error: err}
+}
+
+func (v *ExecTimeout) WrapError(err error) error {
+	return &ExecTimeoutError{error: err, ExecTimeout: *v}
+}
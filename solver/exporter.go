@@ -13,6 +13,19 @@ type exporter struct {
 
 	edge     *edge // for secondaryExporters
 	override *bool
+	vtx      Vertex
+}
+
+// vertex returns the vertex the records of the exporter were created for, or
+// nil if it isn't known.
+func (e *exporter) vertex() Vertex {
+	if e.vtx != nil {
+		return e.vtx
+	}
+	if e.edge != nil {
+		return e.edge.edge.Vertex
+	}
+	return nil
 }
 
 func addBacklinks(t CacheExporterTarget, rec CacheExporterRecord, cm *cacheManager, id string, bkm map[string]CacheExporterRecord) (CacheExporterRecord, error) {
@@ -100,9 +113,14 @@ func (e *exporter) ExportTo(ctx context.Context, t CacheExporterTarget, opt Cach
 		e.record = getBestResult(e.records)
 	}
 
+	if v := e.record; v != nil && addRecord && opt.Filter != nil {
+		addRecord = opt.Filter.IncludeVertex(e.vertex(), v.CreatedAt)
+	}
+
 	var remote *Remote
 	if v := e.record; v != nil && len(e.k.Deps()) > 0 && addRecord {
 		var variants []CacheExporterRecord
+		var variantRemotes []*Remote
 
 		cm := v.cacheManager
 		key := cm.getID(v.key)
@@ -119,11 +137,7 @@ func (e *exporter) ExportTo(ctx context.Context, t CacheExporterTarget, opt Cach
 			remote, remotes = remotes[0], remotes[1:] // pop the first element
 		}
 		if opt.CompressionOpt != nil {
-			for _, r := range remotes { // record all remaining remotes as well
-				rec := t.Add(recKey)
-				rec.AddResult(v.CreatedAt, r)
-				variants = append(variants, rec)
-			}
+			variantRemotes = append(variantRemotes, remotes...) // record all remaining remotes as well
 		}
 
 		if (remote == nil || opt.CompressionOpt != nil) && opt.Mode != CacheExportModeRemoteOnly {
@@ -140,14 +154,19 @@ func (e *exporter) ExportTo(ctx context.Context, t CacheExporterTarget, opt Cach
 				remote, remotes = remotes[0], remotes[1:] // pop the first element
 			}
 			if opt.CompressionOpt != nil {
-				for _, r := range remotes { // record all remaining remotes as well
-					rec := t.Add(recKey)
-					rec.AddResult(v.CreatedAt, r)
-					variants = append(variants, rec)
-				}
+				variantRemotes = append(variantRemotes, remotes...) // record all remaining remotes as well
 			}
 		}
 
+		if remote != nil && opt.Filter != nil && !opt.Filter.IncludeRemote(remote) {
+			remote, variantRemotes = nil, nil
+		}
+		for _, r := range variantRemotes {
+			rec := t.Add(recKey)
+			rec.AddResult(v.CreatedAt, r)
+			variants = append(variants, rec)
+		}
+
 		if remote != nil {
 			for _, rec := range allRec {
 				rec.AddResult(v.CreatedAt, remote)
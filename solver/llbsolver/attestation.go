@@ -0,0 +1,261 @@
+package llbsolver
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/containerd/containerd/platforms"
+	"github.com/docker/distribution/reference"
+	"github.com/moby/buildkit/client/llb"
+	"github.com/moby/buildkit/exporter"
+	attestationtypes "github.com/moby/buildkit/exporter/attestation/types"
+	"github.com/moby/buildkit/exporter/containerimage/exptypes"
+	"github.com/moby/buildkit/frontend"
+	"github.com/moby/buildkit/solver"
+	"github.com/moby/buildkit/solver/llbsolver/provenance"
+	binfotypes "github.com/moby/buildkit/util/buildinfo/types"
+	ocispecs "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/pkg/errors"
+)
+
+const (
+	// defaultSBOMGenerator is the scanner image used for SBOM attestations
+	// when no generator is set in the attest:sbom options.
+	defaultSBOMGenerator = "docker/buildkit-syft-scanner:stable-1"
+
+	sbomScanSourceDir      = "/run/src/core"
+	sbomScanDestinationDir = "/tmp/out"
+)
+
+// parseAttests returns the parameters of the attestations requested with
+// attest:<name>=<key>=<value>,... frontend options.
+func parseAttests(opts map[string]string) (map[string]map[string]string, error) {
+	attests := map[string]map[string]string{}
+	for k, v := range opts {
+		if !strings.HasPrefix(k, exptypes.FrontendAttestPrefix) {
+			continue
+		}
+		name := strings.TrimPrefix(k, exptypes.FrontendAttestPrefix)
+		params := map[string]string{}
+		for _, field := range strings.Split(v, ",") {
+			if field == "" {
+				continue
+			}
+			parts := strings.SplitN(field, "=", 2)
+			if len(parts) != 2 {
+				return nil, errors.Errorf("invalid value %q for %s%s, expected key=value", field, exptypes.FrontendAttestPrefix, name)
+			}
+			params[strings.TrimSpace(parts[0])] = strings.TrimSpace(parts[1])
+		}
+		attests[name] = params
+	}
+	return attests, nil
+}
+
+// resultRefs returns the refs of the result keyed by their attestation keys.
+func resultRefs(res *frontend.Result) map[string]solver.ResultProxy {
+	refs := map[string]solver.ResultProxy{}
+	if res.Ref != nil {
+		refs[""] = res.Ref
+	}
+	for k, r := range res.Refs {
+		if r != nil {
+			refs[k] = r
+		}
+	}
+	return refs
+}
+
+func hasPredicate(atts []frontend.Attestation, predicateType string) bool {
+	for _, att := range atts {
+		if att.InToto.PredicateType == predicateType {
+			return true
+		}
+	}
+	return false
+}
+
+// scanSBOM runs the SBOM generator against every result ref that doesn't
+// already have an SBOM attestation from the frontend and attaches the
+// generated statements to the result.
+func scanSBOM(ctx context.Context, b frontend.FrontendLLBBridge, res *frontend.Result, params map[string]string, sessionID string) error {
+	var targets []string
+	refs := resultRefs(res)
+	for k := range refs {
+		if !hasPredicate(res.Attestations[k], attestationtypes.PredicateSPDX) {
+			targets = append(targets, k)
+		}
+	}
+	if len(targets) == 0 {
+		return nil
+	}
+
+	generator := params["generator"]
+	if generator == "" {
+		generator = defaultSBOMGenerator
+	}
+	scanner, err := sbomScanner(ctx, b, generator)
+	if err != nil {
+		return err
+	}
+
+	for _, k := range targets {
+		def := refs[k].Definition()
+		if def == nil {
+			return errors.Errorf("no definition to scan for SBOM")
+		}
+		op, err := llb.NewDefinitionOp(def)
+		if err != nil {
+			return err
+		}
+		st := scanner(llb.NewState(op))
+		scanDef, err := st.Marshal(ctx)
+		if err != nil {
+			return err
+		}
+		r, err := b.Solve(ctx, frontend.SolveRequest{
+			Definition: scanDef.ToPB(),
+		}, sessionID)
+		if err != nil {
+			return errors.Wrap(err, "failed to generate SBOM")
+		}
+		res.AddAttestation(k, frontend.Attestation{
+			Kind: attestationtypes.KindBundle,
+			Ref:  r.Ref,
+			Path: "/",
+			InToto: attestationtypes.InToto{
+				PredicateType: attestationtypes.PredicateSPDX,
+			},
+		})
+	}
+	return nil
+}
+
+// sbomScanner returns a function that runs the generator image on a target
+// state. The target is mounted at BUILDKIT_SCAN_SOURCE and the generator
+// writes in-toto statements to BUILDKIT_SCAN_DESTINATION.
+func sbomScanner(ctx context.Context, b frontend.FrontendLLBBridge, generator string) (func(llb.State) llb.State, error) {
+	named, err := reference.ParseNormalizedNamed(generator)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid SBOM generator %s", generator)
+	}
+	named = reference.TagNameOnly(named)
+
+	dgst, dt, err := b.ResolveImageConfig(ctx, named.String(), llb.ResolveImageConfigOpt{})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to resolve SBOM generator %s", generator)
+	}
+	var img ocispecs.Image
+	if err := json.Unmarshal(dt, &img); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse image config of SBOM generator %s", generator)
+	}
+	args := append(append([]string{}, img.Config.Entrypoint...), img.Config.Cmd...)
+	if len(args) == 0 {
+		return nil, errors.Errorf("SBOM generator %s has no entrypoint", generator)
+	}
+	ref := named.String()
+	if canonical, err := reference.WithDigest(named, dgst); err == nil {
+		ref = canonical.String()
+	}
+	st, err := llb.Image(ref).WithImageConfig(dt)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(target llb.State) llb.State {
+		run := st.Run(
+			llb.Args(args),
+			llb.AddEnv("BUILDKIT_SCAN_SOURCE", sbomScanSourceDir),
+			llb.AddEnv("BUILDKIT_SCAN_DESTINATION", sbomScanDestinationDir),
+			llb.WithCustomName("[sbom] generating SBOM with "+generator),
+		)
+		run.AddMount(sbomScanSourceDir, target, llb.Readonly)
+		return run.AddMount(sbomScanDestinationDir, llb.Scratch())
+	}, nil
+}
+
+// addProvenance attaches a SLSA provenance attestation to every result ref
+// that doesn't already have one from the frontend.
+func addProvenance(inp *exporter.Source, res *frontend.Result, id string, req frontend.SolveRequest, params map[string]string, startedOn time.Time) error {
+	mode := provenance.ModeMin
+	if v, ok := params["mode"]; ok {
+		switch provenance.Mode(v) {
+		case provenance.ModeMin, provenance.ModeMax:
+			mode = provenance.Mode(v)
+		default:
+			return errors.Errorf("invalid provenance mode %q", v)
+		}
+	}
+
+	pls := map[string]string{}
+	if dt, ok := res.Metadata[exptypes.ExporterPlatformsKey]; ok {
+		var ps exptypes.Platforms
+		if err := json.Unmarshal(dt, &ps); err != nil {
+			return errors.Wrap(err, "failed to parse platforms")
+		}
+		for _, p := range ps.Platforms {
+			pls[p.ID] = platforms.Format(p.Platform)
+		}
+	}
+
+	args := map[string]string{}
+	for k, v := range req.FrontendOpt {
+		if !strings.HasPrefix(k, exptypes.FrontendAttestPrefix) {
+			args[k] = v
+		}
+	}
+
+	finishedOn := time.Now()
+	for k, ref := range resultRefs(res) {
+		if hasPredicate(res.Attestations[k], attestationtypes.PredicateSLSAProvenance) {
+			continue
+		}
+
+		opt := provenance.Opt{
+			Mode:       mode,
+			BuilderID:  params["builder-id"],
+			Ref:        id,
+			Frontend:   req.Frontend,
+			Args:       args,
+			Platform:   pls[k],
+			Definition: ref.Definition(),
+			StartedOn:  &startedOn,
+			FinishedOn: &finishedOn,
+		}
+		biKey := exptypes.ExporterBuildInfo
+		if k != "" {
+			biKey += "/" + k
+		}
+		if dt, ok := res.Metadata[biKey]; ok {
+			var bi binfotypes.BuildInfo
+			if err := json.Unmarshal(dt, &bi); err != nil {
+				return errors.Wrap(err, "failed to parse build info")
+			}
+			opt.BuildInfo = &bi
+		}
+		pr, err := provenance.NewPredicate(opt)
+		if err != nil {
+			return err
+		}
+		dt, err := json.Marshal(pr)
+		if err != nil {
+			return errors.Wrap(err, "failed to marshal provenance")
+		}
+
+		if inp.Attestations == nil {
+			inp.Attestations = map[string][]exporter.Attestation{}
+		}
+		inp.Attestations[k] = append(inp.Attestations[k], exporter.Attestation{
+			Kind: attestationtypes.KindInToto,
+			ContentFunc: func() ([]byte, error) {
+				return dt, nil
+			},
+			InToto: attestationtypes.InToto{
+				PredicateType: attestationtypes.PredicateSLSAProvenance,
+			},
+		})
+	}
+	return nil
+}
@@ -21,6 +21,7 @@ import (
 	"github.com/moby/buildkit/solver/errdefs"
 	llberrdefs "github.com/moby/buildkit/solver/llbsolver/errdefs"
 	"github.com/moby/buildkit/solver/pb"
+	spb "github.com/moby/buildkit/sourcepolicy/pb"
 	"github.com/moby/buildkit/util/bklog"
 	"github.com/moby/buildkit/util/buildinfo"
 	"github.com/moby/buildkit/util/flightcontrol"
@@ -39,6 +40,7 @@ type llbBridge struct {
 	cms                       map[string]solver.CacheManager
 	cmsMu                     sync.Mutex
 	sm                        *session.Manager
+	sourcePolicy              *spb.Policy
 }
 
 func (b *llbBridge) Warn(ctx context.Context, dgst digest.Digest, msg string, opts frontend.WarnOpts) error {
@@ -110,9 +112,18 @@ func (b *llbBridge) loadResult(ctx context.Context, def *pb.Definition, cacheImp
 		cms = append(cms, cm)
 		b.cmsMu.Unlock()
 	}
+	polEngine, err := b.sourcePolicyEngine()
+	if err != nil {
+		return nil, nil, err
+	}
+	def, err = applySourcePolicy(ctx, def, polEngine)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to apply source policy")
+	}
+
 	dpc := &detectPrunedCacheID{}
 
-	edge, err := Load(def, dpc.Load, ValidateEntitlements(ent), WithCacheSources(cms), NormalizeRuntimePlatforms(), WithValidateCaps())
+	edge, err := Load(def, dpc.Load, ValidateEntitlements(ent), WithCacheSources(cms), WithLocalSolveID(loadSolveID(b.builder)), NormalizeRuntimePlatforms(), WithValidateCaps())
 	if err != nil {
 		return nil, nil, errors.Wrap(err, "failed to load LLB")
 	}
@@ -321,6 +332,14 @@ func (b *llbBridge) ResolveImageConfig(ctx context.Context, ref string, opt llb.
 	if err != nil {
 		return "", nil, err
 	}
+	polEngine, err := b.sourcePolicyEngine()
+	if err != nil {
+		return "", nil, err
+	}
+	ref, err = applySourcePolicyToImageRef(ctx, ref, polEngine)
+	if err != nil {
+		return "", nil, errors.Wrap(err, "failed to apply source policy")
+	}
 	if opt.LogName == "" {
 		opt.LogName = fmt.Sprintf("resolve image config for %s", ref)
 	}
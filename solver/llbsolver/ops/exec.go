@@ -9,14 +9,17 @@ import (
 	"path"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/containerd/containerd/platforms"
 	"github.com/moby/buildkit/cache"
 	"github.com/moby/buildkit/executor"
 	"github.com/moby/buildkit/frontend/gateway"
+	gatewayapi "github.com/moby/buildkit/frontend/gateway/pb"
 	"github.com/moby/buildkit/session"
 	"github.com/moby/buildkit/session/secrets"
 	"github.com/moby/buildkit/solver"
+	serrdefs "github.com/moby/buildkit/solver/errdefs"
 	"github.com/moby/buildkit/solver/llbsolver"
 	"github.com/moby/buildkit/solver/llbsolver/errdefs"
 	"github.com/moby/buildkit/solver/llbsolver/mounts"
@@ -322,6 +325,8 @@ func (e *execOp) Exec(ctx context.Context, g session.Group, inputs []solver.Resu
 		ExtraHosts:     extraHosts,
 		Ulimit:         e.op.Meta.Ulimit,
 		CgroupParent:   e.op.Meta.CgroupParent,
+		ResourceLimits: e.op.Meta.ResourceLimits,
+		Timeout:        time.Duration(e.op.Meta.Timeout),
 		NetMode:        e.op.Network,
 		SecurityMode:   e.op.Security,
 	}
@@ -356,6 +361,9 @@ func (e *execOp) Exec(ctx context.Context, g session.Group, inputs []solver.Resu
 		Stdout: stdout,
 		Stderr: stderr,
 	}, nil)
+	if isValidExitError(execErr, e.op.Meta.ValidExitCodes) {
+		execErr = nil
+	}
 
 	for i, out := range p.OutputRefs {
 		if mutable, ok := out.Ref.(cache.MutableRef); ok {
@@ -373,6 +381,26 @@ func (e *execOp) Exec(ctx context.Context, g session.Group, inputs []solver.Resu
 	return results, errors.Wrapf(execErr, "process %q did not complete successfully", strings.Join(e.op.Meta.Args, " "))
 }
 
+// isValidExitError returns true if err is the exit of the process with one of
+// the valid exit codes. A process that was killed on timeout never exited
+// validly.
+func isValidExitError(err error, validExitCodes []int32) bool {
+	var exitErr *gatewayapi.ExitError
+	if err == nil || len(validExitCodes) == 0 || !errors.As(err, &exitErr) {
+		return false
+	}
+	var timeoutErr *serrdefs.ExecTimeoutError
+	if errors.As(err, &timeoutErr) {
+		return false
+	}
+	for _, code := range validExitCodes {
+		if code >= 0 && uint32(code) == exitErr.ExitCode && exitErr.Err == nil {
+			return true
+		}
+	}
+	return false
+}
+
 func proxyEnvList(p *pb.ProxyEnv) []string {
 	out := []string{}
 	if v := p.HttpProxy; v != "" {
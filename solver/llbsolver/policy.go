@@ -0,0 +1,165 @@
+package llbsolver
+
+import (
+	"context"
+	"strings"
+
+	"github.com/docker/distribution/reference"
+	"github.com/moby/buildkit/solver"
+	"github.com/moby/buildkit/solver/pb"
+	srctypes "github.com/moby/buildkit/source/types"
+	"github.com/moby/buildkit/sourcepolicy"
+	spb "github.com/moby/buildkit/sourcepolicy/pb"
+	digest "github.com/opencontainers/go-digest"
+	"github.com/pkg/errors"
+)
+
+const keySourcePolicy = "llb.sourcepolicy"
+
+func loadSourcePolicy(b solver.Builder) ([]*spb.Policy, error) {
+	var pols []*spb.Policy
+	err := b.EachValue(context.TODO(), keySourcePolicy, func(v interface{}) error {
+		p, ok := v.(*spb.Policy)
+		if !ok {
+			return errors.Errorf("invalid source policy %T", v)
+		}
+		pols = append(pols, p)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return pols, nil
+}
+
+// sourcePolicyEngine returns the engine evaluating the daemon policy
+// followed by the policies of the builds sharing the builder.
+func (b *llbBridge) sourcePolicyEngine() (*sourcepolicy.Engine, error) {
+	pols, err := loadSourcePolicy(b.builder)
+	if err != nil {
+		return nil, err
+	}
+	return sourcepolicy.NewEngine(append([]*spb.Policy{b.sourcePolicy}, pols...)), nil
+}
+
+// applySourcePolicy evaluates the source policy against the source
+// operations of a definition. When sources are converted, a new definition
+// is returned in which the converted operations and all operations depending
+// on them have new digests.
+func applySourcePolicy(ctx context.Context, def *pb.Definition, e *sourcepolicy.Engine) (*pb.Definition, error) {
+	ops := make(map[digest.Digest]*pb.Op, len(def.Def))
+	dts := make(map[digest.Digest][]byte, len(def.Def))
+	order := make([]digest.Digest, 0, len(def.Def))
+	mutated := map[digest.Digest]struct{}{}
+	for _, dt := range def.Def {
+		var op pb.Op
+		if err := (&op).Unmarshal(dt); err != nil {
+			return nil, errors.Wrap(err, "failed to parse llb proto op")
+		}
+		dgst := digest.FromBytes(dt)
+		ok, err := e.Evaluate(ctx, &op)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			mutated[dgst] = struct{}{}
+		}
+		ops[dgst] = &op
+		dts[dgst] = dt
+		order = append(order, dgst)
+	}
+	if len(mutated) == 0 {
+		return def, nil
+	}
+
+	remapped := map[digest.Digest]digest.Digest{}
+	var rec func(dgst digest.Digest) (digest.Digest, error)
+	rec = func(dgst digest.Digest) (digest.Digest, error) {
+		if d, ok := remapped[dgst]; ok {
+			return d, nil
+		}
+		op, ok := ops[dgst]
+		if !ok {
+			return "", errors.Errorf("invalid missing input digest %s", dgst)
+		}
+		_, changed := mutated[dgst]
+		for _, inp := range op.Inputs {
+			d, err := rec(inp.Digest)
+			if err != nil {
+				return "", err
+			}
+			if d != inp.Digest {
+				inp.Digest = d
+				changed = true
+			}
+		}
+		d := dgst
+		if changed {
+			dt, err := op.Marshal()
+			if err != nil {
+				return "", err
+			}
+			d = digest.FromBytes(dt)
+			dts[dgst] = dt
+		}
+		remapped[dgst] = d
+		return d, nil
+	}
+
+	out := &pb.Definition{
+		Metadata: make(map[digest.Digest]pb.OpMetadata, len(def.Metadata)),
+	}
+	for _, dgst := range order {
+		if _, err := rec(dgst); err != nil {
+			return nil, err
+		}
+		out.Def = append(out.Def, dts[dgst])
+	}
+	for dgst, md := range def.Metadata {
+		if d, ok := remapped[dgst]; ok {
+			dgst = d
+		}
+		out.Metadata[dgst] = md
+	}
+	if def.Source != nil {
+		out.Source = &pb.Source{
+			Infos:     def.Source.Infos,
+			Locations: make(map[string]*pb.Locations, len(def.Source.Locations)),
+		}
+		for k, l := range def.Source.Locations {
+			if d, ok := remapped[digest.Digest(k)]; ok {
+				k = d.String()
+			}
+			out.Source.Locations[k] = l
+		}
+	}
+	return out, nil
+}
+
+// applySourcePolicyToImageRef evaluates the source policy against an image
+// reference that is resolved outside of LLB, such as for image configs
+// requested by frontends, and returns the converted reference.
+func applySourcePolicyToImageRef(ctx context.Context, ref string, e *sourcepolicy.Engine) (string, error) {
+	named, err := reference.ParseNormalizedNamed(ref)
+	if err != nil {
+		// leave validation of the reference to the resolver
+		return ref, nil
+	}
+	op := &pb.Op{
+		Op: &pb.Op_Source{
+			Source: &pb.SourceOp{Identifier: srctypes.DockerImageScheme + "://" + reference.TagNameOnly(named).String()},
+		},
+	}
+	mutated, err := e.Evaluate(ctx, op)
+	if err != nil {
+		return "", err
+	}
+	if !mutated {
+		return ref, nil
+	}
+	id := op.GetSource().Identifier
+	if !strings.HasPrefix(id, srctypes.DockerImageScheme+"://") {
+		return "", errors.Errorf("source policy converted image %s to non-image source %s", ref, id)
+	}
+	return strings.TrimPrefix(id, srctypes.DockerImageScheme+"://"), nil
+}
@@ -0,0 +1,66 @@
+package llbsolver
+
+import (
+	"context"
+	"testing"
+
+	"github.com/moby/buildkit/client/llb"
+	"github.com/moby/buildkit/solver/pb"
+	"github.com/moby/buildkit/sourcepolicy"
+	spb "github.com/moby/buildkit/sourcepolicy/pb"
+	digest "github.com/opencontainers/go-digest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplySourcePolicy(t *testing.T) {
+	t.Parallel()
+
+	st := llb.Image("docker.io/library/alpine:latest").Run(llb.Shlex("true")).Root()
+	def, err := st.Marshal(context.TODO())
+	require.NoError(t, err)
+	orig := def.ToPB()
+
+	e := sourcepolicy.NewEngine([]*spb.Policy{{
+		Rules: []*spb.Rule{{
+			Action:   spb.PolicyAction_CONVERT,
+			Selector: &spb.Selector{Identifier: "docker-image://docker.io/library/alpine:*"},
+			Updates:  &spb.Update{Identifier: "docker-image://mirror.example.com/library/alpine:${1}"},
+		}},
+	}})
+
+	out, err := applySourcePolicy(context.TODO(), orig, e)
+	require.NoError(t, err)
+	require.Equal(t, len(orig.Def), len(out.Def))
+
+	ops := map[digest.Digest]*pb.Op{}
+	var sourceID string
+	for _, dt := range out.Def {
+		var op pb.Op
+		require.NoError(t, op.Unmarshal(dt))
+		ops[digest.FromBytes(dt)] = &op
+		if src := op.GetSource(); src != nil {
+			sourceID = src.Identifier
+		}
+	}
+	require.Equal(t, "docker-image://mirror.example.com/library/alpine:latest", sourceID)
+
+	// all inputs reference the rewritten digests
+	for _, op := range ops {
+		for _, inp := range op.Inputs {
+			_, ok := ops[inp.Digest]
+			require.True(t, ok, "missing input %s", inp.Digest)
+		}
+	}
+	for dgst := range out.Metadata {
+		_, ok := ops[dgst]
+		require.True(t, ok, "metadata for unknown op %s", dgst)
+	}
+
+	_, err = Load(out)
+	require.NoError(t, err)
+
+	// definitions without matching sources are returned unchanged
+	unchanged, err := applySourcePolicy(context.TODO(), orig, sourcepolicy.NewEngine(nil))
+	require.NoError(t, err)
+	require.Equal(t, orig, unchanged)
+}
@@ -0,0 +1,262 @@
+package provenance
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/docker/distribution/reference"
+	attestationtypes "github.com/moby/buildkit/exporter/attestation/types"
+	"github.com/moby/buildkit/solver/pb"
+	binfotypes "github.com/moby/buildkit/util/buildinfo/types"
+	digest "github.com/opencontainers/go-digest"
+	"github.com/pkg/errors"
+)
+
+// BuildType identifies the build process of buildkit in the provenance.
+const BuildType = "https://mobyproject.org/buildkit@v1"
+
+// Mode defines the amount of detail included in the provenance.
+type Mode string
+
+const (
+	// ModeMin only records the build parameters and materials.
+	ModeMin Mode = "min"
+	// ModeMax additionally records the LLB definition of the build result.
+	ModeMax Mode = "max"
+)
+
+// Predicate is a SLSA v0.2 provenance predicate.
+type Predicate struct {
+	Builder     Builder      `json:"builder"`
+	BuildType   string       `json:"buildType"`
+	Invocation  Invocation   `json:"invocation"`
+	BuildConfig *BuildConfig `json:"buildConfig,omitempty"`
+	Metadata    *Metadata    `json:"metadata,omitempty"`
+	Materials   []Material   `json:"materials,omitempty"`
+}
+
+type Builder struct {
+	ID string `json:"id"`
+}
+
+type Invocation struct {
+	ConfigSource ConfigSource `json:"configSource,omitempty"`
+	Parameters   Parameters   `json:"parameters,omitempty"`
+	Environment  Environment  `json:"environment,omitempty"`
+}
+
+type ConfigSource struct {
+	EntryPoint string `json:"entryPoint,omitempty"`
+}
+
+type Parameters struct {
+	Frontend string            `json:"frontend,omitempty"`
+	Args     map[string]string `json:"args,omitempty"`
+}
+
+type Environment struct {
+	Platform string `json:"platform,omitempty"`
+}
+
+type Metadata struct {
+	BuildInvocationID string       `json:"buildInvocationID,omitempty"`
+	BuildStartedOn    *time.Time   `json:"buildStartedOn,omitempty"`
+	BuildFinishedOn   *time.Time   `json:"buildFinishedOn,omitempty"`
+	Completeness      Completeness `json:"completeness"`
+	Reproducible      bool         `json:"reproducible"`
+}
+
+type Completeness struct {
+	Parameters  bool `json:"parameters"`
+	Environment bool `json:"environment"`
+	Materials   bool `json:"materials"`
+}
+
+type Material struct {
+	URI    string            `json:"uri"`
+	Digest map[string]string `json:"digest,omitempty"`
+}
+
+// BuildConfig contains the LLB steps of the build result.
+type BuildConfig struct {
+	Definition []BuildStep `json:"llbDefinition,omitempty"`
+}
+
+type BuildStep struct {
+	ID     string   `json:"id"`
+	Op     *pb.Op   `json:"op"`
+	Inputs []string `json:"inputs,omitempty"`
+}
+
+// Opt contains the properties of a build that the provenance is created for.
+type Opt struct {
+	Mode      Mode
+	BuilderID string
+	Ref       string
+	Frontend  string
+	Args      map[string]string
+	Platform  string
+	BuildInfo *binfotypes.BuildInfo
+	// Definition is only recorded in ModeMax.
+	Definition *pb.Definition
+	StartedOn  *time.Time
+	FinishedOn *time.Time
+}
+
+// NewPredicate creates a provenance predicate for a build.
+func NewPredicate(opt Opt) (*Predicate, error) {
+	p := &Predicate{
+		Builder: Builder{
+			ID: opt.BuilderID,
+		},
+		BuildType: BuildType,
+		Invocation: Invocation{
+			ConfigSource: ConfigSource{
+				EntryPoint: opt.Frontend,
+			},
+			Parameters: Parameters{
+				Frontend: opt.Frontend,
+				Args:     opt.Args,
+			},
+			Environment: Environment{
+				Platform: opt.Platform,
+			},
+		},
+		Metadata: &Metadata{
+			BuildInvocationID: opt.Ref,
+			BuildStartedOn:    opt.StartedOn,
+			BuildFinishedOn:   opt.FinishedOn,
+			Completeness: Completeness{
+				Parameters:  true,
+				Environment: true,
+			},
+		},
+	}
+
+	if opt.BuildInfo != nil {
+		materials, err := Materials(*opt.BuildInfo)
+		if err != nil {
+			return nil, err
+		}
+		p.Materials = materials
+		p.Metadata.Completeness.Materials = true
+	}
+
+	if opt.Mode == ModeMax && opt.Definition != nil {
+		steps, err := buildSteps(opt.Definition)
+		if err != nil {
+			return nil, err
+		}
+		p.BuildConfig = &BuildConfig{Definition: steps}
+	}
+
+	return p, nil
+}
+
+// Materials returns the materials of a build from the sources of its build
+// info, including the sources of its dependencies.
+func Materials(bi binfotypes.BuildInfo) ([]Material, error) {
+	var materials []Material
+	seen := map[string]struct{}{}
+	var walk func(bi binfotypes.BuildInfo) error
+	walk = func(bi binfotypes.BuildInfo) error {
+		for _, src := range bi.Sources {
+			m, err := material(src)
+			if err != nil {
+				return err
+			}
+			if _, ok := seen[m.URI]; ok {
+				continue
+			}
+			seen[m.URI] = struct{}{}
+			materials = append(materials, m)
+		}
+		keys := make([]string, 0, len(bi.Deps))
+		for k := range bi.Deps {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			if err := walk(bi.Deps[k]); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := walk(bi); err != nil {
+		return nil, err
+	}
+	return materials, nil
+}
+
+func material(src binfotypes.Source) (Material, error) {
+	switch src.Type {
+	case binfotypes.SourceTypeDockerImage:
+		uri, err := dockerPackageURL(src.Ref)
+		if err != nil {
+			return Material{}, err
+		}
+		m := Material{URI: uri}
+		if dgst, err := digest.Parse(src.Pin); err == nil {
+			m.Digest = attestationtypes.DigestSet(dgst)
+		}
+		return m, nil
+	case binfotypes.SourceTypeGit:
+		m := Material{URI: src.Ref}
+		if src.Pin != "" {
+			m.Digest = map[string]string{"sha1": src.Pin}
+		}
+		return m, nil
+	default:
+		m := Material{URI: src.Ref}
+		if dgst, err := digest.Parse(src.Pin); err == nil {
+			m.Digest = attestationtypes.DigestSet(dgst)
+		}
+		return m, nil
+	}
+}
+
+// dockerPackageURL converts an image reference to a docker package URL,
+// e.g. pkg:docker/library/alpine@3.15.
+func dockerPackageURL(ref string) (string, error) {
+	named, err := reference.ParseNormalizedNamed(ref)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to parse %s", ref)
+	}
+	named = reference.TagNameOnly(named)
+
+	version := "latest"
+	if tagged, ok := named.(reference.Tagged); ok {
+		version = tagged.Tag()
+	}
+	if canonical, ok := named.(reference.Canonical); ok {
+		version = canonical.Digest().String()
+	}
+
+	uri := "pkg:docker/" + reference.Path(named) + "@" + strings.ReplaceAll(version, ":", "%3A")
+	if domain := reference.Domain(named); domain != "docker.io" {
+		uri += "?repository_url=" + domain
+	}
+	return uri, nil
+}
+
+func buildSteps(def *pb.Definition) ([]BuildStep, error) {
+	steps := make([]BuildStep, 0, len(def.Def))
+	for _, dt := range def.Def {
+		var op pb.Op
+		if err := op.Unmarshal(dt); err != nil {
+			return nil, errors.Wrap(err, "failed to parse llb definition")
+		}
+		step := BuildStep{
+			ID: digest.FromBytes(dt).String(),
+			Op: &op,
+		}
+		for _, inp := range op.Inputs {
+			step.Inputs = append(step.Inputs, inp.Digest.String()+":"+strconv.FormatInt(int64(inp.Index), 10))
+		}
+		steps = append(steps, step)
+	}
+	return steps, nil
+}
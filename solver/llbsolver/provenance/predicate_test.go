@@ -0,0 +1,82 @@
+package provenance
+
+import (
+	"context"
+	"testing"
+
+	"github.com/moby/buildkit/client/llb"
+	binfotypes "github.com/moby/buildkit/util/buildinfo/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMaterials(t *testing.T) {
+	materials, err := Materials(binfotypes.BuildInfo{
+		Sources: []binfotypes.Source{
+			{
+				Type: binfotypes.SourceTypeDockerImage,
+				Ref:  "docker.io/library/alpine:3.15",
+				Pin:  "sha256:21a3deaa0d32a8057914f36584b5288d2e5ecc984380bc0118285c70fa8c9300",
+			},
+			{
+				Type: binfotypes.SourceTypeGit,
+				Ref:  "https://github.com/moby/buildkit.git#master",
+				Pin:  "5ab6e4e4da83c8c2b0bb0d2ab83f4c5c4d4e2a0f",
+			},
+		},
+		Deps: map[string]binfotypes.BuildInfo{
+			"base": {
+				Sources: []binfotypes.Source{
+					{
+						Type: binfotypes.SourceTypeDockerImage,
+						Ref:  "ghcr.io/foo/bar:latest",
+						Pin:  "sha256:e2c7d6bd0d7fd4a1fcc7c14edc9f4c0c96ba1c3d3f14b12a1e3a1d2e0b5d4c3f",
+					},
+					{
+						Type: binfotypes.SourceTypeDockerImage,
+						Ref:  "docker.io/library/alpine:3.15",
+						Pin:  "sha256:21a3deaa0d32a8057914f36584b5288d2e5ecc984380bc0118285c70fa8c9300",
+					},
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, 3, len(materials))
+
+	require.Equal(t, "pkg:docker/library/alpine@3.15", materials[0].URI)
+	require.Equal(t, "21a3deaa0d32a8057914f36584b5288d2e5ecc984380bc0118285c70fa8c9300", materials[0].Digest["sha256"])
+	require.Equal(t, "https://github.com/moby/buildkit.git#master", materials[1].URI)
+	require.Equal(t, "5ab6e4e4da83c8c2b0bb0d2ab83f4c5c4d4e2a0f", materials[1].Digest["sha1"])
+	require.Equal(t, "pkg:docker/foo/bar@latest?repository_url=ghcr.io", materials[2].URI)
+}
+
+func TestPredicateModes(t *testing.T) {
+	def, err := llb.Image("alpine").Run(llb.Shlex("true")).Root().Marshal(context.TODO())
+	require.NoError(t, err)
+
+	opt := Opt{
+		Mode:       ModeMin,
+		Frontend:   "dockerfile.v0",
+		Args:       map[string]string{"build-arg:FOO": "bar"},
+		Definition: def.ToPB(),
+	}
+	p, err := NewPredicate(opt)
+	require.NoError(t, err)
+	require.Equal(t, BuildType, p.BuildType)
+	require.Equal(t, "dockerfile.v0", p.Invocation.Parameters.Frontend)
+	require.Equal(t, "bar", p.Invocation.Parameters.Args["build-arg:FOO"])
+	require.Nil(t, p.BuildConfig)
+	require.False(t, p.Metadata.Completeness.Materials)
+
+	opt.Mode = ModeMax
+	p, err = NewPredicate(opt)
+	require.NoError(t, err)
+	require.NotNil(t, p.BuildConfig)
+	require.Equal(t, len(def.Def), len(p.BuildConfig.Definition))
+	// the exec step depends on the image source
+	var inputs int
+	for _, s := range p.BuildConfig.Definition {
+		inputs += len(s.Inputs)
+	}
+	require.Greater(t, inputs, 0)
+}
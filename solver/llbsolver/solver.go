@@ -18,10 +18,13 @@ import (
 	"github.com/moby/buildkit/frontend/gateway"
 	"github.com/moby/buildkit/identity"
 	"github.com/moby/buildkit/session"
+	"github.com/moby/buildkit/session/filesync"
 	"github.com/moby/buildkit/solver"
+	spb "github.com/moby/buildkit/sourcepolicy/pb"
 	"github.com/moby/buildkit/util/buildinfo"
 	"github.com/moby/buildkit/util/compression"
 	"github.com/moby/buildkit/util/entitlements"
+	"github.com/moby/buildkit/util/metrics"
 	"github.com/moby/buildkit/util/progress"
 	"github.com/moby/buildkit/worker"
 	digest "github.com/opencontainers/go-digest"
@@ -30,11 +33,15 @@ import (
 )
 
 const keyEntitlements = "llb.entitlements"
+const keySolveID = "llb.solveid"
 
 type ExporterRequest struct {
-	Exporter        exporter.ExporterInstance
-	CacheExporter   remotecache.Exporter
-	CacheExportMode solver.CacheExportMode
+	// Exporters export the result in parallel. If there are several, the keys
+	// of their responses are namespaced with exptypes.ExporterResponseKey.
+	Exporters         []exporter.ExporterInstance
+	CacheExporter     remotecache.Exporter
+	CacheExportMode   solver.CacheExportMode
+	CacheExportFilter solver.CacheExportFilter
 }
 
 // ResolveWorkerFunc returns default worker for the temporary default non-distributed use cases
@@ -49,6 +56,7 @@ type Opt struct {
 	GatewayForwarder *controlgateway.GatewayForwarder
 	SessionManager   *session.Manager
 	WorkerController *worker.Controller
+	SourcePolicy     *spb.Policy
 }
 
 type Solver struct {
@@ -61,6 +69,7 @@ type Solver struct {
 	gatewayForwarder          *controlgateway.GatewayForwarder
 	sm                        *session.Manager
 	entitlements              []string
+	sourcePolicy              *spb.Policy
 }
 
 func New(opt Opt) (*Solver, error) {
@@ -73,6 +82,7 @@ func New(opt Opt) (*Solver, error) {
 		gatewayForwarder:          opt.GatewayForwarder,
 		sm:                        opt.SessionManager,
 		entitlements:              opt.Entitlements,
+		sourcePolicy:              opt.SourcePolicy,
 	}
 
 	s.solver = solver.NewSolver(solver.SolverOpt{
@@ -101,22 +111,50 @@ func (s *Solver) Bridge(b solver.Builder) frontend.FrontendLLBBridge {
 		resolveCacheImporterFuncs: s.resolveCacheImporterFuncs,
 		cms:                       map[string]solver.CacheManager{},
 		sm:                        s.sm,
+		sourcePolicy:              s.sourcePolicy,
 	}
 }
 
-func (s *Solver) Solve(ctx context.Context, id string, sessionID string, req frontend.SolveRequest, exp ExporterRequest, ent []entitlements.Entitlement) (*client.SolveResponse, error) {
+// Solve runs a build. If statusChan is not nil, the progress of the build is
+// sent to it from the creation of the job and it is closed when the build
+// completes.
+func (s *Solver) Solve(ctx context.Context, id string, sessionID string, req frontend.SolveRequest, exp ExporterRequest, ent []entitlements.Entitlement, srcPol *spb.Policy, statusChan chan *client.SolveStatus) (_ *client.SolveResponse, rerr error) {
+	startedOn := time.Now()
+	defer func() {
+		metrics.RecordSolve(ctx, time.Since(startedOn), rerr)
+	}()
+
 	j, err := s.solver.NewJob(id)
 	if err != nil {
+		if statusChan != nil {
+			close(statusChan)
+		}
 		return nil, err
 	}
 
 	defer j.Discard()
 
+	if statusChan != nil {
+		// not using the request context so that the progress is recorded
+		// until the job is discarded
+		j.StatusAsync(context.TODO(), statusChan)
+	}
+
+	attests, err := parseAttests(req.FrontendOpt)
+	if err != nil {
+		return nil, err
+	}
+
 	set, err := entitlements.WhiteList(ent, supportedEntitlements(s.entitlements))
 	if err != nil {
 		return nil, err
 	}
 	j.SetValue(keyEntitlements, set)
+	j.SetValue(keySolveID, id)
+
+	if srcPol != nil {
+		j.SetValue(keySourcePolicy, srcPol)
+	}
 
 	j.SessionID = sessionID
 
@@ -157,6 +195,12 @@ func (s *Solver) Solve(ctx context.Context, id string, sessionID string, req fro
 		})
 	}()
 
+	if params, ok := attests[exptypes.AttestSBOM]; ok && len(exp.Exporters) > 0 {
+		if err := scanSBOM(ctx, s.Bridge(j), res, params, sessionID); err != nil {
+			return nil, err
+		}
+	}
+
 	eg, ctx2 := errgroup.WithContext(ctx)
 	res.EachRef(func(ref solver.ResultProxy) error {
 		eg.Go(func() error {
@@ -199,8 +243,8 @@ func (s *Solver) Solve(ctx context.Context, id string, sessionID string, req fro
 		}
 	}
 
-	var exporterResponse map[string]string
-	if e := exp.Exporter; e != nil {
+	exporterResponse := make(map[string]string)
+	if len(exp.Exporters) > 0 {
 		inp := exporter.Source{
 			Metadata: res.Metadata,
 		}
@@ -241,38 +285,104 @@ func (s *Solver) Solve(ctx context.Context, id string, sessionID string, req fro
 			}
 			inp.Refs = m
 		}
-		if _, ok := asInlineCache(exp.CacheExporter); ok {
-			if err := inBuilderContext(ctx, j, "preparing layers for inline cache", "", func(ctx context.Context, _ session.Group) error {
-				if cr != nil {
-					dtic, err := inlineCache(ctx, exp.CacheExporter, cr, e.Config().Compression, session.NewGroup(sessionID))
+		for k, as := range res.Attestations {
+			for _, a := range as {
+				att := exporter.Attestation{
+					Kind:   a.Kind,
+					Path:   a.Path,
+					InToto: a.InToto,
+				}
+				if a.Ref != nil {
+					r, err := a.Ref.Result(ctx)
 					if err != nil {
-						return err
+						return nil, err
 					}
-					if dtic != nil {
-						inp.Metadata[exptypes.ExporterInlineCache] = dtic
+					workerRef, ok := r.Sys().(*worker.WorkerRef)
+					if !ok {
+						return nil, errors.Errorf("invalid reference: %T", r.Sys())
 					}
+					att.Ref = workerRef.ImmutableRef
 				}
-				for k, res := range crMap {
-					dtic, err := inlineCache(ctx, exp.CacheExporter, res, e.Config().Compression, session.NewGroup(sessionID))
-					if err != nil {
-						return err
+				if inp.Attestations == nil {
+					inp.Attestations = make(map[string][]exporter.Attestation)
+				}
+				inp.Attestations[k] = append(inp.Attestations[k], att)
+			}
+		}
+		if params, ok := attests[exptypes.AttestProvenance]; ok {
+			if err := addProvenance(&inp, res, id, req, params, startedOn); err != nil {
+				return nil, err
+			}
+		}
+		inps := make([]exporter.Source, len(exp.Exporters))
+		for i, e := range exp.Exporters {
+			inps[i] = inp
+			inps[i].Metadata = make(map[string][]byte, len(inp.Metadata))
+			for k, v := range inp.Metadata {
+				inps[i].Metadata[k] = v
+			}
+			if _, ok := asInlineCache(exp.CacheExporter); ok {
+				icid := ""
+				if len(exp.Exporters) > 1 {
+					icid = fmt.Sprintf("preparing layers for inline cache %d", i)
+				}
+				// the layers of the inline cache depend on the compression of the exporter
+				if err := inBuilderContext(ctx, j, "preparing layers for inline cache", icid, func(ctx context.Context, _ session.Group) error {
+					if cr != nil {
+						dtic, err := inlineCache(ctx, exp.CacheExporter, cr, e.Config().Compression, session.NewGroup(sessionID))
+						if err != nil {
+							return err
+						}
+						if dtic != nil {
+							inps[i].Metadata[exptypes.ExporterInlineCache] = dtic
+						}
 					}
-					if dtic != nil {
-						inp.Metadata[fmt.Sprintf("%s/%s", exptypes.ExporterInlineCache, k)] = dtic
+					for k, res := range crMap {
+						dtic, err := inlineCache(ctx, exp.CacheExporter, res, e.Config().Compression, session.NewGroup(sessionID))
+						if err != nil {
+							return err
+						}
+						if dtic != nil {
+							inps[i].Metadata[fmt.Sprintf("%s/%s", exptypes.ExporterInlineCache, k)] = dtic
+						}
 					}
+					return nil
+				}); err != nil {
+					return nil, err
 				}
-				exp.CacheExporter = nil
-				return nil
-			}); err != nil {
-				return nil, err
 			}
 		}
-		if err := inBuilderContext(ctx, j, e.Name(), "", func(ctx context.Context, _ session.Group) error {
-			exporterResponse, err = e.Export(ctx, inp, j.SessionID)
-			return err
-		}); err != nil {
+		if _, ok := asInlineCache(exp.CacheExporter); ok {
+			exp.CacheExporter = nil
+		}
+
+		resps := make([]map[string]string, len(exp.Exporters))
+		eg, ctx := errgroup.WithContext(ctx)
+		for i, e := range exp.Exporters {
+			i, e := i, e
+			id := ""
+			if len(exp.Exporters) > 1 {
+				id = fmt.Sprintf("%s %d", e.Name(), i)
+			}
+			eg.Go(func() error {
+				return inBuilderContext(ctx, j, e.Name(), id, func(ctx context.Context, _ session.Group) error {
+					resp, err := e.Export(filesync.WithExporterID(ctx, i), inps[i], j.SessionID)
+					resps[i] = resp
+					return err
+				})
+			})
+		}
+		if err := eg.Wait(); err != nil {
 			return nil, err
 		}
+		for i, resp := range resps {
+			for k, v := range resp {
+				if len(exp.Exporters) > 1 {
+					k = exptypes.ExporterResponseKey(i, k)
+				}
+				exporterResponse[k] = v
+			}
+		}
 	}
 
 	g := session.NewGroup(j.SessionID)
@@ -301,6 +411,7 @@ func (s *Solver) Solve(ctx context.Context, id string, sessionID string, req fro
 					Mode:           exp.CacheExportMode,
 					Session:        g,
 					CompressionOpt: &compressionConfig,
+					Filter:         exp.CacheExportFilter,
 				})
 				return err
 			}); err != nil {
@@ -314,10 +425,6 @@ func (s *Solver) Solve(ctx context.Context, id string, sessionID string, req fro
 		}
 	}
 
-	if exporterResponse == nil {
-		exporterResponse = make(map[string]string)
-	}
-
 	for k, v := range res.Metadata {
 		if strings.HasPrefix(k, "frontend.") {
 			exporterResponse[k] = string(v)
@@ -491,6 +598,17 @@ func supportedEntitlements(ents []string) []entitlements.Entitlement {
 	return out
 }
 
+func loadSolveID(b solver.Builder) string {
+	var id string
+	b.EachValue(context.TODO(), keySolveID, func(v interface{}) error {
+		if s, ok := v.(string); ok && id == "" {
+			id = s
+		}
+		return nil
+	})
+	return id
+}
+
 func loadEntitlements(b solver.Builder) (entitlements.Set, error) {
 	var ent entitlements.Set = map[entitlements.Entitlement]struct{}{}
 	err := b.EachValue(context.TODO(), keyEntitlements, func(v interface{}) error {
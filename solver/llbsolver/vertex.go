@@ -8,6 +8,7 @@ import (
 	"github.com/moby/buildkit/solver"
 	"github.com/moby/buildkit/solver/pb"
 	"github.com/moby/buildkit/source"
+	srctypes "github.com/moby/buildkit/source/types"
 	"github.com/moby/buildkit/util/entitlements"
 	digest "github.com/opencontainers/go-digest"
 	ocispecs "github.com/opencontainers/image-spec/specs-go/v1"
@@ -68,6 +69,23 @@ func WithCacheSources(cms []solver.CacheManager) LoadOpt {
 	}
 }
 
+// WithLocalSolveID sets the ID of the solve on the local sources so that their
+// cache keys differ between the solves of a shared session.
+func WithLocalSolveID(id string) LoadOpt {
+	return func(op *pb.Op, _ *pb.OpMetadata, _ *solver.VertexOptions) error {
+		if id == "" {
+			return nil
+		}
+		if src := op.GetSource(); src != nil && strings.HasPrefix(src.Identifier, srctypes.LocalScheme+"://") {
+			if src.Attrs == nil {
+				src.Attrs = map[string]string{}
+			}
+			src.Attrs[pb.AttrLocalSolveID] = id
+		}
+		return nil
+	}
+}
+
 func NormalizeRuntimePlatforms() LoadOpt {
 	var defaultPlatform *pb.Platform
 	return func(op *pb.Op, _ *pb.OpMetadata, opt *solver.VertexOptions) error {
@@ -6,6 +6,7 @@ const AttrAuthHeaderSecret = "git.authheadersecret"
 const AttrAuthTokenSecret = "git.authtokensecret"
 const AttrKnownSSHHosts = "git.knownsshhosts"
 const AttrMountSSHSock = "git.mountsshsock"
+const AttrGitChecksum = "git.checksum"
 const AttrLocalSessionID = "local.session"
 const AttrLocalUniqueID = "local.unique"
 const AttrIncludePatterns = "local.includepattern"
@@ -13,6 +14,10 @@ const AttrFollowPaths = "local.followpaths"
 const AttrExcludePatterns = "local.excludepatterns"
 const AttrSharedKeyHint = "local.sharedkeyhint"
 
+// AttrLocalSolveID is set by the solver to the ID of the solve that loads the
+// local source so that every solve in a shared session transfers the files.
+const AttrLocalSolveID = "local.solveid"
+
 const AttrLLBDefinitionFilename = "llbbuild.filename"
 
 const AttrHTTPChecksum = "http.checksum"
@@ -31,4 +36,8 @@ const AttrLocalDiffer = "local.differ"
 const AttrLocalDifferNone = "none"
 const AttrLocalDifferMetadata = "metadata"
 
+// DescriptionDockerfileStage is the key of the vertex description the
+// Dockerfile frontend sets to the name of the stage of the vertex.
+const DescriptionDockerfileStage = "com.docker.dockerfile.v1.stage"
+
 type IsFileAction = isFileAction_Action
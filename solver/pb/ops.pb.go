@@ -495,15 +495,20 @@ func (m *ExecOp) GetSecretenv() []*SecretEnv {
 // Meta is unrelated to LLB metadata.
 // FIXME: rename (ExecContext? ExecArgs?)
 type Meta struct {
-	Args         []string  `protobuf:"bytes,1,rep,name=args,proto3" json:"args,omitempty"`
-	Env          []string  `protobuf:"bytes,2,rep,name=env,proto3" json:"env,omitempty"`
-	Cwd          string    `protobuf:"bytes,3,opt,name=cwd,proto3" json:"cwd,omitempty"`
-	User         string    `protobuf:"bytes,4,opt,name=user,proto3" json:"user,omitempty"`
-	ProxyEnv     *ProxyEnv `protobuf:"bytes,5,opt,name=proxy_env,json=proxyEnv,proto3" json:"proxy_env,omitempty"`
-	ExtraHosts   []*HostIP `protobuf:"bytes,6,rep,name=extraHosts,proto3" json:"extraHosts,omitempty"`
-	Hostname     string    `protobuf:"bytes,7,opt,name=hostname,proto3" json:"hostname,omitempty"`
-	Ulimit       []*Ulimit `protobuf:"bytes,9,rep,name=ulimit,proto3" json:"ulimit,omitempty"`
-	CgroupParent string    `protobuf:"bytes,10,opt,name=cgroupParent,proto3" json:"cgroupParent,omitempty"`
+	Args           []string        `protobuf:"bytes,1,rep,name=args,proto3" json:"args,omitempty"`
+	Env            []string        `protobuf:"bytes,2,rep,name=env,proto3" json:"env,omitempty"`
+	Cwd            string          `protobuf:"bytes,3,opt,name=cwd,proto3" json:"cwd,omitempty"`
+	User           string          `protobuf:"bytes,4,opt,name=user,proto3" json:"user,omitempty"`
+	ProxyEnv       *ProxyEnv       `protobuf:"bytes,5,opt,name=proxy_env,json=proxyEnv,proto3" json:"proxy_env,omitempty"`
+	ExtraHosts     []*HostIP       `protobuf:"bytes,6,rep,name=extraHosts,proto3" json:"extraHosts,omitempty"`
+	Hostname       string          `protobuf:"bytes,7,opt,name=hostname,proto3" json:"hostname,omitempty"`
+	Ulimit         []*Ulimit       `protobuf:"bytes,9,rep,name=ulimit,proto3" json:"ulimit,omitempty"`
+	CgroupParent   string          `protobuf:"bytes,10,opt,name=cgroupParent,proto3" json:"cgroupParent,omitempty"`
+	ResourceLimits *ResourceLimits `protobuf:"bytes,11,opt,name=resourceLimits,proto3" json:"resourceLimits,omitempty"`
+	// timeout in nanoseconds after which the process is killed, zero for no timeout
+	Timeout int64 `protobuf:"varint,12,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	// exit codes other than zero that do not fail the exec
+	ValidExitCodes []int32 `protobuf:"varint,13,rep,packed,name=validExitCodes,proto3" json:"validExitCodes,omitempty"`
 }
 
 func (m *Meta) Reset()         { *m = Meta{} }
@@ -598,6 +603,27 @@ func (m *Meta) GetCgroupParent() string {
 	return ""
 }
 
+func (m *Meta) GetResourceLimits() *ResourceLimits {
+	if m != nil {
+		return m.ResourceLimits
+	}
+	return nil
+}
+
+func (m *Meta) GetTimeout() int64 {
+	if m != nil {
+		return m.Timeout
+	}
+	return 0
+}
+
+func (m *Meta) GetValidExitCodes() []int32 {
+	if m != nil {
+		return m.ValidExitCodes
+	}
+	return nil
+}
+
 type HostIP struct {
 	Host string `protobuf:"bytes,1,opt,name=Host,proto3" json:"Host,omitempty"`
 	IP   string `protobuf:"bytes,2,opt,name=IP,proto3" json:"IP,omitempty"`
@@ -702,6 +728,85 @@ func (m *Ulimit) GetHard() int64 {
 	return 0
 }
 
+// ResourceLimits constrains the resources available to the process of an
+// exec. Zero values are not limited.
+type ResourceLimits struct {
+	// CPU quota in units of 1e-9 CPUs
+	NanoCPUs int64 `protobuf:"varint,1,opt,name=nanoCPUs,proto3" json:"nanoCPUs,omitempty"`
+	// memory limit in bytes
+	Memory int64 `protobuf:"varint,2,opt,name=memory,proto3" json:"memory,omitempty"`
+	// memory plus swap limit in bytes, -1 for unlimited swap
+	MemorySwap int64 `protobuf:"varint,3,opt,name=memorySwap,proto3" json:"memorySwap,omitempty"`
+	// maximum number of processes
+	Pids int64 `protobuf:"varint,4,opt,name=pids,proto3" json:"pids,omitempty"`
+	// relative IO weight between 10 and 1000
+	IoWeight uint32 `protobuf:"varint,5,opt,name=ioWeight,proto3" json:"ioWeight,omitempty"`
+}
+
+func (m *ResourceLimits) Reset()         { *m = ResourceLimits{} }
+func (m *ResourceLimits) String() string { return proto.CompactTextString(m) }
+func (*ResourceLimits) ProtoMessage()    {}
+func (*ResourceLimits) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8de16154b2733812, []int{7}
+}
+func (m *ResourceLimits) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ResourceLimits) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	b = b[:cap(b)]
+	n, err := m.MarshalToSizedBuffer(b)
+	if err != nil {
+		return nil, err
+	}
+	return b[:n], nil
+}
+func (m *ResourceLimits) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResourceLimits.Merge(m, src)
+}
+func (m *ResourceLimits) XXX_Size() int {
+	return m.Size()
+}
+func (m *ResourceLimits) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResourceLimits.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ResourceLimits proto.InternalMessageInfo
+
+func (m *ResourceLimits) GetNanoCPUs() int64 {
+	if m != nil {
+		return m.NanoCPUs
+	}
+	return 0
+}
+
+func (m *ResourceLimits) GetMemory() int64 {
+	if m != nil {
+		return m.Memory
+	}
+	return 0
+}
+
+func (m *ResourceLimits) GetMemorySwap() int64 {
+	if m != nil {
+		return m.MemorySwap
+	}
+	return 0
+}
+
+func (m *ResourceLimits) GetPids() int64 {
+	if m != nil {
+		return m.Pids
+	}
+	return 0
+}
+
+func (m *ResourceLimits) GetIoWeight() uint32 {
+	if m != nil {
+		return m.IoWeight
+	}
+	return 0
+}
+
 // SecretEnv is an environment variable that is backed by a secret.
 type SecretEnv struct {
 	ID       string `protobuf:"bytes,1,opt,name=ID,proto3" json:"ID,omitempty"`
@@ -713,7 +818,7 @@ func (m *SecretEnv) Reset()         { *m = SecretEnv{} }
 func (m *SecretEnv) String() string { return proto.CompactTextString(m) }
 func (*SecretEnv) ProtoMessage()    {}
 func (*SecretEnv) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8de16154b2733812, []int{7}
+	return fileDescriptor_8de16154b2733812, []int{8}
 }
 func (m *SecretEnv) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -778,7 +883,7 @@ func (m *Mount) Reset()         { *m = Mount{} }
 func (m *Mount) String() string { return proto.CompactTextString(m) }
 func (*Mount) ProtoMessage()    {}
 func (*Mount) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8de16154b2733812, []int{8}
+	return fileDescriptor_8de16154b2733812, []int{9}
 }
 func (m *Mount) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -876,7 +981,7 @@ func (m *TmpfsOpt) Reset()         { *m = TmpfsOpt{} }
 func (m *TmpfsOpt) String() string { return proto.CompactTextString(m) }
 func (*TmpfsOpt) ProtoMessage()    {}
 func (*TmpfsOpt) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8de16154b2733812, []int{9}
+	return fileDescriptor_8de16154b2733812, []int{10}
 }
 func (m *TmpfsOpt) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -920,7 +1025,7 @@ func (m *CacheOpt) Reset()         { *m = CacheOpt{} }
 func (m *CacheOpt) String() string { return proto.CompactTextString(m) }
 func (*CacheOpt) ProtoMessage()    {}
 func (*CacheOpt) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8de16154b2733812, []int{10}
+	return fileDescriptor_8de16154b2733812, []int{11}
 }
 func (m *CacheOpt) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -978,7 +1083,7 @@ func (m *SecretOpt) Reset()         { *m = SecretOpt{} }
 func (m *SecretOpt) String() string { return proto.CompactTextString(m) }
 func (*SecretOpt) ProtoMessage()    {}
 func (*SecretOpt) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8de16154b2733812, []int{11}
+	return fileDescriptor_8de16154b2733812, []int{12}
 }
 func (m *SecretOpt) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1057,7 +1162,7 @@ func (m *SSHOpt) Reset()         { *m = SSHOpt{} }
 func (m *SSHOpt) String() string { return proto.CompactTextString(m) }
 func (*SSHOpt) ProtoMessage()    {}
 func (*SSHOpt) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8de16154b2733812, []int{12}
+	return fileDescriptor_8de16154b2733812, []int{13}
 }
 func (m *SSHOpt) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1130,7 +1235,7 @@ func (m *SourceOp) Reset()         { *m = SourceOp{} }
 func (m *SourceOp) String() string { return proto.CompactTextString(m) }
 func (*SourceOp) ProtoMessage()    {}
 func (*SourceOp) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8de16154b2733812, []int{13}
+	return fileDescriptor_8de16154b2733812, []int{14}
 }
 func (m *SourceOp) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1182,7 +1287,7 @@ func (m *BuildOp) Reset()         { *m = BuildOp{} }
 func (m *BuildOp) String() string { return proto.CompactTextString(m) }
 func (*BuildOp) ProtoMessage()    {}
 func (*BuildOp) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8de16154b2733812, []int{14}
+	return fileDescriptor_8de16154b2733812, []int{15}
 }
 func (m *BuildOp) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1237,7 +1342,7 @@ func (m *BuildInput) Reset()         { *m = BuildInput{} }
 func (m *BuildInput) String() string { return proto.CompactTextString(m) }
 func (*BuildInput) ProtoMessage()    {}
 func (*BuildInput) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8de16154b2733812, []int{15}
+	return fileDescriptor_8de16154b2733812, []int{16}
 }
 func (m *BuildInput) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1279,7 +1384,7 @@ func (m *OpMetadata) Reset()         { *m = OpMetadata{} }
 func (m *OpMetadata) String() string { return proto.CompactTextString(m) }
 func (*OpMetadata) ProtoMessage()    {}
 func (*OpMetadata) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8de16154b2733812, []int{16}
+	return fileDescriptor_8de16154b2733812, []int{17}
 }
 func (m *OpMetadata) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1349,7 +1454,7 @@ func (m *Source) Reset()         { *m = Source{} }
 func (m *Source) String() string { return proto.CompactTextString(m) }
 func (*Source) ProtoMessage()    {}
 func (*Source) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8de16154b2733812, []int{17}
+	return fileDescriptor_8de16154b2733812, []int{18}
 }
 func (m *Source) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1397,7 +1502,7 @@ func (m *Locations) Reset()         { *m = Locations{} }
 func (m *Locations) String() string { return proto.CompactTextString(m) }
 func (*Locations) ProtoMessage()    {}
 func (*Locations) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8de16154b2733812, []int{18}
+	return fileDescriptor_8de16154b2733812, []int{19}
 }
 func (m *Locations) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1440,7 +1545,7 @@ func (m *SourceInfo) Reset()         { *m = SourceInfo{} }
 func (m *SourceInfo) String() string { return proto.CompactTextString(m) }
 func (*SourceInfo) ProtoMessage()    {}
 func (*SourceInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8de16154b2733812, []int{19}
+	return fileDescriptor_8de16154b2733812, []int{20}
 }
 func (m *SourceInfo) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1496,7 +1601,7 @@ func (m *Location) Reset()         { *m = Location{} }
 func (m *Location) String() string { return proto.CompactTextString(m) }
 func (*Location) ProtoMessage()    {}
 func (*Location) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8de16154b2733812, []int{20}
+	return fileDescriptor_8de16154b2733812, []int{21}
 }
 func (m *Location) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1545,7 +1650,7 @@ func (m *Range) Reset()         { *m = Range{} }
 func (m *Range) String() string { return proto.CompactTextString(m) }
 func (*Range) ProtoMessage()    {}
 func (*Range) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8de16154b2733812, []int{21}
+	return fileDescriptor_8de16154b2733812, []int{22}
 }
 func (m *Range) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1594,7 +1699,7 @@ func (m *Position) Reset()         { *m = Position{} }
 func (m *Position) String() string { return proto.CompactTextString(m) }
 func (*Position) ProtoMessage()    {}
 func (*Position) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8de16154b2733812, []int{22}
+	return fileDescriptor_8de16154b2733812, []int{23}
 }
 func (m *Position) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1641,7 +1746,7 @@ func (m *ExportCache) Reset()         { *m = ExportCache{} }
 func (m *ExportCache) String() string { return proto.CompactTextString(m) }
 func (*ExportCache) ProtoMessage()    {}
 func (*ExportCache) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8de16154b2733812, []int{23}
+	return fileDescriptor_8de16154b2733812, []int{24}
 }
 func (m *ExportCache) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1683,7 +1788,7 @@ func (m *ProgressGroup) Reset()         { *m = ProgressGroup{} }
 func (m *ProgressGroup) String() string { return proto.CompactTextString(m) }
 func (*ProgressGroup) ProtoMessage()    {}
 func (*ProgressGroup) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8de16154b2733812, []int{24}
+	return fileDescriptor_8de16154b2733812, []int{25}
 }
 func (m *ProgressGroup) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1741,7 +1846,7 @@ func (m *ProxyEnv) Reset()         { *m = ProxyEnv{} }
 func (m *ProxyEnv) String() string { return proto.CompactTextString(m) }
 func (*ProxyEnv) ProtoMessage()    {}
 func (*ProxyEnv) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8de16154b2733812, []int{25}
+	return fileDescriptor_8de16154b2733812, []int{26}
 }
 func (m *ProxyEnv) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1810,7 +1915,7 @@ func (m *WorkerConstraints) Reset()         { *m = WorkerConstraints{} }
 func (m *WorkerConstraints) String() string { return proto.CompactTextString(m) }
 func (*WorkerConstraints) ProtoMessage()    {}
 func (*WorkerConstraints) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8de16154b2733812, []int{26}
+	return fileDescriptor_8de16154b2733812, []int{27}
 }
 func (m *WorkerConstraints) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1857,7 +1962,7 @@ func (m *Definition) Reset()         { *m = Definition{} }
 func (m *Definition) String() string { return proto.CompactTextString(m) }
 func (*Definition) ProtoMessage()    {}
 func (*Definition) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8de16154b2733812, []int{27}
+	return fileDescriptor_8de16154b2733812, []int{28}
 }
 func (m *Definition) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1911,7 +2016,7 @@ func (m *FileOp) Reset()         { *m = FileOp{} }
 func (m *FileOp) String() string { return proto.CompactTextString(m) }
 func (*FileOp) ProtoMessage()    {}
 func (*FileOp) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8de16154b2733812, []int{28}
+	return fileDescriptor_8de16154b2733812, []int{29}
 }
 func (m *FileOp) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1959,7 +2064,7 @@ func (m *FileAction) Reset()         { *m = FileAction{} }
 func (m *FileAction) String() string { return proto.CompactTextString(m) }
 func (*FileAction) ProtoMessage()    {}
 func (*FileAction) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8de16154b2733812, []int{29}
+	return fileDescriptor_8de16154b2733812, []int{30}
 }
 func (m *FileAction) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2086,7 +2191,7 @@ func (m *FileActionCopy) Reset()         { *m = FileActionCopy{} }
 func (m *FileActionCopy) String() string { return proto.CompactTextString(m) }
 func (*FileActionCopy) ProtoMessage()    {}
 func (*FileActionCopy) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8de16154b2733812, []int{30}
+	return fileDescriptor_8de16154b2733812, []int{31}
 }
 func (m *FileActionCopy) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2219,7 +2324,7 @@ func (m *FileActionMkFile) Reset()         { *m = FileActionMkFile{} }
 func (m *FileActionMkFile) String() string { return proto.CompactTextString(m) }
 func (*FileActionMkFile) ProtoMessage()    {}
 func (*FileActionMkFile) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8de16154b2733812, []int{31}
+	return fileDescriptor_8de16154b2733812, []int{32}
 }
 func (m *FileActionMkFile) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2296,7 +2401,7 @@ func (m *FileActionMkDir) Reset()         { *m = FileActionMkDir{} }
 func (m *FileActionMkDir) String() string { return proto.CompactTextString(m) }
 func (*FileActionMkDir) ProtoMessage()    {}
 func (*FileActionMkDir) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8de16154b2733812, []int{32}
+	return fileDescriptor_8de16154b2733812, []int{33}
 }
 func (m *FileActionMkDir) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2369,7 +2474,7 @@ func (m *FileActionRm) Reset()         { *m = FileActionRm{} }
 func (m *FileActionRm) String() string { return proto.CompactTextString(m) }
 func (*FileActionRm) ProtoMessage()    {}
 func (*FileActionRm) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8de16154b2733812, []int{33}
+	return fileDescriptor_8de16154b2733812, []int{34}
 }
 func (m *FileActionRm) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2424,7 +2529,7 @@ func (m *ChownOpt) Reset()         { *m = ChownOpt{} }
 func (m *ChownOpt) String() string { return proto.CompactTextString(m) }
 func (*ChownOpt) ProtoMessage()    {}
 func (*ChownOpt) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8de16154b2733812, []int{34}
+	return fileDescriptor_8de16154b2733812, []int{35}
 }
 func (m *ChownOpt) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2474,7 +2579,7 @@ func (m *UserOpt) Reset()         { *m = UserOpt{} }
 func (m *UserOpt) String() string { return proto.CompactTextString(m) }
 func (*UserOpt) ProtoMessage()    {}
 func (*UserOpt) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8de16154b2733812, []int{35}
+	return fileDescriptor_8de16154b2733812, []int{36}
 }
 func (m *UserOpt) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2553,7 +2658,7 @@ func (m *NamedUserOpt) Reset()         { *m = NamedUserOpt{} }
 func (m *NamedUserOpt) String() string { return proto.CompactTextString(m) }
 func (*NamedUserOpt) ProtoMessage()    {}
 func (*NamedUserOpt) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8de16154b2733812, []int{36}
+	return fileDescriptor_8de16154b2733812, []int{37}
 }
 func (m *NamedUserOpt) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2593,7 +2698,7 @@ func (m *MergeInput) Reset()         { *m = MergeInput{} }
 func (m *MergeInput) String() string { return proto.CompactTextString(m) }
 func (*MergeInput) ProtoMessage()    {}
 func (*MergeInput) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8de16154b2733812, []int{37}
+	return fileDescriptor_8de16154b2733812, []int{38}
 }
 func (m *MergeInput) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2626,7 +2731,7 @@ func (m *MergeOp) Reset()         { *m = MergeOp{} }
 func (m *MergeOp) String() string { return proto.CompactTextString(m) }
 func (*MergeOp) ProtoMessage()    {}
 func (*MergeOp) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8de16154b2733812, []int{38}
+	return fileDescriptor_8de16154b2733812, []int{39}
 }
 func (m *MergeOp) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2666,7 +2771,7 @@ func (m *LowerDiffInput) Reset()         { *m = LowerDiffInput{} }
 func (m *LowerDiffInput) String() string { return proto.CompactTextString(m) }
 func (*LowerDiffInput) ProtoMessage()    {}
 func (*LowerDiffInput) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8de16154b2733812, []int{39}
+	return fileDescriptor_8de16154b2733812, []int{40}
 }
 func (m *LowerDiffInput) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2699,7 +2804,7 @@ func (m *UpperDiffInput) Reset()         { *m = UpperDiffInput{} }
 func (m *UpperDiffInput) String() string { return proto.CompactTextString(m) }
 func (*UpperDiffInput) ProtoMessage()    {}
 func (*UpperDiffInput) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8de16154b2733812, []int{40}
+	return fileDescriptor_8de16154b2733812, []int{41}
 }
 func (m *UpperDiffInput) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2733,7 +2838,7 @@ func (m *DiffOp) Reset()         { *m = DiffOp{} }
 func (m *DiffOp) String() string { return proto.CompactTextString(m) }
 func (*DiffOp) ProtoMessage()    {}
 func (*DiffOp) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8de16154b2733812, []int{41}
+	return fileDescriptor_8de16154b2733812, []int{42}
 }
 func (m *DiffOp) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2784,6 +2889,7 @@ func init() {
 	proto.RegisterType((*Meta)(nil), "pb.Meta")
 	proto.RegisterType((*HostIP)(nil), "pb.HostIP")
 	proto.RegisterType((*Ulimit)(nil), "pb.Ulimit")
+	proto.RegisterType((*ResourceLimits)(nil), "pb.ResourceLimits")
 	proto.RegisterType((*SecretEnv)(nil), "pb.SecretEnv")
 	proto.RegisterType((*Mount)(nil), "pb.Mount")
 	proto.RegisterType((*TmpfsOpt)(nil), "pb.TmpfsOpt")
@@ -2831,166 +2937,173 @@ func init() {
 func init() { proto.RegisterFile("ops.proto", fileDescriptor_8de16154b2733812) }
 
 var fileDescriptor_8de16154b2733812 = []byte{
-	// 2538 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x59, 0xcf, 0x6f, 0x5b, 0xc7,
-	0xf1, 0x17, 0x7f, 0x93, 0x43, 0x89, 0x66, 0xd6, 0x4e, 0xc2, 0xe8, 0xeb, 0xaf, 0xac, 0xbc, 0xe4,
-	0x1b, 0xc8, 0xb2, 0x2d, 0xe1, 0xab, 0x00, 0x71, 0x60, 0x14, 0x45, 0x25, 0x91, 0x8e, 0x18, 0xdb,
-	0xa2, 0xb0, 0xb4, 0x9d, 0x1e, 0x0a, 0x18, 0x4f, 0x8f, 0x4b, 0xe9, 0x41, 0x8f, 0x6f, 0x1f, 0xf6,
-	0x2d, 0x23, 0xb1, 0x87, 0x1e, 0x7a, 0x2f, 0x10, 0xa0, 0x40, 0xd1, 0x4b, 0xd1, 0x7f, 0xa2, 0xc7,
-	0xf6, 0x1e, 0xa0, 0x97, 0x1c, 0x7a, 0x08, 0x7a, 0x48, 0x0b, 0xe7, 0xd2, 0x3f, 0xa2, 0x05, 0x8a,
-	0x99, 0xdd, 0xf7, 0x83, 0x94, 0x02, 0xc7, 0x6d, 0xd1, 0x13, 0xe7, 0xcd, 0x7c, 0x76, 0x66, 0x76,
-	0x77, 0x66, 0x67, 0x76, 0x09, 0x0d, 0x19, 0xc5, 0x5b, 0x91, 0x92, 0x5a, 0xb2, 0x62, 0x74, 0xbc,
-	0x7a, 0xef, 0xc4, 0xd7, 0xa7, 0xd3, 0xe3, 0x2d, 0x4f, 0x4e, 0xb6, 0x4f, 0xe4, 0x89, 0xdc, 0x26,
-	0xd1, 0xf1, 0x74, 0x4c, 0x5f, 0xf4, 0x41, 0x94, 0x19, 0xe2, 0xfc, 0xad, 0x08, 0xc5, 0x41, 0xc4,
-	0xde, 0x85, 0xaa, 0x1f, 0x46, 0x53, 0x1d, 0x77, 0x0a, 0xeb, 0xa5, 0x8d, 0xe6, 0x4e, 0x63, 0x2b,
-	0x3a, 0xde, 0xea, 0x23, 0x87, 0x5b, 0x01, 0x5b, 0x87, 0xb2, 0xb8, 0x10, 0x5e, 0xa7, 0xb8, 0x5e,
-	0xd8, 0x68, 0xee, 0x00, 0x02, 0x7a, 0x17, 0xc2, 0x1b, 0x44, 0x07, 0x4b, 0x9c, 0x24, 0xec, 0x03,
-	0xa8, 0xc6, 0x72, 0xaa, 0x3c, 0xd1, 0x29, 0x11, 0x66, 0x19, 0x31, 0x43, 0xe2, 0x10, 0xca, 0x4a,
-	0x51, 0xd3, 0xd8, 0x0f, 0x44, 0xa7, 0x9c, 0x69, 0x7a, 0xe8, 0x07, 0x06, 0x43, 0x12, 0xf6, 0x1e,
-	0x54, 0x8e, 0xa7, 0x7e, 0x30, 0xea, 0x54, 0x08, 0xd2, 0x44, 0xc8, 0x1e, 0x32, 0x08, 0x63, 0x64,
-	0x08, 0x9a, 0x08, 0x75, 0x22, 0x3a, 0xd5, 0x0c, 0xf4, 0x04, 0x19, 0x06, 0x44, 0x32, 0xb4, 0x35,
-	0xf2, 0xc7, 0xe3, 0x4e, 0x2d, 0xb3, 0xd5, 0xf5, 0xc7, 0x63, 0x63, 0x0b, 0x25, 0x6c, 0x03, 0xea,
-	0x51, 0xe0, 0xea, 0xb1, 0x54, 0x93, 0x0e, 0x64, 0x7e, 0x1f, 0x59, 0x1e, 0x4f, 0xa5, 0xec, 0x3e,
-	0x34, 0x3d, 0x19, 0xc6, 0x5a, 0xb9, 0x7e, 0xa8, 0xe3, 0x4e, 0x93, 0xc0, 0x6f, 0x22, 0xf8, 0x33,
-	0xa9, 0xce, 0x84, 0xda, 0xcf, 0x84, 0x3c, 0x8f, 0xdc, 0x2b, 0x43, 0x51, 0x46, 0xce, 0xaf, 0x0a,
-	0x50, 0x4f, 0xb4, 0x32, 0x07, 0x96, 0x77, 0x95, 0x77, 0xea, 0x6b, 0xe1, 0xe9, 0xa9, 0x12, 0x9d,
-	0xc2, 0x7a, 0x61, 0xa3, 0xc1, 0xe7, 0x78, 0xac, 0x05, 0xc5, 0xc1, 0x90, 0xd6, 0xbb, 0xc1, 0x8b,
-	0x83, 0x21, 0xeb, 0x40, 0xed, 0xb9, 0xab, 0x7c, 0x37, 0xd4, 0xb4, 0xc0, 0x0d, 0x9e, 0x7c, 0xb2,
-	0x9b, 0xd0, 0x18, 0x0c, 0x9f, 0x0b, 0x15, 0xfb, 0x32, 0xa4, 0x65, 0x6d, 0xf0, 0x8c, 0xc1, 0xd6,
-	0x00, 0x06, 0xc3, 0x87, 0xc2, 0x45, 0xa5, 0x71, 0xa7, 0xb2, 0x5e, 0xda, 0x68, 0xf0, 0x1c, 0xc7,
-	0xf9, 0x19, 0x54, 0x68, 0xab, 0xd9, 0xa7, 0x50, 0x1d, 0xf9, 0x27, 0x22, 0xd6, 0xc6, 0x9d, 0xbd,
-	0x9d, 0x2f, 0xbf, 0xb9, 0xb5, 0xf4, 0xe7, 0x6f, 0x6e, 0x6d, 0xe6, 0x62, 0x4a, 0x46, 0x22, 0xf4,
-	0x64, 0xa8, 0x5d, 0x3f, 0x14, 0x2a, 0xde, 0x3e, 0x91, 0xf7, 0xcc, 0x90, 0xad, 0x2e, 0xfd, 0x70,
-	0xab, 0x81, 0xdd, 0x86, 0x8a, 0x1f, 0x8e, 0xc4, 0x05, 0xf9, 0x5f, 0xda, 0xbb, 0x6e, 0x55, 0x35,
-	0x07, 0x53, 0x1d, 0x4d, 0x75, 0x1f, 0x45, 0xdc, 0x20, 0x9c, 0x3f, 0x16, 0xa0, 0x6a, 0x42, 0x89,
-	0xdd, 0x84, 0xf2, 0x44, 0x68, 0x97, 0xec, 0x37, 0x77, 0xea, 0x66, 0x4b, 0xb5, 0xcb, 0x89, 0x8b,
-	0x51, 0x3a, 0x91, 0x53, 0x5c, 0xfb, 0x62, 0x16, 0xa5, 0x4f, 0x90, 0xc3, 0xad, 0x80, 0xfd, 0x1f,
-	0xd4, 0x42, 0xa1, 0xcf, 0xa5, 0x3a, 0xa3, 0x35, 0x6a, 0x99, 0xb0, 0x38, 0x14, 0xfa, 0x89, 0x1c,
-	0x09, 0x9e, 0xc8, 0xd8, 0x5d, 0xa8, 0xc7, 0xc2, 0x9b, 0x2a, 0x5f, 0xcf, 0x68, 0xbd, 0x5a, 0x3b,
-	0x6d, 0x0a, 0x56, 0xcb, 0x23, 0x70, 0x8a, 0x60, 0x77, 0xa0, 0x11, 0x0b, 0x4f, 0x09, 0x2d, 0xc2,
-	0xcf, 0x69, 0xfd, 0x9a, 0x3b, 0x2b, 0x16, 0xae, 0x84, 0xee, 0x85, 0x9f, 0xf3, 0x4c, 0xee, 0xfc,
-	0xa2, 0x08, 0x65, 0xf4, 0x99, 0x31, 0x28, 0xbb, 0xea, 0xc4, 0x64, 0x54, 0x83, 0x13, 0xcd, 0xda,
-	0x50, 0x42, 0x1d, 0x45, 0x62, 0x21, 0x89, 0x1c, 0xef, 0x7c, 0x64, 0x37, 0x14, 0x49, 0x1c, 0x37,
-	0x8d, 0x85, 0xb2, 0xfb, 0x48, 0x34, 0xbb, 0x0d, 0x8d, 0x48, 0xc9, 0x8b, 0xd9, 0x0b, 0xe3, 0x41,
-	0x16, 0xa5, 0xc8, 0x44, 0x07, 0xea, 0x91, 0xa5, 0xd8, 0x26, 0x80, 0xb8, 0xd0, 0xca, 0x3d, 0x90,
-	0xb1, 0x8e, 0x3b, 0x55, 0xf2, 0x96, 0xe2, 0x1e, 0x19, 0xfd, 0x23, 0x9e, 0x93, 0xb2, 0x55, 0xa8,
-	0x9f, 0xca, 0x58, 0x87, 0xee, 0x44, 0x50, 0x86, 0x34, 0x78, 0xfa, 0xcd, 0x1c, 0xa8, 0x4e, 0x03,
-	0x7f, 0xe2, 0xeb, 0x4e, 0x23, 0xd3, 0xf1, 0x8c, 0x38, 0xdc, 0x4a, 0x30, 0x8a, 0xbd, 0x13, 0x25,
-	0xa7, 0xd1, 0x91, 0xab, 0x44, 0xa8, 0x29, 0x7f, 0x1a, 0x7c, 0x8e, 0xe7, 0xdc, 0x85, 0xaa, 0xb1,
-	0x8c, 0x13, 0x43, 0xca, 0xc6, 0x3a, 0xd1, 0x18, 0xe3, 0xfd, 0xa3, 0x24, 0xc6, 0xfb, 0x47, 0x4e,
-	0x17, 0xaa, 0xc6, 0x06, 0xa2, 0x0f, 0xd1, 0x2f, 0x8b, 0x46, 0x1a, 0x79, 0x43, 0x39, 0xd6, 0x26,
-	0xa6, 0x38, 0xd1, 0xa4, 0xd5, 0x55, 0x66, 0x05, 0x4b, 0x9c, 0x68, 0xe7, 0x11, 0x34, 0xd2, 0xbd,
-	0x21, 0x13, 0x5d, 0xab, 0xa6, 0xd8, 0xef, 0xe2, 0x00, 0x9a, 0xb0, 0x31, 0x4a, 0x34, 0x2e, 0x84,
-	0x8c, 0xb4, 0x2f, 0x43, 0x37, 0x20, 0x45, 0x75, 0x9e, 0x7e, 0x3b, 0xbf, 0x2e, 0x41, 0x85, 0x82,
-	0x8c, 0x6d, 0x60, 0x4c, 0x47, 0x53, 0x33, 0x83, 0xd2, 0x1e, 0xb3, 0x31, 0x0d, 0x94, 0x3d, 0x69,
-	0x48, 0x63, 0x26, 0xad, 0x62, 0x7c, 0x05, 0xc2, 0xd3, 0x52, 0x59, 0x3b, 0xe9, 0x37, 0xda, 0x1f,
-	0x61, 0x8e, 0x99, 0x2d, 0x27, 0x9a, 0xdd, 0x81, 0xaa, 0xa4, 0xc4, 0xa0, 0x5d, 0xff, 0x8e, 0x74,
-	0xb1, 0x10, 0x54, 0xae, 0x84, 0x3b, 0x92, 0x61, 0x30, 0xa3, 0x58, 0xa8, 0xf3, 0xf4, 0x1b, 0x43,
-	0x95, 0x32, 0xe1, 0xe9, 0x2c, 0x32, 0x07, 0x63, 0xcb, 0x84, 0xea, 0x93, 0x84, 0xc9, 0x33, 0x39,
-	0x1e, 0x7d, 0x4f, 0x27, 0xd1, 0x38, 0x1e, 0x44, 0xba, 0x73, 0x3d, 0x0b, 0xaa, 0x84, 0xc7, 0x53,
-	0x29, 0x22, 0x3d, 0xd7, 0x3b, 0x15, 0x88, 0xbc, 0x91, 0x21, 0xf7, 0x2d, 0x8f, 0xa7, 0xd2, 0x2c,
-	0x57, 0x10, 0xfa, 0x26, 0x41, 0x73, 0xb9, 0x82, 0xd8, 0x4c, 0x8e, 0x31, 0x36, 0x1c, 0x1e, 0x20,
-	0xf2, 0xad, 0xec, 0x7c, 0x36, 0x1c, 0x6e, 0x25, 0x66, 0xb6, 0xf1, 0x34, 0xd0, 0xfd, 0x6e, 0xe7,
-	0x6d, 0xb3, 0x94, 0xc9, 0xb7, 0xb3, 0x96, 0x4d, 0x00, 0x97, 0x35, 0xf6, 0x7f, 0x6a, 0xe2, 0xa5,
-	0xc4, 0x89, 0x76, 0xfa, 0x50, 0x4f, 0x5c, 0xbc, 0x14, 0x06, 0xf7, 0xa0, 0x16, 0x9f, 0xba, 0xca,
-	0x0f, 0x4f, 0x68, 0x87, 0x5a, 0x3b, 0xd7, 0xd3, 0x19, 0x0d, 0x0d, 0x1f, 0xbd, 0x48, 0x30, 0x8e,
-	0x4c, 0x42, 0xea, 0x2a, 0x5d, 0x6d, 0x28, 0x4d, 0xfd, 0x11, 0xe9, 0x59, 0xe1, 0x48, 0x22, 0xe7,
-	0xc4, 0x37, 0x41, 0xb9, 0xc2, 0x91, 0x44, 0xff, 0x26, 0x72, 0x64, 0xaa, 0xde, 0x0a, 0x27, 0x7a,
-	0x2e, 0xec, 0x2a, 0x0b, 0x61, 0x17, 0x24, 0x6b, 0xf3, 0x5f, 0xb1, 0xf6, 0xcb, 0x02, 0xd4, 0x93,
-	0x52, 0x8d, 0x05, 0xc3, 0x1f, 0x89, 0x50, 0xfb, 0x63, 0x5f, 0x28, 0x6b, 0x38, 0xc7, 0x61, 0xf7,
-	0xa0, 0xe2, 0x6a, 0xad, 0x92, 0x63, 0xf8, 0xed, 0x7c, 0x9d, 0xdf, 0xda, 0x45, 0x49, 0x2f, 0xd4,
-	0x6a, 0xc6, 0x0d, 0x6a, 0xf5, 0x63, 0x80, 0x8c, 0x89, 0xbe, 0x9e, 0x89, 0x99, 0xd5, 0x8a, 0x24,
-	0xbb, 0x01, 0x95, 0xcf, 0xdd, 0x60, 0x9a, 0x64, 0xa4, 0xf9, 0x78, 0x50, 0xfc, 0xb8, 0xe0, 0xfc,
-	0xa1, 0x08, 0x35, 0x5b, 0xf7, 0xd9, 0x5d, 0xa8, 0x51, 0xdd, 0xb7, 0x1e, 0x5d, 0x9d, 0x7e, 0x09,
-	0x84, 0x6d, 0xa7, 0x0d, 0x4d, 0xce, 0x47, 0xab, 0xca, 0x34, 0x36, 0xd6, 0xc7, 0xac, 0xbd, 0x29,
-	0x8d, 0xc4, 0xd8, 0x76, 0x2e, 0x2d, 0xea, 0x13, 0xc4, 0xd8, 0x0f, 0x7d, 0x5c, 0x1f, 0x8e, 0x22,
-	0x76, 0x37, 0x99, 0x75, 0x99, 0x34, 0xbe, 0x95, 0xd7, 0x78, 0x79, 0xd2, 0x7d, 0x68, 0xe6, 0xcc,
-	0x5c, 0x31, 0xeb, 0xf7, 0xf3, 0xb3, 0xb6, 0x26, 0x49, 0x9d, 0x69, 0xbb, 0xb2, 0x55, 0xf8, 0x37,
-	0xd6, 0xef, 0x23, 0x80, 0x4c, 0xe5, 0xf7, 0x3f, 0xbe, 0x9c, 0xdf, 0x97, 0x00, 0x06, 0x11, 0x56,
-	0xb1, 0x91, 0x4b, 0x75, 0x77, 0xd9, 0x3f, 0x09, 0xa5, 0x12, 0x2f, 0x28, 0xcd, 0x69, 0x7c, 0x9d,
-	0x37, 0x0d, 0x8f, 0x32, 0x86, 0xed, 0x42, 0x73, 0x24, 0x62, 0x4f, 0xf9, 0x14, 0x50, 0x76, 0xd1,
-	0x6f, 0xe1, 0x9c, 0x32, 0x3d, 0x5b, 0xdd, 0x0c, 0x61, 0xd6, 0x2a, 0x3f, 0x86, 0xed, 0xc0, 0xb2,
-	0xb8, 0x88, 0xa4, 0xd2, 0xd6, 0x8a, 0x69, 0x0f, 0xaf, 0x99, 0x46, 0x13, 0xf9, 0x64, 0x89, 0x37,
-	0x45, 0xf6, 0xc1, 0x5c, 0x28, 0x7b, 0x6e, 0x14, 0xdb, 0xa2, 0xdc, 0x59, 0xb0, 0xb7, 0xef, 0x46,
-	0x66, 0xd1, 0xf6, 0x3e, 0xc4, 0xb9, 0xfe, 0xfc, 0x2f, 0xb7, 0xee, 0xe4, 0x3a, 0x99, 0x89, 0x3c,
-	0x9e, 0x6d, 0x53, 0xbc, 0x9c, 0xf9, 0x7a, 0x7b, 0xaa, 0xfd, 0x60, 0xdb, 0x8d, 0x7c, 0x54, 0x87,
-	0x03, 0xfb, 0x5d, 0x4e, 0xaa, 0xd9, 0xc7, 0xd0, 0x8a, 0x94, 0x3c, 0x51, 0x22, 0x8e, 0x5f, 0x50,
-	0x5d, 0xb3, 0xfd, 0xe6, 0x1b, 0xb6, 0xfe, 0x92, 0xe4, 0x13, 0x14, 0xf0, 0x95, 0x28, 0xff, 0xb9,
-	0xfa, 0x43, 0x68, 0x2f, 0xce, 0xf8, 0x75, 0x76, 0x6f, 0xf5, 0x3e, 0x34, 0xd2, 0x19, 0xbc, 0x6a,
-	0x60, 0x3d, 0xbf, 0xed, 0xbf, 0x2b, 0x40, 0xd5, 0xe4, 0x23, 0xbb, 0x0f, 0x8d, 0x40, 0x7a, 0x2e,
-	0x3a, 0x90, 0xf4, 0xf6, 0xef, 0x64, 0xe9, 0xba, 0xf5, 0x38, 0x91, 0x99, 0xfd, 0xc8, 0xb0, 0x18,
-	0x9e, 0x7e, 0x38, 0x96, 0x49, 0xfe, 0xb4, 0xb2, 0x41, 0xfd, 0x70, 0x2c, 0xb9, 0x11, 0xae, 0x3e,
-	0x82, 0xd6, 0xbc, 0x8a, 0x2b, 0xfc, 0x7c, 0x6f, 0x3e, 0xd0, 0xa9, 0x1a, 0xa4, 0x83, 0xf2, 0x6e,
-	0xdf, 0x87, 0x46, 0xca, 0x67, 0x9b, 0x97, 0x1d, 0x5f, 0xce, 0x8f, 0xcc, 0xf9, 0xea, 0x04, 0x00,
-	0x99, 0x6b, 0x78, 0xcc, 0xe1, 0x25, 0x22, 0xcc, 0x9a, 0x87, 0xf4, 0x9b, 0x6a, 0xaf, 0xab, 0x5d,
-	0x72, 0x65, 0x99, 0x13, 0xcd, 0xb6, 0x00, 0x46, 0x69, 0xaa, 0x7f, 0xc7, 0x01, 0x90, 0x43, 0x38,
-	0x03, 0xa8, 0x27, 0x4e, 0xb0, 0x75, 0x68, 0xc6, 0xd6, 0x32, 0xf6, 0xba, 0x68, 0xae, 0xc2, 0xf3,
-	0x2c, 0xec, 0x59, 0x95, 0x1b, 0x9e, 0x88, 0xb9, 0x9e, 0x95, 0x23, 0x87, 0x5b, 0x81, 0xf3, 0x19,
-	0x54, 0x88, 0x81, 0x09, 0x1a, 0x6b, 0x57, 0x69, 0xdb, 0xfe, 0x9a, 0x0e, 0x4f, 0xc6, 0x64, 0x76,
-	0xaf, 0x8c, 0x21, 0xcc, 0x0d, 0x80, 0xbd, 0x8f, 0x7d, 0xe4, 0xc8, 0xae, 0xe8, 0x55, 0x38, 0x14,
-	0x3b, 0x3f, 0x80, 0x7a, 0xc2, 0xc6, 0x99, 0x3f, 0xf6, 0x43, 0x61, 0x5d, 0x24, 0x1a, 0xaf, 0x0d,
-	0xfb, 0xa7, 0xae, 0x72, 0x3d, 0x2d, 0x4c, 0x9b, 0x52, 0xe1, 0x19, 0xc3, 0x79, 0x0f, 0x9a, 0xb9,
-	0xbc, 0xc3, 0x70, 0x7b, 0x4e, 0xdb, 0x68, 0xb2, 0xdf, 0x7c, 0x38, 0x9f, 0xc0, 0xca, 0x5c, 0x0e,
-	0x60, 0xb1, 0xf2, 0x47, 0x49, 0xb1, 0x32, 0x85, 0xe8, 0x52, 0xb7, 0xc5, 0xa0, 0x7c, 0x2e, 0xdc,
-	0x33, 0xdb, 0x69, 0x11, 0xed, 0xfc, 0x16, 0x6f, 0x47, 0x49, 0x0f, 0xfb, 0xbf, 0x00, 0xa7, 0x5a,
-	0x47, 0x2f, 0xa8, 0xa9, 0xb5, 0xca, 0x1a, 0xc8, 0x21, 0x04, 0xbb, 0x05, 0x4d, 0xfc, 0x88, 0xad,
-	0xdc, 0xa8, 0xa6, 0x11, 0xb1, 0x01, 0xfc, 0x0f, 0x34, 0xc6, 0xe9, 0xf0, 0x92, 0x8d, 0x81, 0x64,
-	0xf4, 0x3b, 0x50, 0x0f, 0xa5, 0x95, 0x99, 0x1e, 0xbb, 0x16, 0xca, 0x74, 0x9c, 0x1b, 0x04, 0x56,
-	0x56, 0x31, 0xe3, 0xdc, 0x20, 0x20, 0xa1, 0x73, 0x07, 0xde, 0xb8, 0x74, 0xcf, 0x63, 0x6f, 0x41,
-	0x75, 0xec, 0x07, 0x9a, 0x8a, 0x12, 0xf6, 0xf4, 0xf6, 0xcb, 0xf9, 0x47, 0x01, 0x20, 0x8b, 0x1f,
-	0xcc, 0x0a, 0xac, 0x2e, 0x88, 0x59, 0x36, 0xd5, 0x24, 0x80, 0xfa, 0xc4, 0x9e, 0x53, 0x36, 0x32,
-	0x6e, 0xce, 0xc7, 0xdc, 0x56, 0x72, 0x8c, 0x99, 0x13, 0x6c, 0xc7, 0x9e, 0x60, 0xaf, 0x73, 0x17,
-	0x4b, 0x2d, 0x50, 0xa3, 0x95, 0xbf, 0x9a, 0x43, 0x96, 0xce, 0xdc, 0x4a, 0x56, 0x1f, 0xc1, 0xca,
-	0x9c, 0xc9, 0xef, 0x59, 0xb3, 0xb2, 0xf3, 0x36, 0x9f, 0xcb, 0x3b, 0x50, 0x35, 0x77, 0x7a, 0xb6,
-	0x01, 0x35, 0xd7, 0x33, 0x69, 0x9c, 0x3b, 0x4a, 0x50, 0xb8, 0x4b, 0x6c, 0x9e, 0x88, 0x9d, 0x3f,
-	0x15, 0x01, 0x32, 0xfe, 0x6b, 0x74, 0xdb, 0x0f, 0xa0, 0x15, 0x0b, 0x4f, 0x86, 0x23, 0x57, 0xcd,
-	0x48, 0x6a, 0x2f, 0x9d, 0x57, 0x0d, 0x59, 0x40, 0xe6, 0x3a, 0xef, 0xd2, 0xab, 0x3b, 0xef, 0x0d,
-	0x28, 0x7b, 0x32, 0x9a, 0xd9, 0xd2, 0xc4, 0xe6, 0x27, 0xb2, 0x2f, 0xa3, 0xd9, 0xc1, 0x12, 0x27,
-	0x04, 0xdb, 0x82, 0xea, 0xe4, 0x8c, 0x5e, 0x39, 0xcc, 0x6d, 0xed, 0xc6, 0x3c, 0xf6, 0xc9, 0x19,
-	0xd2, 0x07, 0x4b, 0xdc, 0xa2, 0xd8, 0x1d, 0xa8, 0x4c, 0xce, 0x46, 0xbe, 0xb2, 0xc5, 0xe5, 0xfa,
-	0x22, 0xbc, 0xeb, 0x2b, 0x7a, 0xd4, 0x40, 0x0c, 0x73, 0xa0, 0xa8, 0x26, 0xf6, 0x49, 0xa3, 0xbd,
-	0xb0, 0x9a, 0x93, 0x83, 0x25, 0x5e, 0x54, 0x93, 0xbd, 0x3a, 0x54, 0xcd, 0xba, 0x3a, 0x7f, 0x2f,
-	0x41, 0x6b, 0xde, 0x4b, 0xdc, 0xd9, 0x58, 0x79, 0xc9, 0xce, 0xc6, 0xca, 0x4b, 0x2f, 0x25, 0xc5,
-	0xdc, 0xa5, 0xc4, 0x81, 0x8a, 0x3c, 0x0f, 0x85, 0xca, 0x3f, 0xe7, 0xec, 0x9f, 0xca, 0xf3, 0x10,
-	0x1b, 0x63, 0x23, 0x9a, 0xeb, 0x33, 0x2b, 0xb6, 0xcf, 0x7c, 0x1f, 0x56, 0xc6, 0x32, 0x08, 0xe4,
-	0xf9, 0x70, 0x36, 0x09, 0xfc, 0xf0, 0xcc, 0x36, 0x9b, 0xf3, 0x4c, 0xb6, 0x01, 0xd7, 0x46, 0xbe,
-	0x42, 0x77, 0xf6, 0x65, 0xa8, 0x45, 0x48, 0x97, 0x55, 0xc4, 0x2d, 0xb2, 0xd9, 0xa7, 0xb0, 0xee,
-	0x6a, 0x2d, 0x26, 0x91, 0x7e, 0x16, 0x46, 0xae, 0x77, 0xd6, 0x95, 0x1e, 0x65, 0xe1, 0x24, 0x72,
-	0xb5, 0x7f, 0xec, 0x07, 0x78, 0x89, 0xaf, 0xd1, 0xd0, 0x57, 0xe2, 0xd8, 0x07, 0xd0, 0xf2, 0x94,
-	0x70, 0xb5, 0xe8, 0x8a, 0x58, 0x1f, 0xb9, 0xfa, 0xb4, 0x53, 0xa7, 0x91, 0x0b, 0x5c, 0x9c, 0x83,
-	0x8b, 0xde, 0x7e, 0xe6, 0x07, 0x23, 0x0f, 0xaf, 0x97, 0x0d, 0x33, 0x87, 0x39, 0x26, 0xdb, 0x02,
-	0x46, 0x8c, 0xde, 0x24, 0xd2, 0xb3, 0x14, 0x0a, 0x04, 0xbd, 0x42, 0x82, 0x07, 0xae, 0xf6, 0x27,
-	0x22, 0xd6, 0xee, 0x24, 0xa2, 0xf7, 0xa3, 0x12, 0xcf, 0x18, 0xec, 0x36, 0xb4, 0xfd, 0xd0, 0x0b,
-	0xa6, 0x23, 0xf1, 0x22, 0xc2, 0x89, 0xa8, 0x30, 0xee, 0x2c, 0xd3, 0xa9, 0x72, 0xcd, 0xf2, 0x8f,
-	0x2c, 0x1b, 0xa1, 0xe2, 0x62, 0x01, 0xba, 0x62, 0xa0, 0x96, 0x9f, 0x40, 0x9d, 0x2f, 0x0a, 0xd0,
-	0x5e, 0x0c, 0x3c, 0xdc, 0xb6, 0x08, 0x27, 0x6f, 0x2f, 0xd7, 0x48, 0xa7, 0x5b, 0x59, 0xcc, 0x6d,
-	0x65, 0x52, 0x2f, 0x4b, 0xb9, 0x7a, 0x99, 0x86, 0x45, 0xf9, 0xbb, 0xc3, 0x62, 0x6e, 0xa2, 0x95,
-	0x85, 0x89, 0x3a, 0xbf, 0x29, 0xc0, 0xb5, 0x85, 0xe0, 0xfe, 0xde, 0x1e, 0xad, 0x43, 0x73, 0xe2,
-	0x9e, 0x09, 0xf3, 0xb8, 0x10, 0xdb, 0x12, 0x92, 0x67, 0xfd, 0x07, 0xfc, 0x0b, 0x61, 0x39, 0x9f,
-	0x51, 0x57, 0xfa, 0x96, 0x04, 0xc8, 0xa1, 0xd4, 0x0f, 0xe5, 0xd4, 0xd6, 0xe2, 0x24, 0x40, 0x12,
-	0xe6, 0xe5, 0x30, 0x2a, 0x5d, 0x11, 0x46, 0xce, 0x21, 0xd4, 0x13, 0x07, 0xd9, 0x2d, 0xfb, 0xfa,
-	0x53, 0xc8, 0x1e, 0x35, 0x9f, 0xc5, 0x42, 0xa1, 0xef, 0xe6, 0x29, 0xe8, 0x5d, 0xa8, 0x98, 0x36,
-	0xb4, 0x78, 0x19, 0x61, 0x24, 0xce, 0x10, 0x6a, 0x96, 0xc3, 0x36, 0xa1, 0x7a, 0x3c, 0x4b, 0xdf,
-	0x51, 0xec, 0x71, 0x81, 0xdf, 0x23, 0x8b, 0xc0, 0x33, 0xc8, 0x20, 0xd8, 0x0d, 0x28, 0x1f, 0xcf,
-	0xfa, 0x5d, 0x73, 0xb1, 0xc4, 0x93, 0x0c, 0xbf, 0xf6, 0xaa, 0xc6, 0x21, 0xe7, 0x31, 0x2c, 0xe7,
-	0xc7, 0xa5, 0x85, 0xbd, 0x90, 0x2b, 0xec, 0xe9, 0x91, 0x5d, 0x7c, 0xd5, 0x0d, 0xe3, 0x23, 0x00,
-	0x7a, 0xab, 0x7d, 0xdd, 0x9b, 0xc9, 0xff, 0x43, 0xcd, 0xbe, 0xf1, 0xb2, 0x0f, 0x16, 0xde, 0xac,
-	0x5b, 0xe9, 0x03, 0xf0, 0xdc, 0xc3, 0xb5, 0xf3, 0x00, 0x7b, 0xd4, 0x73, 0xa1, 0xba, 0xfe, 0x78,
-	0xfc, 0xba, 0xe6, 0x1e, 0x40, 0xeb, 0x59, 0x14, 0xfd, 0x6b, 0x63, 0x7f, 0x02, 0x55, 0xf3, 0xd4,
-	0x8c, 0x63, 0x02, 0xf4, 0xc0, 0xee, 0x01, 0x33, 0x7d, 0x6c, 0xde, 0x25, 0x6e, 0x00, 0x88, 0x9c,
-	0xa2, 0x3d, 0xbb, 0xb9, 0x84, 0x9c, 0x77, 0x80, 0x1b, 0xc0, 0xe6, 0x06, 0xd4, 0xec, 0xab, 0x26,
-	0x6b, 0x40, 0xe5, 0xd9, 0xe1, 0xb0, 0xf7, 0xb4, 0xbd, 0xc4, 0xea, 0x50, 0x3e, 0x18, 0x0c, 0x9f,
-	0xb6, 0x0b, 0x48, 0x1d, 0x0e, 0x0e, 0x7b, 0xed, 0xe2, 0xe6, 0x6d, 0x58, 0xce, 0xbf, 0x6b, 0xb2,
-	0x26, 0xd4, 0x86, 0xbb, 0x87, 0xdd, 0xbd, 0xc1, 0x8f, 0xdb, 0x4b, 0x6c, 0x19, 0xea, 0xfd, 0xc3,
-	0x61, 0x6f, 0xff, 0x19, 0xef, 0xb5, 0x0b, 0x9b, 0x3f, 0x82, 0x46, 0xfa, 0x50, 0x84, 0x1a, 0xf6,
-	0xfa, 0x87, 0xdd, 0xf6, 0x12, 0x03, 0xa8, 0x0e, 0x7b, 0xfb, 0xbc, 0x87, 0x7a, 0x6b, 0x50, 0x1a,
-	0x0e, 0x0f, 0xda, 0x45, 0xb4, 0xba, 0xbf, 0xbb, 0x7f, 0xd0, 0x6b, 0x97, 0x90, 0x7c, 0xfa, 0xe4,
-	0xe8, 0xe1, 0xb0, 0x5d, 0xde, 0xfc, 0x08, 0xae, 0x2d, 0x3c, 0xa1, 0xd0, 0xe8, 0x83, 0x5d, 0xde,
-	0x43, 0x4d, 0x4d, 0xa8, 0x1d, 0xf1, 0xfe, 0xf3, 0xdd, 0xa7, 0xbd, 0x76, 0x01, 0x05, 0x8f, 0x07,
-	0xfb, 0x8f, 0x7a, 0xdd, 0x76, 0x71, 0xef, 0xe6, 0x97, 0x2f, 0xd7, 0x0a, 0x5f, 0xbd, 0x5c, 0x2b,
-	0x7c, 0xfd, 0x72, 0xad, 0xf0, 0xd7, 0x97, 0x6b, 0x85, 0x2f, 0xbe, 0x5d, 0x5b, 0xfa, 0xea, 0xdb,
-	0xb5, 0xa5, 0xaf, 0xbf, 0x5d, 0x5b, 0x3a, 0xae, 0xd2, 0x9f, 0x15, 0x1f, 0xfe, 0x33, 0x00, 0x00,
-	0xff, 0xff, 0x92, 0xc4, 0x20, 0x2a, 0xec, 0x18, 0x00, 0x00,
+	// 2651 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x59, 0x4f, 0x6f, 0x1b, 0xc9,
+	0xb1, 0x17, 0xff, 0x93, 0x45, 0x8a, 0xe6, 0xb6, 0xbd, 0xbb, 0xb3, 0x7a, 0x7e, 0xb2, 0x76, 0x76,
+	0xdf, 0x42, 0x96, 0x6d, 0x09, 0x4f, 0x0b, 0xac, 0x17, 0x46, 0x10, 0x44, 0x22, 0xe9, 0x15, 0xd7,
+	0xb6, 0x28, 0x34, 0x2d, 0x3b, 0x87, 0x00, 0xc6, 0x68, 0xa6, 0x49, 0x0d, 0x34, 0x9c, 0x1e, 0xf4,
+	0x34, 0x2d, 0x31, 0x87, 0x1c, 0x72, 0xcd, 0x65, 0x81, 0x04, 0x41, 0x2e, 0x41, 0xbe, 0x44, 0x8e,
+	0xc9, 0x7d, 0x81, 0x5c, 0xf6, 0x90, 0xc3, 0x22, 0x87, 0x4d, 0xe0, 0xbd, 0xe4, 0x43, 0x24, 0x40,
+	0x50, 0xdd, 0x3d, 0x7f, 0x48, 0xd9, 0xf1, 0x3a, 0x09, 0x72, 0x62, 0x75, 0xd5, 0xaf, 0xab, 0xaa,
+	0x7b, 0xaa, 0xba, 0xab, 0x8b, 0xd0, 0xe0, 0x51, 0xbc, 0x1d, 0x09, 0x2e, 0x39, 0x29, 0x46, 0x27,
+	0x6b, 0x77, 0x26, 0xbe, 0x3c, 0x9d, 0x9d, 0x6c, 0xbb, 0x7c, 0xba, 0x33, 0xe1, 0x13, 0xbe, 0xa3,
+	0x44, 0x27, 0xb3, 0xb1, 0x1a, 0xa9, 0x81, 0xa2, 0xf4, 0x14, 0xfb, 0xaf, 0x45, 0x28, 0x0e, 0x23,
+	0xf2, 0x3e, 0x54, 0xfd, 0x30, 0x9a, 0xc9, 0xd8, 0x2a, 0x6c, 0x94, 0x36, 0x9b, 0xbb, 0x8d, 0xed,
+	0xe8, 0x64, 0x7b, 0x80, 0x1c, 0x6a, 0x04, 0x64, 0x03, 0xca, 0xec, 0x82, 0xb9, 0x56, 0x71, 0xa3,
+	0xb0, 0xd9, 0xdc, 0x05, 0x04, 0xf4, 0x2f, 0x98, 0x3b, 0x8c, 0x0e, 0x56, 0xa8, 0x92, 0x90, 0x8f,
+	0xa0, 0x1a, 0xf3, 0x99, 0x70, 0x99, 0x55, 0x52, 0x98, 0x16, 0x62, 0x46, 0x8a, 0xa3, 0x50, 0x46,
+	0x8a, 0x9a, 0xc6, 0x7e, 0xc0, 0xac, 0x72, 0xa6, 0xe9, 0xbe, 0x1f, 0x68, 0x8c, 0x92, 0x90, 0x0f,
+	0xa0, 0x72, 0x32, 0xf3, 0x03, 0xcf, 0xaa, 0x28, 0x48, 0x13, 0x21, 0xfb, 0xc8, 0x50, 0x18, 0x2d,
+	0x43, 0xd0, 0x94, 0x89, 0x09, 0xb3, 0xaa, 0x19, 0xe8, 0x11, 0x32, 0x34, 0x48, 0xc9, 0xd0, 0x96,
+	0xe7, 0x8f, 0xc7, 0x56, 0x2d, 0xb3, 0xd5, 0xf3, 0xc7, 0x63, 0x6d, 0x0b, 0x25, 0x64, 0x13, 0xea,
+	0x51, 0xe0, 0xc8, 0x31, 0x17, 0x53, 0x0b, 0x32, 0xbf, 0x8f, 0x0c, 0x8f, 0xa6, 0x52, 0x72, 0x17,
+	0x9a, 0x2e, 0x0f, 0x63, 0x29, 0x1c, 0x3f, 0x94, 0xb1, 0xd5, 0x54, 0xe0, 0xb7, 0x11, 0xfc, 0x94,
+	0x8b, 0x33, 0x26, 0xba, 0x99, 0x90, 0xe6, 0x91, 0xfb, 0x65, 0x28, 0xf2, 0xc8, 0xfe, 0x65, 0x01,
+	0xea, 0x89, 0x56, 0x62, 0x43, 0x6b, 0x4f, 0xb8, 0xa7, 0xbe, 0x64, 0xae, 0x9c, 0x09, 0x66, 0x15,
+	0x36, 0x0a, 0x9b, 0x0d, 0xba, 0xc0, 0x23, 0x6d, 0x28, 0x0e, 0x47, 0x6a, 0xbf, 0x1b, 0xb4, 0x38,
+	0x1c, 0x11, 0x0b, 0x6a, 0x4f, 0x1c, 0xe1, 0x3b, 0xa1, 0x54, 0x1b, 0xdc, 0xa0, 0xc9, 0x90, 0x5c,
+	0x87, 0xc6, 0x70, 0xf4, 0x84, 0x89, 0xd8, 0xe7, 0xa1, 0xda, 0xd6, 0x06, 0xcd, 0x18, 0x64, 0x1d,
+	0x60, 0x38, 0xba, 0xcf, 0x1c, 0x54, 0x1a, 0x5b, 0x95, 0x8d, 0xd2, 0x66, 0x83, 0xe6, 0x38, 0xf6,
+	0x4f, 0xa0, 0xa2, 0x3e, 0x35, 0xf9, 0x1c, 0xaa, 0x9e, 0x3f, 0x61, 0xb1, 0xd4, 0xee, 0xec, 0xef,
+	0x7e, 0xf9, 0xcd, 0x8d, 0x95, 0x3f, 0x7d, 0x73, 0x63, 0x2b, 0x17, 0x53, 0x3c, 0x62, 0xa1, 0xcb,
+	0x43, 0xe9, 0xf8, 0x21, 0x13, 0xf1, 0xce, 0x84, 0xdf, 0xd1, 0x53, 0xb6, 0x7b, 0xea, 0x87, 0x1a,
+	0x0d, 0xe4, 0x26, 0x54, 0xfc, 0xd0, 0x63, 0x17, 0xca, 0xff, 0xd2, 0xfe, 0x55, 0xa3, 0xaa, 0x39,
+	0x9c, 0xc9, 0x68, 0x26, 0x07, 0x28, 0xa2, 0x1a, 0x61, 0xff, 0xa1, 0x00, 0x55, 0x1d, 0x4a, 0xe4,
+	0x3a, 0x94, 0xa7, 0x4c, 0x3a, 0xca, 0x7e, 0x73, 0xb7, 0xae, 0x3f, 0xa9, 0x74, 0xa8, 0xe2, 0x62,
+	0x94, 0x4e, 0xf9, 0x0c, 0xf7, 0xbe, 0x98, 0x45, 0xe9, 0x23, 0xe4, 0x50, 0x23, 0x20, 0xff, 0x07,
+	0xb5, 0x90, 0xc9, 0x73, 0x2e, 0xce, 0xd4, 0x1e, 0xb5, 0x75, 0x58, 0x1c, 0x32, 0xf9, 0x88, 0x7b,
+	0x8c, 0x26, 0x32, 0x72, 0x1b, 0xea, 0x31, 0x73, 0x67, 0xc2, 0x97, 0x73, 0xb5, 0x5f, 0xed, 0xdd,
+	0x8e, 0x0a, 0x56, 0xc3, 0x53, 0xe0, 0x14, 0x41, 0x6e, 0x41, 0x23, 0x66, 0xae, 0x60, 0x92, 0x85,
+	0xcf, 0xd5, 0xfe, 0x35, 0x77, 0x57, 0x0d, 0x5c, 0x30, 0xd9, 0x0f, 0x9f, 0xd3, 0x4c, 0x6e, 0xff,
+	0xac, 0x04, 0x65, 0xf4, 0x99, 0x10, 0x28, 0x3b, 0x62, 0xa2, 0x33, 0xaa, 0x41, 0x15, 0x4d, 0x3a,
+	0x50, 0x42, 0x1d, 0x45, 0xc5, 0x42, 0x12, 0x39, 0xee, 0xb9, 0x67, 0x3e, 0x28, 0x92, 0x38, 0x6f,
+	0x16, 0x33, 0x61, 0xbe, 0xa3, 0xa2, 0xc9, 0x4d, 0x68, 0x44, 0x82, 0x5f, 0xcc, 0x9f, 0x69, 0x0f,
+	0xb2, 0x28, 0x45, 0x26, 0x3a, 0x50, 0x8f, 0x0c, 0x45, 0xb6, 0x00, 0xd8, 0x85, 0x14, 0xce, 0x01,
+	0x8f, 0x65, 0x6c, 0x55, 0x37, 0x4a, 0x49, 0xdc, 0x23, 0x63, 0x70, 0x44, 0x73, 0x52, 0xb2, 0x06,
+	0xf5, 0x53, 0x1e, 0xcb, 0xd0, 0x99, 0x32, 0x95, 0x21, 0x0d, 0x9a, 0x8e, 0x89, 0x0d, 0xd5, 0x59,
+	0xe0, 0x4f, 0x7d, 0x69, 0x35, 0x32, 0x1d, 0xc7, 0x8a, 0x43, 0x8d, 0x04, 0xa3, 0xd8, 0x9d, 0x08,
+	0x3e, 0x8b, 0x8e, 0x1c, 0xc1, 0x42, 0xa9, 0xf2, 0xa7, 0x41, 0x17, 0x78, 0xe4, 0x1e, 0xb4, 0x05,
+	0xd3, 0x99, 0xff, 0x10, 0x27, 0x25, 0x89, 0x43, 0x50, 0x1f, 0x5d, 0x90, 0xd0, 0x25, 0x24, 0x46,
+	0xbc, 0xf4, 0xa7, 0x8c, 0xcf, 0xa4, 0xd5, 0xc2, 0x30, 0xa2, 0xc9, 0x90, 0x7c, 0x04, 0xed, 0xe7,
+	0x4e, 0xe0, 0x7b, 0xfd, 0x0b, 0x5f, 0x76, 0xb9, 0xc7, 0x62, 0x6b, 0x75, 0xa3, 0xb4, 0x59, 0xa1,
+	0x4b, 0x5c, 0xfb, 0x36, 0x54, 0xf5, 0xba, 0x71, 0x5b, 0x91, 0x32, 0x99, 0xa6, 0x68, 0xcc, 0xb0,
+	0xc1, 0x51, 0x92, 0x61, 0x83, 0x23, 0xbb, 0x07, 0x55, 0xbd, 0x42, 0x44, 0x1f, 0xe2, 0xae, 0x18,
+	0x34, 0xd2, 0xc8, 0x1b, 0xf1, 0xb1, 0xd4, 0x11, 0x4d, 0x15, 0xad, 0xb4, 0x3a, 0x42, 0x7f, 0xbf,
+	0x12, 0x55, 0xb4, 0xfd, 0x8b, 0x02, 0xb4, 0x17, 0x17, 0x86, 0x1b, 0x1d, 0x3a, 0x21, 0xef, 0x1e,
+	0x1d, 0xc7, 0x4a, 0x65, 0x89, 0xa6, 0x63, 0xf2, 0x0e, 0x54, 0xa7, 0x6c, 0xca, 0xc5, 0xdc, 0x28,
+	0x36, 0x23, 0x4c, 0x5b, 0x4d, 0x8d, 0xce, 0x9d, 0xc8, 0x18, 0xc8, 0x71, 0xd0, 0x74, 0xe4, 0x7b,
+	0xb1, 0x8a, 0x93, 0x12, 0x55, 0x34, 0xda, 0xf1, 0xf9, 0x53, 0xe6, 0x4f, 0x4e, 0xa5, 0x0a, 0x93,
+	0x55, 0x9a, 0x8e, 0xed, 0x07, 0xd0, 0x48, 0x03, 0x56, 0xad, 0xbc, 0x67, 0x56, 0x57, 0x1c, 0xf4,
+	0x50, 0x99, 0x8a, 0x02, 0xbd, 0x17, 0x8a, 0x46, 0x65, 0x3c, 0x92, 0x3e, 0x0f, 0x9d, 0x40, 0x99,
+	0xaf, 0xd3, 0x74, 0x6c, 0xff, 0xaa, 0x04, 0x15, 0x95, 0x79, 0x64, 0x13, 0x13, 0x3d, 0x9a, 0xe9,
+	0x8d, 0x2d, 0xed, 0x13, 0x93, 0xe8, 0x30, 0x08, 0xf3, 0x79, 0x8e, 0xc7, 0xcb, 0x1a, 0x26, 0x5d,
+	0xc0, 0x5c, 0xc9, 0x85, 0xb1, 0x93, 0x8e, 0xd1, 0xbe, 0x87, 0x07, 0x8f, 0xce, 0x03, 0x45, 0x93,
+	0x5b, 0x50, 0xe5, 0xea, 0xb4, 0xb0, 0xca, 0xaf, 0x3e, 0x43, 0x0c, 0x04, 0x95, 0x0b, 0xe6, 0x78,
+	0x3c, 0x0c, 0xe6, 0x6a, 0xe5, 0x75, 0x9a, 0x8e, 0x31, 0x7f, 0xd5, 0xf1, 0xf0, 0x78, 0x1e, 0xe9,
+	0xdb, 0xa2, 0xad, 0xf3, 0xf7, 0x51, 0xc2, 0xa4, 0x99, 0x1c, 0xef, 0x83, 0xc7, 0xd3, 0x68, 0x1c,
+	0x0f, 0x23, 0x69, 0x5d, 0xcd, 0x32, 0x2d, 0xe1, 0xd1, 0x54, 0x8a, 0x48, 0xd7, 0x71, 0x4f, 0x19,
+	0x22, 0xaf, 0x65, 0xc8, 0xae, 0xe1, 0xd1, 0x54, 0x9a, 0x1d, 0x20, 0x08, 0x7d, 0x5b, 0x41, 0x73,
+	0x07, 0x08, 0x62, 0x33, 0x39, 0x26, 0xde, 0x68, 0x74, 0x80, 0xc8, 0x77, 0xb2, 0x4b, 0x4b, 0x73,
+	0xa8, 0x91, 0xe8, 0xd5, 0xc6, 0xb3, 0x40, 0x0e, 0x7a, 0xd6, 0xbb, 0x7a, 0x2b, 0x93, 0xb1, 0xbd,
+	0x9e, 0x2d, 0x00, 0xb7, 0x35, 0xf6, 0x7f, 0xcc, 0x4c, 0xcc, 0x29, 0xda, 0x1e, 0x40, 0x3d, 0x71,
+	0xf1, 0x52, 0x18, 0xdc, 0x81, 0x5a, 0x7c, 0xea, 0x08, 0x3f, 0x9c, 0xa8, 0x2f, 0xd4, 0xde, 0xbd,
+	0x9a, 0xae, 0x68, 0xa4, 0xf9, 0xe8, 0x45, 0x82, 0xb1, 0x79, 0x12, 0x52, 0x2f, 0xd3, 0xd5, 0x81,
+	0xd2, 0xcc, 0xf7, 0x94, 0x9e, 0x55, 0x8a, 0x24, 0x72, 0x26, 0xbe, 0xce, 0x95, 0x55, 0x8a, 0x24,
+	0xfa, 0x37, 0xe5, 0x9e, 0x2e, 0x05, 0x56, 0xa9, 0xa2, 0x17, 0xc2, 0xae, 0xb2, 0x14, 0x76, 0x41,
+	0xb2, 0x37, 0xff, 0x15, 0x6b, 0x3f, 0x2f, 0x40, 0x3d, 0xa9, 0x5f, 0x30, 0x1d, 0x7d, 0x8f, 0x85,
+	0xd2, 0x1f, 0xfb, 0x4c, 0x18, 0xc3, 0x39, 0x0e, 0xb9, 0x03, 0x15, 0x47, 0x4a, 0x91, 0xdc, 0x4d,
+	0xef, 0xe6, 0x8b, 0x9f, 0xed, 0x3d, 0x94, 0xf4, 0x43, 0x29, 0xe6, 0x54, 0xa3, 0xd6, 0x3e, 0x05,
+	0xc8, 0x98, 0xe8, 0xeb, 0x19, 0x9b, 0x1b, 0xad, 0x48, 0x92, 0x6b, 0x50, 0x79, 0xee, 0x04, 0xb3,
+	0x24, 0x23, 0xf5, 0xe0, 0x5e, 0xf1, 0xd3, 0x82, 0xfd, 0xfb, 0x22, 0xd4, 0x4c, 0x31, 0x44, 0x6e,
+	0x43, 0x4d, 0x15, 0x43, 0x4c, 0xfc, 0x93, 0xf4, 0x4b, 0x20, 0x64, 0x27, 0xad, 0xf2, 0x72, 0x3e,
+	0x1a, 0x55, 0xba, 0xda, 0x33, 0x3e, 0x66, 0x35, 0x5f, 0xc9, 0x63, 0x63, 0x53, 0xce, 0xb5, 0x55,
+	0xf1, 0xc4, 0xc6, 0x7e, 0xe8, 0xe3, 0xfe, 0x50, 0x14, 0x91, 0xdb, 0xc9, 0xaa, 0xcb, 0x4a, 0xe3,
+	0x3b, 0x79, 0x8d, 0x97, 0x17, 0x3d, 0x80, 0x66, 0xce, 0xcc, 0x4b, 0x56, 0xfd, 0x61, 0x7e, 0xd5,
+	0xc6, 0xa4, 0x52, 0xa7, 0xa6, 0xe5, 0x76, 0xe1, 0xdf, 0xd8, 0xbf, 0x4f, 0x00, 0x32, 0x95, 0xdf,
+	0xfd, 0xf8, 0xb2, 0x7f, 0x57, 0x02, 0x18, 0x46, 0x78, 0xb5, 0x7b, 0x8e, 0x2a, 0x46, 0x5a, 0xfe,
+	0x24, 0xe4, 0x82, 0x3d, 0x53, 0x69, 0xae, 0xe6, 0xd7, 0x69, 0x53, 0xf3, 0x54, 0xc6, 0x90, 0x3d,
+	0x68, 0x7a, 0x2c, 0x76, 0x85, 0xaf, 0x02, 0xca, 0x6c, 0xfa, 0x0d, 0x5c, 0x53, 0xa6, 0x67, 0xbb,
+	0x97, 0x21, 0xf4, 0x5e, 0xe5, 0xe7, 0x90, 0x5d, 0x68, 0xb1, 0x8b, 0x88, 0x0b, 0x69, 0xac, 0xe8,
+	0x9a, 0xf9, 0x8a, 0xae, 0xbe, 0x91, 0xaf, 0x2c, 0xd1, 0x26, 0xcb, 0x06, 0xc4, 0x81, 0xb2, 0xeb,
+	0x44, 0xb1, 0xa9, 0x54, 0xac, 0x25, 0x7b, 0x5d, 0x27, 0xd2, 0x9b, 0xb6, 0xff, 0x31, 0xae, 0xf5,
+	0xa7, 0x7f, 0xbe, 0x71, 0x2b, 0x57, 0xde, 0x4d, 0xf9, 0xc9, 0x7c, 0x47, 0xc5, 0xcb, 0x99, 0x2f,
+	0x77, 0x66, 0xd2, 0x0f, 0x76, 0x9c, 0xc8, 0x47, 0x75, 0x38, 0x71, 0xd0, 0xa3, 0x4a, 0x35, 0xf9,
+	0x14, 0xda, 0x91, 0xe0, 0x13, 0xc1, 0xe2, 0xf8, 0x99, 0xba, 0xec, 0x4d, 0x11, 0xfe, 0x96, 0x29,
+	0x4a, 0x94, 0xe4, 0x33, 0x14, 0xd0, 0xd5, 0x28, 0x3f, 0x5c, 0xfb, 0x3e, 0x74, 0x96, 0x57, 0xfc,
+	0x26, 0x5f, 0x6f, 0xed, 0x2e, 0x34, 0xd2, 0x15, 0xbc, 0x6e, 0x62, 0x3d, 0xff, 0xd9, 0x7f, 0x5b,
+	0x80, 0xaa, 0xce, 0x47, 0x72, 0x17, 0x1a, 0x01, 0x77, 0x1d, 0x74, 0x20, 0x79, 0xf0, 0xbc, 0x97,
+	0xa5, 0xeb, 0xf6, 0xc3, 0x44, 0xa6, 0xbf, 0x47, 0x86, 0xc5, 0xf0, 0xf4, 0xc3, 0x31, 0x4f, 0xf2,
+	0xa7, 0x9d, 0x4d, 0x1a, 0x84, 0x63, 0x4e, 0xb5, 0x70, 0xed, 0x01, 0xb4, 0x17, 0x55, 0xbc, 0xc4,
+	0xcf, 0x0f, 0x16, 0x03, 0x5d, 0xdd, 0x06, 0xe9, 0xa4, 0xbc, 0xdb, 0x77, 0xa1, 0x91, 0xf2, 0xc9,
+	0xd6, 0x65, 0xc7, 0x5b, 0xf9, 0x99, 0x39, 0x5f, 0xed, 0x00, 0x20, 0x73, 0x0d, 0x8f, 0x39, 0x7c,
+	0x59, 0x85, 0x59, 0x4d, 0x93, 0x8e, 0xd5, 0xdd, 0xeb, 0x48, 0x47, 0xb9, 0xd2, 0xa2, 0x8a, 0x26,
+	0xdb, 0x00, 0x5e, 0x9a, 0xea, 0xaf, 0x38, 0x00, 0x72, 0x08, 0x7b, 0x08, 0xf5, 0xc4, 0x09, 0xb2,
+	0x01, 0xcd, 0xd8, 0x58, 0xc6, 0x07, 0x00, 0x9a, 0xab, 0xd0, 0x3c, 0x0b, 0x0b, 0x79, 0xe1, 0x84,
+	0x13, 0xb6, 0x50, 0xc8, 0x53, 0xe4, 0x50, 0x23, 0xb0, 0x9f, 0x42, 0x45, 0x31, 0x30, 0x41, 0x63,
+	0xe9, 0x08, 0x69, 0xde, 0x04, 0xba, 0xec, 0xe5, 0xb1, 0x32, 0xbb, 0x5f, 0xc6, 0x10, 0xa6, 0x1a,
+	0x40, 0x3e, 0xc4, 0xe2, 0xda, 0xb3, 0x8a, 0xaf, 0xc4, 0xa1, 0xd8, 0xfe, 0x1e, 0xd4, 0x13, 0x36,
+	0xae, 0xfc, 0xa1, 0x1f, 0x32, 0xe3, 0xa2, 0xa2, 0xf1, 0x2d, 0xd5, 0x3d, 0x75, 0x84, 0xe3, 0x4a,
+	0xa6, 0xcb, 0x94, 0x0a, 0xcd, 0x18, 0xf6, 0x07, 0xd0, 0xcc, 0xe5, 0x1d, 0x86, 0xdb, 0x13, 0xf5,
+	0x19, 0x75, 0xf6, 0xeb, 0x81, 0xfd, 0x19, 0xac, 0x2e, 0xe4, 0x00, 0x5e, 0x56, 0xbe, 0x97, 0x5c,
+	0x56, 0xfa, 0x22, 0xba, 0x54, 0x6d, 0x11, 0x28, 0x9f, 0x33, 0xe7, 0xcc, 0x54, 0x5a, 0x8a, 0xb6,
+	0x7f, 0x83, 0x4f, 0xc6, 0xa4, 0xb0, 0xff, 0x5f, 0x80, 0x53, 0x29, 0xa3, 0x67, 0xaa, 0xd2, 0x37,
+	0xca, 0x1a, 0xc8, 0x51, 0x08, 0x72, 0x03, 0x9a, 0x38, 0x88, 0x8d, 0x5c, 0xab, 0x56, 0x33, 0x62,
+	0x0d, 0xf8, 0x1f, 0x68, 0x8c, 0xd3, 0xe9, 0x25, 0x13, 0x03, 0xc9, 0xec, 0xf7, 0xa0, 0x1e, 0x72,
+	0x23, 0xd3, 0x0f, 0x8f, 0x5a, 0xc8, 0xd3, 0x79, 0x4e, 0x10, 0x18, 0x59, 0x45, 0xcf, 0x73, 0x82,
+	0x40, 0x09, 0xed, 0x5b, 0xf0, 0xd6, 0xa5, 0xc7, 0x2f, 0x56, 0xb4, 0x63, 0x3f, 0x90, 0xea, 0x52,
+	0xc2, 0x87, 0x8e, 0x19, 0xd9, 0x7f, 0x2f, 0x00, 0x64, 0xf1, 0x43, 0x3a, 0xfa, 0x76, 0x41, 0x4c,
+	0x4b, 0xdf, 0x26, 0x01, 0xd4, 0xa7, 0xe6, 0x9c, 0x32, 0x91, 0x71, 0x7d, 0x31, 0xe6, 0xb6, 0x93,
+	0x63, 0x4c, 0x9f, 0x60, 0xbb, 0xe6, 0x04, 0x7b, 0x93, 0x07, 0x6a, 0x6a, 0x41, 0x15, 0x5a, 0xf9,
+	0x7e, 0x05, 0x64, 0xe9, 0x4c, 0x8d, 0x64, 0xed, 0x01, 0xac, 0x2e, 0x98, 0xfc, 0x8e, 0x77, 0x56,
+	0x76, 0xde, 0xe6, 0x73, 0x79, 0x17, 0xaa, 0xba, 0xd1, 0x41, 0x36, 0xa1, 0xe6, 0xb8, 0x3a, 0x8d,
+	0x73, 0x47, 0x09, 0x0a, 0xf7, 0x14, 0x9b, 0x26, 0x62, 0xfb, 0x8f, 0x45, 0x80, 0x8c, 0xff, 0x06,
+	0xd5, 0xf6, 0x3d, 0x68, 0xc7, 0xcc, 0xe5, 0xa1, 0xe7, 0x88, 0xb9, 0x92, 0x5a, 0xc5, 0x57, 0x4e,
+	0x59, 0x42, 0xe6, 0x2a, 0xef, 0xd2, 0xeb, 0x2b, 0xef, 0x4d, 0x28, 0xbb, 0x3c, 0x9a, 0x5b, 0xe5,
+	0xec, 0x59, 0x97, 0x39, 0xdc, 0xe5, 0xd1, 0x1c, 0x5b, 0x2d, 0x88, 0x20, 0xdb, 0x50, 0x9d, 0x9e,
+	0xa9, 0xd6, 0x8f, 0x7e, 0xc2, 0x5e, 0x5b, 0xc4, 0x3e, 0x3a, 0x43, 0x1a, 0x1b, 0x45, 0x1a, 0x45,
+	0x6e, 0x41, 0x65, 0x7a, 0xe6, 0xf9, 0xc2, 0x5c, 0x2e, 0x57, 0x97, 0xe1, 0x3d, 0x5f, 0xa8, 0x4e,
+	0x0f, 0x62, 0x88, 0x0d, 0x45, 0x31, 0x35, 0x7d, 0x9e, 0xce, 0xd2, 0x6e, 0x4e, 0x0f, 0x56, 0x68,
+	0x51, 0x4c, 0xf7, 0xeb, 0x50, 0xd5, 0xfb, 0x6a, 0xff, 0xad, 0x04, 0xed, 0x45, 0x2f, 0xf1, 0xcb,
+	0xc6, 0xc2, 0x4d, 0xbe, 0x6c, 0x2c, 0xdc, 0xf4, 0x51, 0x52, 0xcc, 0x3d, 0x4a, 0x6c, 0xa8, 0xf0,
+	0xf3, 0x90, 0x89, 0x7c, 0x8f, 0xab, 0x7b, 0xca, 0xcf, 0x43, 0x2c, 0x8c, 0xb5, 0x68, 0xa1, 0xce,
+	0xac, 0x98, 0x3a, 0xf3, 0x43, 0x58, 0x1d, 0xf3, 0x20, 0xe0, 0xe7, 0xa3, 0xf9, 0x34, 0xf0, 0xc3,
+	0x33, 0x53, 0x6c, 0x2e, 0x32, 0xc9, 0x26, 0x5c, 0xf1, 0x7c, 0x81, 0xee, 0x74, 0x79, 0x28, 0x59,
+	0xa8, 0x5e, 0xf0, 0x88, 0x5b, 0x66, 0x93, 0xcf, 0x61, 0xc3, 0x91, 0x92, 0x4d, 0x23, 0x79, 0x1c,
+	0x46, 0x8e, 0x7b, 0xd6, 0xe3, 0xae, 0xca, 0xc2, 0x69, 0xe4, 0x48, 0xff, 0xc4, 0x0f, 0xb0, 0xb3,
+	0x51, 0x53, 0x53, 0x5f, 0x8b, 0xc3, 0xc7, 0xb4, 0x2b, 0x98, 0x23, 0x59, 0x8f, 0xc5, 0xf2, 0xc8,
+	0x91, 0xa7, 0x56, 0x5d, 0xcd, 0x5c, 0xe2, 0xe2, 0x1a, 0x1c, 0xf4, 0xf6, 0xa9, 0x1f, 0x78, 0x2e,
+	0xbe, 0x7a, 0x1b, 0x7a, 0x0d, 0x0b, 0x4c, 0xb2, 0x0d, 0x44, 0x31, 0xfa, 0xd3, 0x48, 0xce, 0x53,
+	0x28, 0x28, 0xe8, 0x4b, 0x24, 0x78, 0xe0, 0xe2, 0xab, 0x3e, 0x96, 0xce, 0x34, 0x52, 0xbd, 0x81,
+	0x12, 0xcd, 0x18, 0xe4, 0x26, 0x74, 0xfc, 0xd0, 0x0d, 0x66, 0x1e, 0x7b, 0x16, 0xe1, 0x42, 0x44,
+	0x18, 0x5b, 0x2d, 0x75, 0xaa, 0x5c, 0x31, 0xfc, 0x23, 0xc3, 0x46, 0x28, 0xbb, 0x58, 0x82, 0xae,
+	0x6a, 0x28, 0xbb, 0x58, 0x80, 0xda, 0x5f, 0x14, 0xa0, 0xb3, 0x1c, 0x78, 0xea, 0x41, 0x8d, 0x8b,
+	0x37, 0x6f, 0x7e, 0xa4, 0xd3, 0x4f, 0x59, 0xcc, 0x7d, 0xca, 0xe4, 0xbe, 0x2c, 0xe5, 0xee, 0xcb,
+	0x34, 0x2c, 0xca, 0xaf, 0x0e, 0x8b, 0x85, 0x85, 0x56, 0x96, 0x16, 0x6a, 0xff, 0xba, 0x00, 0x57,
+	0x96, 0x82, 0xfb, 0x3b, 0x7b, 0xb4, 0x01, 0xcd, 0xa9, 0x73, 0xc6, 0x74, 0xc7, 0x25, 0x36, 0x57,
+	0x48, 0x9e, 0xf5, 0x1f, 0xf0, 0x2f, 0x84, 0x56, 0x3e, 0xa3, 0x5e, 0xea, 0x5b, 0x12, 0x20, 0x87,
+	0x5c, 0xde, 0xe7, 0x33, 0x73, 0x17, 0xd7, 0xe9, 0x22, 0xf3, 0x72, 0x18, 0x95, 0x5e, 0x12, 0x46,
+	0xf6, 0x21, 0xd4, 0x13, 0x07, 0xc9, 0x0d, 0xd3, 0x12, 0x2b, 0x64, 0x9d, 0xde, 0xe3, 0x98, 0x09,
+	0xf4, 0x5d, 0x09, 0xc8, 0xfb, 0x50, 0xd1, 0x65, 0x68, 0xf1, 0x32, 0x42, 0x4b, 0xec, 0x11, 0xd4,
+	0x0c, 0x87, 0x6c, 0x41, 0xf5, 0x64, 0x9e, 0xb6, 0x77, 0xcc, 0x71, 0x81, 0x63, 0xcf, 0x20, 0xf0,
+	0x0c, 0xd2, 0x08, 0x72, 0x0d, 0xca, 0x27, 0xf3, 0x41, 0x4f, 0x3f, 0x2c, 0xf1, 0x24, 0xc3, 0xd1,
+	0x7e, 0x55, 0x3b, 0x64, 0x3f, 0x84, 0x56, 0x7e, 0x5e, 0x7a, 0xb1, 0x17, 0x72, 0x17, 0x7b, 0x7a,
+	0x64, 0x17, 0x5f, 0xf7, 0xc2, 0xf8, 0x04, 0x40, 0x35, 0xb0, 0xdf, 0xf4, 0x65, 0xf2, 0xff, 0x50,
+	0x33, 0x8d, 0x6f, 0xec, 0xc1, 0x2f, 0x34, 0xf2, 0xdb, 0x69, 0x57, 0x7c, 0xa1, 0x9b, 0x6f, 0xdf,
+	0xc3, 0x1a, 0xf5, 0x9c, 0x09, 0x6c, 0x86, 0xbf, 0xa9, 0xb9, 0x7b, 0xd0, 0x3e, 0x8e, 0xa2, 0x7f,
+	0x6d, 0xee, 0x8f, 0xa0, 0xaa, 0xfb, 0xef, 0x38, 0x27, 0x40, 0x0f, 0xac, 0x42, 0x76, 0x6f, 0x2c,
+	0xba, 0x44, 0x35, 0x00, 0x91, 0x33, 0xb4, 0x67, 0x15, 0x33, 0xe4, 0xa2, 0x03, 0x54, 0x03, 0xb6,
+	0x36, 0xa1, 0x66, 0x5a, 0xbd, 0xa4, 0x01, 0x95, 0xe3, 0xc3, 0x51, 0xff, 0x71, 0x67, 0x85, 0xd4,
+	0xa1, 0x7c, 0x30, 0x1c, 0x3d, 0xee, 0x14, 0x90, 0x3a, 0x1c, 0x1e, 0xf6, 0x3b, 0xc5, 0xad, 0x9b,
+	0xd0, 0xca, 0x37, 0x7b, 0x49, 0x13, 0x6a, 0xa3, 0xbd, 0xc3, 0xde, 0xfe, 0xf0, 0x87, 0x9d, 0x15,
+	0xd2, 0x82, 0xfa, 0xe0, 0x70, 0xd4, 0xef, 0x1e, 0xd3, 0x7e, 0xa7, 0xb0, 0xf5, 0x03, 0x68, 0xa4,
+	0x8d, 0x22, 0xd4, 0xb0, 0x3f, 0x38, 0xec, 0x75, 0x56, 0x08, 0x40, 0x75, 0xd4, 0xef, 0xd2, 0x3e,
+	0xea, 0xad, 0x41, 0x69, 0x34, 0x3a, 0xe8, 0x14, 0xd1, 0x6a, 0x77, 0xaf, 0x7b, 0xd0, 0xef, 0x94,
+	0x90, 0x7c, 0xfc, 0xe8, 0xe8, 0xfe, 0xa8, 0x53, 0xde, 0xfa, 0x04, 0xae, 0x2c, 0xb5, 0x50, 0xd4,
+	0xec, 0x83, 0x3d, 0xda, 0x47, 0x4d, 0x4d, 0xa8, 0x1d, 0xd1, 0xc1, 0x93, 0xbd, 0xc7, 0xfd, 0x4e,
+	0x01, 0x05, 0x0f, 0x87, 0xdd, 0x07, 0xfd, 0x5e, 0xa7, 0xb8, 0x7f, 0xfd, 0xcb, 0x17, 0xeb, 0x85,
+	0xaf, 0x5e, 0xac, 0x17, 0xbe, 0x7e, 0xb1, 0x5e, 0xf8, 0xcb, 0x8b, 0xf5, 0xc2, 0x17, 0xdf, 0xae,
+	0xaf, 0x7c, 0xf5, 0xed, 0xfa, 0xca, 0xd7, 0xdf, 0xae, 0xaf, 0x9c, 0x54, 0xd5, 0x3f, 0x38, 0x1f,
+	0xff, 0x63, 0x00, 0xad, 0xa9, 0x19, 0xb7, 0x01, 0x1a, 0x00, 0x00,
 }
 
 func (m *Op) Marshal() (dAtA []byte, err error) {
@@ -3377,6 +3490,42 @@ func (m *Meta) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.ValidExitCodes) > 0 {
+		dAtA11 := make([]byte, len(m.ValidExitCodes)*10)
+		var j10 int
+		for _, num1 := range m.ValidExitCodes {
+			num := uint64(num1)
+			for num >= 1<<7 {
+				dAtA11[j10] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j10++
+			}
+			dAtA11[j10] = uint8(num)
+			j10++
+		}
+		i -= j10
+		copy(dAtA[i:], dAtA11[:j10])
+		i = encodeVarintOps(dAtA, i, uint64(j10))
+		i--
+		dAtA[i] = 0x6a
+	}
+	if m.Timeout != 0 {
+		i = encodeVarintOps(dAtA, i, uint64(m.Timeout))
+		i--
+		dAtA[i] = 0x60
+	}
+	if m.ResourceLimits != nil {
+		{
+			size, err := m.ResourceLimits.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintOps(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x5a
+	}
 	if len(m.CgroupParent) > 0 {
 		i -= len(m.CgroupParent)
 		copy(dAtA[i:], m.CgroupParent)
@@ -3543,6 +3692,54 @@ func (m *Ulimit) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *ResourceLimits) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ResourceLimits) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ResourceLimits) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.IoWeight != 0 {
+		i = encodeVarintOps(dAtA, i, uint64(m.IoWeight))
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.Pids != 0 {
+		i = encodeVarintOps(dAtA, i, uint64(m.Pids))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.MemorySwap != 0 {
+		i = encodeVarintOps(dAtA, i, uint64(m.MemorySwap))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.Memory != 0 {
+		i = encodeVarintOps(dAtA, i, uint64(m.Memory))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.NanoCPUs != 0 {
+		i = encodeVarintOps(dAtA, i, uint64(m.NanoCPUs))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
 func (m *SecretEnv) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -5718,6 +5915,20 @@ func (m *Meta) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovOps(uint64(l))
 	}
+	if m.ResourceLimits != nil {
+		l = m.ResourceLimits.Size()
+		n += 1 + l + sovOps(uint64(l))
+	}
+	if m.Timeout != 0 {
+		n += 1 + sovOps(uint64(m.Timeout))
+	}
+	if len(m.ValidExitCodes) > 0 {
+		l = 0
+		for _, e := range m.ValidExitCodes {
+			l += sovOps(uint64(e))
+		}
+		n += 1 + sovOps(uint64(l)) + l
+	}
 	return n
 }
 
@@ -5757,6 +5968,30 @@ func (m *Ulimit) Size() (n int) {
 	return n
 }
 
+func (m *ResourceLimits) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.NanoCPUs != 0 {
+		n += 1 + sovOps(uint64(m.NanoCPUs))
+	}
+	if m.Memory != 0 {
+		n += 1 + sovOps(uint64(m.Memory))
+	}
+	if m.MemorySwap != 0 {
+		n += 1 + sovOps(uint64(m.MemorySwap))
+	}
+	if m.Pids != 0 {
+		n += 1 + sovOps(uint64(m.Pids))
+	}
+	if m.IoWeight != 0 {
+		n += 1 + sovOps(uint64(m.IoWeight))
+	}
+	return n
+}
+
 func (m *SecretEnv) Size() (n int) {
 	if m == nil {
 		return 0
@@ -7771,6 +8006,137 @@ func (m *Meta) Unmarshal(dAtA []byte) error {
 			}
 			m.CgroupParent = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ResourceLimits", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOps
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthOps
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOps
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ResourceLimits == nil {
+				m.ResourceLimits = &ResourceLimits{}
+			}
+			if err := m.ResourceLimits.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 12:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Timeout", wireType)
+			}
+			m.Timeout = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOps
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Timeout |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 13:
+			if wireType == 0 {
+				var v int32
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowOps
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= int32(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.ValidExitCodes = append(m.ValidExitCodes, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowOps
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthOps
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthOps
+				}
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				var elementCount int
+				var count int
+				for _, integer := range dAtA[iNdEx:postIndex] {
+					if integer < 128 {
+						count++
+					}
+				}
+				elementCount = count
+				if elementCount != 0 && len(m.ValidExitCodes) == 0 {
+					m.ValidExitCodes = make([]int32, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v int32
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowOps
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= int32(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.ValidExitCodes = append(m.ValidExitCodes, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field ValidExitCodes", wireType)
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipOps(dAtA[iNdEx:])
@@ -8026,6 +8392,151 @@ func (m *Ulimit) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *ResourceLimits) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowOps
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ResourceLimits: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ResourceLimits: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NanoCPUs", wireType)
+			}
+			m.NanoCPUs = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOps
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.NanoCPUs |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Memory", wireType)
+			}
+			m.Memory = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOps
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Memory |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MemorySwap", wireType)
+			}
+			m.MemorySwap = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOps
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MemorySwap |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Pids", wireType)
+			}
+			m.Pids = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOps
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Pids |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IoWeight", wireType)
+			}
+			m.IoWeight = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOps
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.IoWeight |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipOps(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthOps
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func (m *SecretEnv) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
@@ -13,8 +13,19 @@ import (
 )
 
 func (j *Job) Status(ctx context.Context, ch chan *client.SolveStatus) error {
-	vs := &vertexStream{cache: map[digest.Digest]*client.Vertex{}, wasCached: make(map[digest.Digest]struct{})}
+	return j.status(ctx, j.pr.Reader(ctx), ch)
+}
+
+// StatusAsync is like Status but runs in the background. The progress of the
+// job is subscribed to before it returns, so nothing written to the job
+// after the call is missed.
+func (j *Job) StatusAsync(ctx context.Context, ch chan *client.SolveStatus) {
 	pr := j.pr.Reader(ctx)
+	go j.status(ctx, pr, ch)
+}
+
+func (j *Job) status(ctx context.Context, pr progress.Reader, ch chan *client.SolveStatus) error {
+	vs := &vertexStream{cache: map[digest.Digest]*client.Vertex{}, wasCached: make(map[digest.Digest]struct{})}
 	defer func() {
 		if enc := vs.encore(); len(enc) > 0 {
 			ch <- &client.SolveStatus{Vertexes: enc}
@@ -52,6 +63,15 @@ func (j *Job) Status(ctx context.Context, ch chan *client.SolveStatus) error {
 					Started:   v.Started,
 					Completed: v.Completed,
 				}
+				if r := v.Resources; r != nil {
+					vs.Resources = &client.ResourceUsage{
+						MemoryPeak:   r.MemoryPeak,
+						CPUTime:      time.Duration(r.CPUNanos),
+						IOReadBytes:  r.IOReadBytes,
+						IOWriteBytes: r.IOWriteBytes,
+						Pids:         r.Pids,
+					}
+				}
 				ss.Statuses = append(ss.Statuses, vs)
 			case client.VertexLog:
 				vtx, ok := p.Meta("vertex")
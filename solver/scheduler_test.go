@@ -1450,6 +1450,80 @@ func TestMultipleCacheSources(t *testing.T) {
 	j1 = nil
 }
 
+func TestFallbackCacheSources(t *testing.T) {
+	t.Parallel()
+	ctx := context.TODO()
+
+	graph := func(value string, cacheSource CacheManager) Edge {
+		return Edge{
+			Vertex: vtx(vtxOpt{
+				name:         "v0",
+				cacheKeySeed: "seed0",
+				value:        value + "0",
+				cacheSource:  cacheSource,
+				inputs: []Edge{
+					{Vertex: vtx(vtxOpt{
+						name:         "v1",
+						cacheKeySeed: "seed1",
+						value:        value + "1",
+						cacheSource:  cacheSource,
+					})},
+				},
+			}),
+		}
+	}
+
+	build := func(g Edge, defaultCache CacheManager) string {
+		l := NewSolver(SolverOpt{
+			ResolveOpFunc: testOpResolver,
+			DefaultCache:  defaultCache,
+		})
+		defer l.Close()
+
+		j, err := l.NewJob("j-" + identity.NewID())
+		require.NoError(t, err)
+		defer j.Discard()
+
+		res, _, err := j.Build(ctx, g)
+		require.NoError(t, err)
+		return unwrap(res)
+	}
+
+	cacheA := NewInMemoryCacheManager()
+	require.Equal(t, "resultA0", build(graph("resultA", nil), cacheA))
+
+	cacheB := NewInMemoryCacheManager()
+	require.Equal(t, "resultB0", build(graph("resultB", nil), cacheB))
+
+	// the records of the first source are used even if the later source has
+	// newer ones
+	fallback := NewFallbackCacheManager([]CacheManager{cacheA, cacheB}, []string{"a", "b"})
+	require.Equal(t, "resultA0", build(graph("no-cache", fallback), NewInMemoryCacheManager()))
+
+	fallback = NewFallbackCacheManager([]CacheManager{cacheB, cacheA}, []string{"b", "a"})
+	require.Equal(t, "resultB0", build(graph("no-cache", fallback), NewInMemoryCacheManager()))
+
+	// sources without records are skipped
+	fallback = NewFallbackCacheManager([]CacheManager{NewInMemoryCacheManager(), cacheB, cacheA}, []string{"c", "b", "a"})
+	require.Equal(t, "resultB0", build(graph("no-cache", fallback), NewInMemoryCacheManager()))
+}
+
+func TestFallbackCacheKeySource(t *testing.T) {
+	t.Parallel()
+
+	chain := &cacheChain{names: []string{"a", "b", "c"}}
+	k := &CacheKey{ids: map[*cacheManager]string{}}
+	for i := len(chain.names) - 1; i >= 0; i-- {
+		k.ids[&cacheManager{source: &cacheSource{chain: chain, index: i}}] = "id"
+	}
+	k.ids[&cacheManager{}] = "id"
+
+	// the source is chosen in chain order, not in map iteration order
+	for i := 0; i < 20; i++ {
+		require.Equal(t, "a", keySource(k).name())
+	}
+}
+
 func TestRepeatBuildWithIgnoreCache(t *testing.T) {
 	t.Parallel()
 	ctx := context.TODO()
@@ -2213,6 +2287,71 @@ func TestCacheExporting(t *testing.T) {
 	require.Equal(t, expTarget.records[2].links, 0)
 }
 
+func TestCacheExportingFilter(t *testing.T) {
+	t.Parallel()
+	ctx := context.TODO()
+
+	cacheManager := newTrackingCacheManager(NewInMemoryCacheManager())
+
+	l := NewSolver(SolverOpt{
+		ResolveOpFunc: testOpResolver,
+		DefaultCache:  cacheManager,
+	})
+	defer l.Close()
+
+	j0, err := l.NewJob("j0")
+	require.NoError(t, err)
+
+	defer func() {
+		if j0 != nil {
+			j0.Discard()
+		}
+	}()
+
+	g0 := Edge{
+		Vertex: vtxSum(1, vtxOpt{
+			name: "top",
+			inputs: []Edge{
+				{Vertex: vtxSum(1, vtxOpt{
+					name: "mid",
+					inputs: []Edge{
+						{Vertex: vtxConst(2, vtxOpt{})},
+					},
+				})},
+				{Vertex: vtxConst(3, vtxOpt{})},
+			},
+		}),
+	}
+
+	res, _, err := j0.Build(ctx, g0)
+	require.NoError(t, err)
+	require.Equal(t, unwrapInt(res), 7)
+
+	require.NoError(t, j0.Discard())
+	j0 = nil
+
+	countResults := func(filter CacheExportFilter) int {
+		expTarget := newTestExporterTarget()
+		opt := testExporterOpts(true)
+		opt.Filter = filter
+		_, err := res.CacheKeys()[0].Exporter.ExportTo(ctx, expTarget, opt)
+		require.NoError(t, err)
+		expTarget.normalize()
+
+		require.Equal(t, 4, len(expTarget.records))
+		results := 0
+		for _, r := range expTarget.records {
+			results += r.results
+		}
+		return results
+	}
+
+	require.Equal(t, 2, countResults(nil))
+	require.Equal(t, 1, countResults(&testExportFilter{excludeNames: map[string]struct{}{"mid": {}}}))
+	require.Equal(t, 0, countResults(&testExportFilter{excludeNames: map[string]struct{}{"mid": {}, "top": {}}}))
+	require.Equal(t, 0, countResults(&testExportFilter{excludeRemotes: true}))
+}
+
 func TestCacheExportingModeMin(t *testing.T) {
 	t.Parallel()
 	ctx := context.TODO()
@@ -3794,6 +3933,23 @@ func testExporterOpts(all bool) CacheExportOpt {
 	}
 }
 
+type testExportFilter struct {
+	excludeNames   map[string]struct{}
+	excludeRemotes bool
+}
+
+func (f *testExportFilter) IncludeVertex(vtx Vertex, createdAt time.Time) bool {
+	if vtx == nil {
+		return true
+	}
+	_, ok := f.excludeNames[vtx.Name()]
+	return !ok
+}
+
+func (f *testExportFilter) IncludeRemote(r *Remote) bool {
+	return !f.excludeRemotes
+}
+
 func newTestExporterTarget() *testExporterTarget {
 	return &testExporterTarget{
 		visited: map[interface{}]struct{}{},
@@ -104,6 +104,21 @@ type CacheExportOpt struct {
 	// CompressionOpt is an option to specify the compression of the object to load.
 	// If specified, all objects that meet the option will be cached.
 	CompressionOpt *compression.Config
+	// Filter selects the results that are exported. All results are exported
+	// if it is nil.
+	Filter CacheExportFilter
+}
+
+// CacheExportFilter selects the results exported for the cache records of
+// vertexes. The cache keys of records whose results are not selected are
+// still exported to keep the chains of the selected ones linked.
+type CacheExportFilter interface {
+	// IncludeVertex is called before the result of vtx, created at createdAt,
+	// is loaded for export. vtx is nil if the vertex of the record is unknown.
+	IncludeVertex(vtx Vertex, createdAt time.Time) bool
+	// IncludeRemote is called with the remote a selected result would be
+	// exported as.
+	IncludeRemote(r *Remote) bool
 }
 
 // CacheExporter can export the artifacts of the build chain
@@ -298,6 +298,9 @@ func (gs *gitSourceHandler) CacheKey(ctx context.Context, g session.Group, index
 	defer gs.locker.Unlock(remote)
 
 	if ref := gs.src.Ref; ref != "" && isCommitSHA(ref) {
+		if gs.src.Checksum != "" && !strings.HasPrefix(ref, gs.src.Checksum) {
+			return "", "", nil, false, errors.Errorf("expected checksum to match %s, got %s", gs.src.Checksum, ref)
+		}
 		cacheKey := gs.shaToCacheKey(ref)
 		gs.cacheKey = cacheKey
 		return cacheKey, ref, nil, true, nil
@@ -355,6 +358,21 @@ func (gs *gitSourceHandler) CacheKey(ctx context.Context, g session.Group, index
 	if !isCommitSHA(sha) {
 		return "", "", nil, false, errors.Errorf("invalid commit sha %q", sha)
 	}
+	if gs.src.Checksum != "" && !strings.HasPrefix(sha, gs.src.Checksum) {
+		// annotated tags resolve to the tag object, compare the commit the
+		// tag points to instead
+		buf, err := gitWithinDir(ctx, gitDir, "", sock, knownHosts, gs.auth, "ls-remote", "origin", ref+"^{}")
+		if err != nil {
+			return "", "", nil, false, errors.Wrapf(err, "failed to fetch remote %s", urlutil.RedactCredentials(remote))
+		}
+		var commit string
+		if fields := strings.Fields(buf.String()); len(fields) > 0 {
+			commit = fields[0]
+		}
+		if !strings.HasPrefix(commit, gs.src.Checksum) {
+			return "", "", nil, false, errors.Errorf("expected checksum to match %s, got %s", gs.src.Checksum, sha)
+		}
+	}
 	cacheKey := gs.shaToCacheKey(sha)
 	gs.cacheKey = cacheKey
 	return cacheKey, sha, nil, true, nil
@@ -541,6 +541,70 @@ func testSubdir(t *testing.T, keepGitDir bool) {
 	require.Equal(t, "abc\n", string(dt))
 }
 
+func TestChecksum(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Depends on unimplemented containerd bind-mount support on Windows")
+	}
+
+	t.Parallel()
+	ctx := context.TODO()
+
+	tmpdir, err := os.MkdirTemp("", "buildkit-state")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmpdir)
+
+	gs := setupGitSource(t, tmpdir)
+
+	repodir, err := os.MkdirTemp("", "buildkit-gitsource")
+	require.NoError(t, err)
+	defer os.RemoveAll(repodir)
+
+	err = runShell(repodir,
+		"git init",
+		"git config --local user.email test",
+		"git config --local user.name test",
+		"echo foo > abc",
+		"git add abc",
+		"git commit -m initial",
+		"git tag -a v1 -m v1",
+		"git rev-parse HEAD > ../"+filepath.Base(repodir)+".sha",
+	)
+	require.NoError(t, err)
+
+	shaPath := repodir + ".sha"
+	defer os.Remove(shaPath)
+	dt, err := os.ReadFile(shaPath)
+	require.NoError(t, err)
+	sha := strings.TrimSpace(string(dt))
+
+	for _, tc := range []struct {
+		ref      string
+		checksum string
+		valid    bool
+	}{
+		{ref: "", checksum: sha, valid: true},
+		{ref: "", checksum: sha[:8], valid: true},
+		// annotated tags are compared by the commit they point to
+		{ref: "v1", checksum: sha, valid: true},
+		{ref: sha, checksum: sha, valid: true},
+		{ref: "", checksum: "0000000000000000000000000000000000000000", valid: false},
+		{ref: sha, checksum: "00000000", valid: false},
+	} {
+		id := &source.GitIdentifier{Remote: repodir, Ref: tc.ref, Checksum: tc.checksum}
+
+		g, err := gs.Resolve(ctx, id, nil, nil)
+		require.NoError(t, err)
+
+		_, _, _, _, err = g.CacheKey(ctx, nil, 0)
+		if tc.valid {
+			require.NoError(t, err, "ref %q checksum %q", tc.ref, tc.checksum)
+		} else {
+			require.Error(t, err, "ref %q checksum %q", tc.ref, tc.checksum)
+			require.Contains(t, err.Error(), "expected checksum to match")
+		}
+	}
+}
+
 func setupGitSource(t *testing.T, tmpdir string) source.Source {
 	snapshotter, err := native.NewSnapshotter(filepath.Join(tmpdir, "snapshots"))
 	assert.NoError(t, err)
@@ -108,6 +108,8 @@ func FromLLB(op *pb.Op_Source, platform *pb.Platform) (Identifier, error) {
 				id.KnownSSHHosts = v
 			case pb.AttrMountSSHSock:
 				id.MountSSHSock = v
+			case pb.AttrGitChecksum:
+				id.Checksum = v
 			}
 		}
 	}
@@ -140,6 +142,8 @@ func FromLLB(op *pb.Op_Source, platform *pb.Platform) (Identifier, error) {
 				id.FollowPaths = paths
 			case pb.AttrSharedKeyHint:
 				id.SharedKeyHint = v
+			case pb.AttrLocalSolveID:
+				id.SolveID = v
 			case pb.AttrLocalDiffer:
 				switch v {
 				case pb.AttrLocalDifferMetadata, "":
@@ -215,6 +219,7 @@ type LocalIdentifier struct {
 	ExcludePatterns []string
 	FollowPaths     []string
 	SharedKeyHint   string
+	SolveID         string
 	Differ          fsutil.DiffType
 }
 
@@ -0,0 +1,204 @@
+package local
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/containerd/containerd/mount"
+	"github.com/containerd/continuity/fs"
+	"github.com/docker/docker/pkg/idtools"
+	"github.com/moby/buildkit/cache"
+	"github.com/moby/buildkit/cache/config"
+	"github.com/moby/buildkit/client"
+	"github.com/moby/buildkit/session"
+	"github.com/moby/buildkit/snapshot"
+	"github.com/moby/buildkit/solver"
+	"github.com/moby/buildkit/util/bklog"
+	"github.com/moby/sys/mountinfo"
+	"github.com/pkg/errors"
+)
+
+// bindRef is a local source that is bind-mounted read-only from the host
+// instead of being copied. It wraps an empty placeholder ref that holds the
+// metadata of the source, like the content hashes that are computed lazily
+// from the mounted directory.
+type bindRef struct {
+	cache.ImmutableRef
+	dir *boundDir
+}
+
+// boundDir is shared by the clones of a bindRef.
+type boundDir struct {
+	mu           sync.Mutex
+	cm           cache.Accessor
+	name         string
+	path         string
+	refs         int
+	materialized cache.ImmutableRef
+}
+
+var _ cache.ExternalRef = &bindRef{}
+
+func (ls *localSourceHandler) bind(ctx context.Context, p string) (cache.ImmutableRef, error) {
+	mutable, err := ls.cm.New(ctx, nil, nil, cache.WithRecordType(client.UsageRecordTypeLocalSource), cache.WithDescription(fmt.Sprintf("bind-through local source for %s", ls.src.Name)))
+	if err != nil {
+		return nil, err
+	}
+	placeholder, err := mutable.Commit(ctx)
+	if err != nil {
+		mutable.Release(context.TODO())
+		return nil, err
+	}
+	bklog.G(ctx).Debugf("bind-mounting %s for local %s", p, ls.src.Name)
+	return &bindRef{
+		ImmutableRef: placeholder,
+		dir:          &boundDir{cm: ls.cm, name: ls.src.Name, path: p, refs: 1},
+	}, nil
+}
+
+// ID differs from the ID of the placeholder so that the placeholder can't be
+// loaded instead of the directory. The ref is never saved as a cache result,
+// see solver.ErrNotStorable.
+func (r *bindRef) ID() string {
+	return "bind-" + r.ImmutableRef.ID()
+}
+
+func (r *bindRef) Mount(ctx context.Context, readonly bool, s session.Group) (snapshot.Mountable, error) {
+	return &bindMountable{path: r.dir.path, idmap: r.IdentityMapping()}, nil
+}
+
+func (r *bindRef) Clone() cache.ImmutableRef {
+	r.dir.mu.Lock()
+	r.dir.refs++
+	r.dir.mu.Unlock()
+	return &bindRef{ImmutableRef: r.ImmutableRef.Clone(), dir: r.dir}
+}
+
+func (r *bindRef) Release(ctx context.Context) error {
+	if err := r.dir.release(ctx); err != nil {
+		return err
+	}
+	return r.ImmutableRef.Release(ctx)
+}
+
+// SetCachePolicyRetain does nothing as the placeholder has no data worth
+// retaining.
+func (r *bindRef) SetCachePolicyRetain() error {
+	return nil
+}
+
+func (r *bindRef) GetRemotes(ctx context.Context, createIfNeeded bool, cfg config.RefConfig, all bool, s session.Group) ([]*solver.Remote, error) {
+	ref, err := r.Materialize(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return ref.GetRemotes(ctx, createIfNeeded, cfg, all, s)
+}
+
+func (r *bindRef) LayerChain() cache.RefList {
+	ref, err := r.Materialize(context.TODO())
+	if err != nil {
+		bklog.L.Errorf("failed to copy local source %s: %v", r.dir.name, err)
+		return nil
+	}
+	return ref.LayerChain()
+}
+
+func (r *bindRef) Materialize(ctx context.Context) (cache.ImmutableRef, error) {
+	return r.dir.materialize(ctx)
+}
+
+func (d *boundDir) materialize(ctx context.Context) (_ cache.ImmutableRef, retErr error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.materialized != nil {
+		return d.materialized, nil
+	}
+
+	mutable, err := d.cm.New(ctx, nil, nil, cache.WithRecordType(client.UsageRecordTypeLocalSource), cache.WithDescription(fmt.Sprintf("local source for %s", d.name)))
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if retErr != nil && mutable != nil {
+			mutable.Release(context.TODO())
+		}
+	}()
+
+	m, err := mutable.Mount(ctx, false, nil)
+	if err != nil {
+		return nil, err
+	}
+	lm := snapshot.LocalMounter(m)
+	dest, err := lm.Mount()
+	if err != nil {
+		return nil, err
+	}
+	if err := fs.CopyDir(dest, d.path); err != nil {
+		lm.Unmount()
+		return nil, errors.Wrapf(err, "failed to copy %s", d.path)
+	}
+	if err := lm.Unmount(); err != nil {
+		return nil, err
+	}
+
+	ref, err := mutable.Commit(ctx)
+	if err != nil {
+		return nil, err
+	}
+	mutable = nil
+	d.materialized = ref
+	return ref, nil
+}
+
+func (d *boundDir) release(ctx context.Context) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.refs--
+	if d.refs > 0 || d.materialized == nil {
+		return nil
+	}
+	err := d.materialized.Release(ctx)
+	d.materialized = nil
+	return err
+}
+
+// checkNoSubmounts returns an error if there are mounts below the directory p.
+// Their read-only state isn't inherited by the recursive bind mount of p, so
+// they would be writable by the build.
+func checkNoSubmounts(p string) error {
+	p, err := filepath.EvalSymlinks(p)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	mounts, err := mountinfo.GetMounts(mountinfo.PrefixFilter(p))
+	if err != nil {
+		return errors.Wrap(err, "failed to list mounts")
+	}
+	for _, m := range mounts {
+		if strings.HasPrefix(m.Mountpoint, p+"/") {
+			return errors.Errorf("%s contains mount point %s", p, m.Mountpoint)
+		}
+	}
+	return nil
+}
+
+type bindMountable struct {
+	path  string
+	idmap *idtools.IdentityMapping
+}
+
+func (m *bindMountable) Mount() ([]mount.Mount, func() error, error) {
+	return []mount.Mount{{
+		Type:    "bind",
+		Source:  m.path,
+		Options: []string{"bind", "ro"},
+	}}, func() error { return nil }, nil
+}
+
+func (m *bindMountable) IdentityMapping() *idtools.IdentityMapping {
+	return m.idmap
+}
@@ -0,0 +1,145 @@
+package local
+
+import (
+	"os"
+	"sync"
+
+	"github.com/containerd/continuity/fs"
+	"github.com/moby/buildkit/cache"
+	"github.com/moby/buildkit/cache/contenthash"
+	"github.com/moby/buildkit/session/filesync"
+	digest "github.com/opencontainers/go-digest"
+	"github.com/pkg/errors"
+)
+
+// chunkStore provides the chunks of the files of the previous transfers to
+// a local source ref. The files are kept open during the transfer so that
+// their chunks can still be read after the transfer replaced them.
+type chunkStore struct {
+	mu     sync.Mutex
+	index  map[string]contenthash.FileChunks
+	chunks map[digest.Digest]chunkLocation
+	files  []*os.File
+	// used is set if the transfer used the chunked protocol
+	used bool
+}
+
+type chunkLocation struct {
+	f      *os.File
+	offset int64
+	size   int64
+}
+
+var _ filesync.ChunkStore = &chunkStore{}
+
+func newChunkStore(md cache.RefMetadata, dir string) (*chunkStore, error) {
+	index, err := contenthash.GetChunkIndex(md)
+	if err != nil {
+		return nil, err
+	}
+	cs := &chunkStore{
+		index:  index,
+		chunks: map[digest.Digest]chunkLocation{},
+	}
+	for p, fc := range index {
+		f, err := openIndexedFile(dir, p, fc.Size())
+		if err != nil {
+			// the file was removed or has changed since it was indexed
+			delete(index, p)
+			continue
+		}
+		cs.files = append(cs.files, f)
+		var offset int64
+		for _, c := range fc {
+			cs.chunks[c.Digest] = chunkLocation{f: f, offset: offset, size: c.Size}
+			offset += c.Size
+		}
+	}
+	return cs, nil
+}
+
+func openIndexedFile(dir, p string, size int64) (*os.File, error) {
+	fp, err := fs.RootPath(dir, p)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(fp)
+	if err != nil {
+		return nil, err
+	}
+	fi, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	if !fi.Mode().IsRegular() || fi.Size() != size {
+		f.Close()
+		return nil, errors.Errorf("%s does not match chunk index", p)
+	}
+	return f, nil
+}
+
+func (cs *chunkStore) Chunks() []digest.Digest {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	cs.used = true
+	out := make([]digest.Digest, 0, len(cs.chunks))
+	for dgst := range cs.chunks {
+		out = append(out, dgst)
+	}
+	return out
+}
+
+func (cs *chunkStore) ReadChunk(dgst digest.Digest) ([]byte, error) {
+	cs.mu.Lock()
+	l, ok := cs.chunks[dgst]
+	cs.mu.Unlock()
+	if !ok {
+		return nil, errors.Errorf("chunk %s not found", dgst)
+	}
+	dt := make([]byte, l.size)
+	if _, err := l.f.ReadAt(dt, l.offset); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	if dgst.Algorithm().FromBytes(dt) != dgst {
+		return nil, errors.Errorf("chunk %s has changed", dgst)
+	}
+	return dt, nil
+}
+
+func (cs *chunkStore) SetFileChunks(p string, chunks []filesync.Chunk) error {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	if len(chunks) == 0 {
+		delete(cs.index, p)
+		return nil
+	}
+	fc := make(contenthash.FileChunks, len(chunks))
+	for i, c := range chunks {
+		fc[i] = contenthash.Chunk{Digest: c.Digest, Size: c.Size}
+	}
+	cs.index[p] = fc
+	return nil
+}
+
+// Save records the chunks of the files of the ref for the next transfer.
+func (cs *chunkStore) Save(md cache.RefMetadata) error {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	if !cs.used {
+		// files may have changed without the chunks being recorded
+		return contenthash.SetChunkIndex(md, map[string]contenthash.FileChunks{})
+	}
+	return contenthash.SetChunkIndex(md, cs.index)
+}
+
+func (cs *chunkStore) Close() error {
+	var rerr error
+	for _, f := range cs.files {
+		if err := f.Close(); err != nil && rerr == nil {
+			rerr = errors.WithStack(err)
+		}
+	}
+	cs.files = nil
+	return rerr
+}
@@ -79,7 +79,8 @@ func (ls *localSourceHandler) CacheKey(ctx context.Context, g session.Group, ind
 		IncludePatterns []string
 		ExcludePatterns []string
 		FollowPaths     []string
-	}{SessionID: sessionID, IncludePatterns: ls.src.IncludePatterns, ExcludePatterns: ls.src.ExcludePatterns, FollowPaths: ls.src.FollowPaths})
+		SolveID         string `json:",omitempty"`
+	}{SessionID: sessionID, IncludePatterns: ls.src.IncludePatterns, ExcludePatterns: ls.src.ExcludePatterns, FollowPaths: ls.src.FollowPaths, SolveID: ls.src.SolveID})
 	if err != nil {
 		return "", "", nil, false, err
 	}
@@ -125,6 +126,18 @@ func (ls *localSourceHandler) snapshotWithAnySession(ctx context.Context, g sess
 }
 
 func (ls *localSourceHandler) snapshot(ctx context.Context, caller session.Caller) (out cache.ImmutableRef, retErr error) {
+	// a bind mount can't be filtered by patterns or follow paths
+	if len(ls.src.IncludePatterns) == 0 && len(ls.src.ExcludePatterns) == 0 && len(ls.src.FollowPaths) == 0 {
+		p, err := filesync.LocalDir(ctx, caller, ls.src.Name)
+		if err == nil {
+			err = checkNoSubmounts(p)
+		}
+		if err == nil {
+			return ls.bind(ctx, p)
+		}
+		bklog.G(ctx).Debugf("not bind-mounting local %s: %v", ls.src.Name, err)
+	}
+
 	sharedKey := ls.src.Name + ":" + ls.src.SharedKeyHint + ":" + caller.SharedKey() // TODO: replace caller.SharedKey() with source based hint from client(absolute-path+nodeid)
 
 	var mutable cache.MutableRef
@@ -185,6 +198,12 @@ func (ls *localSourceHandler) snapshot(ctx context.Context, caller session.Calle
 		return nil, err
 	}
 
+	chunks, err := newChunkStore(mutable, dest)
+	if err != nil {
+		return nil, err
+	}
+	defer chunks.Close()
+
 	opt := filesync.FSSendRequestOpt{
 		Name:             ls.src.Name,
 		IncludePatterns:  ls.src.IncludePatterns,
@@ -195,6 +214,7 @@ func (ls *localSourceHandler) snapshot(ctx context.Context, caller session.Calle
 		CacheUpdater:     &cacheUpdater{cc, mount.IdentityMapping()},
 		ProgressCb:       newProgressHandler(ctx, "transferring "+ls.src.Name+":"),
 		Differ:           ls.src.Differ,
+		ChunkStore:       chunks,
 	}
 
 	if idmap := mount.IdentityMapping(); idmap != nil {
@@ -216,6 +236,10 @@ func (ls *localSourceHandler) snapshot(ctx context.Context, caller session.Calle
 		return nil, err
 	}
 
+	if err := chunks.Close(); err != nil {
+		return nil, err
+	}
+
 	if err := lm.Unmount(); err != nil {
 		return nil, err
 	}
@@ -225,6 +249,10 @@ func (ls *localSourceHandler) snapshot(ctx context.Context, caller session.Calle
 		return nil, err
 	}
 
+	if err := chunks.Save(mutable); err != nil {
+		return nil, err
+	}
+
 	// skip storing snapshot by the shared key if it already exists
 	md := cacheRefMetadata{mutable}
 	if md.getSharedKey() != sharedKey {
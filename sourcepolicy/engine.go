@@ -0,0 +1,144 @@
+package sourcepolicy
+
+import (
+	"context"
+	"regexp"
+	"sync"
+
+	"github.com/moby/buildkit/solver/pb"
+	spb "github.com/moby/buildkit/sourcepolicy/pb"
+	"github.com/moby/buildkit/util/bklog"
+	"github.com/pkg/errors"
+)
+
+// ErrSourceDenied is returned when a source is denied by a policy.
+var ErrSourceDenied = errors.New("source denied by policy")
+
+// maxConversions limits the number of times a source can be converted to
+// detect rules that convert sources in a loop.
+const maxConversions = 20
+
+// Engine evaluates source policies against the source operations of a build.
+//
+// Policies are evaluated in order. Within a policy the first rule matching the
+// identifier of a source decides: ALLOW ends the evaluation of the policy,
+// DENY fails the build and CONVERT updates the source, after which all
+// policies are evaluated again against the updated source.
+type Engine struct {
+	pol []*spb.Policy
+
+	mu      sync.Mutex
+	regexps map[*spb.Selector]*regexp.Regexp
+}
+
+// NewEngine returns an engine for the given policies. Nil policies are
+// ignored.
+func NewEngine(pol []*spb.Policy) *Engine {
+	e := &Engine{regexps: map[*spb.Selector]*regexp.Regexp{}}
+	for _, p := range pol {
+		if p != nil {
+			e.pol = append(e.pol, p)
+		}
+	}
+	return e
+}
+
+// Evaluate applies the policies to a source operation and reports whether
+// the operation was changed. Other operations are left untouched.
+func (e *Engine) Evaluate(ctx context.Context, op *pb.Op) (bool, error) {
+	if len(e.pol) == 0 {
+		return false, nil
+	}
+	src := op.GetSource()
+	if src == nil {
+		return false, nil
+	}
+
+	var mutated bool
+	for i := 0; ; i++ {
+		if i >= maxConversions {
+			return false, errors.Errorf("too many conversions of source %s, policy rules may be converting in a loop", src.Identifier)
+		}
+		converted, err := e.evaluate(ctx, src)
+		if err != nil {
+			return false, err
+		}
+		if !converted {
+			return mutated, nil
+		}
+		mutated = true
+	}
+}
+
+func (e *Engine) evaluate(ctx context.Context, src *pb.SourceOp) (bool, error) {
+	for _, p := range e.pol {
+		for _, rule := range p.Rules {
+			if rule.Selector == nil {
+				return false, errors.Errorf("invalid source policy rule without selector")
+			}
+			re, err := e.selectorRegexp(rule.Selector)
+			if err != nil {
+				return false, err
+			}
+			if !re.MatchString(src.Identifier) {
+				continue
+			}
+
+			switch rule.Action {
+			case spb.PolicyAction_ALLOW:
+			case spb.PolicyAction_DENY:
+				return false, errors.Wrapf(ErrSourceDenied, "%s", src.Identifier)
+			case spb.PolicyAction_CONVERT:
+				if rule.Updates == nil {
+					return false, errors.Errorf("invalid convert rule for %s without updates", rule.Selector.Identifier)
+				}
+				if convert(src, re, rule.Updates) {
+					bklog.G(ctx).Debugf("source policy converted source to %s", src.Identifier)
+					return true, nil
+				}
+			default:
+				return false, errors.Errorf("unknown source policy action %v", rule.Action)
+			}
+			break
+		}
+	}
+	return false, nil
+}
+
+// convert applies the updates of a rule to the source and reports whether
+// the source was changed.
+func convert(src *pb.SourceOp, re *regexp.Regexp, upd *spb.Update) bool {
+	var changed bool
+	if upd.Identifier != "" {
+		id := re.ReplaceAllString(src.Identifier, upd.Identifier)
+		if id != src.Identifier {
+			src.Identifier = id
+			changed = true
+		}
+	}
+	for k, v := range upd.Attrs {
+		if cur, ok := src.Attrs[k]; ok && cur == v {
+			continue
+		}
+		if src.Attrs == nil {
+			src.Attrs = map[string]string{}
+		}
+		src.Attrs[k] = v
+		changed = true
+	}
+	return changed
+}
+
+func (e *Engine) selectorRegexp(sel *spb.Selector) (*regexp.Regexp, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if re, ok := e.regexps[sel]; ok {
+		return re, nil
+	}
+	re, err := selectorRegexp(sel)
+	if err != nil {
+		return nil, err
+	}
+	e.regexps[sel] = re
+	return re, nil
+}
@@ -0,0 +1,164 @@
+package sourcepolicy
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/moby/buildkit/solver/pb"
+	spb "github.com/moby/buildkit/sourcepolicy/pb"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func sourceOp(id string) *pb.Op {
+	return &pb.Op{
+		Op: &pb.Op_Source{
+			Source: &pb.SourceOp{Identifier: id},
+		},
+	}
+}
+
+func TestEngineDeny(t *testing.T) {
+	t.Parallel()
+
+	e := NewEngine([]*spb.Policy{{
+		Rules: []*spb.Rule{
+			{
+				Action:   spb.PolicyAction_ALLOW,
+				Selector: &spb.Selector{Identifier: "docker-image://docker.io/library/*"},
+			},
+			{
+				Action:   spb.PolicyAction_DENY,
+				Selector: &spb.Selector{Identifier: "docker-image://*"},
+			},
+		},
+	}})
+
+	mutated, err := e.Evaluate(context.TODO(), sourceOp("docker-image://docker.io/library/alpine:latest"))
+	require.NoError(t, err)
+	require.False(t, mutated)
+
+	_, err = e.Evaluate(context.TODO(), sourceOp("docker-image://docker.io/someone/alpine:latest"))
+	require.Error(t, err)
+	require.True(t, errors.Is(err, ErrSourceDenied))
+
+	mutated, err = e.Evaluate(context.TODO(), sourceOp("git://github.com/moby/buildkit.git"))
+	require.NoError(t, err)
+	require.False(t, mutated)
+
+	mutated, err = e.Evaluate(context.TODO(), &pb.Op{Op: &pb.Op_Exec{Exec: &pb.ExecOp{}}})
+	require.NoError(t, err)
+	require.False(t, mutated)
+}
+
+func TestEngineConvert(t *testing.T) {
+	t.Parallel()
+
+	e := NewEngine([]*spb.Policy{{
+		Rules: []*spb.Rule{
+			{
+				Action:   spb.PolicyAction_CONVERT,
+				Selector: &spb.Selector{Identifier: "docker-image://docker.io/library/alpine:3.15"},
+				Updates: &spb.Update{
+					Identifier: "docker-image://docker.io/library/alpine:3.15@sha256:4edbd2beb5f78b1014028f4fbb99f3237d9561100b6881aabbf5acce2c4f9454",
+				},
+			},
+			{
+				Action:   spb.PolicyAction_CONVERT,
+				Selector: &spb.Selector{Identifier: "docker-image://docker.io/*"},
+				Updates: &spb.Update{
+					Identifier: "docker-image://mirror.example.com/${1}",
+				},
+			},
+			{
+				Action: spb.PolicyAction_CONVERT,
+				Selector: &spb.Selector{
+					Identifier: `^https://example\.com/(.+)\.tar\.gz$`,
+					MatchType:  spb.MatchType_REGEX,
+				},
+				Updates: &spb.Update{
+					Identifier: "https://mirror.example.com/$1.tar.gz",
+					Attrs:      map[string]string{pb.AttrHTTPChecksum: "sha256:abc"},
+				},
+			},
+		},
+	}, nil})
+
+	op := sourceOp("docker-image://docker.io/library/alpine:3.15")
+	mutated, err := e.Evaluate(context.TODO(), op)
+	require.NoError(t, err)
+	require.True(t, mutated)
+	require.Equal(t, "docker-image://mirror.example.com/library/alpine:3.15@sha256:4edbd2beb5f78b1014028f4fbb99f3237d9561100b6881aabbf5acce2c4f9454", op.GetSource().Identifier)
+
+	op = sourceOp("https://example.com/src.tar.gz")
+	mutated, err = e.Evaluate(context.TODO(), op)
+	require.NoError(t, err)
+	require.True(t, mutated)
+	require.Equal(t, "https://mirror.example.com/src.tar.gz", op.GetSource().Identifier)
+	require.Equal(t, "sha256:abc", op.GetSource().Attrs[pb.AttrHTTPChecksum])
+}
+
+func TestEngineConvertLoop(t *testing.T) {
+	t.Parallel()
+
+	e := NewEngine([]*spb.Policy{{
+		Rules: []*spb.Rule{
+			{
+				Action:   spb.PolicyAction_CONVERT,
+				Selector: &spb.Selector{Identifier: "docker-image://a", MatchType: spb.MatchType_EXACT},
+				Updates:  &spb.Update{Identifier: "docker-image://b"},
+			},
+			{
+				Action:   spb.PolicyAction_CONVERT,
+				Selector: &spb.Selector{Identifier: "docker-image://b", MatchType: spb.MatchType_EXACT},
+				Updates:  &spb.Update{Identifier: "docker-image://a"},
+			},
+		},
+	}})
+
+	_, err := e.Evaluate(context.TODO(), sourceOp("docker-image://a"))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "loop")
+}
+
+func TestEngineConvertDeniedByLaterPolicy(t *testing.T) {
+	t.Parallel()
+
+	daemon := &spb.Policy{
+		Rules: []*spb.Rule{{
+			Action:   spb.PolicyAction_DENY,
+			Selector: &spb.Selector{Identifier: "docker-image://untrusted.example.com/*"},
+		}},
+	}
+	request := &spb.Policy{
+		Rules: []*spb.Rule{{
+			Action:   spb.PolicyAction_CONVERT,
+			Selector: &spb.Selector{Identifier: "docker-image://docker.io/*"},
+			Updates:  &spb.Update{Identifier: "docker-image://untrusted.example.com/${1}"},
+		}},
+	}
+
+	_, err := NewEngine([]*spb.Policy{daemon, request}).Evaluate(context.TODO(), sourceOp("docker-image://docker.io/library/alpine:latest"))
+	require.True(t, errors.Is(err, ErrSourceDenied))
+}
+
+func TestPolicyJSON(t *testing.T) {
+	t.Parallel()
+
+	var p spb.Policy
+	err := json.Unmarshal([]byte(`{
+		"rules": [
+			{"action": "DENY", "selector": {"identifier": "docker-image://*"}},
+			{"action": 2, "selector": {"identifier": "^git://.*$", "match_type": "REGEX"}, "updates": {"identifier": "git://mirror"}}
+		]
+	}`), &p)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(p.Rules))
+	require.Equal(t, spb.PolicyAction_DENY, p.Rules[0].Action)
+	require.Equal(t, spb.PolicyAction_CONVERT, p.Rules[1].Action)
+	require.Equal(t, spb.MatchType_REGEX, p.Rules[1].Selector.MatchType)
+
+	err = json.Unmarshal([]byte(`{"rules": [{"action": "BLOCK"}]}`), &p)
+	require.Error(t, err)
+}
@@ -0,0 +1,48 @@
+package sourcepolicy
+
+import (
+	"regexp"
+	"strings"
+
+	spb "github.com/moby/buildkit/sourcepolicy/pb"
+	"github.com/pkg/errors"
+)
+
+// selectorRegexp returns the regular expression that a selector matches
+// identifiers with. Wildcards are converted to capture groups so that their
+// submatches can be referenced in updates.
+func selectorRegexp(sel *spb.Selector) (*regexp.Regexp, error) {
+	var expr string
+	switch sel.MatchType {
+	case spb.MatchType_WILDCARD:
+		expr = wildcardToRegexp(sel.Identifier)
+	case spb.MatchType_EXACT:
+		expr = "^" + regexp.QuoteMeta(sel.Identifier) + "$"
+	case spb.MatchType_REGEX:
+		expr = sel.Identifier
+	default:
+		return nil, errors.Errorf("unknown match type %v", sel.MatchType)
+	}
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid selector %s", sel.Identifier)
+	}
+	return re, nil
+}
+
+func wildcardToRegexp(pattern string) string {
+	var b strings.Builder
+	b.WriteString("^")
+	for _, r := range pattern {
+		switch r {
+		case '*':
+			b.WriteString("(.*)")
+		case '?':
+			b.WriteString("(.)")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	b.WriteString("$")
+	return b.String()
+}
@@ -0,0 +1,53 @@
+package moby_buildkit_v1_sourcepolicy //nolint:revive
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+)
+
+// MarshalJSON encodes the action by its name.
+func (a PolicyAction) MarshalJSON() ([]byte, error) {
+	return json.Marshal(a.String())
+}
+
+// UnmarshalJSON decodes the action from its name or number.
+func (a *PolicyAction) UnmarshalJSON(dt []byte) error {
+	v, err := unmarshalEnum(dt, PolicyAction_value)
+	if err != nil {
+		return errors.Wrap(err, "invalid policy action")
+	}
+	*a = PolicyAction(v)
+	return nil
+}
+
+// MarshalJSON encodes the match type by its name.
+func (t MatchType) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.String())
+}
+
+// UnmarshalJSON decodes the match type from its name or number.
+func (t *MatchType) UnmarshalJSON(dt []byte) error {
+	v, err := unmarshalEnum(dt, MatchType_value)
+	if err != nil {
+		return errors.Wrap(err, "invalid match type")
+	}
+	*t = MatchType(v)
+	return nil
+}
+
+func unmarshalEnum(dt []byte, values map[string]int32) (int32, error) {
+	var s string
+	if err := json.Unmarshal(dt, &s); err == nil {
+		v, ok := values[s]
+		if !ok {
+			return 0, errors.Errorf("unknown value %q", s)
+		}
+		return v, nil
+	}
+	var v int32
+	if err := json.Unmarshal(dt, &v); err != nil {
+		return 0, errors.WithStack(err)
+	}
+	return v, nil
+}
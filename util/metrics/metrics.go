@@ -0,0 +1,148 @@
+// Package metrics defines the metrics recorded by buildkit. Instruments are
+// created on the global OpenTelemetry meter provider and are no-ops until a
+// Provider has been created.
+package metrics
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/global"
+	"go.opentelemetry.io/otel/metric/unit"
+)
+
+const instrumentationName = "github.com/moby/buildkit"
+
+var (
+	solveStatusKey = attribute.Key("status")
+	opTypeKey      = attribute.Key("op_type")
+	cacheResultKey = attribute.Key("result")
+)
+
+var (
+	meter = metric.Must(global.Meter(instrumentationName))
+
+	solveCount = meter.NewInt64Counter("buildkit.solve.count",
+		metric.WithDescription("Number of completed solve requests"))
+	solveDuration = meter.NewFloat64Histogram("buildkit.solve.duration",
+		metric.WithDescription("Duration of solve requests in seconds"),
+		metric.WithUnit("s"))
+	cacheLookups = meter.NewInt64Counter("buildkit.cache.lookups",
+		metric.WithDescription("Number of cache lookups by operation type and result"))
+	pulledBytes = meter.NewInt64Counter("buildkit.pull.bytes",
+		metric.WithDescription("Number of bytes pulled from registries"),
+		metric.WithUnit(unit.Bytes))
+	pushedBytes = meter.NewInt64Counter("buildkit.push.bytes",
+		metric.WithDescription("Number of bytes pushed to registries"),
+		metric.WithUnit(unit.Bytes))
+	activeSessions = meter.NewInt64UpDownCounter("buildkit.sessions.active",
+		metric.WithDescription("Number of sessions attached to the daemon"))
+	networkPoolLookups = meter.NewInt64Counter("buildkit.network.pool.lookups",
+		metric.WithDescription("Number of network namespace requests by whether a pooled namespace was reused"))
+)
+
+// SnapshotUsageFunc returns the number of snapshots and their total size in
+// bytes.
+type SnapshotUsageFunc func(ctx context.Context) (count int64, size int64)
+
+var (
+	snapshotMu     sync.Mutex
+	snapshotUsages = map[*SnapshotUsageFunc]struct{}{}
+)
+
+func init() {
+	var snapshotCount, snapshotSize metric.Int64GaugeObserver
+	batch := meter.NewBatchObserver(func(ctx context.Context, result metric.BatchObserverResult) {
+		var count, size int64
+		snapshotMu.Lock()
+		for f := range snapshotUsages {
+			c, s := (*f)(ctx)
+			count += c
+			size += s
+		}
+		snapshotMu.Unlock()
+		result.Observe(nil, snapshotCount.Observation(count), snapshotSize.Observation(size))
+	})
+	snapshotCount = batch.NewInt64GaugeObserver("buildkit.snapshots.count",
+		metric.WithDescription("Number of snapshots in the build cache"))
+	snapshotSize = batch.NewInt64GaugeObserver("buildkit.snapshots.size",
+		metric.WithDescription("Size of the snapshots in the build cache in bytes"),
+		metric.WithUnit(unit.Bytes))
+}
+
+// RegisterSnapshotUsage adds a source of snapshot usage that is reported
+// with the snapshot metrics. The returned function removes it again.
+func RegisterSnapshotUsage(f SnapshotUsageFunc) func() {
+	snapshotMu.Lock()
+	defer snapshotMu.Unlock()
+	key := &f
+	snapshotUsages[key] = struct{}{}
+	return func() {
+		snapshotMu.Lock()
+		delete(snapshotUsages, key)
+		snapshotMu.Unlock()
+	}
+}
+
+// RecordSolve records a completed solve request.
+func RecordSolve(ctx context.Context, d time.Duration, err error) {
+	status := "success"
+	if err != nil {
+		status = "error"
+		if errors.Is(err, context.Canceled) {
+			status = "canceled"
+		}
+	}
+	attrs := []attribute.KeyValue{solveStatusKey.String(status)}
+	solveCount.Add(ctx, 1, attrs...)
+	solveDuration.Record(ctx, d.Seconds(), attrs...)
+}
+
+// RecordCacheLookup records whether the result of an operation of the
+// given type was loaded from the cache.
+func RecordCacheLookup(ctx context.Context, opType string, hit bool) {
+	result := "miss"
+	if hit {
+		result = "hit"
+	}
+	cacheLookups.Add(ctx, 1, opTypeKey.String(opType), cacheResultKey.String(result))
+}
+
+// RecordPulledBytes records bytes read from a registry.
+func RecordPulledBytes(ctx context.Context, n int64) {
+	if n > 0 {
+		pulledBytes.Add(ctx, n)
+	}
+}
+
+// RecordPushedBytes records bytes written to a registry.
+func RecordPushedBytes(ctx context.Context, n int64) {
+	if n > 0 {
+		pushedBytes.Add(ctx, n)
+	}
+}
+
+// RecordNetworkPoolLookup records whether a network namespace was taken
+// from the pool of pre-created namespaces.
+func RecordNetworkPoolLookup(ctx context.Context, hit bool) {
+	result := "miss"
+	if hit {
+		result = "hit"
+	}
+	networkPoolLookups.Add(ctx, 1, cacheResultKey.String(result))
+}
+
+// SessionStarted records a session attaching to the daemon. SessionEnded
+// must be called when it is closed.
+func SessionStarted(ctx context.Context) {
+	activeSessions.Add(ctx, 1)
+}
+
+// SessionEnded records a session detaching from the daemon.
+func SessionEnded(ctx context.Context) {
+	activeSessions.Add(ctx, -1)
+}
@@ -0,0 +1,167 @@
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/moby/buildkit/util/bklog"
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
+	"go.opentelemetry.io/otel/exporters/prometheus"
+	"go.opentelemetry.io/otel/metric/global"
+	"go.opentelemetry.io/otel/sdk/metric/aggregator/histogram"
+	controller "go.opentelemetry.io/otel/sdk/metric/controller/basic"
+	"go.opentelemetry.io/otel/sdk/metric/export/aggregation"
+	processor "go.opentelemetry.io/otel/sdk/metric/processor/basic"
+	selector "go.opentelemetry.io/otel/sdk/metric/selector/simple"
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+// durationBoundaries are the histogram buckets for durations in seconds.
+var durationBoundaries = []float64{0.1, 0.5, 1, 2.5, 5, 10, 30, 60, 120, 300, 600, 1800, 3600}
+
+const defaultExportInterval = 60 * time.Second
+
+// Provider collects the recorded metrics. They are served in the Prometheus
+// text format by Handler and, when an OTLP endpoint is configured in the
+// environment, periodically pushed to it.
+type Provider struct {
+	// mu serializes collections, as the controller is shared by the
+	// Prometheus handler and the OTLP exporter
+	mu   sync.Mutex
+	ctrl *controller.Controller
+	prom *prometheus.Exporter
+	otlp *otlpmetric.Exporter
+
+	stopCh chan struct{}
+	doneCh chan struct{}
+}
+
+// NewProvider creates the metrics provider and installs it as the global
+// meter provider.
+func NewProvider(ctx context.Context, res *resource.Resource) (*Provider, error) {
+	ctrl := controller.New(
+		processor.NewFactory(
+			selector.NewWithHistogramDistribution(histogram.WithExplicitBoundaries(durationBoundaries)),
+			aggregation.CumulativeTemporalitySelector(),
+			processor.WithMemory(true),
+		),
+		controller.WithResource(res),
+		controller.WithCollectPeriod(0),
+	)
+
+	prom, err := prometheus.New(prometheus.Config{DefaultHistogramBoundaries: durationBoundaries}, ctrl)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create prometheus exporter")
+	}
+
+	p := &Provider{
+		ctrl: ctrl,
+		prom: prom,
+	}
+
+	otlp, err := detectOTLPExporter(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if otlp != nil {
+		interval, err := exportInterval()
+		if err != nil {
+			return nil, err
+		}
+		p.otlp = otlp
+		p.stopCh = make(chan struct{})
+		p.doneCh = make(chan struct{})
+		go p.run(ctx, interval)
+	}
+
+	global.SetMeterProvider(ctrl)
+	return p, nil
+}
+
+// Handler returns the handler serving the metrics in the Prometheus text
+// format.
+func (p *Provider) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		p.prom.ServeHTTP(w, r)
+	})
+}
+
+// Shutdown pushes the metrics one last time and stops the OTLP exporter.
+func (p *Provider) Shutdown(ctx context.Context) error {
+	if p.otlp == nil {
+		return nil
+	}
+	close(p.stopCh)
+	<-p.doneCh
+	if err := p.export(ctx); err != nil {
+		return err
+	}
+	return p.otlp.Shutdown(ctx)
+}
+
+func (p *Provider) run(ctx context.Context, interval time.Duration) {
+	defer close(p.doneCh)
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-p.stopCh:
+			return
+		case <-t.C:
+			if err := p.export(ctx); err != nil {
+				bklog.G(ctx).Warnf("failed to export metrics: %v", err)
+			}
+		}
+	}
+}
+
+func (p *Provider) export(ctx context.Context) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if err := p.ctrl.Collect(ctx); err != nil {
+		return err
+	}
+	return p.otlp.Export(ctx, p.ctrl.Resource(), p.ctrl)
+}
+
+func detectOTLPExporter(ctx context.Context) (*otlpmetric.Exporter, error) {
+	switch n := os.Getenv("OTEL_METRICS_EXPORTER"); n {
+	case "none":
+		return nil, nil
+	case "", "otlp":
+		if n == "" && os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT") == "" && os.Getenv("OTEL_EXPORTER_OTLP_METRICS_ENDPOINT") == "" {
+			return nil, nil
+		}
+	default:
+		return nil, errors.Errorf("unsupported opentelemetry metrics exporter %v", n)
+	}
+
+	proto := os.Getenv("OTEL_EXPORTER_OTLP_METRICS_PROTOCOL")
+	if proto == "" {
+		proto = os.Getenv("OTEL_EXPORTER_OTLP_PROTOCOL")
+	}
+	if proto != "" && proto != "grpc" {
+		return nil, errors.Errorf("unsupported otlp metrics protocol %v", proto)
+	}
+	return otlpmetric.New(ctx, otlpmetricgrpc.NewClient())
+}
+
+func exportInterval() (time.Duration, error) {
+	v := os.Getenv("OTEL_METRIC_EXPORT_INTERVAL")
+	if v == "" {
+		return defaultExportInterval, nil
+	}
+	ms, err := strconv.ParseInt(v, 10, 64)
+	if err != nil || ms <= 0 {
+		return 0, errors.Errorf("invalid OTEL_METRIC_EXPORT_INTERVAL %q", v)
+	}
+	return time.Duration(ms) * time.Millisecond, nil
+}
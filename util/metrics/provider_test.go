@@ -0,0 +1,51 @@
+package metrics
+
+import (
+	"context"
+	"io"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+func TestPrometheusHandler(t *testing.T) {
+	t.Setenv("OTEL_METRICS_EXPORTER", "none")
+
+	p, err := NewProvider(context.TODO(), resource.Empty())
+	require.NoError(t, err)
+	defer p.Shutdown(context.TODO())
+
+	ctx := context.TODO()
+	RecordSolve(ctx, 2*time.Second, nil)
+	RecordSolve(ctx, time.Second, errors.New("failed"))
+	RecordCacheLookup(ctx, "exec", true)
+	RecordCacheLookup(ctx, "exec", false)
+	RecordCacheLookup(ctx, "exec", false)
+	RecordPulledBytes(ctx, 100)
+	SessionStarted(ctx)
+
+	unregister := RegisterSnapshotUsage(func(context.Context) (int64, int64) {
+		return 3, 4096
+	})
+	defer unregister()
+
+	rec := httptest.NewRecorder()
+	p.Handler().ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	dt, err := io.ReadAll(rec.Body)
+	require.NoError(t, err)
+	out := string(dt)
+
+	require.Contains(t, out, `buildkit_solve_count{status="success"} 1`)
+	require.Contains(t, out, `buildkit_solve_count{status="error"} 1`)
+	require.Contains(t, out, `buildkit_solve_duration_count{status="success"} 1`)
+	require.Contains(t, out, `buildkit_cache_lookups{op_type="exec",result="hit"} 1`)
+	require.Contains(t, out, `buildkit_cache_lookups{op_type="exec",result="miss"} 2`)
+	require.Contains(t, out, `buildkit_pull_bytes 100`)
+	require.Contains(t, out, `buildkit_sessions_active 1`)
+	require.Contains(t, out, `buildkit_snapshots_count 3`)
+	require.Contains(t, out, `buildkit_snapshots_size 4096`)
+}
@@ -0,0 +1,21 @@
+// Package bridgeprovider provides network namespaces connected to a bridge
+// that is managed by buildkitd itself, without CNI plugins and configuration.
+package bridgeprovider
+
+const (
+	// DefaultBridgeName is the name of the bridge if none is configured.
+	DefaultBridgeName = "buildkit0"
+	// DefaultSubnet is the subnet of the bridge if none is configured.
+	DefaultSubnet = "10.10.0.0/16"
+)
+
+type Opt struct {
+	// Root is the buildkitd root where the namespaces and the address
+	// allocations are stored
+	Root string
+	// BridgeName is the name of the bridge, created if it does not exist
+	BridgeName string
+	// Subnet is the IPv4 subnet the addresses of the namespaces are
+	// allocated from. The first address is assigned to the bridge.
+	Subnet string
+}
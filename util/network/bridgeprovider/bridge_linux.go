@@ -0,0 +1,349 @@
+//go:build linux
+// +build linux
+
+package bridgeprovider
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+	"syscall"
+
+	"github.com/containerd/containerd/oci"
+	"github.com/coreos/go-iptables/iptables"
+	"github.com/moby/buildkit/identity"
+	"github.com/moby/buildkit/util/network"
+	specs "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/vishvananda/netlink"
+	"github.com/vishvananda/netns"
+	"golang.org/x/sys/unix"
+)
+
+var (
+	providersMu sync.Mutex
+	// providers are shared by bridge name so that multiple workers of the
+	// same daemon can use the same bridge without conflicting allocations
+	providers = map[string]*bridgeProvider{}
+)
+
+// New returns a network provider that connects every namespace to a bridge
+// with an address from opt.Subnet. Traffic leaving the subnet is masqueraded.
+func New(opt Opt) (network.Provider, error) {
+	if opt.BridgeName == "" {
+		opt.BridgeName = DefaultBridgeName
+	}
+	if opt.Subnet == "" {
+		opt.Subnet = DefaultSubnet
+	}
+	_, subnet, err := net.ParseCIDR(opt.Subnet)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid bridge subnet %q", opt.Subnet)
+	}
+
+	providersMu.Lock()
+	defer providersMu.Unlock()
+
+	if p, ok := providers[opt.BridgeName]; ok {
+		if p.subnet.String() != subnet.String() {
+			return nil, errors.Errorf("bridge %s is already used with subnet %s", opt.BridgeName, p.subnet)
+		}
+		p.refs++
+		return p, nil
+	}
+
+	root := filepath.Join(opt.Root, "net/bridge", opt.BridgeName)
+	if err := os.MkdirAll(root, 0700); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	ipam, err := newIPAM(filepath.Join(root, "ipam.json"), subnet)
+	if err != nil {
+		return nil, err
+	}
+	p := &bridgeProvider{
+		root:   root,
+		name:   opt.BridgeName,
+		subnet: ipam.subnet,
+		ipam:   ipam,
+		refs:   1,
+	}
+	// namespaces allocated by a previous run of the daemon are not in use
+	for _, id := range ipam.ids() {
+		if err := p.release(id); err != nil {
+			logrus.Warnf("failed to release stale network namespace %s: %v", id, err)
+		}
+	}
+	if err := p.setupBridge(); err != nil {
+		return nil, errors.Wrapf(err, "failed to set up bridge %s", p.name)
+	}
+	if err := p.setupNAT(); err != nil {
+		return nil, errors.Wrapf(err, "failed to set up NAT for bridge %s", p.name)
+	}
+	providers[opt.BridgeName] = p
+	return p, nil
+}
+
+type bridgeProvider struct {
+	root   string
+	name   string
+	subnet *net.IPNet
+	ipam   *ipam
+	bridge netlink.Link
+	// refs is protected by providersMu
+	refs int
+}
+
+func (p *bridgeProvider) setupBridge() error {
+	link, err := netlink.LinkByName(p.name)
+	if err != nil {
+		if _, ok := err.(netlink.LinkNotFoundError); !ok {
+			return errors.WithStack(err)
+		}
+		br := &netlink.Bridge{LinkAttrs: netlink.LinkAttrs{Name: p.name}}
+		if err := netlink.LinkAdd(br); err != nil && !errors.Is(err, unix.EEXIST) {
+			return errors.Wrap(err, "failed to create bridge")
+		}
+		if link, err = netlink.LinkByName(p.name); err != nil {
+			return errors.WithStack(err)
+		}
+	}
+	if _, ok := link.(*netlink.Bridge); !ok {
+		return errors.Errorf("%s exists and is not a bridge", p.name)
+	}
+	addr := &netlink.Addr{IPNet: &net.IPNet{IP: p.ipam.gateway, Mask: p.subnet.Mask}}
+	if err := netlink.AddrReplace(link, addr); err != nil {
+		return errors.Wrapf(err, "failed to assign %s", addr.IPNet)
+	}
+	if err := netlink.LinkSetUp(link); err != nil {
+		return errors.WithStack(err)
+	}
+	p.bridge = link
+	return nil
+}
+
+func (p *bridgeProvider) setupNAT() error {
+	if err := os.WriteFile("/proc/sys/net/ipv4/ip_forward", []byte("1"), 0644); err != nil {
+		return errors.Wrap(err, "failed to enable IP forwarding")
+	}
+	ipt, err := iptables.New()
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	rules := []struct {
+		table, chain string
+		spec         []string
+	}{
+		{"nat", "POSTROUTING", []string{"-s", p.subnet.String(), "!", "-o", p.name, "-j", "MASQUERADE"}},
+		{"filter", "FORWARD", []string{"-i", p.name, "-j", "ACCEPT"}},
+		{"filter", "FORWARD", []string{"-o", p.name, "-m", "conntrack", "--ctstate", "RELATED,ESTABLISHED", "-j", "ACCEPT"}},
+	}
+	for _, r := range rules {
+		exists, err := ipt.Exists(r.table, r.chain, r.spec...)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		if exists {
+			continue
+		}
+		// insert first so the rules take effect before any DROP policy
+		if err := ipt.Insert(r.table, r.chain, 1, r.spec...); err != nil {
+			return errors.WithStack(err)
+		}
+	}
+	return nil
+}
+
+func (p *bridgeProvider) New() (network.Namespace, error) {
+	id := identity.NewID()
+	ip, err := p.ipam.allocate(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.setup(id, ip); err != nil {
+		if err1 := p.release(id); err1 != nil {
+			logrus.Warnf("failed to release network namespace %s: %v", id, err1)
+		}
+		return nil, err
+	}
+	return &bridgeNS{provider: p, id: id}, nil
+}
+
+func (p *bridgeProvider) Close() error {
+	providersMu.Lock()
+	defer providersMu.Unlock()
+	p.refs--
+	if p.refs == 0 {
+		// the bridge and the NAT rules are kept for the next run, the
+		// namespaces are released when they are closed
+		delete(providers, p.name)
+	}
+	return nil
+}
+
+func (p *bridgeProvider) nsPath(id string) string {
+	return filepath.Join(p.root, id)
+}
+
+// setup creates the namespace id and connects it to the bridge with a veth
+// pair.
+func (p *bridgeProvider) setup(id string, ip net.IP) error {
+	nsPath := p.nsPath(id)
+	if err := createNetNS(nsPath); err != nil {
+		return err
+	}
+	nsh, err := netns.GetFromPath(nsPath)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer nsh.Close()
+
+	hostName, peerName := vethNames(id)
+	veth := &netlink.Veth{
+		LinkAttrs: netlink.LinkAttrs{
+			Name:        hostName,
+			MTU:         p.bridge.Attrs().MTU,
+			MasterIndex: p.bridge.Attrs().Index,
+		},
+		PeerName: peerName,
+	}
+	if err := netlink.LinkAdd(veth); err != nil {
+		return errors.Wrap(err, "failed to create veth pair")
+	}
+	host, err := netlink.LinkByName(hostName)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	if err := netlink.LinkSetUp(host); err != nil {
+		return errors.WithStack(err)
+	}
+	peer, err := netlink.LinkByName(peerName)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	if err := netlink.LinkSetNsFd(peer, int(nsh)); err != nil {
+		return errors.Wrap(err, "failed to move veth to namespace")
+	}
+
+	h, err := netlink.NewHandleAt(nsh)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer h.Delete()
+
+	if peer, err = h.LinkByName(peerName); err != nil {
+		return errors.WithStack(err)
+	}
+	if err := h.LinkSetName(peer, "eth0"); err != nil {
+		return errors.WithStack(err)
+	}
+	if err := h.AddrAdd(peer, &netlink.Addr{IPNet: &net.IPNet{IP: ip, Mask: p.subnet.Mask}}); err != nil {
+		return errors.Wrapf(err, "failed to assign %s", ip)
+	}
+	if err := h.LinkSetUp(peer); err != nil {
+		return errors.WithStack(err)
+	}
+	lo, err := h.LinkByName("lo")
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	if err := h.LinkSetUp(lo); err != nil {
+		return errors.WithStack(err)
+	}
+	if err := h.RouteAdd(&netlink.Route{LinkIndex: peer.Attrs().Index, Gw: p.ipam.gateway}); err != nil {
+		return errors.Wrap(err, "failed to add default route")
+	}
+	return nil
+}
+
+// release removes the veth pair and the namespace id and frees its address.
+func (p *bridgeProvider) release(id string) error {
+	var err error
+	hostName, _ := vethNames(id)
+	if link, err1 := netlink.LinkByName(hostName); err1 == nil {
+		// deleting one end of the pair deletes the other one
+		if err1 := netlink.LinkDel(link); err1 != nil {
+			err = errors.Wrapf(err1, "failed to delete %s", hostName)
+		}
+	}
+	nsPath := p.nsPath(id)
+	if err1 := unix.Unmount(nsPath, unix.MNT_DETACH); err1 != nil && err1 != syscall.EINVAL && err1 != syscall.ENOENT && err == nil {
+		err = errors.Wrap(err1, "error unmounting network namespace")
+	}
+	if err1 := os.Remove(nsPath); err1 != nil && !errors.Is(err1, os.ErrNotExist) && err == nil {
+		err = errors.Wrapf(err1, "error removing network namespace %s", nsPath)
+	}
+	if err1 := p.ipam.release(id); err1 != nil && err == nil {
+		err = err1
+	}
+	return err
+}
+
+type bridgeNS struct {
+	provider *bridgeProvider
+	id       string
+}
+
+func (ns *bridgeNS) Set(s *specs.Spec) error {
+	return oci.WithLinuxNamespace(specs.LinuxNamespace{
+		Type: specs.NetworkNamespace,
+		Path: ns.provider.nsPath(ns.id),
+	})(nil, nil, nil, s)
+}
+
+func (ns *bridgeNS) Close() error {
+	return ns.provider.release(ns.id)
+}
+
+// vethNames returns the names of the host and the namespace ends of the veth
+// pair of namespace id. Interface names are limited to 15 characters.
+func vethNames(id string) (string, string) {
+	return "bk" + id[:13], "bkp" + id[:12]
+}
+
+// createNetNS creates a new network namespace and bind mounts it to nsPath.
+func createNetNS(nsPath string) (err error) {
+	f, err := os.Create(nsPath)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(nsPath)
+		return errors.WithStack(err)
+	}
+	defer func() {
+		if err != nil {
+			os.Remove(nsPath)
+		}
+	}()
+
+	// the namespace is entered on the current thread only
+	runtime.LockOSThread()
+	origin, err := netns.Get()
+	if err != nil {
+		runtime.UnlockOSThread()
+		return errors.WithStack(err)
+	}
+	defer origin.Close()
+
+	newns, err := netns.New()
+	if err != nil {
+		if err1 := netns.Set(origin); err1 != nil {
+			// keep the thread locked so that it exits instead of being reused
+			return errors.Wrap(err1, "failed to restore network namespace")
+		}
+		runtime.UnlockOSThread()
+		return errors.Wrap(err, "failed to create network namespace")
+	}
+	newns.Close()
+
+	err = unix.Mount(fmt.Sprintf("/proc/self/task/%d/ns/net", unix.Gettid()), nsPath, "none", unix.MS_BIND, "")
+	if err1 := netns.Set(origin); err1 != nil {
+		return errors.Wrap(err1, "failed to restore network namespace")
+	}
+	runtime.UnlockOSThread()
+	return errors.Wrapf(err, "failed to mount %s", nsPath)
+}
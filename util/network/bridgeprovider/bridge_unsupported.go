@@ -0,0 +1,13 @@
+//go:build !linux
+// +build !linux
+
+package bridgeprovider
+
+import (
+	"github.com/moby/buildkit/util/network"
+	"github.com/pkg/errors"
+)
+
+func New(opt Opt) (network.Provider, error) {
+	return nil, errors.New("bridge network is only supported on linux")
+}
@@ -0,0 +1,136 @@
+package bridgeprovider
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"net"
+	"os"
+	"sync"
+
+	"github.com/containerd/continuity"
+	"github.com/pkg/errors"
+)
+
+// ipam allocates the addresses of the namespaces from the subnet of the
+// bridge. The allocations are stored in a file so that the namespaces left
+// over by a previous run can be released.
+type ipam struct {
+	path    string
+	subnet  *net.IPNet
+	gateway net.IP
+
+	mu    sync.Mutex
+	state ipamState
+}
+
+type ipamState struct {
+	// Allocations maps the allocated addresses to the IDs of the namespaces.
+	Allocations map[string]string `json:"allocations"`
+	// Last is the last allocated address. Addresses are allocated round
+	// robin so that a released address is not reused right away.
+	Last string `json:"last,omitempty"`
+}
+
+func newIPAM(path string, subnet *net.IPNet) (*ipam, error) {
+	ip := subnet.IP.To4()
+	if ip == nil {
+		return nil, errors.Errorf("invalid subnet %s: only IPv4 is supported", subnet)
+	}
+	if ones, bits := subnet.Mask.Size(); bits-ones < 2 {
+		return nil, errors.Errorf("invalid subnet %s: too small", subnet)
+	}
+	i := &ipam{
+		path:    path,
+		subnet:  &net.IPNet{IP: ip, Mask: subnet.Mask},
+		gateway: uint32ToIP(ipToUint32(ip) + 1),
+		state: ipamState{
+			Allocations: map[string]string{},
+		},
+	}
+	dt, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return i, nil
+		}
+		return nil, errors.WithStack(err)
+	}
+	if err := json.Unmarshal(dt, &i.state); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse %s", path)
+	}
+	if i.state.Allocations == nil {
+		i.state.Allocations = map[string]string{}
+	}
+	return i, nil
+}
+
+// allocate returns a free address of the subnet for the namespace id.
+func (i *ipam) allocate(id string) (net.IP, error) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	ones, bits := i.subnet.Mask.Size()
+	first := ipToUint32(i.subnet.IP) + 2                         // skip the network and gateway addresses
+	last := ipToUint32(i.subnet.IP) + uint32(1)<<(bits-ones) - 2 // skip the broadcast address
+	next := first
+	if ip := net.ParseIP(i.state.Last).To4(); ip != nil && i.subnet.Contains(ip) {
+		next = ipToUint32(ip) + 1
+	}
+	for n := first; n <= last; n++ {
+		if next > last || next < first {
+			next = first
+		}
+		ip := uint32ToIP(next)
+		if _, ok := i.state.Allocations[ip.String()]; !ok {
+			i.state.Allocations[ip.String()] = id
+			i.state.Last = ip.String()
+			if err := i.save(); err != nil {
+				delete(i.state.Allocations, ip.String())
+				return nil, err
+			}
+			return ip, nil
+		}
+		next++
+	}
+	return nil, errors.Errorf("no free address in subnet %s", i.subnet)
+}
+
+// release frees the addresses allocated for the namespace id.
+func (i *ipam) release(id string) error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	for ip, v := range i.state.Allocations {
+		if v == id {
+			delete(i.state.Allocations, ip)
+		}
+	}
+	return i.save()
+}
+
+// ids returns the IDs of the namespaces with allocated addresses.
+func (i *ipam) ids() []string {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	ids := make([]string, 0, len(i.state.Allocations))
+	for _, id := range i.state.Allocations {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+func (i *ipam) save() error {
+	dt, err := json.Marshal(i.state)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	return continuity.AtomicWriteFile(i.path, dt, 0600)
+}
+
+func ipToUint32(ip net.IP) uint32 {
+	return binary.BigEndian.Uint32(ip.To4())
+}
+
+func uint32ToIP(n uint32) net.IP {
+	ip := make(net.IP, 4)
+	binary.BigEndian.PutUint32(ip, n)
+	return ip
+}
@@ -0,0 +1,56 @@
+package bridgeprovider
+
+import (
+	"net"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIPAM(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ipam.json")
+	_, subnet, err := net.ParseCIDR("10.10.0.0/29")
+	require.NoError(t, err)
+
+	i, err := newIPAM(path, subnet)
+	require.NoError(t, err)
+	require.Equal(t, "10.10.0.1", i.gateway.String())
+
+	var ips []string
+	for _, id := range []string{"a", "b", "c", "d", "e"} {
+		ip, err := i.allocate(id)
+		require.NoError(t, err)
+		ips = append(ips, ip.String())
+	}
+	require.Equal(t, []string{"10.10.0.2", "10.10.0.3", "10.10.0.4", "10.10.0.5", "10.10.0.6"}, ips)
+
+	_, err = i.allocate("f")
+	require.Error(t, err)
+
+	// released addresses are reused after the rest of the subnet
+	require.NoError(t, i.release("b"))
+	ip, err := i.allocate("f")
+	require.NoError(t, err)
+	require.Equal(t, "10.10.0.3", ip.String())
+
+	// allocations are restored from the state file
+	i, err = newIPAM(path, subnet)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"a", "c", "d", "e", "f"}, i.ids())
+
+	require.NoError(t, i.release("c"))
+	ip, err = i.allocate("g")
+	require.NoError(t, err)
+	require.Equal(t, "10.10.0.4", ip.String())
+}
+
+func TestIPAMInvalidSubnet(t *testing.T) {
+	dir := t.TempDir()
+	for _, s := range []string{"fd00::/64", "10.10.0.0/31"} {
+		_, subnet, err := net.ParseCIDR(s)
+		require.NoError(t, err)
+		_, err = newIPAM(filepath.Join(dir, "ipam.json"), subnet)
+		require.Error(t, err, s)
+	}
+}
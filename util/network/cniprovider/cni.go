@@ -4,6 +4,7 @@ import (
 	"context"
 	"os"
 	"runtime"
+	"time"
 
 	cni "github.com/containerd/go-cni"
 	"github.com/gofrs/flock"
@@ -17,6 +18,12 @@ type Opt struct {
 	Root       string
 	ConfigPath string
 	BinaryDir  string
+	// PoolSize is the number of network namespaces that are kept ready to be
+	// used, zero disables the pool
+	PoolSize int
+	// PoolMaxAge is the age after which a namespace is no longer reused, zero
+	// for no limit
+	PoolMaxAge time.Duration
 }
 
 func New(opt Opt) (network.Provider, error) {
@@ -46,12 +53,16 @@ func New(opt Opt) (network.Provider, error) {
 	if err := cp.initNetwork(); err != nil {
 		return nil, err
 	}
+	if opt.PoolSize > 0 {
+		cp.pool = newPool(cp.newNS, opt.PoolSize, opt.PoolMaxAge)
+	}
 	return cp, nil
 }
 
 type cniProvider struct {
 	cni.CNI
 	root string
+	pool *cniPool
 }
 
 func (c *cniProvider) initNetwork() error {
@@ -70,6 +81,21 @@ func (c *cniProvider) initNetwork() error {
 }
 
 func (c *cniProvider) New() (network.Namespace, error) {
+	if c.pool != nil {
+		return c.pool.get()
+	}
+	return c.newNS()
+}
+
+// Close releases the pooled network namespaces.
+func (c *cniProvider) Close() error {
+	if c.pool != nil {
+		return c.pool.close()
+	}
+	return nil
+}
+
+func (c *cniProvider) newNS() (*cniNS, error) {
 	id := identity.NewID()
 	nativeID, err := createNetNS(c, id)
 	if err != nil {
@@ -81,20 +107,41 @@ func (c *cniProvider) New() (network.Namespace, error) {
 		return nil, errors.Wrap(err, "CNI setup error")
 	}
 
-	return &cniNS{nativeID: nativeID, id: id, handle: c.CNI}, nil
+	return &cniNS{nativeID: nativeID, id: id, handle: c.CNI, created: time.Now()}, nil
 }
 
 type cniNS struct {
+	// pool is set if the namespace is returned to the pool on Close
+	pool     *cniPool
 	handle   cni.CNI
 	id       string
 	nativeID string
+	created  time.Time
+	// privileged is set if a container could change the configuration of the
+	// namespace, which is then not reused
+	privileged bool
 }
 
 func (ns *cniNS) Set(s *specs.Spec) error {
+	if s.Process != nil && s.Process.Capabilities != nil {
+		for _, c := range s.Process.Capabilities.Bounding {
+			if c == "CAP_NET_ADMIN" || c == "CAP_SYS_ADMIN" {
+				ns.privileged = true
+			}
+		}
+	}
 	return setNetNS(s, ns.nativeID)
 }
 
 func (ns *cniNS) Close() error {
+	if ns.pool != nil {
+		return ns.pool.put(ns)
+	}
+	return ns.release()
+}
+
+// release removes the CNI attachment and deletes the namespace.
+func (ns *cniNS) release() error {
 	err := ns.handle.Remove(context.TODO(), ns.id, ns.nativeID)
 	if err1 := unmountNetNS(ns.nativeID); err1 != nil && err == nil {
 		err = err1
@@ -0,0 +1,157 @@
+package cniprovider
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/moby/buildkit/util/bklog"
+	"github.com/moby/buildkit/util/metrics"
+)
+
+// cniPool keeps network namespaces with their CNI attachment ready so that
+// they don't need to be set up for every container. Closed namespaces are
+// returned to the pool and reused until they reach the max age, unless they
+// were used by a container that could change their configuration or the CNI
+// plugins report that their configuration changed.
+type cniPool struct {
+	newNS  func() (*cniNS, error)
+	size   int
+	maxAge time.Duration
+
+	mu        sync.Mutex
+	available []*cniNS
+	filling   bool
+	closed    bool
+}
+
+func newPool(newNS func() (*cniNS, error), size int, maxAge time.Duration) *cniPool {
+	pool := &cniPool{
+		newNS:  newNS,
+		size:   size,
+		maxAge: maxAge,
+	}
+	go pool.fill()
+	return pool
+}
+
+func (pool *cniPool) get() (*cniNS, error) {
+	var ns *cniNS
+	var expired []*cniNS
+	pool.mu.Lock()
+	for ns == nil && len(pool.available) > 0 {
+		// the oldest namespace is used first so that all of them are cycled
+		n := pool.available[0]
+		pool.available = pool.available[1:]
+		if pool.isExpired(n) {
+			expired = append(expired, n)
+			continue
+		}
+		ns = n
+	}
+	pool.mu.Unlock()
+
+	if len(expired) > 0 {
+		go release(expired)
+	}
+	go pool.fill()
+
+	metrics.RecordNetworkPoolLookup(context.TODO(), ns != nil)
+	if ns != nil {
+		return ns, nil
+	}
+	ns, err := pool.newNS()
+	if err != nil {
+		return nil, err
+	}
+	ns.pool = pool
+	return ns, nil
+}
+
+func (pool *cniPool) put(ns *cniNS) error {
+	if !pool.canPut(ns) {
+		return ns.release()
+	}
+	// the CNI plugins verify that the interfaces, addresses and routes of the
+	// namespace are still as they were set up
+	if err := ns.handle.Check(context.TODO(), ns.id, ns.nativeID); err != nil {
+		bklog.L.Debugf("not reusing network namespace %s: %v", ns.nativeID, err)
+		return ns.release()
+	}
+
+	pool.mu.Lock()
+	if pool.canPutLocked(ns) {
+		pool.available = append(pool.available, ns)
+		pool.mu.Unlock()
+		return nil
+	}
+	pool.mu.Unlock()
+	return ns.release()
+}
+
+func (pool *cniPool) canPut(ns *cniNS) bool {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+	return pool.canPutLocked(ns)
+}
+
+func (pool *cniPool) canPutLocked(ns *cniNS) bool {
+	return !ns.privileged && !pool.closed && len(pool.available) < pool.size && !pool.isExpired(ns)
+}
+
+// fill creates namespaces until the pool has reached its size.
+func (pool *cniPool) fill() {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+	if pool.filling {
+		return
+	}
+	pool.filling = true
+	defer func() {
+		pool.filling = false
+	}()
+
+	for !pool.closed && len(pool.available) < pool.size {
+		pool.mu.Unlock()
+		ns, err := pool.newNS()
+		pool.mu.Lock()
+		if err != nil {
+			bklog.L.Errorf("failed to create network namespace for pool: %+v", err)
+			return
+		}
+		ns.pool = pool
+		if pool.closed {
+			go release([]*cniNS{ns})
+			return
+		}
+		pool.available = append(pool.available, ns)
+	}
+}
+
+func (pool *cniPool) isExpired(ns *cniNS) bool {
+	return pool.maxAge > 0 && time.Since(ns.created) > pool.maxAge
+}
+
+// close releases the available namespaces. Namespaces that are in use are
+// released when they are closed.
+func (pool *cniPool) close() error {
+	pool.mu.Lock()
+	pool.closed = true
+	available := pool.available
+	pool.available = nil
+	pool.mu.Unlock()
+	return release(available)
+}
+
+func release(nss []*cniNS) error {
+	var rerr error
+	for _, ns := range nss {
+		if err := ns.release(); err != nil {
+			bklog.L.Errorf("failed to release network namespace %s: %+v", ns.nativeID, err)
+			if rerr == nil {
+				rerr = err
+			}
+		}
+	}
+	return rerr
+}
@@ -0,0 +1,159 @@
+package cniprovider
+
+import (
+	"context"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	cni "github.com/containerd/go-cni"
+	specs "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+type testCNI struct {
+	cni.CNI
+	mu      sync.Mutex
+	removed []string
+	// changed are the namespaces that fail the check
+	changed map[string]bool
+}
+
+func (c *testCNI) Check(ctx context.Context, id string, path string, opts ...cni.NamespaceOpts) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.changed[id] {
+		return errors.Errorf("interface of %s changed", id)
+	}
+	return nil
+}
+
+func (c *testCNI) Remove(ctx context.Context, id string, path string, opts ...cni.NamespaceOpts) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.removed = append(c.removed, id)
+	return nil
+}
+
+func (c *testCNI) numRemoved() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.removed)
+}
+
+func TestPool(t *testing.T) {
+	dir := t.TempDir()
+	handle := &testCNI{}
+	var mu sync.Mutex
+	var created int
+	newNS := func() (*cniNS, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		created++
+		id := strconv.Itoa(created)
+		return &cniNS{handle: handle, id: id, nativeID: filepath.Join(dir, id), created: time.Now()}, nil
+	}
+	numCreated := func() int {
+		mu.Lock()
+		defer mu.Unlock()
+		return created
+	}
+
+	pool := newPool(newNS, 2, 0)
+	require.Eventually(t, func() bool { return numCreated() == 2 }, 5*time.Second, 10*time.Millisecond)
+
+	ns1, err := pool.get()
+	require.NoError(t, err)
+	require.Equal(t, "1", ns1.id)
+	ns2, err := pool.get()
+	require.NoError(t, err)
+	require.Equal(t, "2", ns2.id)
+
+	// the pool is refilled in the background
+	require.Eventually(t, func() bool { return numCreated() == 4 }, 5*time.Second, 10*time.Millisecond)
+
+	// namespaces are released instead of returned when the pool is full
+	require.NoError(t, ns1.Close())
+	require.Equal(t, 1, handle.numRemoved())
+	require.NoError(t, ns2.Close())
+	require.Equal(t, 2, handle.numRemoved())
+
+	ns3, err := pool.get()
+	require.NoError(t, err)
+	require.Equal(t, "3", ns3.id)
+
+	require.NoError(t, pool.close())
+	require.Eventually(t, func() bool { return handle.numRemoved() >= 3 }, 5*time.Second, 10*time.Millisecond)
+
+	// namespaces in use are released when they are closed
+	require.NoError(t, ns3.Close())
+	require.Eventually(t, func() bool { return handle.numRemoved() == numCreated() }, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestPoolMaxAge(t *testing.T) {
+	dir := t.TempDir()
+	handle := &testCNI{}
+	var mu sync.Mutex
+	var created int
+	newNS := func() (*cniNS, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		created++
+		id := strconv.Itoa(created)
+		return &cniNS{handle: handle, id: id, nativeID: filepath.Join(dir, id), created: time.Now().Add(-time.Hour)}, nil
+	}
+
+	pool := newPool(newNS, 1, time.Minute)
+	require.Eventually(t, func() bool {
+		pool.mu.Lock()
+		defer pool.mu.Unlock()
+		return len(pool.available) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// the pooled namespace is too old and a new one is created
+	ns, err := pool.get()
+	require.NoError(t, err)
+	require.NotEqual(t, "1", ns.id)
+	require.Eventually(t, func() bool { return handle.numRemoved() == 1 }, 5*time.Second, 10*time.Millisecond)
+
+	require.NoError(t, pool.close())
+}
+
+func TestPoolNoReuse(t *testing.T) {
+	dir := t.TempDir()
+	handle := &testCNI{changed: map[string]bool{}}
+	// the pool is only filled by the closed namespaces
+	pool := newPool(func() (*cniNS, error) {
+		return nil, errors.New("no new namespaces")
+	}, 2, 0)
+	defer pool.close()
+	newNS := func(id string) *cniNS {
+		return &cniNS{pool: pool, handle: handle, id: id, nativeID: filepath.Join(dir, id), created: time.Now()}
+	}
+	unprivileged := &specs.Spec{Process: &specs.Process{Capabilities: &specs.LinuxCapabilities{Bounding: []string{"CAP_CHOWN"}}}}
+
+	// a namespace used by a container that could configure it is released
+	ns := newNS("1")
+	require.NoError(t, ns.Set(&specs.Spec{Process: &specs.Process{Capabilities: &specs.LinuxCapabilities{Bounding: []string{"CAP_CHOWN", "CAP_NET_ADMIN"}}}}))
+	require.NoError(t, ns.Close())
+	require.Equal(t, []string{"1"}, handle.removed)
+
+	// a namespace that fails the check is released
+	ns = newNS("2")
+	handle.changed["2"] = true
+	require.NoError(t, ns.Set(unprivileged))
+	require.NoError(t, ns.Close())
+	require.Equal(t, []string{"1", "2"}, handle.removed)
+
+	// an unchanged namespace is returned to the pool
+	ns = newNS("3")
+	require.NoError(t, ns.Set(unprivileged))
+	require.NoError(t, ns.Close())
+	require.Equal(t, []string{"1", "2"}, handle.removed)
+	pool.mu.Lock()
+	require.Equal(t, []*cniNS{ns}, pool.available)
+	pool.mu.Unlock()
+}
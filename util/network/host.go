@@ -19,6 +19,10 @@ func (h *host) New() (Namespace, error) {
 	return &hostNS{}, nil
 }
 
+func (h *host) Close() error {
+	return nil
+}
+
 type hostNS struct {
 }
 
@@ -5,13 +5,15 @@ import (
 
 	"github.com/moby/buildkit/solver/pb"
 	"github.com/moby/buildkit/util/network"
+	"github.com/moby/buildkit/util/network/bridgeprovider"
 	"github.com/moby/buildkit/util/network/cniprovider"
 	"github.com/pkg/errors"
 )
 
 type Opt struct {
-	CNI  cniprovider.Opt
-	Mode string
+	CNI    cniprovider.Opt
+	Bridge bridgeprovider.Opt
+	Mode   string
 }
 
 // Providers returns the network provider set.
@@ -26,6 +28,13 @@ func Providers(opt Opt) (providers map[pb.NetMode]network.Provider, resolvedMode
 		}
 		defaultProvider = cniProvider
 		resolvedMode = opt.Mode
+	case "bridge":
+		bridgeProvider, err := bridgeprovider.New(opt.Bridge)
+		if err != nil {
+			return nil, resolvedMode, err
+		}
+		defaultProvider = bridgeProvider
+		resolvedMode = opt.Mode
 	case "host":
 		hostProvider, ok := getHostProvider()
 		if !ok {
@@ -8,6 +8,7 @@ import (
 
 // Provider interface for Network
 type Provider interface {
+	io.Closer
 	New() (Namespace, error)
 }
 
@@ -15,6 +15,10 @@ func (h *none) New() (Namespace, error) {
 	return &noneNS{}, nil
 }
 
+func (h *none) Close() error {
+	return nil
+}
+
 type noneNS struct {
 }
 
@@ -0,0 +1,82 @@
+// Package peercred records the credentials of the processes connecting to a
+// unix socket so that the daemon can tell if a client runs on the same host.
+package peercred
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	"google.golang.org/grpc/peer"
+)
+
+// Creds are the credentials of the peer process of a unix socket connection
+// at the time it connected.
+type Creds struct {
+	PID int
+	UID int
+	GID int
+}
+
+// Addr is the remote address of a connection with known peer credentials.
+type Addr struct {
+	Creds Creds
+}
+
+func (a *Addr) Network() string {
+	return "unix"
+}
+
+func (a *Addr) String() string {
+	return fmt.Sprintf("pid=%d,uid=%d,gid=%d", a.Creds.PID, a.Creds.UID, a.Creds.GID)
+}
+
+// NewListener returns a listener whose unix socket connections report the
+// credentials of their peers as the remote address.
+func NewListener(l net.Listener) net.Listener {
+	return &listener{Listener: l}
+}
+
+type listener struct {
+	net.Listener
+}
+
+func (l *listener) Accept() (net.Conn, error) {
+	c, err := l.Listener.Accept()
+	if err != nil {
+		return nil, err
+	}
+	uc, ok := c.(*net.UnixConn)
+	if !ok {
+		return c, nil
+	}
+	creds, err := getCreds(uc)
+	if err != nil {
+		// the connection is still accepted but is not considered local
+		return c, nil
+	}
+	return &conn{Conn: c, addr: &Addr{Creds: *creds}}, nil
+}
+
+type conn struct {
+	net.Conn
+	addr *Addr
+}
+
+func (c *conn) RemoteAddr() net.Addr {
+	return c.addr
+}
+
+// FromContext returns the peer credentials of the client of a grpc request.
+func FromContext(ctx context.Context) (*Creds, bool) {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return nil, false
+	}
+	addr, ok := p.Addr.(*Addr)
+	if !ok {
+		return nil, false
+	}
+	creds := addr.Creds
+	return &creds, true
+}
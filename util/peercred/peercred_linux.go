@@ -0,0 +1,26 @@
+package peercred
+
+import (
+	"net"
+
+	"github.com/pkg/errors"
+	"golang.org/x/sys/unix"
+)
+
+func getCreds(c *net.UnixConn) (*Creds, error) {
+	rc, err := c.SyscallConn()
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	var ucred *unix.Ucred
+	var uerr error
+	if err := rc.Control(func(fd uintptr) {
+		ucred, uerr = unix.GetsockoptUcred(int(fd), unix.SOL_SOCKET, unix.SO_PEERCRED)
+	}); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	if uerr != nil {
+		return nil, errors.Wrap(uerr, "failed to get peer credentials")
+	}
+	return &Creds{PID: int(ucred.Pid), UID: int(ucred.Uid), GID: int(ucred.Gid)}, nil
+}
@@ -0,0 +1,31 @@
+package peercred
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestListener(t *testing.T) {
+	l, err := net.Listen("unix", filepath.Join(t.TempDir(), "test.sock"))
+	require.NoError(t, err)
+	l = NewListener(l)
+	defer l.Close()
+
+	c, err := net.Dial("unix", l.Addr().String())
+	require.NoError(t, err)
+	defer c.Close()
+
+	sc, err := l.Accept()
+	require.NoError(t, err)
+	defer sc.Close()
+
+	addr, ok := sc.RemoteAddr().(*Addr)
+	require.True(t, ok)
+	require.Equal(t, os.Getpid(), addr.Creds.PID)
+	require.Equal(t, os.Geteuid(), addr.Creds.UID)
+	require.Equal(t, os.Getegid(), addr.Creds.GID)
+}
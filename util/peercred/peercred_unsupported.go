@@ -0,0 +1,14 @@
+//go:build !linux
+// +build !linux
+
+package peercred
+
+import (
+	"net"
+
+	"github.com/pkg/errors"
+)
+
+func getCreds(c *net.UnixConn) (*Creds, error) {
+	return nil, errors.New("peer credentials are not supported on this platform")
+}
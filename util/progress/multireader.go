@@ -31,6 +31,15 @@ func (mr *MultiReader) Reader(ctx context.Context) Reader {
 	pw, _, ctx := NewFromContext(ctx)
 
 	w := pw.(*progressWriter)
+
+	select {
+	case <-mr.done:
+		w.Close()
+		closeWriter()
+		return pr
+	default:
+	}
+
 	mr.writers[w] = closeWriter
 
 	go func() {
@@ -61,6 +70,7 @@ func (mr *MultiReader) handle() error {
 					w.Close()
 					c()
 				}
+				close(mr.done)
 				mr.mu.Unlock()
 				return nil
 			}
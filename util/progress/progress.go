@@ -104,6 +104,16 @@ type Status struct {
 	Total     int
 	Started   *time.Time
 	Completed *time.Time
+	Resources *ResourceUsage
+}
+
+// ResourceUsage is the cgroup usage of a process reported with a status.
+type ResourceUsage struct {
+	MemoryPeak   int64
+	CPUNanos     int64
+	IOReadBytes  int64
+	IOWriteBytes int64
+	Pids         int64
 }
 
 type progressReader struct {
@@ -132,3 +132,29 @@ func saveProgress(ctx context.Context, pr Reader, t *trace) error {
 		t.items = append(t.items, p...)
 	}
 }
+
+func TestMultiReaderClosed(t *testing.T) {
+	t.Parallel()
+
+	pr, ctx, cancelProgress := NewContext(context.Background())
+	mr := NewMultiReader(pr)
+
+	var trace1 trace
+	eg, _ := errgroup.WithContext(context.Background())
+	pr1 := mr.Reader(context.Background())
+	eg.Go(func() error {
+		return saveProgress(context.Background(), pr1, &trace1)
+	})
+
+	_, err := calc(ctx, 3, "calc")
+	assert.NoError(t, err)
+
+	cancelProgress()
+	assert.NoError(t, eg.Wait())
+	assert.True(t, len(trace1.items) > 0)
+
+	// reader attached after the main reader was closed is closed right away
+	var trace2 trace
+	assert.NoError(t, saveProgress(context.Background(), mr.Reader(context.Background()), &trace2))
+	assert.Equal(t, 0, len(trace2.items))
+}
@@ -650,7 +650,10 @@ func (t *trace) displayInfo() (d displayInfo) {
 				isCompleted: s.Completed != nil,
 				name:        v.indent + "=> " + s.ID,
 			}
-			if s.Total != 0 {
+			if s.Resources != nil {
+				j.name = v.indent + "=> " + s.Name
+				j.status = formatResourceUsage(s.Resources)
+			} else if s.Total != 0 {
 				j.status = fmt.Sprintf("%.2f / %.2f", units.Bytes(s.Current), units.Bytes(s.Total))
 			} else if s.Current != 0 {
 				j.status = fmt.Sprintf("%.2f", units.Bytes(s.Current))
@@ -761,6 +764,11 @@ func setupTerminals(jobs []*job, height int, all bool) []*job {
 	return jobs
 }
 
+// formatResourceUsage formats the cgroup usage reported with a status.
+func formatResourceUsage(r *client.ResourceUsage) string {
+	return fmt.Sprintf("cpu %.1fs mem %.2f io %.2f / %.2f", r.CPUTime.Seconds(), units.Bytes(r.MemoryPeak), units.Bytes(r.IOReadBytes), units.Bytes(r.IOWriteBytes))
+}
+
 func (disp *display) print(d displayInfo, width, height int, all bool) {
 	// this output is inspired by Buck
 	d.jobs = setupTerminals(d.jobs, height, all)
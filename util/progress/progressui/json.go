@@ -0,0 +1,140 @@
+package progressui
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"time"
+
+	"github.com/moby/buildkit/client"
+	digest "github.com/opencontainers/go-digest"
+)
+
+// DisplayRawJSON writes every status received on ch to w as a line of JSON.
+func DisplayRawJSON(ctx context.Context, w io.Writer, ch chan *client.SolveStatus) error {
+	enc := json.NewEncoder(w)
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case ss, ok := <-ch:
+			if !ok {
+				return nil
+			}
+			if err := enc.Encode(ss); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// EventType is the type of an Event.
+type EventType string
+
+const (
+	EventStarted   EventType = "started"
+	EventCached    EventType = "cached"
+	EventCompleted EventType = "completed"
+	EventErrored   EventType = "errored"
+	EventLog       EventType = "log"
+)
+
+// Event is a line written by DisplayEvents. Unlike the statuses written by
+// DisplayRawJSON, the format of the events is kept stable so that it can be
+// consumed by other tools.
+type Event struct {
+	Time   time.Time     `json:"time"`
+	Type   EventType     `json:"type"`
+	Vertex digest.Digest `json:"vertex"`
+	Name   string        `json:"name,omitempty"`
+	// Duration is the time in seconds between the start and the completion
+	// of the vertex.
+	Duration float64 `json:"duration,omitempty"`
+	// Error is set for errored events.
+	Error string `json:"error,omitempty"`
+	// Stream and Data are set for log events. Stream is 1 for stdout and 2
+	// for stderr.
+	Stream int    `json:"stream,omitempty"`
+	Data   string `json:"data,omitempty"`
+}
+
+// DisplayEvents writes an Event to w as a line of JSON when a vertex starts
+// and completes and for every chunk of log of a vertex.
+func DisplayEvents(ctx context.Context, w io.Writer, ch chan *client.SolveStatus) error {
+	enc := json.NewEncoder(w)
+	vertexes := map[digest.Digest]*client.Vertex{}
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case ss, ok := <-ch:
+			if !ok {
+				return nil
+			}
+			for _, e := range solveStatusEvents(vertexes, ss) {
+				if err := enc.Encode(e); err != nil {
+					return err
+				}
+			}
+		}
+	}
+}
+
+// solveStatusEvents returns the events of a status. vertexes holds the last
+// known state of every vertex and is updated with the vertexes of ss.
+func solveStatusEvents(vertexes map[digest.Digest]*client.Vertex, ss *client.SolveStatus) []Event {
+	var events []Event
+	for _, v := range ss.Vertexes {
+		prev := vertexes[v.Digest]
+		vertexes[v.Digest] = v
+
+		if v.Started != nil && !v.Cached && (prev == nil || !sameTime(prev.Started, v.Started)) {
+			events = append(events, Event{
+				Time:   *v.Started,
+				Type:   EventStarted,
+				Vertex: v.Digest,
+				Name:   v.Name,
+			})
+		}
+		if v.Completed != nil && (prev == nil || !sameTime(prev.Completed, v.Completed)) {
+			e := Event{
+				Time:   *v.Completed,
+				Type:   EventCompleted,
+				Vertex: v.Digest,
+				Name:   v.Name,
+			}
+			switch {
+			case v.Error != "":
+				e.Type = EventErrored
+				e.Error = v.Error
+			case v.Cached:
+				e.Type = EventCached
+			}
+			if v.Started != nil {
+				e.Duration = v.Completed.Sub(*v.Started).Seconds()
+			}
+			events = append(events, e)
+		}
+	}
+	for _, l := range ss.Logs {
+		e := Event{
+			Time:   l.Timestamp,
+			Type:   EventLog,
+			Vertex: l.Vertex,
+			Stream: l.Stream,
+			Data:   string(l.Data),
+		}
+		if v, ok := vertexes[l.Vertex]; ok {
+			e.Name = v.Name
+		}
+		events = append(events, e)
+	}
+	return events
+}
+
+func sameTime(t1, t2 *time.Time) bool {
+	if t1 == nil || t2 == nil {
+		return t1 == t2
+	}
+	return t1.Equal(*t2)
+}
@@ -0,0 +1,82 @@
+package progressui
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/moby/buildkit/client"
+	digest "github.com/opencontainers/go-digest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDisplayEvents(t *testing.T) {
+	t0 := time.Unix(100, 0).UTC()
+	t1 := t0.Add(2 * time.Second)
+	t2 := t0.Add(5 * time.Second)
+	dgstA := digest.FromString("a")
+	dgstB := digest.FromString("b")
+	dgstC := digest.FromString("c")
+
+	ch := make(chan *client.SolveStatus, 4)
+	ch <- &client.SolveStatus{
+		Vertexes: []*client.Vertex{
+			{Digest: dgstA, Name: "a", Started: &t0},
+			{Digest: dgstB, Name: "b", Started: &t0, Completed: &t0, Cached: true},
+		},
+	}
+	ch <- &client.SolveStatus{
+		// repeated vertexes without changes don't create events
+		Vertexes: []*client.Vertex{
+			{Digest: dgstA, Name: "a", Started: &t0},
+		},
+		Logs: []*client.VertexLog{
+			{Vertex: dgstA, Stream: 1, Data: []byte("hello\n"), Timestamp: t1},
+		},
+	}
+	ch <- &client.SolveStatus{
+		Vertexes: []*client.Vertex{
+			{Digest: dgstA, Name: "a", Started: &t0, Completed: &t2},
+			{Digest: dgstC, Name: "c", Started: &t1, Completed: &t2, Error: "failed"},
+		},
+	}
+	close(ch)
+
+	buf := &bytes.Buffer{}
+	require.NoError(t, DisplayEvents(context.TODO(), buf, ch))
+
+	var events []Event
+	dec := json.NewDecoder(buf)
+	for dec.More() {
+		var e Event
+		require.NoError(t, dec.Decode(&e))
+		events = append(events, e)
+	}
+	require.Equal(t, []Event{
+		{Time: t0, Type: EventStarted, Vertex: dgstA, Name: "a"},
+		{Time: t0, Type: EventCached, Vertex: dgstB, Name: "b"},
+		{Time: t1, Type: EventLog, Vertex: dgstA, Name: "a", Stream: 1, Data: "hello\n"},
+		{Time: t2, Type: EventCompleted, Vertex: dgstA, Name: "a", Duration: 5},
+		{Time: t1, Type: EventStarted, Vertex: dgstC, Name: "c"},
+		{Time: t2, Type: EventErrored, Vertex: dgstC, Name: "c", Duration: 3, Error: "failed"},
+	}, events)
+}
+
+func TestDisplayRawJSON(t *testing.T) {
+	t0 := time.Unix(100, 0).UTC()
+	ss := &client.SolveStatus{
+		Vertexes: []*client.Vertex{{Digest: digest.FromString("a"), Name: "a", Started: &t0}},
+	}
+	ch := make(chan *client.SolveStatus, 1)
+	ch <- ss
+	close(ch)
+
+	buf := &bytes.Buffer{}
+	require.NoError(t, DisplayRawJSON(context.TODO(), buf, ch))
+
+	var out client.SolveStatus
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &out))
+	require.Equal(t, *ss, out)
+}
@@ -107,7 +107,9 @@ func (p *textMux) printVtx(t *trace, dgst digest.Digest) {
 			}
 
 			var bytes string
-			if s.Total != 0 {
+			if s.Resources != nil {
+				bytes = " " + formatResourceUsage(s.Resources)
+			} else if s.Total != 0 {
 				bytes = fmt.Sprintf(" %.2f / %.2f", units.Bytes(s.Current), units.Bytes(s.Total))
 			} else if s.Current != 0 {
 				bytes = fmt.Sprintf(" %.2f", units.Bytes(s.Current))
@@ -128,7 +130,11 @@ func (p *textMux) printVtx(t *trace, dgst digest.Digest) {
 			} else {
 				isOpenStatus = true
 			}
-			fmt.Fprintf(p.w, "#%d %s%s%s\n", v.index, s.ID, bytes, tm)
+			name := s.ID
+			if s.Resources != nil {
+				name = s.Name
+			}
+			fmt.Fprintf(p.w, "#%d %s%s%s\n", v.index, name, bytes, tm)
 		}
 	}
 	v.statusUpdates = map[string]struct{}{}
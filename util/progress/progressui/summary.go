@@ -0,0 +1,245 @@
+package progressui
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/moby/buildkit/client"
+	digest "github.com/opencontainers/go-digest"
+	"github.com/tonistiigi/units"
+)
+
+// Summary describes where the time of a build went.
+type Summary struct {
+	// Duration is the time in seconds from the start of the first vertex to
+	// the completion of the last one.
+	Duration float64 `json:"duration"`
+	// CriticalPath is the chain of dependent vertexes with the longest total
+	// duration, starting with the vertex that ran first.
+	CriticalPath []SummaryVertex `json:"criticalPath"`
+	// Slowest are the executed vertexes with the longest duration.
+	Slowest []SummaryVertex `json:"slowest"`
+	// Cached, Executed and Errored are the number of completed vertexes.
+	Cached   int `json:"cached"`
+	Executed int `json:"executed"`
+	Errored  int `json:"errored"`
+	// ContextBytes is the size of the local directories transferred to the
+	// daemon and PulledBytes the size of the image layers pulled.
+	ContextBytes int64 `json:"contextBytes"`
+	PulledBytes  int64 `json:"pulledBytes"`
+	// ImageDigests are the digests of the exported images. They are not
+	// part of the progress and set by the caller from the solve response.
+	ImageDigests []string `json:"imageDigests,omitempty"`
+}
+
+type SummaryVertex struct {
+	Digest   digest.Digest `json:"digest"`
+	Name     string        `json:"name"`
+	Duration float64       `json:"duration"`
+	Cached   bool          `json:"cached,omitempty"`
+	Error    string        `json:"error,omitempty"`
+}
+
+// SummaryCollector computes the Summary of a build from its statuses.
+type SummaryCollector struct {
+	vertexes map[digest.Digest]*client.Vertex
+	order    []digest.Digest
+	contexts map[string]int64
+	pulls    map[string]int64
+}
+
+func NewSummaryCollector() *SummaryCollector {
+	return &SummaryCollector{
+		vertexes: map[digest.Digest]*client.Vertex{},
+		contexts: map[string]int64{},
+		pulls:    map[string]int64{},
+	}
+}
+
+// Update records the vertexes and the transfers of a status.
+func (c *SummaryCollector) Update(ss *client.SolveStatus) {
+	for _, v := range ss.Vertexes {
+		if _, ok := c.vertexes[v.Digest]; !ok {
+			c.order = append(c.order, v.Digest)
+		}
+		c.vertexes[v.Digest] = v
+	}
+	for _, s := range ss.Statuses {
+		switch {
+		case strings.HasPrefix(s.ID, "transferring "):
+			c.contexts[s.Vertex.String()+" "+s.ID] = s.Current
+		case digest.Digest(s.ID).Validate() == nil:
+			// layers are only reported while they are fetched, a layer
+			// used by multiple vertexes is pulled once
+			c.pulls[s.ID] = s.Current
+		}
+	}
+}
+
+// Summary returns the summary of the statuses received so far with at most
+// n slowest vertexes.
+func (c *SummaryCollector) Summary(n int) *Summary {
+	s := &Summary{
+		CriticalPath: []SummaryVertex{},
+		Slowest:      []SummaryVertex{},
+	}
+
+	var start, end *time.Time
+	var executed []*client.Vertex
+	for _, dgst := range c.order {
+		v := c.vertexes[dgst]
+		if v.Started != nil && (start == nil || v.Started.Before(*start)) {
+			start = v.Started
+		}
+		if v.Completed == nil {
+			continue
+		}
+		if end == nil || v.Completed.After(*end) {
+			end = v.Completed
+		}
+		switch {
+		case v.Error != "":
+			s.Errored++
+		case v.Cached:
+			s.Cached++
+		default:
+			s.Executed++
+		}
+		if !v.Cached {
+			executed = append(executed, v)
+		}
+	}
+	if start != nil && end != nil {
+		s.Duration = end.Sub(*start).Seconds()
+	}
+
+	sort.SliceStable(executed, func(i, j int) bool {
+		return vertexDuration(executed[i]) > vertexDuration(executed[j])
+	})
+	for i, v := range executed {
+		if i == n {
+			break
+		}
+		s.Slowest = append(s.Slowest, summaryVertex(v))
+	}
+
+	for _, v := range c.criticalPath() {
+		s.CriticalPath = append(s.CriticalPath, summaryVertex(v))
+	}
+
+	for _, b := range c.contexts {
+		s.ContextBytes += b
+	}
+	for _, b := range c.pulls {
+		s.PulledBytes += b
+	}
+	return s
+}
+
+// criticalPath returns the chain of vertexes connected by their inputs with
+// the longest total duration.
+func (c *SummaryCollector) criticalPath() []*client.Vertex {
+	type result struct {
+		total time.Duration
+		next  digest.Digest // the input on the path
+	}
+	results := map[digest.Digest]*result{}
+	var longest func(dgst digest.Digest) *result
+	longest = func(dgst digest.Digest) *result {
+		if r, ok := results[dgst]; ok {
+			return r
+		}
+		// set before visiting the inputs in case the graph has a cycle
+		r := &result{}
+		results[dgst] = r
+		v := c.vertexes[dgst]
+		for _, inp := range v.Inputs {
+			if _, ok := c.vertexes[inp]; !ok {
+				continue
+			}
+			if ir := longest(inp); r.next == "" || ir.total > results[r.next].total {
+				r.next = inp
+			}
+		}
+		r.total = vertexDuration(v)
+		if r.next != "" {
+			r.total += results[r.next].total
+		}
+		return r
+	}
+
+	var last digest.Digest
+	for _, dgst := range c.order {
+		if r := longest(dgst); last == "" || r.total > results[last].total {
+			last = dgst
+		}
+	}
+	var path []*client.Vertex
+	for dgst := last; dgst != ""; dgst = results[dgst].next {
+		path = append(path, c.vertexes[dgst])
+	}
+	// the inputs come first
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path
+}
+
+// PrintSummary writes s to w as text.
+func PrintSummary(w io.Writer, s *Summary) {
+	fmt.Fprintf(w, "Build summary:\n")
+	fmt.Fprintf(w, "  Total time: %.1fs\n", s.Duration)
+	fmt.Fprintf(w, "  Steps: %d executed, %d cached, %d errored\n", s.Executed, s.Cached, s.Errored)
+	fmt.Fprintf(w, "  Transferred: %.2f context, %.2f pulled\n", units.Bytes(s.ContextBytes), units.Bytes(s.PulledBytes))
+	if len(s.CriticalPath) > 0 {
+		var total float64
+		for _, v := range s.CriticalPath {
+			total += v.Duration
+		}
+		fmt.Fprintf(w, "  Critical path (%.1fs):\n", total)
+		printSummaryVertexes(w, s.CriticalPath)
+	}
+	if len(s.Slowest) > 0 {
+		fmt.Fprintf(w, "  Slowest steps:\n")
+		printSummaryVertexes(w, s.Slowest)
+	}
+	if len(s.ImageDigests) > 0 {
+		fmt.Fprintf(w, "  Images:\n")
+		for _, dgst := range s.ImageDigests {
+			fmt.Fprintf(w, "    %s\n", dgst)
+		}
+	}
+}
+
+func printSummaryVertexes(w io.Writer, vertexes []SummaryVertex) {
+	for _, v := range vertexes {
+		status := ""
+		switch {
+		case v.Error != "":
+			status = " ERROR"
+		case v.Cached:
+			status = " CACHED"
+		}
+		fmt.Fprintf(w, "    %6.1fs %s%s\n", v.Duration, v.Name, status)
+	}
+}
+
+func summaryVertex(v *client.Vertex) SummaryVertex {
+	return SummaryVertex{
+		Digest:   v.Digest,
+		Name:     v.Name,
+		Duration: vertexDuration(v).Seconds(),
+		Cached:   v.Cached,
+		Error:    v.Error,
+	}
+}
+
+func vertexDuration(v *client.Vertex) time.Duration {
+	if v.Started == nil || v.Completed == nil {
+		return 0
+	}
+	return v.Completed.Sub(*v.Started)
+}
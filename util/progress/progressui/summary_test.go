@@ -0,0 +1,75 @@
+package progressui
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/moby/buildkit/client"
+	digest "github.com/opencontainers/go-digest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSummary(t *testing.T) {
+	t0 := time.Unix(100, 0)
+	at := func(sec int) *time.Time {
+		t := t0.Add(time.Duration(sec) * time.Second)
+		return &t
+	}
+	img := digest.FromString("img")
+	ctx := digest.FromString("ctx")
+	deps := digest.FromString("deps")
+	lint := digest.FromString("lint")
+	build := digest.FromString("build")
+	layer := digest.FromString("layer")
+
+	c := NewSummaryCollector()
+	c.Update(&client.SolveStatus{
+		Vertexes: []*client.Vertex{
+			{Digest: img, Name: "pull", Started: at(0), Completed: at(3)},
+			{Digest: ctx, Name: "context", Started: at(0), Completed: at(1)},
+		},
+		Statuses: []*client.VertexStatus{
+			{ID: layer.String(), Vertex: img, Current: 100, Total: 1000},
+			{ID: "transferring context:", Vertex: ctx, Current: 10},
+		},
+	})
+	c.Update(&client.SolveStatus{
+		Vertexes: []*client.Vertex{
+			{Digest: deps, Name: "deps", Inputs: []digest.Digest{img}, Started: at(3), Completed: at(3), Cached: true},
+			{Digest: lint, Name: "lint", Inputs: []digest.Digest{ctx, deps}, Started: at(3), Completed: at(4), Error: "failed"},
+			{Digest: build, Name: "build", Inputs: []digest.Digest{ctx, deps}, Started: at(3), Completed: at(8)},
+		},
+		Statuses: []*client.VertexStatus{
+			{ID: layer.String(), Vertex: img, Current: 1000, Total: 1000},
+			{ID: "transferring context:", Vertex: ctx, Current: 20},
+		},
+	})
+
+	s := c.Summary(2)
+	require.Equal(t, 8.0, s.Duration)
+	require.Equal(t, 3, s.Executed)
+	require.Equal(t, 1, s.Cached)
+	require.Equal(t, 1, s.Errored)
+	require.Equal(t, int64(20), s.ContextBytes)
+	require.Equal(t, int64(1000), s.PulledBytes)
+
+	var names []string
+	for _, v := range s.CriticalPath {
+		names = append(names, v.Name)
+	}
+	require.Equal(t, []string{"pull", "deps", "build"}, names)
+
+	names = nil
+	for _, v := range s.Slowest {
+		names = append(names, v.Name)
+	}
+	require.Equal(t, []string{"build", "pull"}, names)
+
+	s.ImageDigests = []string{"sha256:abc"}
+	buf := &bytes.Buffer{}
+	PrintSummary(buf, s)
+	require.Contains(t, buf.String(), "Total time: 8.0s")
+	require.Contains(t, buf.String(), "Critical path (8.0s)")
+	require.Contains(t, buf.String(), "sha256:abc")
+}
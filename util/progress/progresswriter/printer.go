@@ -80,14 +80,21 @@ func NewPrinter(ctx context.Context, out console.File, mode string) (Writer, err
 				return nil, errors.Wrap(err, "failed to get console")
 			}
 		}
-	case "plain":
+	case "plain", "rawjson", "event":
 	default:
 		return nil, errors.Errorf("invalid progress mode %s", mode)
 	}
 
 	go func() {
 		// not using shared context to not disrupt display but let is finish reporting errors
-		_, pw.err = progressui.DisplaySolveStatus(ctx, "", c, out, statusCh)
+		switch mode {
+		case "rawjson":
+			pw.err = progressui.DisplayRawJSON(ctx, out, statusCh)
+		case "event":
+			pw.err = progressui.DisplayEvents(ctx, out, statusCh)
+		default:
+			_, pw.err = progressui.DisplaySolveStatus(ctx, "", c, out, statusCh)
+		}
 		close(doneCh)
 	}()
 	return pw, nil
@@ -8,6 +8,7 @@ import (
 	"github.com/containerd/containerd/content"
 	"github.com/containerd/containerd/errdefs"
 	"github.com/containerd/containerd/remotes"
+	"github.com/moby/buildkit/util/metrics"
 	"github.com/moby/buildkit/util/progress"
 	ocispecs "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/pkg/errors"
@@ -30,9 +31,13 @@ func (p *ProviderWithProgress) ReaderAt(ctx context.Context, desc ocispecs.Descr
 		return nil, err
 	}
 
+	_, err = p.Manager.Info(ctx, desc.Digest)
+	existed := err == nil
+
+	metricsCtx := ctx
 	ctx, cancel := context.WithCancel(ctx)
 	doneCh := make(chan struct{})
-	go trackProgress(ctx, desc, p.Manager, doneCh)
+	go trackProgress(ctx, metricsCtx, desc, p.Manager, existed, doneCh)
 	return readerAtWithCancel{ReaderAt: ra, cancel: cancel, doneCh: doneCh}, nil
 }
 
@@ -63,9 +68,13 @@ func (f *FetcherWithProgress) Fetch(ctx context.Context, desc ocispecs.Descripto
 		return nil, err
 	}
 
+	_, err = f.Manager.Info(ctx, desc.Digest)
+	existed := err == nil
+
+	metricsCtx := ctx
 	ctx, cancel := context.WithCancel(ctx)
 	doneCh := make(chan struct{})
-	go trackProgress(ctx, desc, f.Manager, doneCh)
+	go trackProgress(ctx, metricsCtx, desc, f.Manager, existed, doneCh)
 	return readerWithCancel{ReadCloser: rc, cancel: cancel, doneCh: doneCh}, nil
 }
 
@@ -85,7 +94,11 @@ func (r readerWithCancel) Close() error {
 	return r.ReadCloser.Close()
 }
 
-func trackProgress(ctx context.Context, desc ocispecs.Descriptor, manager PullManager, doneCh chan<- struct{}) {
+// trackProgress reports the progress of the ingest of desc until ctx is
+// canceled. If the blob did not exist before, its size is recorded as pulled
+// once it is committed, so reads of content that is already local and retried
+// reads are not counted.
+func trackProgress(ctx, metricsCtx context.Context, desc ocispecs.Descriptor, manager PullManager, existed bool, doneCh chan<- struct{}) {
 	defer close(doneCh)
 
 	ticker := time.NewTicker(150 * time.Millisecond)
@@ -124,6 +137,9 @@ func trackProgress(ctx context.Context, desc ocispecs.Descriptor, manager PullMa
 
 		info, err := manager.Info(ctx, desc.Digest)
 		if err == nil {
+			if !existed {
+				metrics.RecordPulledBytes(metricsCtx, info.Size)
+			}
 			pw.Write(desc.Digest.String(), progress.Status{
 				Current:   int(info.Size),
 				Total:     int(info.Size),
@@ -15,9 +15,11 @@ import (
 	"github.com/containerd/containerd/remotes"
 	"github.com/containerd/containerd/remotes/docker"
 	"github.com/docker/distribution/reference"
+	attestationtypes "github.com/moby/buildkit/exporter/attestation/types"
 	"github.com/moby/buildkit/session"
 	"github.com/moby/buildkit/util/flightcontrol"
 	"github.com/moby/buildkit/util/imageutil"
+	"github.com/moby/buildkit/util/metrics"
 	"github.com/moby/buildkit/util/progress"
 	"github.com/moby/buildkit/util/progress/logs"
 	"github.com/moby/buildkit/util/resolver"
@@ -104,7 +106,7 @@ func Push(ctx context.Context, sm *session.Manager, sid string, provider content
 		}
 	})
 
-	pushHandler := retryhandler.New(limited.PushHandler(pusher, provider, ref), logs.LoggerFromContext(ctx))
+	pushHandler := retryhandler.New(limited.PushHandler(pusher, meteredProvider{provider}, ref), logs.LoggerFromContext(ctx))
 	pushUpdateSourceHandler, err := updateDistributionSourceHandler(manager, pushHandler, ref)
 	if err != nil {
 		return err
@@ -266,7 +268,8 @@ func childrenHandler(provider content.Provider) images.HandlerFunc {
 			}
 		case images.MediaTypeDockerSchema2Layer, images.MediaTypeDockerSchema2LayerGzip,
 			images.MediaTypeDockerSchema2Config, ocispecs.MediaTypeImageConfig,
-			ocispecs.MediaTypeImageLayer, ocispecs.MediaTypeImageLayerGzip:
+			ocispecs.MediaTypeImageLayer, ocispecs.MediaTypeImageLayerGzip,
+			attestationtypes.MediaTypeInToto:
 			// childless data types.
 			return nil, nil
 		default:
@@ -293,7 +296,8 @@ func updateDistributionSourceHandler(manager content.Manager, pushF images.Handl
 
 		switch desc.MediaType {
 		case images.MediaTypeDockerSchema2Layer, images.MediaTypeDockerSchema2LayerGzip,
-			ocispecs.MediaTypeImageLayer, ocispecs.MediaTypeImageLayerGzip:
+			ocispecs.MediaTypeImageLayer, ocispecs.MediaTypeImageLayerGzip,
+			attestationtypes.MediaTypeInToto:
 			islayer = true
 		}
 
@@ -345,3 +349,27 @@ func dedupeHandler(h images.HandlerFunc) images.HandlerFunc {
 		return res.([]ocispecs.Descriptor), nil
 	})
 }
+
+// meteredProvider records the bytes read from the provider while pushing
+type meteredProvider struct {
+	content.Provider
+}
+
+func (p meteredProvider) ReaderAt(ctx context.Context, desc ocispecs.Descriptor) (content.ReaderAt, error) {
+	ra, err := p.Provider.ReaderAt(ctx, desc)
+	if err != nil {
+		return nil, err
+	}
+	return &meteredReaderAt{ReaderAt: ra, ctx: ctx}, nil
+}
+
+type meteredReaderAt struct {
+	content.ReaderAt
+	ctx context.Context
+}
+
+func (ra *meteredReaderAt) ReadAt(p []byte, off int64) (int, error) {
+	n, err := ra.ReaderAt.ReadAt(p, off)
+	metrics.RecordPushedBytes(ra.ctx, int64(n))
+	return n, err
+}
@@ -0,0 +1,113 @@
+package integration
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"os/exec"
+	"regexp"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// AzuriteAccountName and AzuriteAccountKey are the well-known development
+	// credentials of the Azurite storage emulator.
+	AzuriteAccountName = "devstoreaccount1"
+	AzuriteAccountKey  = "Eby8vdM02xNOcqFlqUwJPLlmEtlCDXJ1OUzFT50uSRZ6IFsuFq2UVErCz4I6tq/K1SZFPTOtr/KBHBeksoGMGw=="
+)
+
+// NewAzuriteServer starts the blob service of the Azurite emulator and
+// returns its account URL.
+func NewAzuriteServer(dir string) (accountURL string, cl func() error, err error) {
+	if err := lookupBinary("azurite-blob"); err != nil {
+		return "", nil, err
+	}
+
+	deferF := &multiCloser{}
+	cl = deferF.F()
+
+	defer func() {
+		if err != nil {
+			deferF.F()()
+			cl = nil
+		}
+	}()
+
+	if dir == "" {
+		tmpdir, err := os.MkdirTemp("", "test-azurite")
+		if err != nil {
+			return "", nil, err
+		}
+		deferF.append(func() error { return os.RemoveAll(tmpdir) })
+		dir = tmpdir
+	}
+
+	port, err := freePort()
+	if err != nil {
+		return "", nil, err
+	}
+
+	cmd := exec.Command("azurite-blob", "--blobHost", "127.0.0.1", "--blobPort", fmt.Sprint(port), "--location", dir)
+	rc, err := cmd.StdoutPipe()
+	if err != nil {
+		return "", nil, err
+	}
+	cmd.Stderr = cmd.Stdout
+	stop, err := startCmd(cmd, nil)
+	if err != nil {
+		return "", nil, err
+	}
+	deferF.append(stop)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	if err := waitAzurite(ctx, rc); err != nil {
+		return "", nil, err
+	}
+
+	return fmt.Sprintf("http://127.0.0.1:%d/%s", port, AzuriteAccountName), cl, nil
+}
+
+func waitAzurite(ctx context.Context, rc io.ReadCloser) error {
+	r := regexp.MustCompile(`successfully listening at`)
+	s := bufio.NewScanner(rc)
+	found := make(chan struct{})
+	defer func() {
+		close(found)
+		go io.Copy(io.Discard, rc)
+	}()
+
+	go func() {
+		select {
+		case <-ctx.Done():
+			select {
+			case <-found:
+				return
+			default:
+				rc.Close()
+			}
+		case <-found:
+		}
+	}()
+
+	for s.Scan() {
+		if r.Match(s.Bytes()) {
+			return nil
+		}
+	}
+	return errors.Errorf("azurite did not start")
+}
+
+func freePort() (int, error) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return 0, err
+	}
+	defer l.Close()
+	return l.Addr().(*net.TCPAddr).Port, nil
+}
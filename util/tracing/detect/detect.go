@@ -87,11 +87,7 @@ func detect() error {
 	// enable log with traceID when valid exporter
 	bklog.EnableLogWithTraceID(true)
 
-	res, err := resource.Detect(context.Background(), serviceNameDetector{})
-	if err != nil {
-		return err
-	}
-	res, err = resource.Merge(resource.Default(), res)
+	res, err := Resource()
 	if err != nil {
 		return err
 	}
@@ -127,6 +123,16 @@ func Exporter() (sdktrace.SpanExporter, error) {
 	return exporter, nil
 }
 
+// Resource returns the OpenTelemetry resource describing the current
+// process.
+func Resource() (*resource.Resource, error) {
+	res, err := resource.Detect(context.Background(), serviceNameDetector{})
+	if err != nil {
+		return nil, err
+	}
+	return resource.Merge(resource.Default(), res)
+}
+
 func Shutdown(ctx context.Context) error {
 	for _, c := range closers {
 		if err := c(ctx); err != nil {
@@ -41,6 +41,7 @@ import (
 	"github.com/moby/buildkit/source/local"
 	"github.com/moby/buildkit/util/archutil"
 	"github.com/moby/buildkit/util/bklog"
+	"github.com/moby/buildkit/util/network"
 	"github.com/moby/buildkit/util/progress"
 	"github.com/moby/buildkit/util/progress/controller"
 	digest "github.com/opencontainers/go-digest"
@@ -63,18 +64,20 @@ type WorkerOpt struct {
 	GCPolicy        []client.PruneInfo
 	BuildkitVersion client.BuildkitVersion
 	Executor        executor.Executor
-	Snapshotter     snapshot.Snapshotter
-	ContentStore    content.Store
-	Applier         diff.Applier
-	Differ          diff.Comparer
-	ImageStore      images.Store // optional
-	RegistryHosts   docker.RegistryHosts
-	IdentityMapping *idtools.IdentityMapping
-	LeaseManager    leases.Manager
-	GarbageCollect  func(context.Context) (gc.Stats, error)
-	ParallelismSem  *semaphore.Weighted
-	MetadataStore   *metadata.Store
-	MountPoolRoot   string
+	// NetworkProviders are closed with the worker
+	NetworkProviders map[pb.NetMode]network.Provider
+	Snapshotter      snapshot.Snapshotter
+	ContentStore     content.Store
+	Applier          diff.Applier
+	Differ           diff.Comparer
+	ImageStore       images.Store // optional
+	RegistryHosts    docker.RegistryHosts
+	IdentityMapping  *idtools.IdentityMapping
+	LeaseManager     leases.Manager
+	GarbageCollect   func(context.Context) (gc.Stats, error)
+	ParallelismSem   *semaphore.Weighted
+	MetadataStore    *metadata.Store
+	MountPoolRoot    string
 }
 
 // Worker is a local worker instance with dedicated snapshotter, cache, and so on.
@@ -261,6 +264,17 @@ func (w *Worker) LoadRef(ctx context.Context, id string, hidden bool) (cache.Imm
 	return ref, nil
 }
 
+// Close releases the resources held by the network providers of the worker.
+func (w *Worker) Close() error {
+	var rerr error
+	for _, provider := range w.NetworkProviders {
+		if err := provider.Close(); err != nil && rerr == nil {
+			rerr = err
+		}
+	}
+	return rerr
+}
+
 func (w *Worker) Executor() executor.Executor {
 	return w.WorkerOpt.Executor
 }
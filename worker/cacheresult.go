@@ -5,6 +5,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/moby/buildkit/cache"
 	cacheconfig "github.com/moby/buildkit/cache/config"
 	"github.com/moby/buildkit/session"
 	"github.com/moby/buildkit/solver"
@@ -28,6 +29,9 @@ func (s *cacheResultStorage) Save(res solver.Result, createdAt time.Time) (solve
 		return solver.CacheResult{}, errors.Errorf("invalid result: %T", res.Sys())
 	}
 	if ref.ImmutableRef != nil {
+		if _, ok := ref.ImmutableRef.(cache.ExternalRef); ok {
+			return solver.CacheResult{}, errors.WithStack(solver.ErrNotStorable)
+		}
 		if !ref.ImmutableRef.HasCachePolicyRetain() {
 			if err := ref.ImmutableRef.SetCachePolicyRetain(); err != nil {
 				return solver.CacheResult{}, err
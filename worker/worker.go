@@ -36,6 +36,8 @@ type Worker interface {
 	ContentStore() content.Store
 	Executor() executor.Executor
 	CacheManager() cache.Manager
+	// Close releases the resources held by the worker after the last build.
+	Close() error
 }
 
 type Infos interface {
@@ -49,7 +51,7 @@ const (
 	LabelExecutor            = labelPrefix + "executor"    // "oci" or "containerd"
 	LabelSnapshotter         = labelPrefix + "snapshotter" // containerd snapshotter name ("overlay", "native", ...)
 	LabelHostname            = labelPrefix + "hostname"
-	LabelNetwork             = labelPrefix + "network" // "cni" or "host"
+	LabelNetwork             = labelPrefix + "network" // "cni", "bridge" or "host"
 	LabelApparmorProfile     = labelPrefix + "apparmor.profile"
 	LabelOCIProcessMode      = labelPrefix + "oci.process-mode"     // OCI worker: process mode ("sandbox", "no-sandbox")
 	LabelContainerdUUID      = labelPrefix + "containerd.uuid"      // containerd worker: containerd UUID
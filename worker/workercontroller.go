@@ -22,6 +22,17 @@ func (c *Controller) Add(w Worker) error {
 	return nil
 }
 
+// Close closes all the workers.
+func (c *Controller) Close() error {
+	var rerr error
+	for _, w := range c.workers {
+		if err := w.Close(); err != nil && rerr == nil {
+			rerr = err
+		}
+	}
+	return rerr
+}
+
 // List lists workers
 func (c *Controller) List(filterStrings ...string) ([]Worker, error) {
 	filter, err := filters.ParseAll(filterStrings...)